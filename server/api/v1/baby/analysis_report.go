@@ -0,0 +1,141 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type AnalysisReportApi struct{}
+
+// GetReportList 获取分析报告列表
+// @Tags AnalysisReport
+// @Summary 分页获取日报、周报、月报列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.AnalysisReportSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.AnalysisReportListResponse,msg=string} "获取成功"
+// @Router /baby/report/list [get]
+func (a *AnalysisReportApi) GetReportList(c *gin.Context) {
+	var req request.AnalysisReportSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := analysisReportService.GetReportList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取分析报告列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetReport 获取分析报告详情
+// @Tags AnalysisReport
+// @Summary 获取分析报告详情
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "报告ID"
+// @Success 200 {object} response.Response{data=response.AnalysisReportResponse,msg=string} "获取成功"
+// @Router /baby/report/{id} [get]
+func (a *AnalysisReportApi) GetReport(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	report, err := analysisReportService.GetReport(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取分析报告失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(report, "获取成功", c)
+}
+
+// RegenerateReport 重新生成分析报告
+// @Tags AnalysisReport
+// @Summary 按需生成或重新生成指定周期的报告
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.RegenerateReportRequest true "报告周期"
+// @Success 200 {object} response.Response{data=response.AnalysisReportResponse,msg=string} "生成成功"
+// @Router /baby/report/regenerate [post]
+func (a *AnalysisReportApi) RegenerateReport(c *gin.Context) {
+	var req request.RegenerateReportRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	report, err := analysisReportService.RegenerateReport(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("生成分析报告失败!", zap.Error(err))
+		response.FailWithMessage("生成失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(report, "生成成功", c)
+}
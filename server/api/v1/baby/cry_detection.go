@@ -0,0 +1,261 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type CryDetectionApi struct{}
+
+// CreateCryDetection 上报哭声事件
+// @Tags CryDetection
+// @Summary 上报哭声事件
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateCryDetectionRequest true "哭声事件"
+// @Success 200 {object} response.Response{data=response.CryDetectionResponse,msg=string} "上报成功"
+// @Router /baby/cry [post]
+func (cr *CryDetectionApi) CreateCryDetection(c *gin.Context) {
+	var req request.CreateCryDetectionRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	detection, err := cryDetectionService.CreateCryDetection(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("上报哭声事件失败!", zap.Error(err))
+		response.FailWithMessage("上报失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(detection, "上报成功", c)
+}
+
+// GetCryDetection 获取哭声事件详情
+// @Tags CryDetection
+// @Summary 获取哭声事件详情
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "哭声记录ID"
+// @Success 200 {object} response.Response{data=response.CryDetectionResponse,msg=string} "获取成功"
+// @Router /baby/cry/{id} [get]
+func (cr *CryDetectionApi) GetCryDetection(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	detection, err := cryDetectionService.GetCryDetection(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取哭声事件失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(detection, "获取成功", c)
+}
+
+// GetCryDetectionList 获取哭声事件列表
+// @Tags CryDetection
+// @Summary 分页获取哭声事件列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.CryDetectionSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.CryDetectionListResponse,msg=string} "获取成功"
+// @Router /baby/cry/list [get]
+func (cr *CryDetectionApi) GetCryDetectionList(c *gin.Context) {
+	var req request.CryDetectionSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := cryDetectionService.GetCryDetectionList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取哭声事件列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// AcknowledgeCryDetections 确认哭声事件
+// @Tags CryDetection
+// @Summary 批量确认哭声事件
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.AcknowledgeCryRequest true "哭声记录ID列表"
+// @Success 200 {object} response.Response{data=int,msg=string} "确认成功"
+// @Router /baby/cry/acknowledge [post]
+func (cr *CryDetectionApi) AcknowledgeCryDetections(c *gin.Context) {
+	var req request.AcknowledgeCryRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	count, err := cryDetectionService.AcknowledgeCryDetections(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("确认哭声事件失败!", zap.Error(err))
+		response.FailWithMessage("确认失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(count, "确认成功", c)
+}
+
+// SubmitFeedback 反馈识别结果
+// @Tags CryDetection
+// @Summary 反馈哭声识别是否准确
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CryFeedbackRequest true "反馈信息"
+// @Success 200 {object} response.Response{msg=string} "反馈成功"
+// @Router /baby/cry/feedback [post]
+func (cr *CryDetectionApi) SubmitFeedback(c *gin.Context) {
+	var req request.CryFeedbackRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = cryDetectionService.SubmitFeedback(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("提交哭声反馈失败!", zap.Error(err))
+		response.FailWithMessage("反馈失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("反馈成功", c)
+}
+
+// GetAccuracyReport 获取识别准确率
+// @Tags CryDetection
+// @Summary 按设备、哭声类型和周统计识别准确率
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.CryAccuracySearch true "统计条件"
+// @Success 200 {object} response.Response{data=response.CryAccuracyReport,msg=string} "获取成功"
+// @Router /baby/cry/accuracy [get]
+func (cr *CryDetectionApi) GetAccuracyReport(c *gin.Context) {
+	var req request.CryAccuracySearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	report, err := cryDetectionService.GetAccuracyReport(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取识别准确率失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(report, "获取成功", c)
+}
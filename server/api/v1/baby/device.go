@@ -0,0 +1,533 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type DeviceApi struct{}
+
+// AddDevice 绑定设备
+// @Tags Device
+// @Summary 绑定设备
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.AddDeviceRequest true "设备信息"
+// @Success 200 {object} response.Response{msg=string} "绑定成功"
+// @Router /baby/device [post]
+func (d *DeviceApi) AddDevice(c *gin.Context) {
+	var req request.AddDeviceRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = deviceService.AddDevice(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("绑定设备失败!", zap.Error(err))
+		response.FailWithMessage("绑定失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("绑定成功", c)
+}
+
+// GetDevice 获取设备详情
+// @Tags Device
+// @Summary 获取设备详情
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "设备ID"
+// @Success 200 {object} response.Response{data=response.DeviceResponse,msg=string} "获取成功"
+// @Router /baby/device/{id} [get]
+func (d *DeviceApi) GetDevice(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	device, err := deviceService.GetDevice(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取设备详情失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(device, "获取成功", c)
+}
+
+// GetDeviceList 获取设备列表
+// @Tags Device
+// @Summary 获取设备列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.DeviceSearch true "搜索参数"
+// @Success 200 {object} response.Response{data=response.DeviceListResponse,msg=string} "获取成功"
+// @Router /baby/device/list [get]
+func (d *DeviceApi) GetDeviceList(c *gin.Context) {
+	var req request.DeviceSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := deviceService.GetDeviceList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取设备列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// UpdateDevice 更新设备信息
+// @Tags Device
+// @Summary 更新设备信息
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateDeviceRequest true "设备信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /baby/device [put]
+func (d *DeviceApi) UpdateDevice(c *gin.Context) {
+	var req request.UpdateDeviceRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = deviceService.UpdateDevice(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新设备失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// UnbindDevice 解绑设备
+// @Tags Device
+// @Summary 解绑设备
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "设备ID"
+// @Success 200 {object} response.Response{msg=string} "解绑成功"
+// @Router /baby/device/{id} [delete]
+func (d *DeviceApi) UnbindDevice(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = deviceService.UnbindDevice(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("解绑设备失败!", zap.Error(err))
+		response.FailWithMessage("解绑失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("解绑成功", c)
+}
+
+// GetDeviceConfigs 获取设备配置
+// @Tags Device
+// @Summary 获取设备配置
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "设备ID"
+// @Success 200 {object} response.Response{data=[]response.DeviceConfigResponse,msg=string} "获取成功"
+// @Router /baby/device/{id}/configs [get]
+func (d *DeviceApi) GetDeviceConfigs(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	configs, err := deviceService.GetDeviceConfigs(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取设备配置失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(configs, "获取成功", c)
+}
+
+// UpdateDeviceConfigs 更新设备配置
+// @Tags Device
+// @Summary 批量更新设备配置
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateDeviceConfigRequest true "配置信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /baby/device/configs [put]
+func (d *DeviceApi) UpdateDeviceConfigs(c *gin.Context) {
+	var req request.UpdateDeviceConfigRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = deviceService.UpdateDeviceConfigs(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新设备配置失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// ShareDevice 分享设备
+// @Tags Device
+// @Summary 邀请其他用户共享设备
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ShareDeviceRequest true "分享信息"
+// @Success 200 {object} response.Response{msg=string} "邀请成功"
+// @Router /baby/device/share [post]
+func (d *DeviceApi) ShareDevice(c *gin.Context) {
+	var req request.ShareDeviceRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = deviceService.ShareDevice(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("分享设备失败!", zap.Error(err))
+		response.FailWithMessage("分享失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("邀请成功", c)
+}
+
+// AcceptShare 接受设备分享
+// @Tags Device
+// @Summary 接受设备分享邀请
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.AcceptShareRequest true "分享ID"
+// @Success 200 {object} response.Response{msg=string} "接受成功"
+// @Router /baby/device/share/accept [post]
+func (d *DeviceApi) AcceptShare(c *gin.Context) {
+	var req request.AcceptShareRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = deviceService.AcceptShare(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("接受设备分享失败!", zap.Error(err))
+		response.FailWithMessage("接受失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("接受成功", c)
+}
+
+// DeclineShare 拒绝或退出设备分享
+// @Tags Device
+// @Summary 拒绝设备分享邀请或退出已接受的分享
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.DeclineShareRequest true "分享ID"
+// @Success 200 {object} response.Response{msg=string} "操作成功"
+// @Router /baby/device/share/decline [post]
+func (d *DeviceApi) DeclineShare(c *gin.Context) {
+	var req request.DeclineShareRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = deviceService.DeclineShare(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("拒绝设备分享失败!", zap.Error(err))
+		response.FailWithMessage("操作失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("操作成功", c)
+}
+
+// RevokeShare 撤销设备分享
+// @Tags Device
+// @Summary 设备所有者撤销分享
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "分享ID"
+// @Success 200 {object} response.Response{msg=string} "撤销成功"
+// @Router /baby/device/share/{id} [delete]
+func (d *DeviceApi) RevokeShare(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = deviceService.RevokeShare(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("撤销设备分享失败!", zap.Error(err))
+		response.FailWithMessage("撤销失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("撤销成功", c)
+}
+
+// GetDeviceShares 获取设备分享列表
+// @Tags Device
+// @Summary 获取设备分享列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "设备ID"
+// @Success 200 {object} response.Response{data=[]response.DeviceShareResponse,msg=string} "获取成功"
+// @Router /baby/device/{id}/shares [get]
+func (d *DeviceApi) GetDeviceShares(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	shares, err := deviceService.GetDeviceShares(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取设备分享列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(shares, "获取成功", c)
+}
+
+// GetShareInvitations 获取待处理的分享邀请
+// @Tags Device
+// @Summary 获取当前用户待处理的设备分享邀请
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]response.DeviceShareResponse,msg=string} "获取成功"
+// @Router /baby/device/share/invitations [get]
+func (d *DeviceApi) GetShareInvitations(c *gin.Context) {
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	invitations, err := deviceService.GetShareInvitations(customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取分享邀请失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(invitations, "获取成功", c)
+}
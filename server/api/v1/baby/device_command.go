@@ -0,0 +1,141 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type DeviceCommandApi struct{}
+
+// SendCommand 下发设备命令
+// @Tags DeviceCommand
+// @Summary 下发设备命令
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.DeviceCommandRequest true "命令信息"
+// @Success 200 {object} response.Response{data=response.DeviceCommandResponse,msg=string} "下发成功"
+// @Router /baby/device/command [post]
+func (d *DeviceCommandApi) SendCommand(c *gin.Context) {
+	var req request.DeviceCommandRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	command, err := deviceCommandService.SendCommand(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("下发设备命令失败!", zap.Error(err))
+		response.FailWithMessage("下发失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(command, "下发成功", c)
+}
+
+// GetCommand 获取命令详情
+// @Tags DeviceCommand
+// @Summary 获取命令详情，用于轮询执行结果
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "命令ID"
+// @Success 200 {object} response.Response{data=response.DeviceCommandResponse,msg=string} "获取成功"
+// @Router /baby/device/command/{id} [get]
+func (d *DeviceCommandApi) GetCommand(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	command, err := deviceCommandService.GetCommand(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取命令详情失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(command, "获取成功", c)
+}
+
+// GetCommandList 获取命令记录列表
+// @Tags DeviceCommand
+// @Summary 分页获取命令记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.DeviceCommandSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.DeviceCommandListResponse,msg=string} "获取成功"
+// @Router /baby/device/command/list [get]
+func (d *DeviceCommandApi) GetCommandList(c *gin.Context) {
+	var req request.DeviceCommandSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := deviceCommandService.GetCommandList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取命令列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
@@ -0,0 +1,167 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type DeviceTelemetryApi struct{}
+
+// ReportTelemetry 设备批量上报状态、日志和环境数据
+// @Tags DeviceTelemetry
+// @Summary 设备批量上报状态、日志和环境数据
+// @accept application/json
+// @Produce application/json
+// @Param X-Device-Id header string true "设备ID"
+// @Param X-Timestamp header string true "Unix时间戳(秒)"
+// @Param X-Nonce header string true "随机串(8-64位)"
+// @Param X-Signature header string true "HMAC-SHA256签名"
+// @Param data body request.DeviceTelemetryRequest true "上报数据"
+// @Success 200 {object} response.Response{data=response.DeviceTelemetryResponse,msg=string} "上报成功"
+// @Router /baby/iot/telemetry [post]
+func (d *DeviceTelemetryApi) ReportTelemetry(c *gin.Context) {
+	device, ok := getSignedDevice(c)
+	if !ok {
+		return
+	}
+
+	var req request.DeviceTelemetryRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	result, err := deviceTelemetryService.Ingest(device, &req)
+	if err != nil {
+		global.GVA_LOG.Error("设备数据上报失败!", zap.Uint("deviceID", device.ID), zap.Error(err))
+		response.FailWithMessage("上报失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(result, "上报成功", c)
+}
+
+// ReportCommandResult 设备回传命令执行结果
+// @Tags DeviceTelemetry
+// @Summary 设备回传命令执行结果
+// @accept application/json
+// @Produce application/json
+// @Param X-Device-Id header string true "设备ID"
+// @Param X-Timestamp header string true "Unix时间戳(秒)"
+// @Param X-Nonce header string true "随机串(8-64位)"
+// @Param X-Signature header string true "HMAC-SHA256签名"
+// @Param data body request.DeviceCommandResultRequest true "执行结果"
+// @Success 200 {object} response.Response{msg=string} "回传成功"
+// @Router /baby/iot/command/result [post]
+func (d *DeviceTelemetryApi) ReportCommandResult(c *gin.Context) {
+	device, ok := getSignedDevice(c)
+	if !ok {
+		return
+	}
+
+	var req request.DeviceCommandResultRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	err = deviceTelemetryService.ReportCommandResult(device, &req)
+	if err != nil {
+		global.GVA_LOG.Error("回传命令结果失败!", zap.Uint("deviceID", device.ID), zap.Error(err))
+		response.FailWithMessage("回传失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("回传成功", c)
+}
+
+// ReportCryDetection 设备上报哭声检测事件
+// @Tags DeviceTelemetry
+// @Summary 设备上报哭声检测事件
+// @accept application/json
+// @Produce application/json
+// @Param X-Device-Id header string true "设备ID"
+// @Param X-Timestamp header string true "Unix时间戳(秒)"
+// @Param X-Nonce header string true "随机串(8-64位)"
+// @Param X-Signature header string true "HMAC-SHA256签名"
+// @Param data body request.DeviceCryDetectionRequest true "哭声事件"
+// @Success 200 {object} response.Response{data=response.CryDetectionResponse,msg=string} "上报成功"
+// @Router /baby/iot/cry [post]
+func (d *DeviceTelemetryApi) ReportCryDetection(c *gin.Context) {
+	device, ok := getSignedDevice(c)
+	if !ok {
+		return
+	}
+
+	var req request.DeviceCryDetectionRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	detection, err := cryDetectionService.RecordDeviceCryDetection(device, &req)
+	if err != nil {
+		global.GVA_LOG.Error("设备上报哭声事件失败!", zap.Uint("deviceID", device.ID), zap.Error(err))
+		response.FailWithMessage("上报失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(detection, "上报成功", c)
+}
+
+// ReportMovementDetection 设备上报动作检测事件
+// @Tags DeviceTelemetry
+// @Summary 设备上报动作检测事件
+// @accept application/json
+// @Produce application/json
+// @Param X-Device-Id header string true "设备ID"
+// @Param X-Timestamp header string true "Unix时间戳(秒)"
+// @Param X-Nonce header string true "随机串(8-64位)"
+// @Param X-Signature header string true "HMAC-SHA256签名"
+// @Param data body request.DeviceMovementDetectionRequest true "动作事件"
+// @Success 200 {object} response.Response{data=response.MovementDetectionResponse,msg=string} "上报成功"
+// @Router /baby/iot/movement [post]
+func (d *DeviceTelemetryApi) ReportMovementDetection(c *gin.Context) {
+	device, ok := getSignedDevice(c)
+	if !ok {
+		return
+	}
+
+	var req request.DeviceMovementDetectionRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	detection, err := deviceTelemetryService.RecordMovementDetection(device, &req)
+	if err != nil {
+		global.GVA_LOG.Error("设备上报动作事件失败!", zap.Uint("deviceID", device.ID), zap.Error(err))
+		response.FailWithMessage("上报失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(detection, "上报成功", c)
+}
+
+// getSignedDevice 获取签名中间件校验通过的设备
+func getSignedDevice(c *gin.Context) (*baby.Device, bool) {
+	value, exist := c.Get("device")
+	if !exist {
+		response.NoAuth("设备未认证", c)
+		return nil, false
+	}
+	device, ok := value.(*baby.Device)
+	if !ok {
+		response.NoAuth("设备认证信息错误", c)
+		return nil, false
+	}
+	return device, true
+}
@@ -3,11 +3,51 @@ package baby
 import "baby_admin/server/service"
 
 type ApiGroup struct {
+	AnalysisReportApi
 	BabyProfileApi
+	CryDetectionApi
+	DeviceApi
+	DeviceCommandApi
+	DeviceTelemetryApi
+	FamilyApi
+	FavoriteApi
+	FeedingApi
+	GrowthRecordApi
+	HealthApi
+	MediaApi
+	MembershipAdminApi
+	MembershipApi
+	MilestoneApi
 	MusicApi
+	ParentingApi
+	PlaybackApi
+	SleepRecordApi
+	SmartAlertApi
+	VaccineAdminApi
+	VaccineApi
 }
 
 var (
-	babyProfileService = service.ServiceGroupApp.BabyServiceGroup.BabyProfileService
-	musicService       = service.ServiceGroupApp.BabyServiceGroup.MusicService
+	analysisReportService  = service.ServiceGroupApp.BabyServiceGroup.AnalysisReportService
+	babyProfileService     = service.ServiceGroupApp.BabyServiceGroup.BabyProfileService
+	cryDetectionService    = service.ServiceGroupApp.BabyServiceGroup.CryDetectionService
+	deviceService          = service.ServiceGroupApp.BabyServiceGroup.DeviceService
+	deviceCommandService   = service.ServiceGroupApp.BabyServiceGroup.DeviceCommandService
+	deviceTelemetryService = service.ServiceGroupApp.BabyServiceGroup.DeviceTelemetryService
+	familyService          = service.ServiceGroupApp.BabyServiceGroup.FamilyService
+	favoriteService        = service.ServiceGroupApp.BabyServiceGroup.FavoriteService
+	feedingService         = service.ServiceGroupApp.BabyServiceGroup.FeedingService
+	growthRecordService    = service.ServiceGroupApp.BabyServiceGroup.GrowthRecordService
+	healthService          = service.ServiceGroupApp.BabyServiceGroup.HealthService
+	mediaService           = service.ServiceGroupApp.BabyServiceGroup.MediaService
+	membershipAdminService = service.ServiceGroupApp.BabyServiceGroup.MembershipAdminService
+	membershipService      = service.ServiceGroupApp.BabyServiceGroup.MembershipService
+	milestoneService       = service.ServiceGroupApp.BabyServiceGroup.MilestoneService
+	musicService           = service.ServiceGroupApp.BabyServiceGroup.MusicService
+	parentingService       = service.ServiceGroupApp.BabyServiceGroup.ParentingService
+	playbackService        = service.ServiceGroupApp.BabyServiceGroup.PlaybackService
+	sleepRecordService     = service.ServiceGroupApp.BabyServiceGroup.SleepRecordService
+	smartAlertService      = service.ServiceGroupApp.BabyServiceGroup.SmartAlertService
+	vaccineAdminService    = service.ServiceGroupApp.BabyServiceGroup.VaccineAdminService
+	vaccineService         = service.ServiceGroupApp.BabyServiceGroup.VaccineService
 )
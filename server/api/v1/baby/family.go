@@ -0,0 +1,485 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type FamilyApi struct{}
+
+// CreateFamily 创建家庭
+// @Tags Family
+// @Summary 创建家庭
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateFamilyRequest true "家庭信息"
+// @Success 200 {object} response.Response{data=response.FamilyGroupResponse,msg=string} "创建成功"
+// @Router /baby/family [post]
+func (f *FamilyApi) CreateFamily(c *gin.Context) {
+	var req request.CreateFamilyRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	family, err := familyService.CreateFamily(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建家庭失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(family, "创建成功", c)
+}
+
+// GetMyFamilies 获取我的家庭
+// @Tags Family
+// @Summary 获取当前用户已加入及待审核的家庭列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]response.FamilyGroupResponse,msg=string} "获取成功"
+// @Router /baby/family/list [get]
+func (f *FamilyApi) GetMyFamilies(c *gin.Context) {
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := familyService.GetMyFamilies(customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取家庭列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetFamilyDetail 获取家庭详情
+// @Tags Family
+// @Summary 获取家庭成员、待审核申请及家庭中的宝宝
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "家庭ID"
+// @Success 200 {object} response.Response{data=response.FamilyDetailResponse,msg=string} "获取成功"
+// @Router /baby/family/{id} [get]
+func (f *FamilyApi) GetFamilyDetail(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	detail, err := familyService.GetFamilyDetail(customClaims.BaseClaims.ID, uint(id))
+	if err != nil {
+		global.GVA_LOG.Error("获取家庭详情失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(detail, "获取成功", c)
+}
+
+// UpdateFamily 更新家庭信息
+// @Tags Family
+// @Summary 更新家庭信息
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateFamilyRequest true "家庭信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /baby/family [put]
+func (f *FamilyApi) UpdateFamily(c *gin.Context) {
+	var req request.UpdateFamilyRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = familyService.UpdateFamily(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新家庭信息失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// RefreshInviteCode 生成邀请码
+// @Tags Family
+// @Summary 生成新的邀请码并设置加入角色和有效期，旧邀请码失效
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.RefreshInviteCodeRequest true "邀请设置"
+// @Success 200 {object} response.Response{data=response.FamilyInviteResponse,msg=string} "生成成功"
+// @Router /baby/family/invite [post]
+func (f *FamilyApi) RefreshInviteCode(c *gin.Context) {
+	var req request.RefreshInviteCodeRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	invite, err := familyService.RefreshInviteCode(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("生成邀请码失败!", zap.Error(err))
+		response.FailWithMessage("生成失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(invite, "生成成功", c)
+}
+
+// JoinFamily 申请加入家庭
+// @Tags Family
+// @Summary 通过邀请码申请加入家庭，需家长审核
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.JoinFamilyRequest true "邀请码"
+// @Success 200 {object} response.Response{data=response.FamilyGroupResponse,msg=string} "申请成功，等待审核"
+// @Router /baby/family/join [post]
+func (f *FamilyApi) JoinFamily(c *gin.Context) {
+	var req request.JoinFamilyRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	family, err := familyService.JoinFamily(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("申请加入家庭失败!", zap.Error(err))
+		response.FailWithMessage("申请失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(family, "申请成功，等待审核", c)
+}
+
+// ReviewMember 审核加入申请
+// @Tags Family
+// @Summary 审核加入申请
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ReviewFamilyMemberRequest true "审核结果"
+// @Success 200 {object} response.Response{msg=string} "审核成功"
+// @Router /baby/family/review [post]
+func (f *FamilyApi) ReviewMember(c *gin.Context) {
+	var req request.ReviewFamilyMemberRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = familyService.ReviewMember(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("审核加入申请失败!", zap.Error(err))
+		response.FailWithMessage("审核失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("审核成功", c)
+}
+
+// UpdateMember 更新家庭成员
+// @Tags Family
+// @Summary 修改成员角色及关系
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateFamilyMemberRequest true "成员信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /baby/family/member [put]
+func (f *FamilyApi) UpdateMember(c *gin.Context) {
+	var req request.UpdateFamilyMemberRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = familyService.UpdateMember(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新家庭成员失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// RemoveMember 移出家庭成员
+// @Tags Family
+// @Summary 移出家庭成员
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "成员ID"
+// @Success 200 {object} response.Response{msg=string} "移出成功"
+// @Router /baby/family/member/{id} [delete]
+func (f *FamilyApi) RemoveMember(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = familyService.RemoveMember(customClaims.BaseClaims.ID, uint(id))
+	if err != nil {
+		global.GVA_LOG.Error("移出家庭成员失败!", zap.Error(err))
+		response.FailWithMessage("移出失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("移出成功", c)
+}
+
+// LeaveFamily 退出家庭
+// @Tags Family
+// @Summary 退出家庭
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "家庭ID"
+// @Success 200 {object} response.Response{msg=string} "已退出家庭"
+// @Router /baby/family/leave/{id} [post]
+func (f *FamilyApi) LeaveFamily(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = familyService.LeaveFamily(customClaims.BaseClaims.ID, uint(id))
+	if err != nil {
+		global.GVA_LOG.Error("退出家庭失败!", zap.Error(err))
+		response.FailWithMessage("退出失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("已退出家庭", c)
+}
+
+// TransferFamily 转让家庭
+// @Tags Family
+// @Summary 将创建者身份转让给其他已加入的成员
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.TransferFamilyRequest true "转让信息"
+// @Success 200 {object} response.Response{msg=string} "转让成功"
+// @Router /baby/family/transfer [post]
+func (f *FamilyApi) TransferFamily(c *gin.Context) {
+	var req request.TransferFamilyRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = familyService.TransferFamily(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("转让家庭失败!", zap.Error(err))
+		response.FailWithMessage("转让失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("转让成功", c)
+}
+
+// DeleteFamily 解散家庭
+// @Tags Family
+// @Summary 解散家庭
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "家庭ID"
+// @Success 200 {object} response.Response{msg=string} "解散成功"
+// @Router /baby/family/{id} [delete]
+func (f *FamilyApi) DeleteFamily(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = familyService.DeleteFamily(customClaims.BaseClaims.ID, uint(id))
+	if err != nil {
+		global.GVA_LOG.Error("解散家庭失败!", zap.Error(err))
+		response.FailWithMessage("解散失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("解散成功", c)
+}
@@ -0,0 +1,333 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type FavoriteApi struct{}
+
+// ToggleFavorite 切换收藏状态
+// @Tags Favorite
+// @Summary 收藏或取消收藏文章、视频、音乐
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ToggleFavoriteRequest true "收藏内容"
+// @Success 200 {object} response.Response{data=response.FavoriteToggleResponse,msg=string} "操作成功"
+// @Router /baby/favorite/toggle [post]
+func (f *FavoriteApi) ToggleFavorite(c *gin.Context) {
+	var req request.ToggleFavoriteRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	result, err := favoriteService.ToggleFavorite(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("切换收藏状态失败!", zap.Error(err))
+		response.FailWithMessage("操作失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(result, "操作成功", c)
+}
+
+// GetFavoriteList 获取收藏列表
+// @Tags Favorite
+// @Summary 分页获取收藏列表，可按类型和收藏夹筛选
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.FavoriteSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.FavoriteListResponse,msg=string} "获取成功"
+// @Router /baby/favorite/list [get]
+func (f *FavoriteApi) GetFavoriteList(c *gin.Context) {
+	var req request.FavoriteSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := favoriteService.GetFavoriteList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取收藏列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// CheckFavorites 批量查询收藏状态
+// @Tags Favorite
+// @Summary 批量查询收藏状态
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CheckFavoriteRequest true "内容ID列表"
+// @Success 200 {object} response.Response{data=[]response.FavoriteStatus,msg=string} "获取成功"
+// @Router /baby/favorite/check [post]
+func (f *FavoriteApi) CheckFavorites(c *gin.Context) {
+	var req request.CheckFavoriteRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	statuses, err := favoriteService.CheckFavorites(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("查询收藏状态失败!", zap.Error(err))
+		response.FailWithMessage("查询失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(statuses, "获取成功", c)
+}
+
+// MoveFavorites 移动收藏
+// @Tags Favorite
+// @Summary 将收藏移动到指定收藏夹
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.MoveFavoritesRequest true "移动参数"
+// @Success 200 {object} response.Response{data=map[string]int64,msg=string} "移动成功"
+// @Router /baby/favorite/move [post]
+func (f *FavoriteApi) MoveFavorites(c *gin.Context) {
+	var req request.MoveFavoritesRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	count, err := favoriteService.MoveFavorites(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("移动收藏失败!", zap.Error(err))
+		response.FailWithMessage("移动失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(gin.H{"count": count}, "移动成功", c)
+}
+
+// GetFolderList 获取收藏夹列表
+// @Tags Favorite
+// @Summary 获取收藏夹列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]response.FavoriteFolderResponse,msg=string} "获取成功"
+// @Router /baby/favorite/folder/list [get]
+func (f *FavoriteApi) GetFolderList(c *gin.Context) {
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	folders, err := favoriteService.GetFolderList(customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取收藏夹列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(folders, "获取成功", c)
+}
+
+// CreateFolder 创建收藏夹
+// @Tags Favorite
+// @Summary 创建收藏夹
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateFavoriteFolderRequest true "收藏夹信息"
+// @Success 200 {object} response.Response{data=response.FavoriteFolderResponse,msg=string} "创建成功"
+// @Router /baby/favorite/folder [post]
+func (f *FavoriteApi) CreateFolder(c *gin.Context) {
+	var req request.CreateFavoriteFolderRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	folder, err := favoriteService.CreateFolder(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(folder, "创建成功", c)
+}
+
+// UpdateFolder 更新收藏夹
+// @Tags Favorite
+// @Summary 更新收藏夹
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateFavoriteFolderRequest true "收藏夹信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /baby/favorite/folder [put]
+func (f *FavoriteApi) UpdateFolder(c *gin.Context) {
+	var req request.UpdateFavoriteFolderRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = favoriteService.UpdateFolder(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteFolder 删除收藏夹
+// @Tags Favorite
+// @Summary 删除收藏夹，其中的收藏移回默认收藏夹
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "收藏夹ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /baby/favorite/folder/{id} [delete]
+func (f *FavoriteApi) DeleteFolder(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = favoriteService.DeleteFolder(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
@@ -0,0 +1,499 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type FeedingApi struct{}
+
+// StartBreastFeeding 开始母乳亲喂
+// @Tags Feeding
+// @Summary 开始母乳亲喂计时
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.StartBreastFeedingRequest true "亲喂信息"
+// @Success 200 {object} response.Response{data=response.FeedingRecordResponse,msg=string} "开始记录亲喂"
+// @Router /baby/feeding/breast/start [post]
+func (f *FeedingApi) StartBreastFeeding(c *gin.Context) {
+	var req request.StartBreastFeedingRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := feedingService.StartBreastFeeding(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("开始亲喂失败!", zap.Error(err))
+		response.FailWithMessage("操作失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "开始记录亲喂", c)
+}
+
+// SwitchBreastSide 切换哺乳侧
+// @Tags Feeding
+// @Summary 切换哺乳侧或从暂停继续计时
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.SwitchBreastSideRequest true "哺乳侧"
+// @Success 200 {object} response.Response{data=response.FeedingRecordResponse,msg=string} "已切换"
+// @Router /baby/feeding/breast/switch [post]
+func (f *FeedingApi) SwitchBreastSide(c *gin.Context) {
+	var req request.SwitchBreastSideRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := feedingService.SwitchBreastSide(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("切换哺乳侧失败!", zap.Error(err))
+		response.FailWithMessage("操作失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "已切换", c)
+}
+
+// PauseBreastFeeding 暂停亲喂计时
+// @Tags Feeding
+// @Summary 暂停亲喂计时
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "喂养记录ID"
+// @Success 200 {object} response.Response{data=response.FeedingRecordResponse,msg=string} "已暂停"
+// @Router /baby/feeding/breast/pause/{id} [post]
+func (f *FeedingApi) PauseBreastFeeding(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := feedingService.PauseBreastFeeding(customClaims.BaseClaims.ID, uint(id))
+	if err != nil {
+		global.GVA_LOG.Error("暂停亲喂失败!", zap.Error(err))
+		response.FailWithMessage("操作失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "已暂停", c)
+}
+
+// EndFeeding 结束喂养
+// @Tags Feeding
+// @Summary 结束喂养
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.EndFeedingRequest true "结束信息"
+// @Success 200 {object} response.Response{data=response.FeedingRecordResponse,msg=string} "喂养已结束"
+// @Router /baby/feeding/end [post]
+func (f *FeedingApi) EndFeeding(c *gin.Context) {
+	var req request.EndFeedingRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := feedingService.EndFeeding(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("结束喂养失败!", zap.Error(err))
+		response.FailWithMessage("操作失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "喂养已结束", c)
+}
+
+// CreateFeedingRecord 补录喂养记录
+// @Tags Feeding
+// @Summary 补录喂养记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateFeedingRecordRequest true "喂养记录信息"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /baby/feeding [post]
+func (f *FeedingApi) CreateFeedingRecord(c *gin.Context) {
+	var req request.CreateFeedingRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = feedingService.CreateFeedingRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建喂养记录失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("创建成功", c)
+}
+
+// GetFeedingRecord 获取喂养记录详情
+// @Tags Feeding
+// @Summary 获取喂养记录详情
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "喂养记录ID"
+// @Success 200 {object} response.Response{data=response.FeedingRecordResponse,msg=string} "获取成功"
+// @Router /baby/feeding/{id} [get]
+func (f *FeedingApi) GetFeedingRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := feedingService.GetFeedingRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取喂养记录失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "获取成功", c)
+}
+
+// GetCurrentFeeding 获取进行中的喂养
+// @Tags Feeding
+// @Summary 获取进行中的喂养
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param baby_id query int true "宝宝ID"
+// @Success 200 {object} response.Response{data=response.FeedingRecordResponse,msg=string} "获取成功"
+// @Router /baby/feeding/current [get]
+func (f *FeedingApi) GetCurrentFeeding(c *gin.Context) {
+	babyID, err := strconv.ParseUint(c.Query("baby_id"), 10, 32)
+	if err != nil || babyID == 0 {
+		response.FailWithMessage("宝宝ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := feedingService.GetCurrentFeeding(customClaims.BaseClaims.ID, uint(babyID))
+	if err != nil {
+		global.GVA_LOG.Error("获取进行中的喂养失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "获取成功", c)
+}
+
+// GetFeedingRecordList 获取喂养记录列表
+// @Tags Feeding
+// @Summary 分页获取喂养记录列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.FeedingRecordSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.FeedingRecordListResponse,msg=string} "获取成功"
+// @Router /baby/feeding/list [get]
+func (f *FeedingApi) GetFeedingRecordList(c *gin.Context) {
+	var req request.FeedingRecordSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := feedingService.GetFeedingRecordList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取喂养记录列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// UpdateFeedingRecord 修正喂养记录
+// @Tags Feeding
+// @Summary 修正喂养记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateFeedingRecordRequest true "喂养记录信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /baby/feeding [put]
+func (f *FeedingApi) UpdateFeedingRecord(c *gin.Context) {
+	var req request.UpdateFeedingRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = feedingService.UpdateFeedingRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新喂养记录失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteFeedingRecord 删除喂养记录
+// @Tags Feeding
+// @Summary 删除喂养记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "喂养记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /baby/feeding/{id} [delete]
+func (f *FeedingApi) DeleteFeedingRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = feedingService.DeleteFeedingRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除喂养记录失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetFeedingSummary 获取喂养日汇总
+// @Tags Feeding
+// @Summary 获取宝宝当日喂养次数、奶量、辅食及距上次喂养的间隔
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.FeedingSummaryRequest true "汇总条件"
+// @Success 200 {object} response.Response{data=response.FeedingDailySummary,msg=string} "获取成功"
+// @Router /baby/feeding/summary [get]
+func (f *FeedingApi) GetFeedingSummary(c *gin.Context) {
+	var req request.FeedingSummaryRequest
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	summary, err := feedingService.GetFeedingSummary(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取喂养汇总失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(summary, "获取成功", c)
+}
+
+// GetAllergenHistory 获取过敏原添加记录
+// @Tags Feeding
+// @Summary 获取过敏原添加记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param baby_id query int true "宝宝ID"
+// @Success 200 {object} response.Response{data=[]response.FeedingAllergenResponse,msg=string} "获取成功"
+// @Router /baby/feeding/allergens [get]
+func (f *FeedingApi) GetAllergenHistory(c *gin.Context) {
+	babyID, err := strconv.ParseUint(c.Query("baby_id"), 10, 32)
+	if err != nil || babyID == 0 {
+		response.FailWithMessage("宝宝ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := feedingService.GetAllergenHistory(customClaims.BaseClaims.ID, uint(babyID))
+	if err != nil {
+		global.GVA_LOG.Error("获取过敏原记录失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
@@ -0,0 +1,299 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type GrowthRecordApi struct{}
+
+// CreateGrowthRecord 创建成长记录
+// @Tags GrowthRecord
+// @Summary 创建成长记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateGrowthRecordRequest true "成长记录信息"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /baby/growth [post]
+func (g *GrowthRecordApi) CreateGrowthRecord(c *gin.Context) {
+	var req request.CreateGrowthRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = growthRecordService.CreateGrowthRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建成长记录失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("创建成功", c)
+}
+
+// GetGrowthRecord 获取成长记录详情
+// @Tags GrowthRecord
+// @Summary 获取成长记录详情
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "成长记录ID"
+// @Success 200 {object} response.Response{data=response.GrowthRecordResponse,msg=string} "获取成功"
+// @Router /baby/growth/{id} [get]
+func (g *GrowthRecordApi) GetGrowthRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := growthRecordService.GetGrowthRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取成长记录失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "获取成功", c)
+}
+
+// GetGrowthRecordList 获取成长记录列表
+// @Tags GrowthRecord
+// @Summary 获取成长记录列表，支持按日期范围、记录类型和标签筛选
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.GrowthRecordSearch true "搜索参数"
+// @Success 200 {object} response.Response{data=response.GrowthRecordListResponse,msg=string} "获取成功"
+// @Router /baby/growth/list [get]
+func (g *GrowthRecordApi) GetGrowthRecordList(c *gin.Context) {
+	var req request.GrowthRecordSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := growthRecordService.GetGrowthRecordList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取成长记录列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// UpdateGrowthRecord 更新成长记录
+// @Tags GrowthRecord
+// @Summary 更新成长记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateGrowthRecordRequest true "成长记录信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /baby/growth [put]
+func (g *GrowthRecordApi) UpdateGrowthRecord(c *gin.Context) {
+	var req request.UpdateGrowthRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = growthRecordService.UpdateGrowthRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新成长记录失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteGrowthRecord 删除成长记录
+// @Tags GrowthRecord
+// @Summary 删除成长记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "成长记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /baby/growth/{id} [delete]
+func (g *GrowthRecordApi) DeleteGrowthRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = growthRecordService.DeleteGrowthRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除成长记录失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetGrowthStatistics 获取成长统计
+// @Tags GrowthRecord
+// @Summary 获取宝宝成长统计
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param baby_id query int true "宝宝ID"
+// @Success 200 {object} response.Response{data=response.GrowthStatistics,msg=string} "获取成功"
+// @Router /baby/growth/statistics [get]
+func (g *GrowthRecordApi) GetGrowthStatistics(c *gin.Context) {
+	babyID, err := strconv.ParseUint(c.Query("baby_id"), 10, 32)
+	if err != nil || babyID == 0 {
+		response.FailWithMessage("宝宝ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	stats, err := growthRecordService.GetGrowthStatistics(customClaims.BaseClaims.ID, uint(babyID))
+	if err != nil {
+		global.GVA_LOG.Error("获取成长统计失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(stats, "获取成功", c)
+}
+
+// GetGrowthStandard 获取WHO生长标准评估
+// @Tags GrowthRecord
+// @Summary 按WHO 0-5岁生长标准计算宝宝体重、身长的百分位和Z评分，返回参考曲线
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param baby_id query int true "宝宝ID"
+// @Success 200 {object} response.Response{data=response.GrowthStandardResponse,msg=string} "获取成功"
+// @Router /baby/growth/standard [get]
+func (g *GrowthRecordApi) GetGrowthStandard(c *gin.Context) {
+	babyID, err := strconv.ParseUint(c.Query("baby_id"), 10, 32)
+	if err != nil || babyID == 0 {
+		response.FailWithMessage("宝宝ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	standard, err := growthRecordService.GetGrowthStandard(customClaims.BaseClaims.ID, uint(babyID))
+	if err != nil {
+		global.GVA_LOG.Error("获取生长标准评估失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(standard, "获取成功", c)
+}
@@ -0,0 +1,604 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type HealthApi struct{}
+
+// CreateDiaperRecord 记录换尿布
+// @Tags Health
+// @Summary 记录换尿布
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateDiaperRecordRequest true "换尿布记录信息"
+// @Success 200 {object} response.Response{data=response.DiaperRecordResponse,msg=string} "记录成功"
+// @Router /baby/health/diaper [post]
+func (h *HealthApi) CreateDiaperRecord(c *gin.Context) {
+	var req request.CreateDiaperRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := healthService.CreateDiaperRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建换尿布记录失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "记录成功", c)
+}
+
+// GetDiaperRecordList 获取换尿布记录列表
+// @Tags Health
+// @Summary 分页获取换尿布记录列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.HealthRecordSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.DiaperRecordListResponse,msg=string} "获取成功"
+// @Router /baby/health/diaper/list [get]
+func (h *HealthApi) GetDiaperRecordList(c *gin.Context) {
+	var req request.HealthRecordSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := healthService.GetDiaperRecordList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取换尿布记录列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// DeleteDiaperRecord 删除换尿布记录
+// @Tags Health
+// @Summary 删除换尿布记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "换尿布记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /baby/health/diaper/{id} [delete]
+func (h *HealthApi) DeleteDiaperRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = healthService.DeleteDiaperRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除换尿布记录失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
+
+// CreateTemperatureRecord 记录体温
+// @Tags Health
+// @Summary 记录体温
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateTemperatureRecordRequest true "体温记录信息"
+// @Success 200 {object} response.Response{data=response.TemperatureRecordResponse,msg=string} "记录成功"
+// @Router /baby/health/temperature [post]
+func (h *HealthApi) CreateTemperatureRecord(c *gin.Context) {
+	var req request.CreateTemperatureRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := healthService.CreateTemperatureRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建体温记录失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "记录成功", c)
+}
+
+// GetTemperatureRecordList 获取体温记录列表
+// @Tags Health
+// @Summary 分页获取体温记录列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.HealthRecordSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.TemperatureRecordListResponse,msg=string} "获取成功"
+// @Router /baby/health/temperature/list [get]
+func (h *HealthApi) GetTemperatureRecordList(c *gin.Context) {
+	var req request.HealthRecordSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := healthService.GetTemperatureRecordList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取体温记录列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// DeleteTemperatureRecord 删除体温记录
+// @Tags Health
+// @Summary 删除体温记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "体温记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /baby/health/temperature/{id} [delete]
+func (h *HealthApi) DeleteTemperatureRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = healthService.DeleteTemperatureRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除体温记录失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
+
+// CreateMedicationRecord 记录服药
+// @Tags Health
+// @Summary 记录服药
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateMedicationRecordRequest true "用药记录信息"
+// @Success 200 {object} response.Response{data=response.MedicationRecordResponse,msg=string} "记录成功"
+// @Router /baby/health/medication [post]
+func (h *HealthApi) CreateMedicationRecord(c *gin.Context) {
+	var req request.CreateMedicationRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := healthService.CreateMedicationRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建用药记录失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "记录成功", c)
+}
+
+// GetMedicationRecordList 获取用药记录列表
+// @Tags Health
+// @Summary 分页获取用药记录列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.HealthRecordSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.MedicationRecordListResponse,msg=string} "获取成功"
+// @Router /baby/health/medication/list [get]
+func (h *HealthApi) GetMedicationRecordList(c *gin.Context) {
+	var req request.HealthRecordSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := healthService.GetMedicationRecordList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取用药记录列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// DeleteMedicationRecord 删除用药记录
+// @Tags Health
+// @Summary 删除用药记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "用药记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /baby/health/medication/{id} [delete]
+func (h *HealthApi) DeleteMedicationRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = healthService.DeleteMedicationRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除用药记录失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetMedicationReminders 获取用药提醒
+// @Tags Health
+// @Summary 获取宝宝正在使用的药品及下次可服药时间
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param baby_id query int true "宝宝ID"
+// @Success 200 {object} response.Response{data=[]response.MedicationReminderResponse,msg=string} "获取成功"
+// @Router /baby/health/medication/reminders [get]
+func (h *HealthApi) GetMedicationReminders(c *gin.Context) {
+	babyID, err := strconv.ParseUint(c.Query("baby_id"), 10, 32)
+	if err != nil || babyID == 0 {
+		response.FailWithMessage("宝宝ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := healthService.GetMedicationReminders(customClaims.BaseClaims.ID, uint(babyID))
+	if err != nil {
+		global.GVA_LOG.Error("获取用药提醒失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// CreateSymptomRecord 记录症状
+// @Tags Health
+// @Summary 记录症状
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateSymptomRecordRequest true "症状记录信息"
+// @Success 200 {object} response.Response{data=response.SymptomRecordResponse,msg=string} "记录成功"
+// @Router /baby/health/symptom [post]
+func (h *HealthApi) CreateSymptomRecord(c *gin.Context) {
+	var req request.CreateSymptomRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := healthService.CreateSymptomRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建症状记录失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "记录成功", c)
+}
+
+// GetSymptomRecordList 获取症状记录列表
+// @Tags Health
+// @Summary 分页获取症状记录列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.HealthRecordSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.SymptomRecordListResponse,msg=string} "获取成功"
+// @Router /baby/health/symptom/list [get]
+func (h *HealthApi) GetSymptomRecordList(c *gin.Context) {
+	var req request.HealthRecordSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := healthService.GetSymptomRecordList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取症状记录列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// DeleteSymptomRecord 删除症状记录
+// @Tags Health
+// @Summary 删除症状记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "症状记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /baby/health/symptom/{id} [delete]
+func (h *HealthApi) DeleteSymptomRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = healthService.DeleteSymptomRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除症状记录失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetHealthSummary 获取健康日汇总
+// @Tags Health
+// @Summary 获取宝宝当日尿布、体温、用药、症状汇总
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.HealthSummaryRequest true "汇总条件"
+// @Success 200 {object} response.Response{data=response.HealthDailySummary,msg=string} "获取成功"
+// @Router /baby/health/summary [get]
+func (h *HealthApi) GetHealthSummary(c *gin.Context) {
+	var req request.HealthSummaryRequest
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	summary, err := healthService.GetHealthSummary(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取健康汇总失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(summary, "获取成功", c)
+}
@@ -0,0 +1,152 @@
+package baby
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	"baby_admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MediaApi struct{}
+
+// mediaProxyClient 代理远程存储的媒体，只限制建立连接和等待响应头的时间，不限制传输总时长
+var mediaProxyClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		ResponseHeaderTimeout: 15 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+	},
+}
+
+// mediaProxyRequestHeaders 转发给远程存储的请求头，用于分段和条件请求
+var mediaProxyRequestHeaders = []string{"Range", "If-Range", "If-None-Match", "If-Modified-Since"}
+
+// mediaProxyResponseHeaders 回传给客户端的远程存储响应头
+var mediaProxyResponseHeaders = []string{"Content-Type", "Content-Length", "Content-Range", "Accept-Ranges", "ETag", "Last-Modified"}
+
+// ServeMedia 媒体网关
+// @Tags Media
+// @Summary 校验签名后播放音视频，支持Range分段请求，其他存储由网关代理，不暴露源地址
+// @Produce octet-stream
+// @Param type path string true "媒体类型: audio, video"
+// @Param id path int true "音乐或视频ID"
+// @Param data query request.MediaRequest true "签名参数"
+// @Success 200 {file} file "媒体文件"
+// @Success 206 {file} file "媒体文件片段"
+// @Router /media/{type}/{id} [get]
+func (m *MediaApi) ServeMedia(c *gin.Context) {
+	var req request.MediaRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		mediaFail(c, http.StatusForbidden, "媒体地址签名无效")
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		mediaFail(c, http.StatusNotFound, "媒体文件不存在")
+		return
+	}
+
+	target, err := mediaService.ResolveMedia(c.Param("type"), uint(id), &req)
+	if err != nil {
+		mediaFail(c, http.StatusForbidden, err.Error())
+		return
+	}
+	maxAge := int(time.Until(target.ExpiresAt).Seconds())
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	if target.SourceURL != "" {
+		proxyMedia(c, target.SourceURL, maxAge)
+		return
+	}
+
+	file, err := os.Open(target.FilePath)
+	if err != nil {
+		global.GVA_LOG.Error("打开媒体文件失败!", zap.String("path", target.FilePath), zap.Error(err))
+		mediaFail(c, http.StatusNotFound, "媒体文件不存在")
+		return
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil || stat.IsDir() {
+		mediaFail(c, http.StatusNotFound, "媒体文件不存在")
+		return
+	}
+
+	ext := strings.ToLower(filepath.Ext(target.FilePath))
+	contentType, ok := utils.MediaContentTypes[ext]
+	if !ok {
+		contentType = mime.TypeByExtension(ext)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("ETag", fmt.Sprintf(`"%x-%x"`, stat.ModTime().UnixNano(), stat.Size()))
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+	// ServeContent 处理 Range/If-Range/If-None-Match，分段请求返回206
+	http.ServeContent(c.Writer, c.Request, stat.Name(), stat.ModTime(), file)
+}
+
+// proxyMedia 代理远程存储的媒体，转发分段请求并流式回传内容
+func proxyMedia(c *gin.Context, sourceURL string, maxAge int) {
+	proxyReq, err := http.NewRequestWithContext(c.Request.Context(), c.Request.Method, sourceURL, nil)
+	if err != nil {
+		global.GVA_LOG.Error("创建媒体代理请求失败!", zap.String("url", sourceURL), zap.Error(err))
+		mediaFail(c, http.StatusBadGateway, "媒体文件获取失败")
+		return
+	}
+	for _, header := range mediaProxyRequestHeaders {
+		if value := c.GetHeader(header); value != "" {
+			proxyReq.Header.Set(header, value)
+		}
+	}
+
+	resp, err := mediaProxyClient.Do(proxyReq)
+	if err != nil {
+		global.GVA_LOG.Error("获取远程媒体失败!", zap.String("url", sourceURL), zap.Error(err))
+		mediaFail(c, http.StatusBadGateway, "媒体文件获取失败")
+		return
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		mediaFail(c, http.StatusNotFound, "媒体文件不存在")
+		return
+	case resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusRequestedRangeNotSatisfiable:
+		global.GVA_LOG.Error("远程媒体返回异常状态!", zap.String("url", sourceURL), zap.Int("status", resp.StatusCode))
+		mediaFail(c, http.StatusBadGateway, "媒体文件获取失败")
+		return
+	}
+
+	for _, header := range mediaProxyResponseHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			c.Header(header, value)
+		}
+	}
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+	c.Status(resp.StatusCode)
+	if c.Request.Method == http.MethodHead {
+		return
+	}
+	// 客户端中途断开时复制失败属正常情况，不记录错误
+	_, _ = io.Copy(c.Writer, resp.Body)
+}
+
+func mediaFail(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, response.Response{Code: response.ERROR, Data: gin.H{}, Msg: message})
+}
@@ -0,0 +1,62 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MembershipApi struct{}
+
+// GetMyMembership 获取会员状态
+// @Tags Membership
+// @Summary 获取当前用户的会员状态及可开通的套餐
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=response.MembershipResponse,msg=string} "获取成功"
+// @Router /baby/membership [get]
+func (m *MembershipApi) GetMyMembership(c *gin.Context) {
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	membership, err := membershipService.GetMyMembership(customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取会员状态失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(membership, "获取成功", c)
+}
+
+// GetVipPlans 获取会员套餐
+// @Tags Membership
+// @Summary 获取会员套餐
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]response.VipPlanResponse,msg=string} "获取成功"
+// @Router /baby/membership/plans [get]
+func (m *MembershipApi) GetVipPlans(c *gin.Context) {
+	plans, err := membershipService.GetVipPlans()
+	if err != nil {
+		global.GVA_LOG.Error("获取会员套餐失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(plans, "获取成功", c)
+}
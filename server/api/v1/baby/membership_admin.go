@@ -0,0 +1,272 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	commonReq "baby_admin/server/model/common/request"
+	"baby_admin/server/model/common/response"
+	"baby_admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// MembershipAdminApi 会员套餐及用户会员管理，挂载在管理后台鉴权路由组
+type MembershipAdminApi struct{}
+
+// CreateVipPlan
+// @Tags      MembershipAdmin
+// @Summary   创建会员套餐
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.CreateVipPlanRequest   true  "会员套餐信息"
+// @Success   200   {object}  response.Response{msg=string}  "创建会员套餐"
+// @Router    /membershipAdmin/createVipPlan [post]
+func (m *MembershipAdminApi) CreateVipPlan(c *gin.Context) {
+	var req request.CreateVipPlanRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	_, err = membershipAdminService.CreateVipPlan(&req)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// UpdateVipPlan
+// @Tags      MembershipAdmin
+// @Summary   更新会员套餐
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.UpdateVipPlanRequest   true  "会员套餐信息"
+// @Success   200   {object}  response.Response{msg=string}  "更新会员套餐"
+// @Router    /membershipAdmin/updateVipPlan [put]
+func (m *MembershipAdminApi) UpdateVipPlan(c *gin.Context) {
+	var req request.UpdateVipPlanRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = membershipAdminService.UpdateVipPlan(&req)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteVipPlan
+// @Tags      MembershipAdmin
+// @Summary   删除会员套餐
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.GetById                true  "会员套餐ID"
+// @Success   200   {object}  response.Response{msg=string}  "删除会员套餐"
+// @Router    /membershipAdmin/deleteVipPlan [delete]
+func (m *MembershipAdminApi) DeleteVipPlan(c *gin.Context) {
+	var req commonReq.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = membershipAdminService.DeleteVipPlan(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetVipPlanList
+// @Tags      MembershipAdmin
+// @Summary   分页获取会员套餐列表
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.VipPlanSearch                                   true  "页码, 每页大小, 搜索条件"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取会员套餐列表,返回包括列表,总数,页码,每页数量"
+// @Router    /membershipAdmin/getVipPlanList [get]
+func (m *MembershipAdminApi) GetVipPlanList(c *gin.Context) {
+	var req request.VipPlanSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+	list, total, err := membershipAdminService.GetVipPlanList(&req)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// GrantMembership
+// @Tags      MembershipAdmin
+// @Summary   开通会员
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.MembershipChangeRequest true  "用户ID及套餐或天数"
+// @Success   200   {object}  response.Response{msg=string}  "开通会员"
+// @Router    /membershipAdmin/grantMembership [post]
+func (m *MembershipAdminApi) GrantMembership(c *gin.Context) {
+	var req request.MembershipChangeRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = membershipAdminService.GrantMembership(utils.GetUserID(c), &req)
+	if err != nil {
+		global.GVA_LOG.Error("开通失败!", zap.Error(err))
+		response.FailWithMessage("开通失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("开通成功", c)
+}
+
+// ExtendMembership
+// @Tags      MembershipAdmin
+// @Summary   延长会员
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.MembershipChangeRequest true  "用户ID及套餐或天数"
+// @Success   200   {object}  response.Response{msg=string}  "延长会员"
+// @Router    /membershipAdmin/extendMembership [post]
+func (m *MembershipAdminApi) ExtendMembership(c *gin.Context) {
+	var req request.MembershipChangeRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = membershipAdminService.ExtendMembership(utils.GetUserID(c), &req)
+	if err != nil {
+		global.GVA_LOG.Error("延期失败!", zap.Error(err))
+		response.FailWithMessage("延期失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("延期成功", c)
+}
+
+// RevokeMembership
+// @Tags      MembershipAdmin
+// @Summary   撤销会员
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.RevokeMembershipRequest true  "用户ID及撤销原因"
+// @Success   200   {object}  response.Response{msg=string}  "撤销会员"
+// @Router    /membershipAdmin/revokeMembership [post]
+func (m *MembershipAdminApi) RevokeMembership(c *gin.Context) {
+	var req request.RevokeMembershipRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = membershipAdminService.RevokeMembership(utils.GetUserID(c), &req)
+	if err != nil {
+		global.GVA_LOG.Error("撤销失败!", zap.Error(err))
+		response.FailWithMessage("撤销失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("撤销成功", c)
+}
+
+// GetMemberList
+// @Tags      MembershipAdmin
+// @Summary   分页获取会员用户列表
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.MemberSearch                                    true  "页码, 每页大小, 搜索条件"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取会员用户列表,返回包括列表,总数,页码,每页数量"
+// @Router    /membershipAdmin/getMemberList [get]
+func (m *MembershipAdminApi) GetMemberList(c *gin.Context) {
+	var req request.MemberSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+	list, total, err := membershipAdminService.GetMemberList(&req)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// GetMembershipLogList
+// @Tags      MembershipAdmin
+// @Summary   分页获取会员变更记录
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.MembershipLogSearch                             true  "页码, 每页大小, 搜索条件"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取会员变更记录,返回包括列表,总数,页码,每页数量"
+// @Router    /membershipAdmin/getMembershipLogList [get]
+func (m *MembershipAdminApi) GetMembershipLogList(c *gin.Context) {
+	var req request.MembershipLogSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+	list, total, err := membershipAdminService.GetMembershipLogList(&req)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
@@ -0,0 +1,132 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MilestoneApi struct{}
+
+// GetMilestoneChecklist 获取里程碑清单
+// @Tags Milestone
+// @Summary 获取宝宝当前月龄的里程碑清单及逾期未达成的重要里程碑
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.MilestoneChecklistRequest true "查询条件"
+// @Success 200 {object} response.Response{data=response.MilestoneChecklistResponse,msg=string} "获取成功"
+// @Router /baby/milestone/checklist [get]
+func (m *MilestoneApi) GetMilestoneChecklist(c *gin.Context) {
+	var req request.MilestoneChecklistRequest
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	checklist, err := milestoneService.GetMilestoneChecklist(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取里程碑清单失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(checklist, "获取成功", c)
+}
+
+// AchieveMilestone 标记里程碑达成
+// @Tags Milestone
+// @Summary 标记里程碑达成并关联里程碑类型的成长记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.AchieveMilestoneRequest true "达成信息"
+// @Success 200 {object} response.Response{data=response.BabyMilestoneResponse,msg=string} "标记成功"
+// @Router /baby/milestone/achieve [post]
+func (m *MilestoneApi) AchieveMilestone(c *gin.Context) {
+	var req request.AchieveMilestoneRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	milestone, err := milestoneService.AchieveMilestone(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("标记里程碑达成失败!", zap.Error(err))
+		response.FailWithMessage("标记失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(milestone, "标记成功", c)
+}
+
+// CancelMilestone 取消里程碑达成
+// @Tags Milestone
+// @Summary 取消里程碑达成
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CancelMilestoneRequest true "里程碑信息"
+// @Success 200 {object} response.Response{msg=string} "取消成功"
+// @Router /baby/milestone/achieve [delete]
+func (m *MilestoneApi) CancelMilestone(c *gin.Context) {
+	var req request.CancelMilestoneRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = milestoneService.CancelMilestone(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("取消里程碑达成失败!", zap.Error(err))
+		response.FailWithMessage("取消失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("取消成功", c)
+}
@@ -259,11 +259,11 @@ func (m *MusicApi) GetUserFavorites(c *gin.Context) {
 // @Security ApiKeyAuth
 // @accept application/json
 // @Produce application/json
-// @Param data query commonReq.PageInfo true "分页参数"
+// @Param data query request.MusicHistorySearch true "分页参数及宝宝ID"
 // @Success 200 {object} response.Response{data=[]response.MusicHistoryResponse,msg=string} "获取成功"
 // @Router /music/history [get]
 func (m *MusicApi) GetPlayHistory(c *gin.Context) {
-	var req commonReq.PageInfo
+	var req request.MusicHistorySearch
 	err := c.ShouldBindQuery(&req)
 	if err != nil {
 		response.FailWithMessage(err.Error(), c)
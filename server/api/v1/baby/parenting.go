@@ -0,0 +1,425 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	commonReq "baby_admin/server/model/common/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type ParentingApi struct{}
+
+// GetParentingCategories 获取育儿分类
+// @Tags Parenting
+// @Summary 获取育儿分类
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]response.ParentingCategoryResponse,msg=string} "获取成功"
+// @Router /baby/parenting/categories [get]
+func (p *ParentingApi) GetParentingCategories(c *gin.Context) {
+	categories, err := parentingService.GetParentingCategories()
+	if err != nil {
+		global.GVA_LOG.Error("获取育儿分类失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(categories, "获取成功", c)
+}
+
+// GetArticleList 获取育儿文章列表
+// @Tags Parenting
+// @Summary 按分类、年龄段、难度和标签分页获取育儿文章
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.ParentingArticleSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.ParentingArticleListResponse,msg=string} "获取成功"
+// @Router /baby/parenting/article/list [get]
+func (p *ParentingApi) GetArticleList(c *gin.Context) {
+	var req request.ParentingArticleSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := parentingService.GetArticleList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取育儿文章列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetArticleDetail 获取育儿文章详情
+// @Tags Parenting
+// @Summary 获取育儿文章详情
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "文章ID"
+// @Success 200 {object} response.Response{data=response.ParentingArticleResponse,msg=string} "获取成功"
+// @Router /baby/parenting/article/{id} [get]
+func (p *ParentingApi) GetArticleDetail(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	article, err := parentingService.GetArticleDetail(customClaims.BaseClaims.ID, uint(id))
+	if err != nil {
+		global.GVA_LOG.Error("获取育儿文章详情失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(article, "获取成功", c)
+}
+
+// GetVideoList 获取育儿视频列表
+// @Tags Parenting
+// @Summary 按分类、年龄段和标签分页获取育儿视频
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.ParentingVideoSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.ParentingVideoListResponse,msg=string} "获取成功"
+// @Router /baby/parenting/video/list [get]
+func (p *ParentingApi) GetVideoList(c *gin.Context) {
+	var req request.ParentingVideoSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := parentingService.GetVideoList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取育儿视频列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetVideoDetail 获取育儿视频详情
+// @Tags Parenting
+// @Summary 获取育儿视频详情
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "视频ID"
+// @Success 200 {object} response.Response{data=response.ParentingVideoResponse,msg=string} "获取成功"
+// @Router /baby/parenting/video/{id} [get]
+func (p *ParentingApi) GetVideoDetail(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	video, err := parentingService.GetVideoDetail(customClaims.BaseClaims.ID, uint(id))
+	if err != nil {
+		global.GVA_LOG.Error("获取育儿视频详情失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(video, "获取成功", c)
+}
+
+// GetBabyFeed 获取宝宝专属育儿内容
+// @Tags Parenting
+// @Summary 按宝宝月龄推荐文章和视频，未指定宝宝时使用当前宝宝
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.GetRecommendationsRequest true "宝宝ID和数量"
+// @Success 200 {object} response.Response{data=response.ParentingRecommendationResponse,msg=string} "获取成功"
+// @Router /baby/parenting/feed [get]
+func (p *ParentingApi) GetBabyFeed(c *gin.Context) {
+	var req request.GetRecommendationsRequest
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	feed, err := parentingService.GetBabyFeed(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取育儿推荐失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(feed, "获取成功", c)
+}
+
+// ReportArticleProgress 上报文章阅读进度
+// @Tags Parenting
+// @Summary 上报文章阅读进度，每人每天首次阅读计入浏览量
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ReadArticleRequest true "阅读进度"
+// @Success 200 {object} response.Response{data=response.ArticleReadProgressResponse,msg=string} "上报成功"
+// @Router /baby/parenting/article/progress [post]
+func (p *ParentingApi) ReportArticleProgress(c *gin.Context) {
+	var req request.ReadArticleRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	progress, err := parentingService.ReportArticleProgress(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("上报文章阅读进度失败!", zap.Error(err))
+		response.FailWithMessage("上报失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(progress, "上报成功", c)
+}
+
+// ReportVideoProgress 上报视频观看进度
+// @Tags Parenting
+// @Summary 上报视频观看进度和播放位置，每人每天首次观看计入观看量
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.WatchVideoRequest true "观看进度"
+// @Success 200 {object} response.Response{data=response.VideoWatchProgressResponse,msg=string} "上报成功"
+// @Router /baby/parenting/video/progress [post]
+func (p *ParentingApi) ReportVideoProgress(c *gin.Context) {
+	var req request.WatchVideoRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	progress, err := parentingService.ReportVideoProgress(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("上报视频观看进度失败!", zap.Error(err))
+		response.FailWithMessage("上报失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(progress, "上报成功", c)
+}
+
+// GetContinueReading 获取继续阅读列表
+// @Tags Parenting
+// @Summary 获取继续阅读列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query commonReq.PageInfo true "分页参数"
+// @Success 200 {object} response.Response{data=response.ArticleReadProgressListResponse,msg=string} "获取成功"
+// @Router /baby/parenting/article/continue [get]
+func (p *ParentingApi) GetContinueReading(c *gin.Context) {
+	var req commonReq.PageInfo
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := parentingService.GetContinueReading(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取继续阅读列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetContinueWatching 获取继续观看列表
+// @Tags Parenting
+// @Summary 获取继续观看列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query commonReq.PageInfo true "分页参数"
+// @Success 200 {object} response.Response{data=response.VideoWatchProgressListResponse,msg=string} "获取成功"
+// @Router /baby/parenting/video/continue [get]
+func (p *ParentingApi) GetContinueWatching(c *gin.Context) {
+	var req commonReq.PageInfo
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := parentingService.GetContinueWatching(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取继续观看列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
@@ -0,0 +1,416 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PlaybackApi struct{}
+
+// StartSession 开始播放会话
+// @Tags Playback
+// @Summary 按播放列表或音乐队列开始播放，可设置循环模式、睡眠定时和淡出时长
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.StartPlaybackRequest true "播放设置"
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "开始播放"
+// @Router /baby/playback/start [post]
+func (p *PlaybackApi) StartSession(c *gin.Context) {
+	var req request.StartPlaybackRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	session, err := playbackService.StartSession(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("开始播放会话失败!", zap.Error(err))
+		response.FailWithMessage("播放失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "开始播放", c)
+}
+
+// GetCurrentSession 获取当前播放会话
+// @Tags Playback
+// @Summary 获取未结束的播放会话，用于继续播放，没有时返回空
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "获取成功"
+// @Router /baby/playback/current [get]
+func (p *PlaybackApi) GetCurrentSession(c *gin.Context) {
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	session, err := playbackService.GetCurrentSession(customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取播放会话失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "获取成功", c)
+}
+
+// ReportProgress 同步播放进度
+// @Tags Playback
+// @Summary 同步当前播放位置并累计单曲播放时长，睡眠定时到达或队列播完时会话结束
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.PlaybackProgressRequest true "播放进度"
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "同步成功"
+// @Router /baby/playback/progress [post]
+func (p *PlaybackApi) ReportProgress(c *gin.Context) {
+	var req request.PlaybackProgressRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	session, err := playbackService.ReportProgress(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("同步播放进度失败!", zap.Error(err))
+		response.FailWithMessage("同步失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "同步成功", c)
+}
+
+// PauseSession 暂停播放
+// @Tags Playback
+// @Summary 暂停播放会话并记录最后的进度
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.PlaybackProgressRequest true "播放进度"
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "已暂停"
+// @Router /baby/playback/pause [post]
+func (p *PlaybackApi) PauseSession(c *gin.Context) {
+	var req request.PlaybackProgressRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	session, err := playbackService.PauseSession(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("暂停播放失败!", zap.Error(err))
+		response.FailWithMessage("暂停失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "已暂停", c)
+}
+
+// ResumeSession 继续播放
+// @Tags Playback
+// @Summary 继续播放会话，可切换到音响设备或切回小程序
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ResumePlaybackRequest true "会话ID及播放设备"
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "继续播放"
+// @Router /baby/playback/resume [post]
+func (p *PlaybackApi) ResumeSession(c *gin.Context) {
+	var req request.ResumePlaybackRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	session, err := playbackService.ResumeSession(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("继续播放失败!", zap.Error(err))
+		response.FailWithMessage("继续播放失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "继续播放", c)
+}
+
+// StopSession 停止播放
+// @Tags Playback
+// @Summary 结束播放会话，每首音乐按实际播放时长写入播放历史
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.PlaybackProgressRequest true "播放进度"
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "已停止"
+// @Router /baby/playback/stop [post]
+func (p *PlaybackApi) StopSession(c *gin.Context) {
+	var req request.PlaybackProgressRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	session, err := playbackService.StopSession(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("停止播放失败!", zap.Error(err))
+		response.FailWithMessage("停止失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "已停止", c)
+}
+
+// UpdateSettings 更新播放设置
+// @Tags Playback
+// @Summary 修改循环模式、睡眠定时和淡出时长，睡眠定时从修改时重新计时
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdatePlaybackSettingsRequest true "播放设置"
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "更新成功"
+// @Router /baby/playback/settings [put]
+func (p *PlaybackApi) UpdateSettings(c *gin.Context) {
+	var req request.UpdatePlaybackSettingsRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	session, err := playbackService.UpdateSettings(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新播放设置失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "更新成功", c)
+}
+
+// DeviceResumeSession 音响设备接续播放
+// @Tags Playback
+// @Summary 音响设备继续本设备上的会话，或接手设备所有者最近的会话
+// @accept application/json
+// @Produce application/json
+// @Param X-Device-Id header string true "设备ID"
+// @Param X-Timestamp header string true "Unix时间戳(秒)"
+// @Param X-Nonce header string true "随机串(8-64位)"
+// @Param X-Signature header string true "HMAC-SHA256签名"
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "继续播放"
+// @Router /baby/iot/playback/resume [post]
+func (p *PlaybackApi) DeviceResumeSession(c *gin.Context) {
+	device, ok := getSignedDevice(c)
+	if !ok {
+		return
+	}
+
+	session, err := playbackService.DeviceResumeSession(device)
+	if err != nil {
+		global.GVA_LOG.Error("设备继续播放失败!", zap.Uint("deviceID", device.ID), zap.Error(err))
+		response.FailWithMessage("继续播放失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "继续播放", c)
+}
+
+// DeviceReportProgress 音响设备同步播放进度
+// @Tags Playback
+// @Summary 音响设备同步播放进度
+// @accept application/json
+// @Produce application/json
+// @Param X-Device-Id header string true "设备ID"
+// @Param X-Timestamp header string true "Unix时间戳(秒)"
+// @Param X-Nonce header string true "随机串(8-64位)"
+// @Param X-Signature header string true "HMAC-SHA256签名"
+// @Param data body request.PlaybackProgressRequest true "播放进度"
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "同步成功"
+// @Router /baby/iot/playback/progress [post]
+func (p *PlaybackApi) DeviceReportProgress(c *gin.Context) {
+	device, ok := getSignedDevice(c)
+	if !ok {
+		return
+	}
+
+	var req request.PlaybackProgressRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	session, err := playbackService.DeviceReportProgress(device, &req)
+	if err != nil {
+		global.GVA_LOG.Error("设备同步播放进度失败!", zap.Uint("deviceID", device.ID), zap.Error(err))
+		response.FailWithMessage("同步失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "同步成功", c)
+}
+
+// DevicePauseSession 音响设备暂停播放
+// @Tags Playback
+// @Summary 音响设备暂停播放
+// @accept application/json
+// @Produce application/json
+// @Param X-Device-Id header string true "设备ID"
+// @Param X-Timestamp header string true "Unix时间戳(秒)"
+// @Param X-Nonce header string true "随机串(8-64位)"
+// @Param X-Signature header string true "HMAC-SHA256签名"
+// @Param data body request.PlaybackProgressRequest true "播放进度"
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "已暂停"
+// @Router /baby/iot/playback/pause [post]
+func (p *PlaybackApi) DevicePauseSession(c *gin.Context) {
+	device, ok := getSignedDevice(c)
+	if !ok {
+		return
+	}
+
+	var req request.PlaybackProgressRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	session, err := playbackService.DevicePauseSession(device, &req)
+	if err != nil {
+		global.GVA_LOG.Error("设备暂停播放失败!", zap.Uint("deviceID", device.ID), zap.Error(err))
+		response.FailWithMessage("暂停失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "已暂停", c)
+}
+
+// DeviceStopSession 音响设备停止播放并结束会话
+// @Tags Playback
+// @Summary 音响设备停止播放并结束会话
+// @accept application/json
+// @Produce application/json
+// @Param X-Device-Id header string true "设备ID"
+// @Param X-Timestamp header string true "Unix时间戳(秒)"
+// @Param X-Nonce header string true "随机串(8-64位)"
+// @Param X-Signature header string true "HMAC-SHA256签名"
+// @Param data body request.PlaybackProgressRequest true "播放进度"
+// @Success 200 {object} response.Response{data=response.PlaybackSessionResponse,msg=string} "已停止"
+// @Router /baby/iot/playback/stop [post]
+func (p *PlaybackApi) DeviceStopSession(c *gin.Context) {
+	device, ok := getSignedDevice(c)
+	if !ok {
+		return
+	}
+
+	var req request.PlaybackProgressRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	session, err := playbackService.DeviceStopSession(device, &req)
+	if err != nil {
+		global.GVA_LOG.Error("设备停止播放失败!", zap.Uint("deviceID", device.ID), zap.Error(err))
+		response.FailWithMessage("停止失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(session, "已停止", c)
+}
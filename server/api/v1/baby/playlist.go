@@ -0,0 +1,428 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	commonReq "baby_admin/server/model/common/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+// CreatePlaylist 创建播放列表
+// @Tags Music
+// @Summary 创建播放列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreatePlaylistRequest true "播放列表信息"
+// @Success 200 {object} response.Response{data=response.PlaylistResponse,msg=string} "创建成功"
+// @Router /music/playlist [post]
+func (m *MusicApi) CreatePlaylist(c *gin.Context) {
+	var req request.CreatePlaylistRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	playlist, err := musicService.CreatePlaylist(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建播放列表失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(playlist, "创建成功", c)
+}
+
+// UpdatePlaylist 更新播放列表
+// @Tags Music
+// @Summary 更新播放列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdatePlaylistRequest true "播放列表信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /music/playlist [put]
+func (m *MusicApi) UpdatePlaylist(c *gin.Context) {
+	var req request.UpdatePlaylistRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = musicService.UpdatePlaylist(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新播放列表失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeletePlaylist 删除播放列表
+// @Tags Music
+// @Summary 删除播放列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "播放列表ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /music/playlist/{id} [delete]
+func (m *MusicApi) DeletePlaylist(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = musicService.DeletePlaylist(customClaims.BaseClaims.ID, uint(id))
+	if err != nil {
+		global.GVA_LOG.Error("删除播放列表失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetPlaylistDetail 获取播放列表详情
+// @Tags Music
+// @Summary 获取自己的或公开的播放列表及其中的音乐
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "播放列表ID"
+// @Success 200 {object} response.Response{data=response.PlaylistResponse,msg=string} "获取成功"
+// @Router /music/playlist/{id} [get]
+func (m *MusicApi) GetPlaylistDetail(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	playlist, err := musicService.GetPlaylistDetail(customClaims.BaseClaims.ID, uint(id))
+	if err != nil {
+		global.GVA_LOG.Error("获取播放列表详情失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(playlist, "获取成功", c)
+}
+
+// GetMyPlaylists 获取我的播放列表
+// @Tags Music
+// @Summary 获取我的播放列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query commonReq.PageInfo true "分页参数"
+// @Success 200 {object} response.Response{data=response.PlaylistListResponse,msg=string} "获取成功"
+// @Router /music/playlist/list [get]
+func (m *MusicApi) GetMyPlaylists(c *gin.Context) {
+	var req commonReq.PageInfo
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := musicService.GetMyPlaylists(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取播放列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetPublicPlaylists 浏览公开播放列表
+// @Tags Music
+// @Summary 浏览公开播放列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.PlaylistSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.PlaylistListResponse,msg=string} "获取成功"
+// @Router /music/playlist/public [get]
+func (m *MusicApi) GetPublicPlaylists(c *gin.Context) {
+	var req request.PlaylistSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := musicService.GetPublicPlaylists(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取公开播放列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// AddMusicToPlaylist 添加音乐到播放列表
+// @Tags Music
+// @Summary 将音乐追加到播放列表末尾，已在列表中的音乐跳过
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.AddMusicToPlaylistRequest true "播放列表ID及音乐ID"
+// @Success 200 {object} response.Response{data=int,msg=string} "添加成功"
+// @Router /music/playlist/musics [post]
+func (m *MusicApi) AddMusicToPlaylist(c *gin.Context) {
+	var req request.AddMusicToPlaylistRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	added, err := musicService.AddMusicToPlaylist(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("添加音乐到播放列表失败!", zap.Error(err))
+		response.FailWithMessage("添加失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(added, "添加成功", c)
+}
+
+// RemoveMusicFromPlaylist 从播放列表移除音乐
+// @Tags Music
+// @Summary 从播放列表移除音乐
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.RemoveMusicFromPlaylistRequest true "播放列表ID及音乐ID"
+// @Success 200 {object} response.Response{msg=string} "移除成功"
+// @Router /music/playlist/musics [delete]
+func (m *MusicApi) RemoveMusicFromPlaylist(c *gin.Context) {
+	var req request.RemoveMusicFromPlaylistRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = musicService.RemoveMusicFromPlaylist(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("从播放列表移除音乐失败!", zap.Error(err))
+		response.FailWithMessage("移除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("移除成功", c)
+}
+
+// ReorderPlaylist 调整播放列表顺序
+// @Tags Music
+// @Summary 按传入的音乐ID顺序重排播放列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ReorderPlaylistRequest true "播放列表ID及全部音乐的新顺序"
+// @Success 200 {object} response.Response{msg=string} "排序成功"
+// @Router /music/playlist/reorder [put]
+func (m *MusicApi) ReorderPlaylist(c *gin.Context) {
+	var req request.ReorderPlaylistRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = musicService.ReorderPlaylist(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("调整播放列表顺序失败!", zap.Error(err))
+		response.FailWithMessage("排序失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("排序成功", c)
+}
+
+// ClonePlaylist 复制播放列表
+// @Tags Music
+// @Summary 将公开的播放列表复制到自己名下
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ClonePlaylistRequest true "播放列表ID及新名称"
+// @Success 200 {object} response.Response{data=response.PlaylistResponse,msg=string} "复制成功"
+// @Router /music/playlist/clone [post]
+func (m *MusicApi) ClonePlaylist(c *gin.Context) {
+	var req request.ClonePlaylistRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	playlist, err := musicService.ClonePlaylist(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("复制播放列表失败!", zap.Error(err))
+		response.FailWithMessage("复制失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(playlist, "复制成功", c)
+}
@@ -0,0 +1,380 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type SleepRecordApi struct{}
+
+// StartSleep 开始睡眠
+// @Tags SleepRecord
+// @Summary 开始睡眠
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.StartSleepRequest true "睡眠信息"
+// @Success 200 {object} response.Response{data=response.SleepRecordResponse,msg=string} "开始记录睡眠"
+// @Router /baby/sleep/start [post]
+func (s *SleepRecordApi) StartSleep(c *gin.Context) {
+	var req request.StartSleepRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := sleepRecordService.StartSleep(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("开始睡眠失败!", zap.Error(err))
+		response.FailWithMessage("操作失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "开始记录睡眠", c)
+}
+
+// EndSleep 结束睡眠
+// @Tags SleepRecord
+// @Summary 结束睡眠
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.EndSleepRequest true "结束信息"
+// @Success 200 {object} response.Response{data=response.SleepRecordResponse,msg=string} "睡眠已结束"
+// @Router /baby/sleep/end [post]
+func (s *SleepRecordApi) EndSleep(c *gin.Context) {
+	var req request.EndSleepRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := sleepRecordService.EndSleep(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("结束睡眠失败!", zap.Error(err))
+		response.FailWithMessage("操作失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "睡眠已结束", c)
+}
+
+// CreateSleepRecord 补录睡眠记录
+// @Tags SleepRecord
+// @Summary 补录睡眠记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateSleepRecordRequest true "睡眠记录信息"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /baby/sleep [post]
+func (s *SleepRecordApi) CreateSleepRecord(c *gin.Context) {
+	var req request.CreateSleepRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = sleepRecordService.CreateSleepRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建睡眠记录失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("创建成功", c)
+}
+
+// GetSleepRecord 获取睡眠记录详情
+// @Tags SleepRecord
+// @Summary 获取睡眠记录详情
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "睡眠记录ID"
+// @Success 200 {object} response.Response{data=response.SleepRecordResponse,msg=string} "获取成功"
+// @Router /baby/sleep/{id} [get]
+func (s *SleepRecordApi) GetSleepRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := sleepRecordService.GetSleepRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取睡眠记录失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "获取成功", c)
+}
+
+// GetCurrentSleep 获取进行中的睡眠
+// @Tags SleepRecord
+// @Summary 获取进行中的睡眠
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param baby_id query int true "宝宝ID"
+// @Success 200 {object} response.Response{data=response.SleepRecordResponse,msg=string} "获取成功"
+// @Router /baby/sleep/current [get]
+func (s *SleepRecordApi) GetCurrentSleep(c *gin.Context) {
+	babyID, err := strconv.ParseUint(c.Query("baby_id"), 10, 32)
+	if err != nil || babyID == 0 {
+		response.FailWithMessage("宝宝ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := sleepRecordService.GetCurrentSleep(customClaims.BaseClaims.ID, uint(babyID))
+	if err != nil {
+		global.GVA_LOG.Error("获取进行中的睡眠失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "获取成功", c)
+}
+
+// GetSleepRecordList 获取睡眠记录列表
+// @Tags SleepRecord
+// @Summary 分页获取睡眠记录列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.SleepRecordSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.SleepRecordListResponse,msg=string} "获取成功"
+// @Router /baby/sleep/list [get]
+func (s *SleepRecordApi) GetSleepRecordList(c *gin.Context) {
+	var req request.SleepRecordSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := sleepRecordService.GetSleepRecordList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取睡眠记录列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// UpdateSleepRecord 修正睡眠记录
+// @Tags SleepRecord
+// @Summary 修正睡眠记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateSleepRecordRequest true "睡眠记录信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /baby/sleep [put]
+func (s *SleepRecordApi) UpdateSleepRecord(c *gin.Context) {
+	var req request.UpdateSleepRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = sleepRecordService.UpdateSleepRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新睡眠记录失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteSleepRecord 删除睡眠记录
+// @Tags SleepRecord
+// @Summary 删除睡眠记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "睡眠记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /baby/sleep/{id} [delete]
+func (s *SleepRecordApi) DeleteSleepRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = sleepRecordService.DeleteSleepRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除睡眠记录失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetSleepStatistics 获取睡眠统计
+// @Tags SleepRecord
+// @Summary 获取宝宝每日睡眠汇总及7/30天趋势
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.SleepStatisticsRequest true "统计条件"
+// @Success 200 {object} response.Response{data=response.SleepAnalytics,msg=string} "获取成功"
+// @Router /baby/sleep/statistics [get]
+func (s *SleepRecordApi) GetSleepStatistics(c *gin.Context) {
+	var req request.SleepStatisticsRequest
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	stats, err := sleepRecordService.GetSleepStatistics(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取睡眠统计失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(stats, "获取成功", c)
+}
@@ -0,0 +1,373 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type SmartAlertApi struct{}
+
+// GetAlertList 获取警报列表
+// @Tags SmartAlert
+// @Summary 分页获取警报列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.SmartAlertSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.SmartAlertListResponse,msg=string} "获取成功"
+// @Router /baby/alert/list [get]
+func (s *SmartAlertApi) GetAlertList(c *gin.Context) {
+	var req request.SmartAlertSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := smartAlertService.GetAlertList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取警报列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetAlertSummary 获取警报汇总
+// @Tags SmartAlert
+// @Summary 获取未读、未处理警报数量
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=response.AlertSummary,msg=string} "获取成功"
+// @Router /baby/alert/summary [get]
+func (s *SmartAlertApi) GetAlertSummary(c *gin.Context) {
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	summary, err := smartAlertService.GetAlertSummary(customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取警报汇总失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(summary, "获取成功", c)
+}
+
+// GetAlert 获取警报详情
+// @Tags SmartAlert
+// @Summary 获取警报详情
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "警报ID"
+// @Success 200 {object} response.Response{data=response.SmartAlertResponse,msg=string} "获取成功"
+// @Router /baby/alert/{id} [get]
+func (s *SmartAlertApi) GetAlert(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	alert, err := smartAlertService.GetAlert(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取警报详情失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(alert, "获取成功", c)
+}
+
+// MarkAlertsRead 标记警报已读
+// @Tags SmartAlert
+// @Summary 标记警报已读
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.MarkAlertReadRequest true "警报ID列表"
+// @Success 200 {object} response.Response{data=map[string]int64,msg=string} "标记成功"
+// @Router /baby/alert/read [post]
+func (s *SmartAlertApi) MarkAlertsRead(c *gin.Context) {
+	var req request.MarkAlertReadRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	count, err := smartAlertService.MarkAlertsRead(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("标记警报已读失败!", zap.Error(err))
+		response.FailWithMessage("操作失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(gin.H{"count": count}, "标记成功", c)
+}
+
+// ProcessAlert 处理警报
+// @Tags SmartAlert
+// @Summary 处理警报
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ProcessAlertRequest true "处理信息"
+// @Success 200 {object} response.Response{msg=string} "处理成功"
+// @Router /baby/alert/process [post]
+func (s *SmartAlertApi) ProcessAlert(c *gin.Context) {
+	var req request.ProcessAlertRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = smartAlertService.ProcessAlert(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("处理警报失败!", zap.Error(err))
+		response.FailWithMessage("处理失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("处理成功", c)
+}
+
+// GetAlertRuleList 获取警报规则列表
+// @Tags SmartAlert
+// @Summary 获取警报规则列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.AlertRuleSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=[]response.AlertRuleResponse,msg=string} "获取成功"
+// @Router /baby/alert/rule/list [get]
+func (s *SmartAlertApi) GetAlertRuleList(c *gin.Context) {
+	var req request.AlertRuleSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	rules, err := smartAlertService.GetAlertRuleList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取警报规则列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(rules, "获取成功", c)
+}
+
+// CreateAlertRule 创建警报规则
+// @Tags SmartAlert
+// @Summary 创建警报规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateAlertRuleRequest true "规则信息"
+// @Success 200 {object} response.Response{data=response.AlertRuleResponse,msg=string} "创建成功"
+// @Router /baby/alert/rule [post]
+func (s *SmartAlertApi) CreateAlertRule(c *gin.Context) {
+	var req request.CreateAlertRuleRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	rule, err := smartAlertService.CreateAlertRule(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("创建警报规则失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(rule, "创建成功", c)
+}
+
+// UpdateAlertRule 更新警报规则
+// @Tags SmartAlert
+// @Summary 更新警报规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateAlertRuleRequest true "规则信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /baby/alert/rule [put]
+func (s *SmartAlertApi) UpdateAlertRule(c *gin.Context) {
+	var req request.UpdateAlertRuleRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = smartAlertService.UpdateAlertRule(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新警报规则失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteAlertRule 删除警报规则
+// @Tags SmartAlert
+// @Summary 删除警报规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "规则ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /baby/alert/rule/{id} [delete]
+func (s *SmartAlertApi) DeleteAlertRule(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = smartAlertService.DeleteAlertRule(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除警报规则失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
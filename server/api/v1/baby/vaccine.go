@@ -0,0 +1,280 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/common/response"
+	systemReq "baby_admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+type VaccineApi struct{}
+
+// GetVaccineCatalog 获取疫苗目录
+// @Tags Vaccine
+// @Summary 获取启用的疫苗及各剂次推荐接种年龄
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]response.VaccineResponse,msg=string} "获取成功"
+// @Router /baby/vaccine/catalog [get]
+func (v *VaccineApi) GetVaccineCatalog(c *gin.Context) {
+	list, err := vaccineService.GetVaccineCatalog()
+	if err != nil {
+		global.GVA_LOG.Error("获取疫苗目录失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetVaccinationPlan 获取接种计划
+// @Tags Vaccine
+// @Summary 按宝宝出生日期推算的接种计划及各剂次状态
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.VaccinationPlanRequest true "宝宝ID"
+// @Success 200 {object} response.Response{data=response.VaccinationPlanResponse,msg=string} "获取成功"
+// @Router /baby/vaccine/plan [get]
+func (v *VaccineApi) GetVaccinationPlan(c *gin.Context) {
+	var req request.VaccinationPlanRequest
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	plan, err := vaccineService.GetVaccinationPlan(customClaims.BaseClaims.ID, req.BabyID)
+	if err != nil {
+		global.GVA_LOG.Error("获取接种计划失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(plan, "获取成功", c)
+}
+
+// GetUpcomingVaccinations 获取待接种疫苗
+// @Tags Vaccine
+// @Summary 获取即将接种及逾期未接种的剂次
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.VaccinationPlanRequest true "宝宝ID"
+// @Success 200 {object} response.Response{data=response.VaccinationUpcomingResponse,msg=string} "获取成功"
+// @Router /baby/vaccine/upcoming [get]
+func (v *VaccineApi) GetUpcomingVaccinations(c *gin.Context) {
+	var req request.VaccinationPlanRequest
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	result, err := vaccineService.GetUpcomingVaccinations(customClaims.BaseClaims.ID, req.BabyID)
+	if err != nil {
+		global.GVA_LOG.Error("获取待接种疫苗失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(result, "获取成功", c)
+}
+
+// CreateVaccinationRecord 记录接种
+// @Tags Vaccine
+// @Summary 记录接种
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateVaccinationRecordRequest true "接种信息"
+// @Success 200 {object} response.Response{data=response.VaccinationRecordResponse,msg=string} "记录成功"
+// @Router /baby/vaccine/record [post]
+func (v *VaccineApi) CreateVaccinationRecord(c *gin.Context) {
+	var req request.CreateVaccinationRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	record, err := vaccineService.CreateVaccinationRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("记录接种失败!", zap.Error(err))
+		response.FailWithMessage("记录失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(record, "记录成功", c)
+}
+
+// UpdateVaccinationRecord 更新接种记录
+// @Tags Vaccine
+// @Summary 更新接种记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateVaccinationRecordRequest true "接种信息"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /baby/vaccine/record [put]
+func (v *VaccineApi) UpdateVaccinationRecord(c *gin.Context) {
+	var req request.UpdateVaccinationRecordRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = vaccineService.UpdateVaccinationRecord(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("更新接种记录失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("更新成功", c)
+}
+
+// GetVaccinationRecordList 获取接种记录列表
+// @Tags Vaccine
+// @Summary 分页获取接种记录列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.VaccinationRecordSearch true "搜索条件"
+// @Success 200 {object} response.Response{data=response.VaccinationRecordListResponse,msg=string} "获取成功"
+// @Router /baby/vaccine/record/list [get]
+func (v *VaccineApi) GetVaccinationRecordList(c *gin.Context) {
+	var req request.VaccinationRecordSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	// 设置默认分页参数
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	list, err := vaccineService.GetVaccinationRecordList(customClaims.BaseClaims.ID, &req)
+	if err != nil {
+		global.GVA_LOG.Error("获取接种记录列表失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// DeleteVaccinationRecord 删除接种记录
+// @Tags Vaccine
+// @Summary 删除接种记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param id path int true "接种记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /baby/vaccine/record/{id} [delete]
+func (v *VaccineApi) DeleteVaccinationRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		response.FailWithMessage("ID格式错误", c)
+		return
+	}
+
+	// 获取当前用户ID
+	claims, exist := c.Get("claims")
+	if !exist {
+		response.FailWithMessage("未登录或非法访问", c)
+		return
+	}
+
+	customClaims, ok := claims.(*systemReq.CustomClaims)
+	if !ok {
+		response.FailWithMessage("token解析失败", c)
+		return
+	}
+
+	err = vaccineService.DeleteVaccinationRecord(uint(id), customClaims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("删除接种记录失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+
+	response.OkWithMessage("删除成功", c)
+}
@@ -0,0 +1,224 @@
+package baby
+
+import (
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby/request"
+	commonReq "baby_admin/server/model/common/request"
+	"baby_admin/server/model/common/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// VaccineAdminApi 疫苗目录及接种程序管理，挂载在管理后台鉴权路由组
+type VaccineAdminApi struct{}
+
+// CreateVaccine
+// @Tags      VaccineAdmin
+// @Summary   创建疫苗
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.CreateVaccineRequest   true  "疫苗信息"
+// @Success   200   {object}  response.Response{msg=string}  "创建疫苗"
+// @Router    /vaccineAdmin/createVaccine [post]
+func (v *VaccineAdminApi) CreateVaccine(c *gin.Context) {
+	var req request.CreateVaccineRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	_, err = vaccineAdminService.CreateVaccine(&req)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// UpdateVaccine
+// @Tags      VaccineAdmin
+// @Summary   更新疫苗
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.UpdateVaccineRequest   true  "疫苗信息"
+// @Success   200   {object}  response.Response{msg=string}  "更新疫苗"
+// @Router    /vaccineAdmin/updateVaccine [put]
+func (v *VaccineAdminApi) UpdateVaccine(c *gin.Context) {
+	var req request.UpdateVaccineRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = vaccineAdminService.UpdateVaccine(&req)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteVaccine
+// @Tags      VaccineAdmin
+// @Summary   删除疫苗
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.GetById                true  "疫苗ID"
+// @Success   200   {object}  response.Response{msg=string}  "删除疫苗"
+// @Router    /vaccineAdmin/deleteVaccine [delete]
+func (v *VaccineAdminApi) DeleteVaccine(c *gin.Context) {
+	var req commonReq.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = vaccineAdminService.DeleteVaccine(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetVaccineList
+// @Tags      VaccineAdmin
+// @Summary   分页获取疫苗列表
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.VaccineSearch                                   true  "页码, 每页大小, 搜索条件"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取疫苗列表,返回包括列表,总数,页码,每页数量"
+// @Router    /vaccineAdmin/getVaccineList [get]
+func (v *VaccineAdminApi) GetVaccineList(c *gin.Context) {
+	var req request.VaccineSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+	list, total, err := vaccineAdminService.GetVaccineList(&req)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// CreateSchedule
+// @Tags      VaccineAdmin
+// @Summary   创建接种程序剂次
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.CreateVaccineScheduleRequest  true  "接种程序信息"
+// @Success   200   {object}  response.Response{msg=string}         "创建接种程序剂次"
+// @Router    /vaccineAdmin/createSchedule [post]
+func (v *VaccineAdminApi) CreateSchedule(c *gin.Context) {
+	var req request.CreateVaccineScheduleRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	_, err = vaccineAdminService.CreateSchedule(&req)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// UpdateSchedule
+// @Tags      VaccineAdmin
+// @Summary   更新接种程序剂次
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.UpdateVaccineScheduleRequest  true  "接种程序信息"
+// @Success   200   {object}  response.Response{msg=string}         "更新接种程序剂次"
+// @Router    /vaccineAdmin/updateSchedule [put]
+func (v *VaccineAdminApi) UpdateSchedule(c *gin.Context) {
+	var req request.UpdateVaccineScheduleRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = vaccineAdminService.UpdateSchedule(&req)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteSchedule
+// @Tags      VaccineAdmin
+// @Summary   删除接种程序剂次
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.GetById                true  "接种程序ID"
+// @Success   200   {object}  response.Response{msg=string}  "删除接种程序剂次"
+// @Router    /vaccineAdmin/deleteSchedule [delete]
+func (v *VaccineAdminApi) DeleteSchedule(c *gin.Context) {
+	var req commonReq.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = vaccineAdminService.DeleteSchedule(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetScheduleList
+// @Tags      VaccineAdmin
+// @Summary   获取接种程序列表
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.VaccineScheduleSearch                                             true  "疫苗ID"
+// @Success   200   {object}  response.Response{data=[]response.VaccineScheduleResponse,msg=string}  "获取接种程序列表"
+// @Router    /vaccineAdmin/getScheduleList [get]
+func (v *VaccineAdminApi) GetScheduleList(c *gin.Context) {
+	var req request.VaccineScheduleSearch
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, err := vaccineAdminService.GetScheduleList(&req)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
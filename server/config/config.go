@@ -39,4 +39,8 @@ type Server struct {
 	MCP MCP `mapstructure:"mcp" json:"mcp" yaml:"mcp"`
 	// 小程序配置
 	Miniprogram Miniprogram `mapstructure:"miniprogram" json:"miniprogram" yaml:"miniprogram"`
+	// 设备配置
+	Device Device `mapstructure:"device" json:"device" yaml:"device"`
+	// 媒体网关配置
+	Media Media `mapstructure:"media" json:"media" yaml:"media"`
 }
@@ -0,0 +1,7 @@
+package config
+
+type Device struct {
+	HeartbeatTimeout int    `mapstructure:"heartbeat-timeout" json:"heartbeat-timeout" yaml:"heartbeat-timeout"`    // 心跳超时时间(秒)，超过该时长未上报的设备标记为离线
+	OfflineCheckSpec string `mapstructure:"offline-check-spec" json:"offline-check-spec" yaml:"offline-check-spec"` // 离线检测定时任务的cron表达式(含秒)
+	Transport        string `mapstructure:"transport" json:"transport" yaml:"transport"`                            // 命令下发通道：mock为进程内模拟通道，为空时不下发
+}
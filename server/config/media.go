@@ -0,0 +1,7 @@
+package config
+
+type Media struct {
+	SignKey string `mapstructure:"sign-key" json:"sign-key" yaml:"sign-key"` // 媒体地址签名密钥，为空时使用jwt签名密钥
+	Expires int    `mapstructure:"expires" json:"expires" yaml:"expires"`    // 签名地址有效期(秒)
+	BaseURL string `mapstructure:"base-url" json:"base-url" yaml:"base-url"` // 媒体网关对外访问前缀，如 https://api.example.com/api
+}
@@ -0,0 +1,22 @@
+package initialize
+
+import (
+	"baby_admin/server/service/baby"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"go.uber.org/zap"
+)
+
+// DeviceTransport 按配置设置设备命令下发通道
+func DeviceTransport() {
+	name := global.GVA_CONFIG.Device.Transport
+	transport, err := baby.NewDeviceTransport(name)
+	if err != nil {
+		global.GVA_LOG.Error("设备命令下发通道配置错误", zap.Error(err))
+		return
+	}
+	if name == baby.DeviceTransportMock {
+		global.GVA_LOG.Warn("设备命令使用模拟通道，命令不会下发到真实设备")
+	}
+	baby.SetDeviceTransport(transport)
+}
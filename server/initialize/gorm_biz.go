@@ -3,6 +3,7 @@ package initialize
 import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"baby_admin/server/model/baby"
+	"gorm.io/gorm"
 )
 
 func bizModel() error {
@@ -10,19 +11,36 @@ func bizModel() error {
 	err := db.AutoMigrate(
 		// 婴儿陪护相关模型
 		&baby.BabyProfile{},
+		&baby.FamilyGroup{},
+		&baby.FamilyMember{},
+		&baby.UserActiveBaby{},
 		&baby.GrowthRecord{},
+		&baby.BabyMilestone{},
+		&baby.FeedingRecord{},
+		&baby.DiaperRecord{},
+		&baby.TemperatureRecord{},
+		&baby.MedicationRecord{},
+		&baby.SymptomRecord{},
+		&baby.Vaccine{},
+		&baby.VaccineSchedule{},
+		&baby.VaccinationRecord{},
+		&baby.VaccinationReminder{},
 		&baby.MusicCategory{},
 		&baby.Music{},
 		&baby.UserMusicHistory{},
 		&baby.UserMusicFavorite{},
 		&baby.Playlist{},
 		&baby.PlaylistMusic{},
+		&baby.MusicSimilarity{},
+		&baby.PlaybackSession{},
+		&baby.PlaybackSessionTrack{},
 		// 智能分析相关模型
 		&baby.SleepRecord{},
 		&baby.CryDetection{},
 		&baby.MovementDetection{},
 		&baby.EnvironmentData{},
 		&baby.SmartAlert{},
+		&baby.AlertRule{},
 		&baby.AnalysisReport{},
 		// 育儿指导相关模型
 		&baby.ParentingCategory{},
@@ -32,6 +50,7 @@ func bizModel() error {
 		&baby.UserArticleRead{},
 		&baby.UserVideoWatch{},
 		&baby.UserContentFavorite{},
+		&baby.FavoriteFolder{},
 		// 设备管理相关模型
 		&baby.Device{},
 		&baby.DeviceConfig{},
@@ -39,9 +58,49 @@ func bizModel() error {
 		&baby.DeviceStatus{},
 		&baby.DeviceShare{},
 		&baby.DeviceLog{},
+		// 会员相关模型
+		&baby.VipPlan{},
+		&baby.VipMembershipLog{},
 	)
 	if err != nil {
 		return err
 	}
-	return nil
+	return migrateActiveBabies(db)
+}
+
+// migrateActiveBabies 将旧版宝宝档案上的当前宝宝标记(baby_profiles.is_active)迁移为每个用户各自的选择，迁移后删除该字段
+func migrateActiveBabies(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&baby.BabyProfile{}, "is_active") {
+		return nil
+	}
+
+	var legacy []baby.UserActiveBaby
+	err := db.Table("baby_profiles").Select("user_id, MAX(id) AS baby_id").
+		Where("is_active = ? AND deleted_at IS NULL", true).Group("user_id").Scan(&legacy).Error
+	if err != nil {
+		return err
+	}
+
+	// 已经选择过当前宝宝的用户保留新的选择
+	var selected []uint
+	if err := db.Model(&baby.UserActiveBaby{}).Pluck("user_id", &selected).Error; err != nil {
+		return err
+	}
+	selectedUsers := make(map[uint]bool, len(selected))
+	for _, userID := range selected {
+		selectedUsers[userID] = true
+	}
+	var rows []baby.UserActiveBaby
+	for _, row := range legacy {
+		if !selectedUsers[row.UserID] {
+			rows = append(rows, baby.UserActiveBaby{UserID: row.UserID, BabyID: row.BabyID})
+		}
+	}
+	if len(rows) > 0 {
+		if err := db.Create(&rows).Error; err != nil {
+			return err
+		}
+	}
+
+	return db.Migrator().DropColumn(&baby.BabyProfile{}, "is_active")
 }
@@ -1,6 +1,7 @@
 package initialize
 
 import (
+	_ "github.com/flipped-aurora/gin-vue-admin/server/source/baby"
 	_ "github.com/flipped-aurora/gin-vue-admin/server/source/example"
 	_ "github.com/flipped-aurora/gin-vue-admin/server/source/system"
 )
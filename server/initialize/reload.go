@@ -31,6 +31,7 @@ func Reload() error {
 	// 重新初始化其他配置
 	OtherInit()
 	DBList()
+	DeviceTransport()
 
 	if global.GVA_DB != nil {
 		// 确保数据库表结构是最新的
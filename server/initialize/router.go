@@ -8,6 +8,7 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
 	"github.com/flipped-aurora/gin-vue-admin/server/router"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -18,6 +19,10 @@ type justFilesFilesystem struct {
 }
 
 func (fs justFilesFilesystem) Open(name string) (http.File, error) {
+	// 音视频只能通过媒体网关签名访问
+	if utils.IsMediaFile(name) {
+		return nil, os.ErrNotExist
+	}
 	f, err := fs.fs.Open(name)
 	if err != nil {
 		return nil, err
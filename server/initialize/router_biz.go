@@ -20,8 +20,46 @@ func initBizRouter(routers ...*gin.RouterGroup) {
 	{
 		// 宝宝档案路由 - 需要鉴权
 		babyRouter.InitBabyProfileRouter(publicGroup) // 公开路由组，但内部会使用鉴权中间件
+		// 成长记录路由 - 需要鉴权
+		babyRouter.InitGrowthRecordRouter(publicGroup)
 		// 音乐相关路由 - 需要鉴权
 		babyRouter.InitMusicRouter(publicGroup)
+		// 设备管理路由 - 需要鉴权
+		babyRouter.InitDeviceRouter(publicGroup)
+		// 设备数据上报路由 - 设备签名鉴权
+		babyRouter.InitDeviceTelemetryRouter(publicGroup)
+		// 睡眠记录路由 - 需要鉴权
+		babyRouter.InitSleepRecordRouter(publicGroup)
+		// 哭声检测路由 - 需要鉴权
+		babyRouter.InitCryDetectionRouter(publicGroup)
+		// 智能警报路由 - 需要鉴权
+		babyRouter.InitSmartAlertRouter(publicGroup)
+		// 分析报告路由 - 需要鉴权
+		babyRouter.InitAnalysisReportRouter(publicGroup)
+		// 育儿内容路由 - 需要鉴权
+		babyRouter.InitParentingRouter(publicGroup)
+		// 收藏路由 - 需要鉴权
+		babyRouter.InitFavoriteRouter(publicGroup)
+		// 里程碑路由 - 需要鉴权
+		babyRouter.InitMilestoneRouter(publicGroup)
+		// 喂养记录路由 - 需要鉴权
+		babyRouter.InitFeedingRouter(publicGroup)
+		// 健康日记路由 - 需要鉴权
+		babyRouter.InitHealthRouter(publicGroup)
+		// 疫苗接种路由 - 需要鉴权
+		babyRouter.InitVaccineRouter(publicGroup)
+		// 疫苗目录及接种程序管理 - 管理后台鉴权
+		babyRouter.InitVaccineAdminRouter(privateGroup)
+		// 家庭组路由 - 需要鉴权
+		babyRouter.InitFamilyRouter(publicGroup)
+		// 会员路由 - 需要鉴权
+		babyRouter.InitMembershipRouter(publicGroup)
+		// 会员套餐及用户会员管理 - 管理后台鉴权
+		babyRouter.InitMembershipAdminRouter(privateGroup)
+		// 媒体网关路由（签名鉴权）
+		babyRouter.InitMediaRouter(publicGroup)
+		// 播放会话路由（小程序登录鉴权，音响设备签名鉴权）
+		babyRouter.InitPlaybackRouter(publicGroup)
 	}
 
 	holder(publicGroup, privateGroup)
@@ -2,6 +2,8 @@ package initialize
 
 import (
 	"fmt"
+	"time"
+	"baby_admin/server/service"
 	"github.com/flipped-aurora/gin-vue-admin/server/task"
 
 	"github.com/robfig/cron/v3"
@@ -24,6 +26,101 @@ func Timer() {
 			fmt.Println("add timer error:", err)
 		}
 
+		// 设备命令超时检测及补发
+		_, err = global.GVA_Timer.AddTaskByFunc("DeviceCommandSweep", "0 */1 * * * *", func() {
+			err := service.ServiceGroupApp.BabyServiceGroup.DeviceCommandService.SweepCommands()
+			if err != nil {
+				fmt.Println("timer error:", err)
+			}
+		}, "设备命令超时处理及排队命令补发", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 设备心跳超时离线检测
+		offlineCheckSpec := global.GVA_CONFIG.Device.OfflineCheckSpec
+		if offlineCheckSpec == "" {
+			offlineCheckSpec = "0 */1 * * * *"
+		}
+		_, err = global.GVA_Timer.AddTaskByFunc("DeviceOfflineCheck", offlineCheckSpec, func() {
+			timeout := time.Duration(global.GVA_CONFIG.Device.HeartbeatTimeout) * time.Second
+			_, err := service.ServiceGroupApp.BabyServiceGroup.DeviceService.DetectOfflineDevices(timeout)
+			if err != nil {
+				fmt.Println("timer error:", err)
+			}
+		}, "设备心跳超时离线检测", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 分析报告生成：每天凌晨生成前一日日报，周一生成上周周报，每月1日生成上月月报
+		reportTasks := []struct {
+			name       string
+			spec       string
+			reportType int
+			desc       string
+		}{
+			{"AnalysisReportDaily", "0 10 0 * * *", 1, "生成宝宝前一日分析日报"},
+			{"AnalysisReportWeekly", "0 20 0 * * 1", 2, "生成宝宝上周分析周报"},
+			{"AnalysisReportMonthly", "0 30 0 1 * *", 3, "生成宝宝上月分析月报"},
+		}
+		for _, t := range reportTasks {
+			reportType := t.reportType
+			_, err = global.GVA_Timer.AddTaskByFunc(t.name, t.spec, func() {
+				_, err := service.ServiceGroupApp.BabyServiceGroup.AnalysisReportService.GenerateReports(reportType, time.Now())
+				if err != nil {
+					fmt.Println("timer error:", err)
+				}
+			}, t.desc, option...)
+			if err != nil {
+				fmt.Println("add timer error:", err)
+			}
+		}
+
+		// 用药提醒：到下次服药时间时创建提醒
+		_, err = global.GVA_Timer.AddTaskByFunc("HealthMedicationReminder", "0 */1 * * * *", func() {
+			_, err := service.ServiceGroupApp.BabyServiceGroup.HealthService.SendMedicationReminders(time.Now())
+			if err != nil {
+				fmt.Println("timer error:", err)
+			}
+		}, "到下次服药时间时发送用药提醒", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 疫苗接种提醒：每天上午9点提醒即将接种和逾期未接种的剂次
+		_, err = global.GVA_Timer.AddTaskByFunc("VaccinationReminder", "0 0 9 * * *", func() {
+			_, err := service.ServiceGroupApp.BabyServiceGroup.VaccineService.SendVaccinationReminders(time.Now())
+			if err != nil {
+				fmt.Println("timer error:", err)
+			}
+		}, "每天提醒即将接种和逾期未接种的疫苗", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 音乐相似度：每天凌晨根据播放历史和收藏重新计算，供“因为你听过”推荐使用
+		_, err = global.GVA_Timer.AddTaskByFunc("MusicSimilarityRebuild", "0 0 3 * * *", func() {
+			_, err := service.ServiceGroupApp.BabyServiceGroup.MusicService.RebuildMusicSimilarities(time.Now())
+			if err != nil {
+				fmt.Println("timer error:", err)
+			}
+		}, "根据播放历史和收藏重新计算音乐相似度", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 播放会话：睡眠定时到达或长时间未同步的会话自动结束并写入播放历史
+		_, err = global.GVA_Timer.AddTaskByFunc("PlaybackSessionSweep", "0 */1 * * * *", func() {
+			_, err := service.ServiceGroupApp.BabyServiceGroup.PlaybackService.SweepSessions(time.Now())
+			if err != nil {
+				fmt.Println("timer error:", err)
+			}
+		}, "结束睡眠定时已到或长时间未同步的播放会话", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
 		// 其他定时任务定在这里 参考上方使用方法
 
 		//_, err := global.GVA_Timer.AddTaskByFunc("定时任务标识", "corn表达式", func() {
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+
+	"baby_admin/server/service"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/gin-gonic/gin"
+)
+
+// deviceBodyLimit 设备单次上报的请求体上限
+const deviceBodyLimit = 1 << 20
+
+// DeviceSignAuth 设备签名鉴权中间件
+// 设备使用DeviceSecret对请求签名，请求头携带 X-Device-Id、X-Timestamp、X-Nonce、X-Signature，
+// 签名算法见 baby.SignDeviceRequest。校验通过后设备信息存入上下文的 "device" 键
+func DeviceSignAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deviceID, err := strconv.ParseUint(c.GetHeader("X-Device-Id"), 10, 32)
+		if err != nil {
+			response.NoAuth("设备ID格式错误", c)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, deviceBodyLimit))
+		if err != nil {
+			response.FailWithMessage("请求体过大或读取失败", c)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+
+		device, err := service.ServiceGroupApp.BabyServiceGroup.DeviceTelemetryService.AuthenticateDevice(
+			uint(deviceID),
+			c.GetHeader("X-Timestamp"),
+			c.GetHeader("X-Nonce"),
+			c.GetHeader("X-Signature"),
+			body,
+		)
+		if err != nil {
+			response.NoAuth(err.Error(), c)
+			c.Abort()
+			return
+		}
+
+		c.Set("device", device)
+		c.Next()
+	}
+}
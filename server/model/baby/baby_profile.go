@@ -2,6 +2,7 @@ package baby
 
 import (
 	"fmt"
+	"math"
 	"time"
 	"baby_admin/server/global"
 )
@@ -9,16 +10,20 @@ import (
 // BabyProfile 宝宝档案表
 type BabyProfile struct {
 	global.GVA_MODEL
-	UserID      uint      `json:"user_id" gorm:"not null;comment:用户ID"`
-	Name        string    `json:"name" gorm:"size:50;not null;comment:宝宝姓名"`
-	Gender      int       `json:"gender" gorm:"not null;default:0;comment:性别:0未知,1男,2女"`
-	Birthday    time.Time `json:"birthday" gorm:"not null;comment:出生日期"`
-	Avatar      string    `json:"avatar" gorm:"size:255;comment:头像URL"`
-	Weight      float64   `json:"weight" gorm:"type:decimal(5,2);comment:体重(kg)"`
-	Height      float64   `json:"height" gorm:"type:decimal(5,2);comment:身高(cm)"`
-	BloodType   string    `json:"blood_type" gorm:"size:10;comment:血型"`
-	Remark      string    `json:"remark" gorm:"type:text;comment:备注"`
-	IsActive    bool      `json:"is_active" gorm:"default:true;comment:是否为当前活跃宝宝"`
+	UserID    uint      `json:"user_id" gorm:"not null;comment:创建者用户ID"`
+	FamilyID  uint      `json:"family_id" gorm:"default:0;index;comment:家庭组ID,0表示仅创建者可见"`
+	Name      string    `json:"name" gorm:"size:50;not null;comment:宝宝姓名"`
+	Gender    int       `json:"gender" gorm:"not null;default:0;comment:性别:0未知,1男,2女"`
+	Birthday  time.Time `json:"birthday" gorm:"not null;comment:出生日期"`
+	Avatar    string    `json:"avatar" gorm:"size:255;comment:头像URL"`
+	Weight    float64   `json:"weight" gorm:"type:decimal(5,2);comment:体重(kg)"`
+	Height    float64   `json:"height" gorm:"type:decimal(5,2);comment:身高(cm)"`
+	BloodType string    `json:"blood_type" gorm:"size:10;comment:血型"`
+	Remark    string    `json:"remark" gorm:"type:text;comment:备注"`
+
+	// 出生胎龄，未填写时为0，按足月计算
+	GestationalWeeks int `json:"gestational_weeks" gorm:"default:0;comment:出生胎龄(周)"`
+	GestationalDays  int `json:"gestational_days" gorm:"default:0;comment:出生胎龄(天),0-6"`
 }
 
 // TableName 指定表名
@@ -26,31 +31,153 @@ func (BabyProfile) TableName() string {
 	return "baby_profiles"
 }
 
-// GetAge 计算年龄(月)
-func (b *BabyProfile) GetAge() int {
-	now := time.Now()
-	months := int(now.Month()) - int(b.Birthday.Month())
-	years := now.Year() - b.Birthday.Year()
-	
-	if months < 0 {
-		years--
-		months += 12
+// 早产矫正年龄相关常量
+const (
+	fullTermWeeks         = 40 // 足月胎龄(周)，矫正年龄按预产期计算
+	prematureWeeks        = 37 // 出生胎龄不足该周数为早产
+	correctedAgeMaxMonths = 24 // 实际月龄满24个月后不再使用矫正年龄
+)
+
+// BabyAge 宝宝年龄，按自然日计算
+type BabyAge struct {
+	Days      int `json:"days"`       // 总天数
+	Weeks     int `json:"weeks"`      // 整周数
+	Months    int `json:"months"`     // 整月数
+	MonthDays int `json:"month_days"` // 整月之外的天数
+}
+
+// CalculateAge 计算从start到at的年龄，满月以日期对齐为准，月末出生时以次月月末为满月
+func CalculateAge(start time.Time, at time.Time) BabyAge {
+	startDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+	endDate := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.Local)
+	if !endDate.After(startDate) {
+		return BabyAge{}
+	}
+
+	months := (endDate.Year()-startDate.Year())*12 + int(endDate.Month()) - int(startDate.Month())
+	anchor := addMonthsClamped(startDate, months)
+	if anchor.After(endDate) {
+		months--
+		anchor = addMonthsClamped(startDate, months)
+	}
+
+	days := daysBetween(startDate, endDate)
+	return BabyAge{
+		Days:      days,
+		Weeks:     days / 7,
+		Months:    months,
+		MonthDays: daysBetween(anchor, endDate),
 	}
-	
-	return years*12 + months
+}
+
+// addMonthsClamped 增加月数，目标月份没有对应日期时取该月最后一天
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	day := t.Day()
+	if lastDay := first.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween 两个零点之间的天数，四舍五入消除夏令时的影响
+func daysBetween(start time.Time, end time.Time) int {
+	return int(math.Round(end.Sub(start).Hours() / 24))
+}
+
+// IsPremature 是否早产，未填写出生胎龄时视为足月
+func (b *BabyProfile) IsPremature() bool {
+	return b.GestationalWeeks > 0 && b.GestationalWeeks < prematureWeeks
+}
+
+// PrematureDays 距足月提前出生的天数
+func (b *BabyProfile) PrematureDays() int {
+	if !b.IsPremature() {
+		return 0
+	}
+	return fullTermWeeks*7 - (b.GestationalWeeks*7 + b.GestationalDays)
+}
+
+// UsesCorrectedAge at时刻是否使用矫正年龄：早产且实际月龄未满24个月
+func (b *BabyProfile) UsesCorrectedAge(at time.Time) bool {
+	return b.IsPremature() && CalculateAge(b.Birthday, at).Months < correctedAgeMaxMonths
+}
+
+// AgeReferenceDate 计算at时刻发育年龄的起始日期，使用矫正年龄时为预产期，否则为出生日期
+func (b *BabyProfile) AgeReferenceDate(at time.Time) time.Time {
+	if b.UsesCorrectedAge(at) {
+		return b.Birthday.AddDate(0, 0, b.PrematureDays())
+	}
+	return b.Birthday
+}
+
+// GetAgeAt 计算at时刻的实际年龄
+func (b *BabyProfile) GetAgeAt(at time.Time) BabyAge {
+	return CalculateAge(b.Birthday, at)
+}
+
+// GetDevelopmentalAgeAt 计算at时刻的发育年龄，早产儿24月龄前为矫正年龄，用于推荐、里程碑和生长评估
+func (b *BabyProfile) GetDevelopmentalAgeAt(at time.Time) BabyAge {
+	return CalculateAge(b.AgeReferenceDate(at), at)
+}
+
+// GetAge 计算实际年龄(月)
+func (b *BabyProfile) GetAge() int {
+	return b.GetAgeAt(time.Now()).Months
+}
+
+// GetDevelopmentalAge 计算发育年龄(月)
+func (b *BabyProfile) GetDevelopmentalAge() int {
+	return b.GetDevelopmentalAgeAt(time.Now()).Months
+}
+
+// GetAgeRange 按发育年龄获取适龄内容的年龄段
+func (b *BabyProfile) GetAgeRange() string {
+	return AgeRangeOfMonths(b.GetDevelopmentalAge())
 }
 
 // GetAgeText 获取年龄文本描述
 func (b *BabyProfile) GetAgeText() string {
-	months := b.GetAge()
-	years := months / 12
-	remainingMonths := months % 12
-	
+	return formatAge(b.GetAgeAt(time.Now()))
+}
+
+// GetDevelopmentalAgeText 获取发育年龄文本描述，使用矫正年龄时加"矫正"前缀
+func (b *BabyProfile) GetDevelopmentalAgeText() string {
+	now := time.Now()
+	if b.UsesCorrectedAge(now) {
+		return "矫正" + formatAge(b.GetDevelopmentalAgeAt(now))
+	}
+	return formatAge(b.GetAgeAt(now))
+}
+
+// formatAge 年龄文本，满月前显示天数
+func formatAge(age BabyAge) string {
+	years := age.Months / 12
+	remainingMonths := age.Months % 12
+
 	if years > 0 {
 		if remainingMonths > 0 {
 			return fmt.Sprintf("%d岁%d个月", years, remainingMonths)
 		}
 		return fmt.Sprintf("%d岁", years)
 	}
+	if remainingMonths == 0 {
+		return fmt.Sprintf("%d天", age.Days)
+	}
 	return fmt.Sprintf("%d个月", remainingMonths)
-}
\ No newline at end of file
+}
+
+// AgeRangeOfMonths 根据月龄获取年龄段，与音乐、育儿内容的AgeRange取值一致
+func AgeRangeOfMonths(ageInMonths int) string {
+	if ageInMonths <= 6 {
+		return "0-6月"
+	} else if ageInMonths <= 12 {
+		return "6-12月"
+	} else if ageInMonths <= 24 {
+		return "1-2岁"
+	} else if ageInMonths <= 36 {
+		return "2-3岁"
+	} else {
+		return "3岁+"
+	}
+}
@@ -1,6 +1,7 @@
 package baby
 
 import (
+	"encoding/json"
 	"time"
 	"baby_admin/server/global"
 )
@@ -101,6 +102,11 @@ func (DeviceShare) TableName() string {
 	return "device_shares"
 }
 
+// DeviceSharePermissions 分享权限配置，对应DeviceShare.Permissions
+type DeviceSharePermissions struct {
+	CommandTypes []int `json:"command_types,omitempty"` // 允许下发的命令类型，为空表示按分享类型不做限制
+}
+
 // DeviceLog 设备日志表
 type DeviceLog struct {
 	global.GVA_MODEL
@@ -213,6 +219,35 @@ func (ds *DeviceShare) IsExpired() bool {
 	return time.Now().After(*ds.ExpiredAt)
 }
 
+// GetPermissions 解析分享权限配置
+func (ds *DeviceShare) GetPermissions() (DeviceSharePermissions, error) {
+	var permissions DeviceSharePermissions
+	if ds.Permissions == "" {
+		return permissions, nil
+	}
+	err := json.Unmarshal([]byte(ds.Permissions), &permissions)
+	return permissions, err
+}
+
+// IsAccepted 判断分享是否已被接受
+func (ds *DeviceShare) IsAccepted() bool {
+	return ds.AcceptedAt != nil
+}
+
+// GetShareStatusText 获取分享状态文本
+func (ds *DeviceShare) GetShareStatusText() string {
+	switch {
+	case !ds.IsActive:
+		return "已失效"
+	case ds.IsExpired():
+		return "已过期"
+	case !ds.IsAccepted():
+		return "待接受"
+	default:
+		return "生效中"
+	}
+}
+
 // GetLogLevelText 获取日志级别文本
 func (dl *DeviceLog) GetLogLevelText() string {
 	switch dl.LogLevel {
@@ -0,0 +1,93 @@
+package baby
+
+import (
+	"time"
+	"baby_admin/server/global"
+)
+
+// FamilyGroup 家庭组表，组内成员按角色共享宝宝档案
+type FamilyGroup struct {
+	global.GVA_MODEL
+	Name            string     `json:"name" gorm:"size:50;not null;comment:家庭名称"`
+	OwnerID         uint       `json:"owner_id" gorm:"not null;index;comment:创建者用户ID"`
+	InviteCode      string     `json:"invite_code" gorm:"size:16;uniqueIndex;comment:邀请码"`
+	InviteRole      int        `json:"invite_role" gorm:"default:3;comment:通过邀请码加入的默认角色"`
+	InviteExpiredAt *time.Time `json:"invite_expired_at" gorm:"comment:邀请码过期时间"`
+}
+
+// TableName 指定表名
+func (FamilyGroup) TableName() string {
+	return "family_groups"
+}
+
+// GetInviteRoleText 获取邀请码默认角色文本
+func (f *FamilyGroup) GetInviteRoleText() string {
+	return familyRoleText(f.InviteRole)
+}
+
+// IsInviteValid 邀请码是否有效
+func (f *FamilyGroup) IsInviteValid(now time.Time) bool {
+	return f.InviteCode != "" && (f.InviteExpiredAt == nil || f.InviteExpiredAt.After(now))
+}
+
+// FamilyMember 家庭成员表，同一用户在一个家庭中只有一条未删除的记录，拒绝或移出时删除
+type FamilyMember struct {
+	global.GVA_MODEL
+	FamilyID   uint       `json:"family_id" gorm:"not null;index:idx_family_member;comment:家庭组ID"`
+	UserID     uint       `json:"user_id" gorm:"not null;index:idx_family_member;index;comment:用户ID"`
+	Role       int        `json:"role" gorm:"not null;comment:角色:1创建者,2家长,3看护人,4观察者"`
+	Status     int        `json:"status" gorm:"default:1;comment:状态:1待审核,2已加入"`
+	Relation   string     `json:"relation" gorm:"size:20;comment:与宝宝的关系,如妈妈、外婆"`
+	ReviewedBy uint       `json:"reviewed_by" gorm:"default:0;comment:审核人用户ID"`
+	JoinedAt   *time.Time `json:"joined_at" gorm:"comment:加入时间"`
+}
+
+// TableName 指定表名
+func (FamilyMember) TableName() string {
+	return "family_members"
+}
+
+// GetRoleText 获取角色文本
+func (m *FamilyMember) GetRoleText() string {
+	return familyRoleText(m.Role)
+}
+
+// GetStatusText 获取状态文本
+func (m *FamilyMember) GetStatusText() string {
+	switch m.Status {
+	case 1:
+		return "待审核"
+	case 2:
+		return "已加入"
+	default:
+		return "未知"
+	}
+}
+
+// familyRoleText 家庭角色文本
+func familyRoleText(role int) string {
+	switch role {
+	case 1:
+		return "创建者"
+	case 2:
+		return "家长"
+	case 3:
+		return "看护人"
+	case 4:
+		return "观察者"
+	default:
+		return "未知"
+	}
+}
+
+// UserActiveBaby 用户当前选择的宝宝，宝宝档案由家庭共享，当前宝宝由每个成员各自选择
+type UserActiveBaby struct {
+	global.GVA_MODEL
+	UserID uint `json:"user_id" gorm:"not null;uniqueIndex;comment:用户ID"`
+	BabyID uint `json:"baby_id" gorm:"not null;comment:宝宝ID"`
+}
+
+// TableName 指定表名
+func (UserActiveBaby) TableName() string {
+	return "user_active_babies"
+}
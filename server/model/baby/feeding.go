@@ -0,0 +1,60 @@
+package baby
+
+import (
+	"time"
+	"baby_admin/server/global"
+)
+
+// FeedingRecord 喂养记录表
+type FeedingRecord struct {
+	global.GVA_MODEL
+	UserID        uint       `json:"user_id" gorm:"not null;comment:记录人用户ID"`
+	BabyID        uint       `json:"baby_id" gorm:"not null;index:idx_feeding_baby_time;comment:宝宝ID"`
+	FeedingType   int        `json:"feeding_type" gorm:"not null;default:1;comment:喂养类型:1母乳亲喂,2瓶喂,3辅食"`
+	StartTime     time.Time  `json:"start_time" gorm:"not null;index:idx_feeding_baby_time;comment:开始时间"`
+	EndTime       *time.Time `json:"end_time" gorm:"comment:结束时间"`
+	LeftDuration  int        `json:"left_duration" gorm:"default:0;comment:左侧哺乳时长(秒)"`
+	RightDuration int        `json:"right_duration" gorm:"default:0;comment:右侧哺乳时长(秒)"`
+	ActiveSide    string     `json:"active_side" gorm:"size:10;comment:正在计时的哺乳侧:left,right,空为暂停"`
+	SideStartedAt *time.Time `json:"side_started_at" gorm:"comment:当前侧开始计时时间"`
+	LastSide      string     `json:"last_side" gorm:"size:10;comment:最后哺乳的一侧"`
+	MilkType      int        `json:"milk_type" gorm:"default:0;comment:奶类:1母乳,2配方奶"`
+	Amount        int        `json:"amount" gorm:"default:0;comment:奶量(ml)"`
+	FoodName      string     `json:"food_name" gorm:"size:100;comment:辅食名称"`
+	FoodAmount    int        `json:"food_amount" gorm:"default:0;comment:辅食量(g)"`
+	Allergens     string     `json:"allergens" gorm:"size:255;comment:含有的过敏原,逗号分隔"`
+	Reaction      int        `json:"reaction" gorm:"default:0;comment:过敏反应:0无,1轻微,2明显"`
+	Status        int        `json:"status" gorm:"default:2;comment:状态:1进行中,2已完成"`
+	Notes         string     `json:"notes" gorm:"type:text;comment:备注"`
+}
+
+// TableName 指定表名
+func (FeedingRecord) TableName() string {
+	return "feeding_records"
+}
+
+// GetFeedingTypeText 获取喂养类型文本
+func (f *FeedingRecord) GetFeedingTypeText() string {
+	switch f.FeedingType {
+	case 1:
+		return "母乳亲喂"
+	case 2:
+		return "瓶喂"
+	case 3:
+		return "辅食"
+	default:
+		return "未知类型"
+	}
+}
+
+// GetMilkTypeText 获取奶类文本
+func (f *FeedingRecord) GetMilkTypeText() string {
+	switch f.MilkType {
+	case 1:
+		return "母乳"
+	case 2:
+		return "配方奶"
+	default:
+		return ""
+	}
+}
@@ -49,4 +49,20 @@ func (g *GrowthRecord) GetRecordTypeText() string {
 	default:
 		return "未知类型"
 	}
-}
\ No newline at end of file
+}
+
+// BabyMilestone 宝宝里程碑达成记录表
+type BabyMilestone struct {
+	global.GVA_MODEL
+	UserID         uint      `json:"user_id" gorm:"not null;comment:记录人用户ID"`
+	BabyID         uint      `json:"baby_id" gorm:"not null;uniqueIndex:idx_baby_milestone;comment:宝宝ID"`
+	MilestoneID    uint      `json:"milestone_id" gorm:"not null;uniqueIndex:idx_baby_milestone;comment:里程碑ID"`
+	AchievedAt     time.Time `json:"achieved_at" gorm:"not null;comment:达成日期"`
+	GrowthRecordID uint      `json:"growth_record_id" gorm:"default:0;index;comment:关联成长记录ID"`
+	Note           string    `json:"note" gorm:"size:500;comment:备注"`
+}
+
+// TableName 指定表名
+func (BabyMilestone) TableName() string {
+	return "baby_milestones"
+}
@@ -0,0 +1,156 @@
+package baby
+
+import (
+	"time"
+	"baby_admin/server/global"
+)
+
+// DiaperRecord 换尿布记录表
+type DiaperRecord struct {
+	global.GVA_MODEL
+	UserID     uint      `json:"user_id" gorm:"not null;comment:记录人用户ID"`
+	BabyID     uint      `json:"baby_id" gorm:"not null;index:idx_diaper_baby_time;comment:宝宝ID"`
+	RecordTime time.Time `json:"record_time" gorm:"not null;index:idx_diaper_baby_time;comment:更换时间"`
+	DiaperType int       `json:"diaper_type" gorm:"not null;comment:尿布类型:1尿湿,2便便,3尿湿+便便,4干爽"`
+	StoolColor string    `json:"stool_color" gorm:"size:20;comment:大便颜色"`
+	Notes      string    `json:"notes" gorm:"type:text;comment:备注"`
+}
+
+// TableName 指定表名
+func (DiaperRecord) TableName() string {
+	return "diaper_records"
+}
+
+// IsWet 是否尿湿
+func (d *DiaperRecord) IsWet() bool {
+	return d.DiaperType == 1 || d.DiaperType == 3
+}
+
+// IsDirty 是否有便便
+func (d *DiaperRecord) IsDirty() bool {
+	return d.DiaperType == 2 || d.DiaperType == 3
+}
+
+// GetDiaperTypeText 获取尿布类型文本
+func (d *DiaperRecord) GetDiaperTypeText() string {
+	switch d.DiaperType {
+	case 1:
+		return "尿湿"
+	case 2:
+		return "便便"
+	case 3:
+		return "尿湿+便便"
+	case 4:
+		return "干爽"
+	default:
+		return "未知"
+	}
+}
+
+// TemperatureRecord 体温记录表
+type TemperatureRecord struct {
+	global.GVA_MODEL
+	UserID      uint      `json:"user_id" gorm:"not null;comment:记录人用户ID"`
+	BabyID      uint      `json:"baby_id" gorm:"not null;index:idx_temperature_baby_time;comment:宝宝ID"`
+	MeasuredAt  time.Time `json:"measured_at" gorm:"not null;index:idx_temperature_baby_time;comment:测量时间"`
+	Temperature float64   `json:"temperature" gorm:"type:decimal(4,1);not null;comment:体温(℃)"`
+	Site        int       `json:"site" gorm:"default:1;comment:测量部位:1腋下,2耳温,3额温,4肛温,5口腔"`
+	FeverLevel  int       `json:"fever_level" gorm:"default:0;comment:体温等级:-1体温过低,0正常,1低热,2中度发热,3高热,4超高热"`
+	AlertID     uint      `json:"alert_id" gorm:"default:0;comment:触发的警报ID"`
+	Notes       string    `json:"notes" gorm:"type:text;comment:备注"`
+}
+
+// TableName 指定表名
+func (TemperatureRecord) TableName() string {
+	return "temperature_records"
+}
+
+// GetSiteText 获取测量部位文本
+func (t *TemperatureRecord) GetSiteText() string {
+	switch t.Site {
+	case 1:
+		return "腋下"
+	case 2:
+		return "耳温"
+	case 3:
+		return "额温"
+	case 4:
+		return "肛温"
+	case 5:
+		return "口腔"
+	default:
+		return "未知"
+	}
+}
+
+// GetFeverLevelText 获取体温等级文本
+func (t *TemperatureRecord) GetFeverLevelText() string {
+	switch t.FeverLevel {
+	case -1:
+		return "体温过低"
+	case 0:
+		return "正常"
+	case 1:
+		return "低热"
+	case 2:
+		return "中度发热"
+	case 3:
+		return "高热"
+	case 4:
+		return "超高热"
+	default:
+		return "未知"
+	}
+}
+
+// MedicationRecord 用药记录表，每条记录为一次服药
+type MedicationRecord struct {
+	global.GVA_MODEL
+	UserID        uint       `json:"user_id" gorm:"not null;comment:记录人用户ID"`
+	BabyID        uint       `json:"baby_id" gorm:"not null;index:idx_medication_baby_time;comment:宝宝ID"`
+	MedicineName  string     `json:"medicine_name" gorm:"size:100;not null;comment:药品名称"`
+	Dosage        float64    `json:"dosage" gorm:"type:decimal(8,2);comment:剂量"`
+	Unit          string     `json:"unit" gorm:"size:20;comment:剂量单位,如ml、mg、滴"`
+	TakenAt       time.Time  `json:"taken_at" gorm:"not null;index:idx_medication_baby_time;comment:服药时间"`
+	IntervalHours int        `json:"interval_hours" gorm:"default:0;comment:服药间隔(小时),0表示不提醒"`
+	MaxDailyDoses int        `json:"max_daily_doses" gorm:"default:0;comment:24小时内最多服药次数,0表示不限"`
+	NextDoseAt    *time.Time `json:"next_dose_at" gorm:"index;comment:下次可服药时间"`
+	RemindStatus  int        `json:"remind_status" gorm:"default:0;comment:提醒状态:0不提醒,1待提醒,2已提醒,3已被后续服药取代"`
+	Reason        string     `json:"reason" gorm:"size:100;comment:用药原因"`
+	Notes         string     `json:"notes" gorm:"type:text;comment:备注"`
+}
+
+// TableName 指定表名
+func (MedicationRecord) TableName() string {
+	return "medication_records"
+}
+
+// SymptomRecord 症状记录表
+type SymptomRecord struct {
+	global.GVA_MODEL
+	UserID     uint      `json:"user_id" gorm:"not null;comment:记录人用户ID"`
+	BabyID     uint      `json:"baby_id" gorm:"not null;index:idx_symptom_baby_time;comment:宝宝ID"`
+	RecordTime time.Time `json:"record_time" gorm:"not null;index:idx_symptom_baby_time;comment:记录时间"`
+	Symptom    string    `json:"symptom" gorm:"size:50;not null;comment:症状,如咳嗽、流涕、呕吐、腹泻、皮疹"`
+	Severity   int       `json:"severity" gorm:"default:1;comment:严重程度:1轻微,2中等,3严重"`
+	Notes      string    `json:"notes" gorm:"type:text;comment:备注"`
+}
+
+// TableName 指定表名
+func (SymptomRecord) TableName() string {
+	return "symptom_records"
+}
+
+// GetSeverityText 获取严重程度文本
+func (s *SymptomRecord) GetSeverityText() string {
+	switch s.Severity {
+	case 1:
+		return "轻微"
+	case 2:
+		return "中等"
+	case 3:
+		return "严重"
+	default:
+		return "未知"
+	}
+}
@@ -0,0 +1,56 @@
+package baby
+
+import (
+	"time"
+	"baby_admin/server/global"
+)
+
+// VipPlan 会员套餐表
+type VipPlan struct {
+	global.GVA_MODEL
+	Code          string `json:"code" gorm:"size:30;not null;uniqueIndex;comment:套餐编码"`
+	Name          string `json:"name" gorm:"size:50;not null;comment:套餐名称"`
+	DurationDays  int    `json:"duration_days" gorm:"not null;comment:会员天数"`
+	Price         int    `json:"price" gorm:"default:0;comment:售价(分)"`
+	OriginalPrice int    `json:"original_price" gorm:"default:0;comment:原价(分)"`
+	Description   string `json:"description" gorm:"size:255;comment:套餐说明"`
+	IsActive      bool   `json:"is_active" gorm:"default:true;comment:是否启用"`
+	SortOrder     int    `json:"sort_order" gorm:"default:0;comment:排序权重"`
+}
+
+// TableName 指定表名
+func (VipPlan) TableName() string {
+	return "vip_plans"
+}
+
+// VipMembershipLog 会员变更记录表，每次开通、延期、撤销各记一条
+type VipMembershipLog struct {
+	global.GVA_MODEL
+	UserID        uint       `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	PlanID        uint       `json:"plan_id" gorm:"default:0;comment:会员套餐ID"`
+	Action        int        `json:"action" gorm:"not null;comment:操作:1开通,2延期,3撤销"`
+	Days          int        `json:"days" gorm:"default:0;comment:变更天数"`
+	ExpiredBefore *time.Time `json:"expired_before" gorm:"comment:变更前到期时间"`
+	ExpiredAfter  *time.Time `json:"expired_after" gorm:"comment:变更后到期时间"`
+	OperatorID    uint       `json:"operator_id" gorm:"default:0;comment:操作人(管理员)ID"`
+	Reason        string     `json:"reason" gorm:"size:255;comment:变更原因"`
+}
+
+// TableName 指定表名
+func (VipMembershipLog) TableName() string {
+	return "vip_membership_logs"
+}
+
+// GetActionText 获取操作文本
+func (l *VipMembershipLog) GetActionText() string {
+	switch l.Action {
+	case 1:
+		return "开通"
+	case 2:
+		return "延期"
+	case 3:
+		return "撤销"
+	default:
+		return "未知"
+	}
+}
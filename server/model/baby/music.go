@@ -49,7 +49,7 @@ func (Music) TableName() string {
 // UserMusicHistory 用户音乐播放历史
 type UserMusicHistory struct {
 	global.GVA_MODEL
-	UserID     uint `json:"user_id" gorm:"not null;comment:用户ID"`
+	UserID     uint `json:"user_id" gorm:"not null;index;comment:用户ID"`
 	MusicID    uint `json:"music_id" gorm:"not null;comment:音乐ID"`
 	BabyID     uint `json:"baby_id" gorm:"comment:宝宝ID"`
 	PlayTime   int  `json:"play_time" gorm:"default:0;comment:播放时长(秒)"`
@@ -64,8 +64,9 @@ func (UserMusicHistory) TableName() string {
 // UserMusicFavorite 用户音乐收藏
 type UserMusicFavorite struct {
 	global.GVA_MODEL
-	UserID  uint `json:"user_id" gorm:"not null;comment:用户ID"`
-	MusicID uint `json:"music_id" gorm:"not null;comment:音乐ID"`
+	UserID   uint `json:"user_id" gorm:"not null;comment:用户ID"`
+	MusicID  uint `json:"music_id" gorm:"not null;comment:音乐ID"`
+	FolderID uint `json:"folder_id" gorm:"default:0;index;comment:收藏夹ID,0为默认收藏夹"`
 }
 
 // TableName 指定表名
@@ -76,12 +77,14 @@ func (UserMusicFavorite) TableName() string {
 // Playlist 播放列表
 type Playlist struct {
 	global.GVA_MODEL
-	UserID      uint   `json:"user_id" gorm:"not null;comment:用户ID"`
+	UserID      uint   `json:"user_id" gorm:"not null;index;comment:用户ID"`
 	Name        string `json:"name" gorm:"size:50;not null;comment:播放列表名称"`
 	Description string `json:"description" gorm:"size:255;comment:描述"`
 	CoverURL    string `json:"cover_url" gorm:"size:500;comment:封面图片"`
 	IsPublic    bool   `json:"is_public" gorm:"default:false;comment:是否公开"`
 	MusicCount  int    `json:"music_count" gorm:"default:0;comment:音乐数量"`
+	SourceID    uint   `json:"source_id" gorm:"default:0;comment:复制来源播放列表ID"`
+	CloneCount  int64  `json:"clone_count" gorm:"default:0;comment:被复制次数"`
 }
 
 // TableName 指定表名
@@ -92,7 +95,7 @@ func (Playlist) TableName() string {
 // PlaylistMusic 播放列表音乐关联
 type PlaylistMusic struct {
 	global.GVA_MODEL
-	PlaylistID uint `json:"playlist_id" gorm:"not null;comment:播放列表ID"`
+	PlaylistID uint `json:"playlist_id" gorm:"not null;index;comment:播放列表ID"`
 	MusicID    uint `json:"music_id" gorm:"not null;comment:音乐ID"`
 	SortOrder  int  `json:"sort_order" gorm:"default:0;comment:排序权重"`
 }
@@ -100,4 +103,18 @@ type PlaylistMusic struct {
 // TableName 指定表名
 func (PlaylistMusic) TableName() string {
 	return "playlist_musics"
+}
+
+// MusicSimilarity 音乐相似度，由定时任务根据播放历史和收藏离线计算，每首音乐只保留最相似的若干首
+type MusicSimilarity struct {
+	global.GVA_MODEL
+	MusicID        uint    `json:"music_id" gorm:"not null;index;comment:音乐ID"`
+	SimilarMusicID uint    `json:"similar_music_id" gorm:"not null;comment:相似音乐ID"`
+	Score          float64 `json:"score" gorm:"comment:相似度"`
+	CoUsers        int     `json:"co_users" gorm:"comment:共同收听的用户数"`
+}
+
+// TableName 指定表名
+func (MusicSimilarity) TableName() string {
+	return "music_similarities"
 }
\ No newline at end of file
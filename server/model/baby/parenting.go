@@ -103,8 +103,8 @@ func (ParentingMilestone) TableName() string {
 // UserArticleRead 用户文章阅读记录表
 type UserArticleRead struct {
 	global.GVA_MODEL
-	UserID    uint      `json:"user_id" gorm:"not null;comment:用户ID"`
-	ArticleID uint      `json:"article_id" gorm:"not null;comment:文章ID"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_user_article;comment:用户ID"`
+	ArticleID uint      `json:"article_id" gorm:"not null;uniqueIndex:idx_user_article;comment:文章ID"`
 	ReadTime  int       `json:"read_time" gorm:"default:0;comment:阅读时长(秒)"`
 	Progress  int       `json:"progress" gorm:"default:0;comment:阅读进度(百分比)"`
 	IsFinished bool     `json:"is_finished" gorm:"default:false;comment:是否读完"`
@@ -119,10 +119,11 @@ func (UserArticleRead) TableName() string {
 // UserVideoWatch 用户视频观看记录表
 type UserVideoWatch struct {
 	global.GVA_MODEL
-	UserID      uint      `json:"user_id" gorm:"not null;comment:用户ID"`
-	VideoID     uint      `json:"video_id" gorm:"not null;comment:视频ID"`
+	UserID      uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_user_video;comment:用户ID"`
+	VideoID     uint      `json:"video_id" gorm:"not null;uniqueIndex:idx_user_video;comment:视频ID"`
 	WatchTime   int       `json:"watch_time" gorm:"default:0;comment:观看时长(秒)"`
 	Progress    int       `json:"progress" gorm:"default:0;comment:观看进度(百分比)"`
+	Position    int       `json:"position" gorm:"default:0;comment:上次观看位置(秒)"`
 	IsFinished  bool      `json:"is_finished" gorm:"default:false;comment:是否看完"`
 	LastWatchAt time.Time `json:"last_watch_at" gorm:"comment:最后观看时间"`
 }
@@ -138,6 +139,7 @@ type UserContentFavorite struct {
 	UserID      uint `json:"user_id" gorm:"not null;comment:用户ID"`
 	ContentType int  `json:"content_type" gorm:"not null;comment:内容类型:1文章,2视频"`
 	ContentID   uint `json:"content_id" gorm:"not null;comment:内容ID"`
+	FolderID    uint `json:"folder_id" gorm:"default:0;index;comment:收藏夹ID,0为默认收藏夹"`
 }
 
 // TableName 指定表名
@@ -145,6 +147,19 @@ func (UserContentFavorite) TableName() string {
 	return "user_content_favorites"
 }
 
+// FavoriteFolder 用户收藏夹表，文章、视频和音乐收藏共用
+type FavoriteFolder struct {
+	global.GVA_MODEL
+	UserID    uint   `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	Name      string `json:"name" gorm:"size:50;not null;comment:收藏夹名称"`
+	SortOrder int    `json:"sort_order" gorm:"default:0;comment:排序权重"`
+}
+
+// TableName 指定表名
+func (FavoriteFolder) TableName() string {
+	return "favorite_folders"
+}
+
 // GetDifficultyText 获取难度等级文本
 func (p *ParentingArticle) GetDifficultyText() string {
 	switch p.Difficulty {
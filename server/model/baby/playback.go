@@ -0,0 +1,119 @@
+package baby
+
+import (
+	"encoding/json"
+	"time"
+	"baby_admin/server/global"
+)
+
+// PlaybackSession 播放会话，记录播放队列、循环模式和睡眠定时，小程序和配对的音响设备都可以接续播放
+type PlaybackSession struct {
+	global.GVA_MODEL
+	UserID         uint       `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	BabyID         uint       `json:"baby_id" gorm:"default:0;comment:宝宝ID"`
+	DeviceID       uint       `json:"device_id" gorm:"default:0;index;comment:正在播放的音响设备ID,0为小程序播放"`
+	PlaylistID     uint       `json:"playlist_id" gorm:"default:0;comment:来源播放列表ID,0为自定义队列"`
+	Queue          string     `json:"queue" gorm:"type:text;comment:播放队列,音乐ID的JSON数组"`
+	CurrentIndex   int        `json:"current_index" gorm:"default:0;comment:当前播放的队列位置"`
+	Position       int        `json:"position" gorm:"default:0;comment:当前曲目播放位置(秒)"`
+	LoopMode       int        `json:"loop_mode" gorm:"default:1;comment:循环模式:1顺序播放,2列表循环,3单曲循环"`
+	Status         int        `json:"status" gorm:"default:1;index;comment:会话状态:1播放中,2已暂停,3已结束"`
+	SleepMinutes   int        `json:"sleep_minutes" gorm:"default:0;comment:睡眠定时(分钟),0为不定时"`
+	FadeOutSeconds int        `json:"fade_out_seconds" gorm:"default:0;comment:定时结束前的淡出时长(秒)"`
+	SleepAt        *time.Time `json:"sleep_at" gorm:"comment:睡眠定时停止时间"`
+	LastActiveAt   time.Time  `json:"last_active_at" gorm:"comment:最后一次同步播放进度的时间"`
+	EndedAt        *time.Time `json:"ended_at" gorm:"comment:结束时间"`
+	EndReason      int        `json:"end_reason" gorm:"default:0;comment:结束原因:1手动停止,2睡眠定时,3队列播完,4开始新会话,5长时间未同步"`
+}
+
+// TableName 指定表名
+func (PlaybackSession) TableName() string {
+	return "playback_sessions"
+}
+
+// GetQueue 解析播放队列
+func (ps *PlaybackSession) GetQueue() ([]uint, error) {
+	var queue []uint
+	if ps.Queue == "" {
+		return queue, nil
+	}
+	err := json.Unmarshal([]byte(ps.Queue), &queue)
+	return queue, err
+}
+
+// SetQueue 设置播放队列
+func (ps *PlaybackSession) SetQueue(queue []uint) error {
+	data, err := json.Marshal(queue)
+	if err != nil {
+		return err
+	}
+	ps.Queue = string(data)
+	return nil
+}
+
+// IsEnded 判断会话是否已结束
+func (ps *PlaybackSession) IsEnded() bool {
+	return ps.Status == 3
+}
+
+// GetLoopModeText 获取循环模式文本
+func (ps *PlaybackSession) GetLoopModeText() string {
+	switch ps.LoopMode {
+	case 1:
+		return "顺序播放"
+	case 2:
+		return "列表循环"
+	case 3:
+		return "单曲循环"
+	default:
+		return "未知模式"
+	}
+}
+
+// GetStatusText 获取会话状态文本
+func (ps *PlaybackSession) GetStatusText() string {
+	switch ps.Status {
+	case 1:
+		return "播放中"
+	case 2:
+		return "已暂停"
+	case 3:
+		return "已结束"
+	default:
+		return "未知状态"
+	}
+}
+
+// GetEndReasonText 获取结束原因文本
+func (ps *PlaybackSession) GetEndReasonText() string {
+	switch ps.EndReason {
+	case 0:
+		return ""
+	case 1:
+		return "手动停止"
+	case 2:
+		return "睡眠定时"
+	case 3:
+		return "队列播完"
+	case 4:
+		return "开始新会话"
+	case 5:
+		return "长时间未同步"
+	default:
+		return "未知原因"
+	}
+}
+
+// PlaybackSessionTrack 会话内每首音乐的累计播放情况，会话结束时汇总写入播放历史
+type PlaybackSessionTrack struct {
+	global.GVA_MODEL
+	SessionID  uint `json:"session_id" gorm:"not null;index;comment:播放会话ID"`
+	MusicID    uint `json:"music_id" gorm:"not null;comment:音乐ID"`
+	PlayTime   int  `json:"play_time" gorm:"default:0;comment:累计播放时长(秒)"`
+	IsFinished bool `json:"is_finished" gorm:"default:false;comment:是否完整播放过"`
+}
+
+// TableName 指定表名
+func (PlaybackSessionTrack) TableName() string {
+	return "playback_session_tracks"
+}
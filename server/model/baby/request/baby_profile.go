@@ -24,7 +24,11 @@ type CreateBabyProfileRequest struct {
 	Height    float64   `json:"height"`
 	BloodType string    `json:"blood_type"`
 	Remark    string    `json:"remark"`
-	IsActive  bool      `json:"is_active"`
+	IsActive  bool      `json:"is_active"` // 设为自己的当前宝宝
+	FamilyID  uint      `json:"family_id"` // 所属家庭，为空时放入自己创建的家庭
+	// 出生胎龄，早产儿填写后推荐、里程碑和生长评估按矫正年龄计算
+	GestationalWeeks int `json:"gestational_weeks" binding:"omitempty,min=22,max=44"`
+	GestationalDays  int `json:"gestational_days" binding:"min=0,max=6"`
 }
 
 // UpdateBabyProfileRequest 更新宝宝档案请求
@@ -38,13 +42,18 @@ type UpdateBabyProfileRequest struct {
 	Height    float64   `json:"height"`
 	BloodType string    `json:"blood_type"`
 	Remark    string    `json:"remark"`
-	IsActive  bool      `json:"is_active"`
+	IsActive  bool      `json:"is_active"` // 设为自己的当前宝宝
+	FamilyID  uint      `json:"family_id"` // 移入其他家庭，为空时不变，仅创建者可操作
+	// 出生胎龄，早产儿填写后推荐、里程碑和生长评估按矫正年龄计算
+	GestationalWeeks int `json:"gestational_weeks" binding:"omitempty,min=22,max=44"`
+	GestationalDays  int `json:"gestational_days" binding:"min=0,max=6"`
 }
 
 // ToBabyProfile 转换为BabyProfile模型
-func (req *CreateBabyProfileRequest) ToBabyProfile(userID uint) *baby.BabyProfile {
+func (req *CreateBabyProfileRequest) ToBabyProfile(userID uint, familyID uint) *baby.BabyProfile {
 	return &baby.BabyProfile{
 		UserID:    userID,
+		FamilyID:  familyID,
 		Name:      req.Name,
 		Gender:    req.Gender,
 		Birthday:  req.Birthday,
@@ -53,6 +62,8 @@ func (req *CreateBabyProfileRequest) ToBabyProfile(userID uint) *baby.BabyProfil
 		Height:    req.Height,
 		BloodType: req.BloodType,
 		Remark:    req.Remark,
-		IsActive:  req.IsActive,
+
+		GestationalWeeks: req.GestationalWeeks,
+		GestationalDays:  req.GestationalDays,
 	}
 }
@@ -44,6 +44,14 @@ type DeviceCommandRequest struct {
 	Parameters  string `json:"parameters"` // JSON格式的参数
 }
 
+// DeviceCommandSearch 设备命令搜索条件
+type DeviceCommandSearch struct {
+	request.PageInfo
+	DeviceID    uint `json:"device_id" form:"device_id"`
+	CommandType int  `json:"command_type" form:"command_type"`
+	Status      int  `json:"status" form:"status"`
+}
+
 // UpdateDeviceConfigRequest 更新设备配置请求
 type UpdateDeviceConfigRequest struct {
 	DeviceID uint                      `json:"device_id" binding:"required"`
@@ -70,6 +78,58 @@ type AcceptShareRequest struct {
 	ShareID uint `json:"share_id" binding:"required"`
 }
 
+// DeclineShareRequest 拒绝或退出分享请求
+type DeclineShareRequest struct {
+	ShareID uint `json:"share_id" binding:"required"`
+}
+
+// DeviceTelemetryRequest 设备批量上报请求，由设备端签名调用
+type DeviceTelemetryRequest struct {
+	Statuses        []DeviceStatusSample    `json:"statuses" binding:"omitempty,max=200,dive"`
+	Logs            []DeviceLogSample       `json:"logs" binding:"omitempty,max=200,dive"`
+	Environment     []EnvironmentDataSample `json:"environment" binding:"omitempty,max=200,dive"`
+	FirmwareVersion string                  `json:"firmware_version" binding:"max=20"`
+	HardwareVersion string                  `json:"hardware_version" binding:"max=20"`
+}
+
+// DeviceStatusSample 设备状态采样
+type DeviceStatusSample struct {
+	StatusType  int    `json:"status_type" binding:"required,oneof=1 2 3 4"`
+	StatusValue string `json:"status_value" binding:"max=100"`
+	StatusData  string `json:"status_data"`
+	IsAlert     bool   `json:"is_alert"`
+	Timestamp   int64  `json:"timestamp"` // 采样时间(Unix秒)，为空时取服务器时间
+}
+
+// DeviceLogSample 设备日志采样
+type DeviceLogSample struct {
+	LogLevel  int    `json:"log_level" binding:"required,oneof=1 2 3 4"`
+	LogType   int    `json:"log_type" binding:"required,oneof=1 2 3 4"`
+	Message   string `json:"message" binding:"required"`
+	Details   string `json:"details"`
+	Source    string `json:"source" binding:"max=50"`
+	Timestamp int64  `json:"timestamp"` // 采样时间(Unix秒)，为空时取服务器时间
+}
+
+// EnvironmentDataSample 环境数据采样
+type EnvironmentDataSample struct {
+	Temperature float64 `json:"temperature" binding:"min=-40,max=85"`
+	Humidity    float64 `json:"humidity" binding:"min=0,max=100"`
+	AirQuality  int     `json:"air_quality" binding:"min=0,max=500"`
+	NoiseLevel  float64 `json:"noise_level" binding:"min=0,max=200"`
+	Brightness  int     `json:"brightness" binding:"min=0"`
+	CO2Level    int     `json:"co2_level" binding:"min=0"`
+	Timestamp   int64   `json:"timestamp"` // 采样时间(Unix秒)，为空时取服务器时间
+}
+
+// DeviceCommandResultRequest 设备回传命令执行结果
+type DeviceCommandResultRequest struct {
+	CommandID uint   `json:"command_id" binding:"required"`
+	Success   bool   `json:"success"`
+	Response  string `json:"response"`
+	ErrorMsg  string `json:"error_msg"`
+}
+
 // DeviceLogSearch 设备日志搜索条件
 type DeviceLogSearch struct {
 	request.PageInfo
@@ -0,0 +1,46 @@
+package request
+
+// CreateFamilyRequest 创建家庭请求
+type CreateFamilyRequest struct {
+	Name     string `json:"name" binding:"required,max=50"`
+	Relation string `json:"relation" binding:"max=20"` // 创建者与宝宝的关系
+}
+
+// UpdateFamilyRequest 更新家庭请求
+type UpdateFamilyRequest struct {
+	ID   uint   `json:"id" binding:"required"`
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+// RefreshInviteCodeRequest 生成邀请码请求，新邀请码生成后旧邀请码失效
+type RefreshInviteCodeRequest struct {
+	FamilyID  uint `json:"family_id" binding:"required"`
+	Role      int  `json:"role" binding:"required,oneof=2 3 4"` // 通过邀请码加入的默认角色
+	ValidDays int  `json:"valid_days" binding:"min=0,max=30"`   // 有效天数，0为默认7天
+}
+
+// JoinFamilyRequest 申请加入家庭请求
+type JoinFamilyRequest struct {
+	InviteCode string `json:"invite_code" binding:"required,max=16"`
+	Relation   string `json:"relation" binding:"max=20"`
+}
+
+// ReviewFamilyMemberRequest 审核加入申请请求
+type ReviewFamilyMemberRequest struct {
+	MemberID uint `json:"member_id" binding:"required"`
+	Approve  bool `json:"approve"`
+	Role     int  `json:"role" binding:"omitempty,oneof=2 3 4"` // 为空时使用申请时的角色
+}
+
+// UpdateFamilyMemberRequest 修改成员角色请求
+type UpdateFamilyMemberRequest struct {
+	MemberID uint   `json:"member_id" binding:"required"`
+	Role     int    `json:"role" binding:"required,oneof=2 3 4"`
+	Relation string `json:"relation" binding:"max=20"`
+}
+
+// TransferFamilyRequest 转让家庭请求
+type TransferFamilyRequest struct {
+	FamilyID uint `json:"family_id" binding:"required"`
+	MemberID uint `json:"member_id" binding:"required"`
+}
@@ -0,0 +1,43 @@
+package request
+
+import "baby_admin/server/model/common/request"
+
+// ToggleFavoriteRequest 切换收藏请求
+type ToggleFavoriteRequest struct {
+	ContentType int  `json:"content_type" binding:"required,oneof=1 2 3"` // 1文章,2视频,3音乐
+	ContentID   uint `json:"content_id" binding:"required"`
+	FolderID    uint `json:"folder_id"` // 收藏到指定收藏夹，为空时使用默认收藏夹
+}
+
+// FavoriteSearch 收藏搜索条件
+type FavoriteSearch struct {
+	request.PageInfo
+	ContentType int   `json:"content_type" form:"content_type" binding:"omitempty,oneof=1 2 3"` // 为空时返回全部类型
+	FolderID    *uint `json:"folder_id" form:"folder_id"`                                       // 0为默认收藏夹，为空时不限
+}
+
+// CheckFavoriteRequest 批量查询收藏状态请求
+type CheckFavoriteRequest struct {
+	ContentType int    `json:"content_type" binding:"required,oneof=1 2 3"`
+	ContentIDs  []uint `json:"content_ids" binding:"required,min=1,max=100"`
+}
+
+// MoveFavoritesRequest 移动收藏到收藏夹请求
+type MoveFavoritesRequest struct {
+	ContentType int    `json:"content_type" binding:"required,oneof=1 2 3"`
+	ContentIDs  []uint `json:"content_ids" binding:"required,min=1,max=100"`
+	FolderID    uint   `json:"folder_id"` // 0为默认收藏夹
+}
+
+// CreateFavoriteFolderRequest 创建收藏夹请求
+type CreateFavoriteFolderRequest struct {
+	Name      string `json:"name" binding:"required,max=50"`
+	SortOrder int    `json:"sort_order"`
+}
+
+// UpdateFavoriteFolderRequest 更新收藏夹请求
+type UpdateFavoriteFolderRequest struct {
+	ID        uint   `json:"id" binding:"required"`
+	Name      string `json:"name" binding:"required,max=50"`
+	SortOrder int    `json:"sort_order"`
+}
@@ -0,0 +1,65 @@
+package request
+
+import (
+	"baby_admin/server/model/common/request"
+	"time"
+)
+
+// FeedingRecordSearch 喂养记录搜索条件
+type FeedingRecordSearch struct {
+	request.PageInfo
+	BabyID      uint   `json:"baby_id" form:"baby_id"`
+	FeedingType int    `json:"feeding_type" form:"feeding_type"`
+	StartDate   string `json:"start_date" form:"start_date"`
+	EndDate     string `json:"end_date" form:"end_date"`
+}
+
+// StartBreastFeedingRequest 开始母乳亲喂计时请求
+type StartBreastFeedingRequest struct {
+	BabyID    uint       `json:"baby_id" binding:"required"`
+	Side      string     `json:"side" binding:"required,oneof=left right"`
+	StartTime *time.Time `json:"start_time"` // 为空时为当前时间
+}
+
+// SwitchBreastSideRequest 切换哺乳侧请求，暂停后也通过该接口继续计时
+type SwitchBreastSideRequest struct {
+	ID   uint   `json:"id" binding:"required"`
+	Side string `json:"side" binding:"required,oneof=left right"`
+}
+
+// EndFeedingRequest 结束进行中的喂养请求
+type EndFeedingRequest struct {
+	ID      uint       `json:"id" binding:"required"`
+	EndTime *time.Time `json:"end_time"` // 为空时为当前时间
+	Notes   string     `json:"notes"`
+}
+
+// CreateFeedingRecordRequest 补录喂养记录请求
+type CreateFeedingRecordRequest struct {
+	BabyID        uint       `json:"baby_id" binding:"required"`
+	FeedingType   int        `json:"feeding_type" binding:"required,oneof=1 2 3"`
+	StartTime     time.Time  `json:"start_time" binding:"required"`
+	EndTime       *time.Time `json:"end_time"`
+	LeftDuration  int        `json:"left_duration" binding:"min=0,max=14400"`  // 左侧哺乳时长(秒)
+	RightDuration int        `json:"right_duration" binding:"min=0,max=14400"` // 右侧哺乳时长(秒)
+	LastSide      string     `json:"last_side" binding:"omitempty,oneof=left right"`
+	MilkType      int        `json:"milk_type" binding:"omitempty,oneof=1 2"`
+	Amount        int        `json:"amount" binding:"min=0,max=1000"` // 奶量(ml)
+	FoodName      string     `json:"food_name" binding:"max=100"`
+	FoodAmount    int        `json:"food_amount" binding:"min=0,max=1000"` // 辅食量(g)
+	Allergens     string     `json:"allergens"`                            // 过敏原，逗号分隔
+	Reaction      int        `json:"reaction" binding:"min=0,max=2"`
+	Notes         string     `json:"notes"`
+}
+
+// UpdateFeedingRecordRequest 更新喂养记录请求
+type UpdateFeedingRecordRequest struct {
+	ID uint `json:"id" binding:"required"`
+	CreateFeedingRecordRequest
+}
+
+// FeedingSummaryRequest 喂养日汇总请求
+type FeedingSummaryRequest struct {
+	BabyID uint   `json:"baby_id" form:"baby_id" binding:"required"`
+	Date   string `json:"date" form:"date"` // 统计日期，默认今天
+}
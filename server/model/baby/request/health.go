@@ -0,0 +1,60 @@
+package request
+
+import (
+	"baby_admin/server/model/common/request"
+	"time"
+)
+
+// HealthRecordSearch 健康记录搜索条件，尿布、体温、用药、症状列表通用
+type HealthRecordSearch struct {
+	request.PageInfo
+	BabyID    uint   `json:"baby_id" form:"baby_id"`
+	StartDate string `json:"start_date" form:"start_date"`
+	EndDate   string `json:"end_date" form:"end_date"`
+}
+
+// CreateDiaperRecordRequest 记录换尿布请求
+type CreateDiaperRecordRequest struct {
+	BabyID     uint       `json:"baby_id" binding:"required"`
+	RecordTime *time.Time `json:"record_time"` // 为空时为当前时间
+	DiaperType int        `json:"diaper_type" binding:"required,oneof=1 2 3 4"`
+	StoolColor string     `json:"stool_color" binding:"max=20"`
+	Notes      string     `json:"notes"`
+}
+
+// CreateTemperatureRecordRequest 记录体温请求
+type CreateTemperatureRecordRequest struct {
+	BabyID      uint       `json:"baby_id" binding:"required"`
+	MeasuredAt  *time.Time `json:"measured_at"` // 为空时为当前时间
+	Temperature float64    `json:"temperature" binding:"required,min=30,max=45"`
+	Site        int        `json:"site" binding:"omitempty,oneof=1 2 3 4 5"` // 默认腋下
+	Notes       string     `json:"notes"`
+}
+
+// CreateMedicationRecordRequest 记录服药请求
+type CreateMedicationRecordRequest struct {
+	BabyID        uint       `json:"baby_id" binding:"required"`
+	MedicineName  string     `json:"medicine_name" binding:"required,max=100"`
+	Dosage        float64    `json:"dosage" binding:"min=0"`
+	Unit          string     `json:"unit" binding:"max=20"`
+	TakenAt       *time.Time `json:"taken_at"` // 为空时为当前时间
+	IntervalHours int        `json:"interval_hours" binding:"min=0,max=72"`
+	MaxDailyDoses int        `json:"max_daily_doses" binding:"min=0,max=24"`
+	Reason        string     `json:"reason" binding:"max=100"`
+	Notes         string     `json:"notes"`
+}
+
+// CreateSymptomRecordRequest 记录症状请求
+type CreateSymptomRecordRequest struct {
+	BabyID     uint       `json:"baby_id" binding:"required"`
+	RecordTime *time.Time `json:"record_time"` // 为空时为当前时间
+	Symptom    string     `json:"symptom" binding:"required,max=50"`
+	Severity   int        `json:"severity" binding:"omitempty,oneof=1 2 3"`
+	Notes      string     `json:"notes"`
+}
+
+// HealthSummaryRequest 健康日汇总请求
+type HealthSummaryRequest struct {
+	BabyID uint   `json:"baby_id" form:"baby_id" binding:"required"`
+	Date   string `json:"date" form:"date"` // 统计日期，默认今天
+}
@@ -0,0 +1,8 @@
+package request
+
+// MediaRequest 媒体网关签名参数，由服务端签发的播放地址携带
+type MediaRequest struct {
+	UID     uint   `form:"uid" binding:"required"`
+	Expires int64  `form:"expires" binding:"required"`
+	Sign    string `form:"sign" binding:"required"`
+}
@@ -0,0 +1,56 @@
+package request
+
+import "baby_admin/server/model/common/request"
+
+// CreateVipPlanRequest 创建会员套餐请求（管理端）
+type CreateVipPlanRequest struct {
+	Code          string `json:"code" binding:"required,max=30"`
+	Name          string `json:"name" binding:"required,max=50"`
+	DurationDays  int    `json:"duration_days" binding:"required,min=1,max=3660"`
+	Price         int    `json:"price" binding:"min=0"`
+	OriginalPrice int    `json:"original_price" binding:"min=0"`
+	Description   string `json:"description" binding:"max=255"`
+	SortOrder     int    `json:"sort_order"`
+	IsActive      *bool  `json:"is_active"` // 为空时默认启用
+}
+
+// UpdateVipPlanRequest 更新会员套餐请求（管理端）
+type UpdateVipPlanRequest struct {
+	ID uint `json:"id" binding:"required"`
+	CreateVipPlanRequest
+}
+
+// VipPlanSearch 会员套餐搜索条件（管理端）
+type VipPlanSearch struct {
+	request.PageInfo
+	Name     string `json:"name" form:"name"`
+	IsActive *bool  `json:"is_active" form:"is_active"`
+}
+
+// MembershipChangeRequest 开通或延期会员请求（管理端），指定套餐时按套餐天数，指定天数时以天数为准
+type MembershipChangeRequest struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	PlanID uint   `json:"plan_id"`
+	Days   int    `json:"days" binding:"min=0,max=3660"`
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// RevokeMembershipRequest 撤销会员请求（管理端）
+type RevokeMembershipRequest struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// MemberSearch 会员用户搜索条件（管理端）
+type MemberSearch struct {
+	request.PageInfo
+	Keyword string `json:"keyword" form:"keyword"`                     // 昵称、用户名或手机号
+	Status  int    `json:"status" form:"status" binding:"oneof=0 1 2"` // 0全部开通过会员的用户,1有效,2已过期
+}
+
+// MembershipLogSearch 会员变更记录搜索条件（管理端）
+type MembershipLogSearch struct {
+	request.PageInfo
+	UserID uint `json:"user_id" form:"user_id"`
+	Action int  `json:"action" form:"action"`
+}
@@ -0,0 +1,26 @@
+package request
+
+import "time"
+
+// MilestoneChecklistRequest 宝宝里程碑清单请求
+type MilestoneChecklistRequest struct {
+	BabyID   uint   `json:"baby_id" form:"baby_id" binding:"required"`
+	Category string `json:"category" form:"category"` // 发育类别，为空时返回全部
+}
+
+// AchieveMilestoneRequest 标记里程碑达成请求
+// 未指定GrowthRecordID时自动创建一条里程碑类型的成长记录
+type AchieveMilestoneRequest struct {
+	BabyID         uint      `json:"baby_id" binding:"required"`
+	MilestoneID    uint      `json:"milestone_id" binding:"required"`
+	AchievedAt     time.Time `json:"achieved_at" binding:"required"`
+	Note           string    `json:"note" binding:"max=500"`
+	GrowthRecordID uint      `json:"growth_record_id"` // 关联已有的里程碑成长记录
+	IsPrivate      bool      `json:"is_private"`       // 自动创建的成长记录是否私密
+}
+
+// CancelMilestoneRequest 取消里程碑达成请求
+type CancelMilestoneRequest struct {
+	BabyID      uint `json:"baby_id" binding:"required"`
+	MilestoneID uint `json:"milestone_id" binding:"required"`
+}
@@ -12,6 +12,12 @@ type MusicSearch struct {
 	IsVIP      *bool  `json:"is_vip" form:"is_vip"`
 }
 
+// MusicHistorySearch 播放历史查询条件
+type MusicHistorySearch struct {
+	request.PageInfo
+	BabyID uint `json:"baby_id" form:"baby_id"` // 指定宝宝时返回家庭成员的全部播放记录
+}
+
 // PlayMusicRequest 播放音乐请求
 type PlayMusicRequest struct {
 	MusicID  uint `json:"music_id" binding:"required"`
@@ -47,3 +53,21 @@ type RemoveMusicFromPlaylistRequest struct {
 	PlaylistID uint   `json:"playlist_id" binding:"required"`
 	MusicIDs   []uint `json:"music_ids" binding:"required,min=1"`
 }
+
+// ReorderPlaylistRequest 调整播放列表顺序请求，需传入列表中全部音乐的新顺序
+type ReorderPlaylistRequest struct {
+	PlaylistID uint   `json:"playlist_id" binding:"required"`
+	MusicIDs   []uint `json:"music_ids" binding:"required,min=1"`
+}
+
+// ClonePlaylistRequest 复制公开播放列表请求
+type ClonePlaylistRequest struct {
+	PlaylistID uint   `json:"playlist_id" binding:"required"`
+	Name       string `json:"name" binding:"max=50"` // 为空时沿用原名称
+}
+
+// PlaylistSearch 公开播放列表搜索条件
+type PlaylistSearch struct {
+	request.PageInfo
+	Keyword string `json:"keyword" form:"keyword"`
+}
@@ -36,23 +36,18 @@ type ParentingMilestoneSearch struct {
 // ReadArticleRequest 阅读文章请求
 type ReadArticleRequest struct {
 	ArticleID  uint `json:"article_id" binding:"required"`
-	ReadTime   int  `json:"read_time"`   // 阅读时长(秒)
-	Progress   int  `json:"progress"`    // 阅读进度(百分比)
-	IsFinished bool `json:"is_finished"` // 是否读完
+	ReadTime   int  `json:"read_time" binding:"min=0,max=86400"` // 距上次上报新增的阅读时长(秒)
+	Progress   int  `json:"progress" binding:"min=0,max=100"`    // 阅读进度(百分比)
+	IsFinished bool `json:"is_finished"`                         // 是否读完
 }
 
 // WatchVideoRequest 观看视频请求
 type WatchVideoRequest struct {
 	VideoID    uint `json:"video_id" binding:"required"`
-	WatchTime  int  `json:"watch_time"`  // 观看时长(秒)
-	Progress   int  `json:"progress"`    // 观看进度(百分比)
-	IsFinished bool `json:"is_finished"` // 是否看完
-}
-
-// ToggleFavoriteRequest 切换收藏请求
-type ToggleFavoriteRequest struct {
-	ContentType int  `json:"content_type" binding:"required,oneof=1 2"` // 1文章,2视频
-	ContentID   uint `json:"content_id" binding:"required"`
+	WatchTime  int  `json:"watch_time" binding:"min=0,max=86400"` // 距上次上报新增的观看时长(秒)
+	Progress   int  `json:"progress" binding:"min=0,max=100"`     // 观看进度(百分比)
+	Position   int  `json:"position" binding:"min=0"`             // 当前播放位置(秒)，用于续播
+	IsFinished bool `json:"is_finished"`                          // 是否看完
 }
 
 // GetRecommendationsRequest 获取推荐内容请求
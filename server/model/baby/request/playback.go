@@ -0,0 +1,37 @@
+package request
+
+// StartPlaybackRequest 开始播放会话请求，播放列表和音乐ID列表二选一
+type StartPlaybackRequest struct {
+	PlaylistID     uint   `json:"playlist_id"`                               // 播放列表ID
+	MusicIDs       []uint `json:"music_ids" binding:"max=200"`               // 自定义播放队列
+	StartIndex     int    `json:"start_index" binding:"min=0"`               // 从队列中第几首开始播放
+	BabyID         uint   `json:"baby_id"`                                   // 宝宝ID
+	DeviceID       uint   `json:"device_id"`                                 // 在音响设备上播放，0为小程序播放
+	LoopMode       int    `json:"loop_mode" binding:"omitempty,oneof=1 2 3"` // 循环模式:1顺序播放,2列表循环,3单曲循环，默认顺序播放
+	SleepMinutes   int    `json:"sleep_minutes" binding:"min=0,max=720"`     // 睡眠定时(分钟)，0为不定时
+	FadeOutSeconds int    `json:"fade_out_seconds" binding:"min=0,max=600"`  // 定时结束前的淡出时长(秒)
+}
+
+// UpdatePlaybackSettingsRequest 更新循环模式和睡眠定时请求，睡眠定时从修改时重新计时
+type UpdatePlaybackSettingsRequest struct {
+	SessionID      uint `json:"session_id" binding:"required"`
+	LoopMode       int  `json:"loop_mode" binding:"omitempty,oneof=1 2 3"`
+	SleepMinutes   *int `json:"sleep_minutes" binding:"omitempty,min=0,max=720"`
+	FadeOutSeconds *int `json:"fade_out_seconds" binding:"omitempty,min=0,max=600"`
+}
+
+// PlaybackProgressRequest 同步播放进度请求，暂停和停止时也携带最后的进度
+type PlaybackProgressRequest struct {
+	SessionID     uint `json:"session_id" binding:"required"`
+	MusicID       uint `json:"music_id"`                       // 本次上报播放时长对应的音乐，为0时只同步位置
+	PlayedSeconds int  `json:"played_seconds" binding:"min=0"` // 距上次上报该音乐新增的播放时长(秒)
+	Finished      bool `json:"finished"`                       // 该音乐是否已完整播放
+	CurrentIndex  int  `json:"current_index" binding:"min=0"`  // 正在播放的队列位置
+	Position      int  `json:"position" binding:"min=0"`       // 当前曲目播放位置(秒)
+}
+
+// ResumePlaybackRequest 继续播放请求，可切换到音响设备或切回小程序
+type ResumePlaybackRequest struct {
+	SessionID uint `json:"session_id" binding:"required"`
+	DeviceID  uint `json:"device_id"` // 在音响设备上继续播放，0为小程序播放
+}
@@ -15,40 +15,74 @@ type SleepRecordSearch struct {
 	Status    int    `json:"status" form:"status"`
 }
 
-// CreateSleepRecordRequest 创建睡眠记录请求
+// CreateSleepRecordRequest 创建睡眠记录请求，填写结束时间即为补录，否则等同于开始睡眠
 type CreateSleepRecordRequest struct {
 	BabyID      uint       `json:"baby_id" binding:"required"`
 	DeviceID    uint       `json:"device_id"`
 	StartTime   time.Time  `json:"start_time" binding:"required"`
 	EndTime     *time.Time `json:"end_time"`
-	Quality     int        `json:"quality"`
+	Quality     int        `json:"quality" binding:"min=0,max=10"`
+	DeepSleep   int        `json:"deep_sleep" binding:"min=0"`
+	LightSleep  int        `json:"light_sleep" binding:"min=0"`
+	AwakeCount  int        `json:"awake_count" binding:"min=0"`
 	Temperature float64    `json:"temperature"`
 	Humidity    float64    `json:"humidity"`
 	NoiseLevel  float64    `json:"noise_level"`
 	Notes       string     `json:"notes"`
 }
 
-// UpdateSleepRecordRequest 更新睡眠记录请求
+// UpdateSleepRecordRequest 更新睡眠记录请求，用于修正已有记录
 type UpdateSleepRecordRequest struct {
 	ID          uint       `json:"id" binding:"required"`
+	StartTime   *time.Time `json:"start_time"`
 	EndTime     *time.Time `json:"end_time"`
-	Duration    int        `json:"duration"`
-	Quality     int        `json:"quality"`
-	DeepSleep   int        `json:"deep_sleep"`
-	LightSleep  int        `json:"light_sleep"`
-	AwakeCount  int        `json:"awake_count"`
+	Duration    int        `json:"duration"` // 由开始和结束时间计算，传入值将被忽略
+	Quality     int        `json:"quality" binding:"min=0,max=10"`
+	DeepSleep   int        `json:"deep_sleep" binding:"min=0"`
+	LightSleep  int        `json:"light_sleep" binding:"min=0"`
+	AwakeCount  int        `json:"awake_count" binding:"min=0"`
 	Temperature float64    `json:"temperature"`
 	Humidity    float64    `json:"humidity"`
 	NoiseLevel  float64    `json:"noise_level"`
-	Status      int        `json:"status"`
+	Status      int        `json:"status" binding:"omitempty,oneof=2 3"`
 	Notes       string     `json:"notes"`
 }
 
+// StartSleepRequest 开始睡眠请求
+type StartSleepRequest struct {
+	BabyID      uint       `json:"baby_id" binding:"required"`
+	DeviceID    uint       `json:"device_id"`
+	StartTime   *time.Time `json:"start_time"` // 为空时取当前时间
+	Temperature float64    `json:"temperature"`
+	Humidity    float64    `json:"humidity"`
+	NoiseLevel  float64    `json:"noise_level"`
+	Notes       string     `json:"notes"`
+}
+
+// EndSleepRequest 结束睡眠请求
+type EndSleepRequest struct {
+	ID         uint       `json:"id" binding:"required"`
+	EndTime    *time.Time `json:"end_time"` // 为空时取当前时间
+	Quality    int        `json:"quality" binding:"min=0,max=10"`
+	DeepSleep  int        `json:"deep_sleep" binding:"min=0"`
+	LightSleep int        `json:"light_sleep" binding:"min=0"`
+	AwakeCount int        `json:"awake_count" binding:"min=0"`
+	Notes      string     `json:"notes"`
+}
+
+// SleepStatisticsRequest 睡眠统计请求
+type SleepStatisticsRequest struct {
+	BabyID  uint   `json:"baby_id" form:"baby_id" binding:"required"`
+	Days    int    `json:"days" form:"days" binding:"omitempty,oneof=1 7 30"` // 统计天数，默认7天
+	EndDate string `json:"end_date" form:"end_date"`                          // 统计截止日期(含)，默认今天
+}
+
 // CryDetectionSearch 哭声检测搜索条件
 type CryDetectionSearch struct {
 	request.PageInfo
 	UserID      uint   `json:"user_id" form:"user_id"`
 	BabyID      uint   `json:"baby_id" form:"baby_id"`
+	DeviceID    uint   `json:"device_id" form:"device_id"`
 	CryType     int    `json:"cry_type" form:"cry_type"`
 	StartDate   string `json:"start_date" form:"start_date"`
 	EndDate     string `json:"end_date" form:"end_date"`
@@ -60,12 +94,43 @@ type CreateCryDetectionRequest struct {
 	BabyID     uint      `json:"baby_id" binding:"required"`
 	DeviceID   uint      `json:"device_id"`
 	DetectedAt time.Time `json:"detected_at" binding:"required"`
-	Duration   int       `json:"duration"`
-	Intensity  int       `json:"intensity"`
-	CryType    int       `json:"cry_type"`
-	Confidence float64   `json:"confidence"`
-	AudioURL   string    `json:"audio_url"`
-	VideoURL   string    `json:"video_url"`
+	Duration   int       `json:"duration" binding:"min=0"`
+	Intensity  int       `json:"intensity" binding:"omitempty,min=1,max=10"`
+	CryType    int       `json:"cry_type" binding:"required,oneof=1 2 3 4 5"`
+	Confidence float64   `json:"confidence" binding:"min=0,max=1"`
+	AudioURL   string    `json:"audio_url" binding:"max=500"`
+	VideoURL   string    `json:"video_url" binding:"max=500"`
+}
+
+// DeviceCryDetectionRequest 设备上报哭声检测请求，未指定宝宝时归属设备主人的当前宝宝
+type DeviceCryDetectionRequest struct {
+	BabyID     uint    `json:"baby_id"`
+	Timestamp  int64   `json:"timestamp"` // 检测时间(Unix秒)，为空时取服务器时间
+	Duration   int     `json:"duration" binding:"min=0"`
+	Intensity  int     `json:"intensity" binding:"omitempty,min=1,max=10"`
+	CryType    int     `json:"cry_type" binding:"required,oneof=1 2 3 4 5"`
+	Confidence float64 `json:"confidence" binding:"min=0,max=1"`
+	AudioURL   string  `json:"audio_url" binding:"max=500"`
+	VideoURL   string  `json:"video_url" binding:"max=500"`
+}
+
+// AcknowledgeCryRequest 确认哭声事件请求
+type AcknowledgeCryRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1,max=100"`
+}
+
+// CryFeedbackRequest 哭声识别反馈请求
+type CryFeedbackRequest struct {
+	ID       uint `json:"id" binding:"required"`
+	Feedback int  `json:"feedback" binding:"required,oneof=1 2"` // 1准确,2不准确
+}
+
+// CryAccuracySearch 哭声识别准确率统计条件
+type CryAccuracySearch struct {
+	BabyID    uint   `json:"baby_id" form:"baby_id"`
+	DeviceID  uint   `json:"device_id" form:"device_id"`
+	StartDate string `json:"start_date" form:"start_date"`
+	EndDate   string `json:"end_date" form:"end_date"`
 }
 
 // MovementDetectionSearch 动作检测搜索条件
@@ -121,6 +186,51 @@ type ProcessAlertRequest struct {
 	UserAction string `json:"user_action" binding:"required"`
 }
 
+// MarkAlertReadRequest 标记警报已读请求，All为true时标记全部未读警报
+type MarkAlertReadRequest struct {
+	IDs []uint `json:"ids" binding:"max=100"`
+	All bool   `json:"all"`
+}
+
+// AlertRuleSearch 警报规则搜索条件
+type AlertRuleSearch struct {
+	BabyID   uint `json:"baby_id" form:"baby_id"`
+	DeviceID uint `json:"device_id" form:"device_id"`
+	RuleType int  `json:"rule_type" form:"rule_type"`
+}
+
+// CreateAlertRuleRequest 创建警报规则请求
+type CreateAlertRuleRequest struct {
+	BabyID          uint     `json:"baby_id"`   // 为空表示全部宝宝
+	DeviceID        uint     `json:"device_id"` // 为空表示全部设备
+	Name            string   `json:"name" binding:"required,max=50"`
+	RuleType        int      `json:"rule_type" binding:"required,oneof=1 2 3 4 5 6 7"`
+	MinValue        *float64 `json:"min_value"`
+	MaxValue        *float64 `json:"max_value"`
+	DurationSeconds int      `json:"duration_seconds" binding:"min=0,max=3600"`
+	AlertLevel      int      `json:"alert_level" binding:"required,oneof=1 2 3 4"`
+	CooldownMinutes int      `json:"cooldown_minutes" binding:"min=0,max=1440"`
+	IsEnabled       *bool    `json:"is_enabled"` // 为空时创建默认启用，更新保持不变
+}
+
+// UpdateAlertRuleRequest 更新警报规则请求
+type UpdateAlertRuleRequest struct {
+	ID uint `json:"id" binding:"required"`
+	CreateAlertRuleRequest
+}
+
+// DeviceMovementDetectionRequest 设备上报动作检测请求，未指定宝宝时归属设备主人的当前宝宝
+type DeviceMovementDetectionRequest struct {
+	BabyID       uint   `json:"baby_id"`
+	Timestamp    int64  `json:"timestamp"` // 检测时间(Unix秒)，为空时取服务器时间
+	MovementType int    `json:"movement_type" binding:"required,oneof=1 2 3 4 5"`
+	Intensity    int    `json:"intensity" binding:"omitempty,min=1,max=10"`
+	Duration     int    `json:"duration" binding:"min=0"`
+	VideoURL     string `json:"video_url" binding:"max=500"`
+	ThumbnailURL string `json:"thumbnail_url" binding:"max=500"`
+	IsAlert      bool   `json:"is_alert"`
+}
+
 // EnvironmentDataSearch 环境数据搜索条件
 type EnvironmentDataSearch struct {
 	request.PageInfo
@@ -148,3 +258,19 @@ type AnalysisReportRequest struct {
 	StartDate  time.Time `json:"start_date" binding:"required"`
 	EndDate    time.Time `json:"end_date" binding:"required"`
 }
+
+// AnalysisReportSearch 分析报告搜索条件
+type AnalysisReportSearch struct {
+	request.PageInfo
+	BabyID     uint   `json:"baby_id" form:"baby_id"`
+	ReportType int    `json:"report_type" form:"report_type" binding:"omitempty,oneof=1 2 3"`
+	StartDate  string `json:"start_date" form:"start_date"` // 统计周期开始日期下限(2006-01-02)
+	EndDate    string `json:"end_date" form:"end_date"`     // 统计周期开始日期上限(2006-01-02)
+}
+
+// RegenerateReportRequest 重新生成分析报告请求，ReportDate为周期内任意一天
+type RegenerateReportRequest struct {
+	BabyID     uint   `json:"baby_id" binding:"required"`
+	ReportType int    `json:"report_type" binding:"required,oneof=1 2 3"`
+	ReportDate string `json:"report_date" binding:"required"` // 2006-01-02
+}
@@ -0,0 +1,84 @@
+package request
+
+import (
+	"baby_admin/server/model/common/request"
+	"time"
+)
+
+// VaccinationPlanRequest 宝宝接种计划请求
+type VaccinationPlanRequest struct {
+	BabyID uint `json:"baby_id" form:"baby_id" binding:"required"`
+}
+
+// VaccinationRecordSearch 接种记录搜索条件
+type VaccinationRecordSearch struct {
+	request.PageInfo
+	BabyID    uint `json:"baby_id" form:"baby_id"`
+	VaccineID uint `json:"vaccine_id" form:"vaccine_id"`
+}
+
+// CreateVaccinationRecordRequest 记录接种请求
+type CreateVaccinationRecordRequest struct {
+	BabyID       uint      `json:"baby_id" binding:"required"`
+	VaccineID    uint      `json:"vaccine_id" binding:"required"`
+	DoseNumber   int       `json:"dose_number" binding:"required,min=1,max=10"`
+	VaccinatedAt time.Time `json:"vaccinated_at" binding:"required"`
+	BatchNumber  string    `json:"batch_number" binding:"max=50"`
+	Manufacturer string    `json:"manufacturer" binding:"max=100"`
+	Clinic       string    `json:"clinic" binding:"max=100"`
+	Reaction     string    `json:"reaction" binding:"max=255"`
+	Notes        string    `json:"notes"`
+}
+
+// UpdateVaccinationRecordRequest 更新接种记录请求
+type UpdateVaccinationRecordRequest struct {
+	ID uint `json:"id" binding:"required"`
+	CreateVaccinationRecordRequest
+}
+
+// VaccineSearch 疫苗目录搜索条件（管理端）
+type VaccineSearch struct {
+	request.PageInfo
+	Name     string `json:"name" form:"name"`
+	Category int    `json:"category" form:"category"`
+	IsActive *bool  `json:"is_active" form:"is_active"`
+}
+
+// CreateVaccineRequest 创建疫苗请求（管理端）
+type CreateVaccineRequest struct {
+	Code        string `json:"code" binding:"required,max=30"`
+	Name        string `json:"name" binding:"required,max=100"`
+	Category    int    `json:"category" binding:"required,oneof=1 2"`
+	Prevents    string `json:"prevents" binding:"max=200"`
+	Description string `json:"description"`
+	SortOrder   int    `json:"sort_order"`
+	IsActive    *bool  `json:"is_active"` // 为空时默认启用
+}
+
+// UpdateVaccineRequest 更新疫苗请求（管理端）
+type UpdateVaccineRequest struct {
+	ID uint `json:"id" binding:"required"`
+	CreateVaccineRequest
+}
+
+// VaccineScheduleSearch 接种程序搜索条件（管理端）
+type VaccineScheduleSearch struct {
+	VaccineID uint `json:"vaccine_id" form:"vaccine_id"`
+}
+
+// CreateVaccineScheduleRequest 创建接种程序剂次请求（管理端）
+type CreateVaccineScheduleRequest struct {
+	VaccineID  uint   `json:"vaccine_id" binding:"required"`
+	DoseNumber int    `json:"dose_number" binding:"required,min=1,max=10"`
+	AgeMonths  int    `json:"age_months" binding:"min=0,max=216"`
+	AgeDays    int    `json:"age_days" binding:"min=0,max=365"`
+	GraceDays  int    `json:"grace_days" binding:"min=0,max=3650"`
+	IsActive   *bool  `json:"is_active"` // 为空时默认启用
+	Notes      string `json:"notes" binding:"max=255"`
+}
+
+// UpdateVaccineScheduleRequest 更新接种程序剂次请求（管理端）
+type UpdateVaccineScheduleRequest struct {
+	ID uint `json:"id" binding:"required"`
+	CreateVaccineScheduleRequest
+}
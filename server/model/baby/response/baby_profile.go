@@ -9,6 +9,7 @@ import (
 type BabyProfileResponse struct {
 	ID         uint      `json:"id"`
 	UserID     uint      `json:"user_id"`
+	FamilyID   uint      `json:"family_id"`
 	Name       string    `json:"name"`
 	Gender     int       `json:"gender"`
 	GenderText string    `json:"gender_text"`
@@ -18,17 +19,29 @@ type BabyProfileResponse struct {
 	Height     float64   `json:"height"`
 	BloodType  string    `json:"blood_type"`
 	Remark     string    `json:"remark"`
-	IsActive   bool      `json:"is_active"`
-	Age        int       `json:"age"`      // 年龄(月)
-	AgeText    string    `json:"age_text"` // 年龄描述
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	IsActive   bool      `json:"is_active"` // 是否为当前用户选择的宝宝
+	Role       int       `json:"role"`      // 当前用户在宝宝所属家庭中的角色
+	RoleText   string    `json:"role_text"`
+	Age        int       `json:"age"`       // 年龄(月)
+	AgeText    string    `json:"age_text"`  // 年龄描述
+	AgeDays    int       `json:"age_days"`  // 年龄(天)
+	AgeWeeks   int       `json:"age_weeks"` // 年龄(周)
+
+	GestationalWeeks int       `json:"gestational_weeks"`  // 出生胎龄(周)
+	GestationalDays  int       `json:"gestational_days"`   // 出生胎龄(天)
+	IsPremature      bool      `json:"is_premature"`       // 是否早产
+	IsCorrectedAge   bool      `json:"is_corrected_age"`   // 当前是否使用矫正年龄
+	CorrectedAge     int       `json:"corrected_age"`      // 矫正年龄(月)，未使用矫正年龄时与实际年龄相同
+	CorrectedAgeText string    `json:"corrected_age_text"` // 矫正年龄描述
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
 }
 
 // FromBabyProfile 从BabyProfile模型转换
 func (r *BabyProfileResponse) FromBabyProfile(profile *baby.BabyProfile) {
 	r.ID = profile.ID
 	r.UserID = profile.UserID
+	r.FamilyID = profile.FamilyID
 	r.Name = profile.Name
 	r.Gender = profile.Gender
 	r.GenderText = getGenderText(profile.Gender)
@@ -38,9 +51,18 @@ func (r *BabyProfileResponse) FromBabyProfile(profile *baby.BabyProfile) {
 	r.Height = profile.Height
 	r.BloodType = profile.BloodType
 	r.Remark = profile.Remark
-	r.IsActive = profile.IsActive
-	r.Age = profile.GetAge()
+	now := time.Now()
+	age := profile.GetAgeAt(now)
+	r.Age = age.Months
 	r.AgeText = profile.GetAgeText()
+	r.AgeDays = age.Days
+	r.AgeWeeks = age.Weeks
+	r.GestationalWeeks = profile.GestationalWeeks
+	r.GestationalDays = profile.GestationalDays
+	r.IsPremature = profile.IsPremature()
+	r.IsCorrectedAge = profile.UsesCorrectedAge(now)
+	r.CorrectedAge = profile.GetDevelopmentalAgeAt(now).Months
+	r.CorrectedAgeText = profile.GetDevelopmentalAgeText()
 	r.CreatedAt = profile.CreatedAt
 	r.UpdatedAt = profile.UpdatedAt
 }
@@ -27,6 +27,9 @@ type DeviceResponse struct {
 	ActivatedAt     time.Time  `json:"activated_at"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
+	// 访问身份
+	IsOwner   bool `json:"is_owner"`             // 是否为设备所有者
+	ShareType int  `json:"share_type,omitempty"` // 被分享时的权限类型
 	// 关联信息
 	ShareCount     int    `json:"share_count,omitempty"`     // 分享数量
 	CommandCount   int    `json:"command_count,omitempty"`   // 命令数量
@@ -71,6 +74,20 @@ type DeviceConfigResponse struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// FromDeviceConfig 从DeviceConfig模型转换
+func (d *DeviceConfigResponse) FromDeviceConfig(config *baby.DeviceConfig) {
+	d.ID = config.ID
+	d.DeviceID = config.DeviceID
+	d.ConfigKey = config.ConfigKey
+	d.ConfigValue = config.ConfigValue
+	d.ValueType = config.ValueType
+	d.Description = config.Description
+	d.IsEditable = config.IsEditable
+	d.DefaultValue = config.DefaultValue
+	d.CreatedAt = config.CreatedAt
+	d.UpdatedAt = config.UpdatedAt
+}
+
 // DeviceCommandResponse 设备命令响应
 type DeviceCommandResponse struct {
 	ID              uint       `json:"id"`
@@ -131,6 +148,7 @@ type DeviceShareResponse struct {
 	IsActive       bool       `json:"is_active"`
 	IsExpired      bool       `json:"is_expired"`
 	AcceptedAt     *time.Time `json:"accepted_at"`
+	StatusText     string     `json:"status_text"`
 	CreatedAt      time.Time  `json:"created_at"`
 }
 
@@ -150,6 +168,7 @@ func (d *DeviceShareResponse) FromDeviceShare(share *baby.DeviceShare, deviceNam
 	d.IsActive = share.IsActive
 	d.IsExpired = share.IsExpired()
 	d.AcceptedAt = share.AcceptedAt
+	d.StatusText = share.GetShareStatusText()
 	d.CreatedAt = share.CreatedAt
 }
 
@@ -203,6 +222,22 @@ type DeviceListResponse struct {
 	PageSize int              `json:"page_size"`
 }
 
+// DeviceTelemetryResponse 设备上报响应
+type DeviceTelemetryResponse struct {
+	StatusCount      int   `json:"status_count"`      // 写入的状态条数
+	LogCount         int   `json:"log_count"`         // 写入的日志条数
+	EnvironmentCount int   `json:"environment_count"` // 写入的环境数据条数
+	ServerTime       int64 `json:"server_time"`       // 服务器时间(Unix秒)，供设备校时
+}
+
+// DeviceCommandListResponse 设备命令列表响应
+type DeviceCommandListResponse struct {
+	List     []DeviceCommandResponse `json:"list"`
+	Total    int64                   `json:"total"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
+
 // DeviceStatistics 设备统计
 type DeviceStatistics struct {
 	TotalDevices   int64                   `json:"total_devices"`   // 总设备数
@@ -0,0 +1,83 @@
+package response
+
+import (
+	"baby_admin/server/model/baby"
+	"time"
+)
+
+// FamilyGroupResponse 家庭响应
+type FamilyGroupResponse struct {
+	ID              uint       `json:"id"`
+	Name            string     `json:"name"`
+	OwnerID         uint       `json:"owner_id"`
+	MyRole          int        `json:"my_role"`
+	MyRoleText      string     `json:"my_role_text"`
+	MyStatus        int        `json:"my_status"` // 1待审核,2已加入
+	MemberCount     int64      `json:"member_count"`
+	BabyCount       int64      `json:"baby_count"`
+	InviteCode      string     `json:"invite_code"` // 仅创建者和家长可见
+	InviteRole      int        `json:"invite_role"`
+	InviteRoleText  string     `json:"invite_role_text"`
+	InviteExpiredAt *time.Time `json:"invite_expired_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// FromFamilyGroup 从FamilyGroup模型转换，member为当前用户的成员记录
+func (r *FamilyGroupResponse) FromFamilyGroup(family *baby.FamilyGroup, member *baby.FamilyMember) {
+	r.ID = family.ID
+	r.Name = family.Name
+	r.OwnerID = family.OwnerID
+	r.MyRole = member.Role
+	r.MyRoleText = member.GetRoleText()
+	r.MyStatus = member.Status
+	r.CreatedAt = family.CreatedAt
+}
+
+// FamilyMemberResponse 家庭成员响应
+type FamilyMemberResponse struct {
+	ID         uint       `json:"id"`
+	FamilyID   uint       `json:"family_id"`
+	UserID     uint       `json:"user_id"`
+	NickName   string     `json:"nick_name"`
+	Avatar     string     `json:"avatar"`
+	Role       int        `json:"role"`
+	RoleText   string     `json:"role_text"`
+	Status     int        `json:"status"`
+	StatusText string     `json:"status_text"`
+	Relation   string     `json:"relation"`
+	JoinedAt   *time.Time `json:"joined_at"`
+	CreatedAt  time.Time  `json:"created_at"` // 申请时间
+}
+
+// FromFamilyMember 从FamilyMember模型转换
+func (r *FamilyMemberResponse) FromFamilyMember(member *baby.FamilyMember, nickName string, avatar string) {
+	r.ID = member.ID
+	r.FamilyID = member.FamilyID
+	r.UserID = member.UserID
+	r.NickName = nickName
+	r.Avatar = avatar
+	r.Role = member.Role
+	r.RoleText = member.GetRoleText()
+	r.Status = member.Status
+	r.StatusText = member.GetStatusText()
+	r.Relation = member.Relation
+	r.JoinedAt = member.JoinedAt
+	r.CreatedAt = member.CreatedAt
+}
+
+// FamilyDetailResponse 家庭详情
+type FamilyDetailResponse struct {
+	FamilyGroupResponse
+	Members        []FamilyMemberResponse `json:"members"`
+	PendingMembers []FamilyMemberResponse `json:"pending_members"` // 仅创建者和家长可见
+	Babies         []BabyProfileResponse  `json:"babies"`
+}
+
+// FamilyInviteResponse 邀请码响应
+type FamilyInviteResponse struct {
+	FamilyID       uint       `json:"family_id"`
+	InviteCode     string     `json:"invite_code"`
+	InviteRole     int        `json:"invite_role"`
+	InviteRoleText string     `json:"invite_role_text"`
+	ExpiredAt      *time.Time `json:"expired_at"`
+}
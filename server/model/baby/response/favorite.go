@@ -0,0 +1,48 @@
+package response
+
+import "time"
+
+// FavoriteToggleResponse 切换收藏响应
+type FavoriteToggleResponse struct {
+	ContentType int   `json:"content_type"`
+	ContentID   uint  `json:"content_id"`
+	IsFavorited bool  `json:"is_favorited"` // 操作后的收藏状态
+	LikeCount   int64 `json:"like_count"`   // 操作后的点赞数
+}
+
+// FavoriteItemResponse 收藏项响应，按内容类型返回对应的内容详情
+type FavoriteItemResponse struct {
+	ID              uint                      `json:"id"`
+	ContentType     int                       `json:"content_type"`
+	ContentTypeText string                    `json:"content_type_text"`
+	ContentID       uint                      `json:"content_id"`
+	FolderID        uint                      `json:"folder_id"`
+	Article         *ParentingArticleResponse `json:"article,omitempty"`
+	Video           *ParentingVideoResponse   `json:"video,omitempty"`
+	Music           *MusicResponse            `json:"music,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+}
+
+// FavoriteListResponse 收藏列表响应
+type FavoriteListResponse struct {
+	List     []FavoriteItemResponse `json:"list"`
+	Total    int64                  `json:"total"`
+	Page     int                    `json:"page"`
+	PageSize int                    `json:"page_size"`
+}
+
+// FavoriteStatus 单个内容的收藏状态
+type FavoriteStatus struct {
+	ContentID   uint `json:"content_id"`
+	IsFavorited bool `json:"is_favorited"`
+	FolderID    uint `json:"folder_id"`
+}
+
+// FavoriteFolderResponse 收藏夹响应，ID为0的是默认收藏夹
+type FavoriteFolderResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	SortOrder int       `json:"sort_order"`
+	ItemCount int64     `json:"item_count"` // 收藏数量
+	CreatedAt time.Time `json:"created_at"`
+}
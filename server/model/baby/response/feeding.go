@@ -0,0 +1,117 @@
+package response
+
+import (
+	"baby_admin/server/model/baby"
+	"strings"
+	"time"
+)
+
+// FeedingRecordResponse 喂养记录响应
+type FeedingRecordResponse struct {
+	ID              uint       `json:"id"`
+	UserID          uint       `json:"user_id"`
+	BabyID          uint       `json:"baby_id"`
+	BabyName        string     `json:"baby_name"`
+	FeedingType     int        `json:"feeding_type"`
+	FeedingTypeText string     `json:"feeding_type_text"`
+	StartTime       time.Time  `json:"start_time"`
+	EndTime         *time.Time `json:"end_time"`
+	LeftDuration    int        `json:"left_duration"`  // 左侧哺乳时长(秒)，进行中时包含当前计时
+	RightDuration   int        `json:"right_duration"` // 右侧哺乳时长(秒)，进行中时包含当前计时
+	ActiveSide      string     `json:"active_side"`
+	SideStartedAt   *time.Time `json:"side_started_at"`
+	LastSide        string     `json:"last_side"`
+	MilkType        int        `json:"milk_type"`
+	MilkTypeText    string     `json:"milk_type_text"`
+	Amount          int        `json:"amount"`
+	FoodName        string     `json:"food_name"`
+	FoodAmount      int        `json:"food_amount"`
+	Allergens       []string   `json:"allergens"`
+	Reaction        int        `json:"reaction"`
+	Status          int        `json:"status"`
+	Notes           string     `json:"notes"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// FromFeedingRecord 从FeedingRecord模型转换，进行中的哺乳计时算到now
+func (f *FeedingRecordResponse) FromFeedingRecord(record *baby.FeedingRecord, babyName string, now time.Time) {
+	f.ID = record.ID
+	f.UserID = record.UserID
+	f.BabyID = record.BabyID
+	f.BabyName = babyName
+	f.FeedingType = record.FeedingType
+	f.FeedingTypeText = record.GetFeedingTypeText()
+	f.StartTime = record.StartTime
+	f.EndTime = record.EndTime
+	f.LeftDuration = record.LeftDuration
+	f.RightDuration = record.RightDuration
+	f.ActiveSide = record.ActiveSide
+	f.SideStartedAt = record.SideStartedAt
+	f.LastSide = record.LastSide
+	f.MilkType = record.MilkType
+	f.MilkTypeText = record.GetMilkTypeText()
+	f.Amount = record.Amount
+	f.FoodName = record.FoodName
+	f.FoodAmount = record.FoodAmount
+	f.Reaction = record.Reaction
+	f.Status = record.Status
+	f.Notes = record.Notes
+	f.CreatedAt = record.CreatedAt
+	f.UpdatedAt = record.UpdatedAt
+
+	if record.SideStartedAt != nil && now.After(*record.SideStartedAt) {
+		elapsed := int(now.Sub(*record.SideStartedAt).Seconds())
+		switch record.ActiveSide {
+		case "left":
+			f.LeftDuration += elapsed
+		case "right":
+			f.RightDuration += elapsed
+		}
+	}
+
+	f.Allergens = []string{}
+	if record.Allergens != "" {
+		f.Allergens = strings.Split(record.Allergens, ",")
+	}
+}
+
+// FeedingRecordListResponse 喂养记录列表响应
+type FeedingRecordListResponse struct {
+	List     []FeedingRecordResponse `json:"list"`
+	Total    int64                   `json:"total"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
+
+// FeedingDailySummary 喂养日汇总
+type FeedingDailySummary struct {
+	BabyID               uint                   `json:"baby_id"`
+	Date                 string                 `json:"date"`
+	TotalCount           int                    `json:"total_count"`             // 喂养总次数
+	BreastCount          int                    `json:"breast_count"`            // 亲喂次数
+	BreastLeftSeconds    int                    `json:"breast_left_seconds"`     // 左侧哺乳总时长(秒)
+	BreastRightSeconds   int                    `json:"breast_right_seconds"`    // 右侧哺乳总时长(秒)
+	BottleCount          int                    `json:"bottle_count"`            // 瓶喂次数
+	BreastMilkAmount     int                    `json:"breast_milk_amount"`      // 瓶喂母乳总量(ml)
+	FormulaAmount        int                    `json:"formula_amount"`          // 配方奶总量(ml)
+	SolidCount           int                    `json:"solid_count"`             // 辅食次数
+	SolidAmount          int                    `json:"solid_amount"`            // 辅食总量(g)
+	Foods                []string               `json:"foods"`                   // 当日辅食
+	Allergens            []string               `json:"allergens"`               // 当日接触的过敏原
+	AverageInterval      int                    `json:"average_interval"`        // 平均喂养间隔(分钟)
+	LastFeedingAt        *time.Time             `json:"last_feeding_at"`         // 最近一次喂养开始时间
+	MinutesSinceLastFeed int                    `json:"minutes_since_last_feed"` // 距最近一次喂养开始的分钟数
+	NextBreastSide       string                 `json:"next_breast_side"`        // 建议下次先喂的一侧
+	InProgress           *FeedingRecordResponse `json:"in_progress"`             // 进行中的喂养
+}
+
+// FeedingAllergenResponse 过敏原接触记录
+type FeedingAllergenResponse struct {
+	Allergen     string    `json:"allergen"`
+	FirstFedAt   time.Time `json:"first_fed_at"` // 首次添加时间
+	LastFedAt    time.Time `json:"last_fed_at"`  // 最近添加时间
+	Times        int       `json:"times"`        // 添加次数
+	MaxReaction  int       `json:"max_reaction"` // 最严重的过敏反应:0无,1轻微,2明显
+	ReactionText string    `json:"reaction_text"`
+}
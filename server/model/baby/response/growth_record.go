@@ -82,6 +82,8 @@ type GrowthStatistics struct {
 	RecentRecords  []GrowthRecordResponse `json:"recent_records"`  // 最近记录
 	WeightRecords  []WeightRecord         `json:"weight_records"`  // 体重记录
 	HeightRecords  []HeightRecord         `json:"height_records"`  // 身高记录
+	// 各项WHO生长标准指标的最新评估，宝宝性别未知时为空
+	StandardAssessments []GrowthAssessment `json:"standard_assessments"`
 }
 
 // WeightRecord 体重记录
@@ -95,3 +97,51 @@ type HeightRecord struct {
 	Date   time.Time `json:"date"`
 	Height float64   `json:"height"`
 }
+
+// GrowthAssessment 单次测量的WHO生长标准评估
+type GrowthAssessment struct {
+	Indicator    string    `json:"indicator"`     // 指标:weight_for_age,length_for_age,weight_for_length
+	RecordID     uint      `json:"record_id"`     // 成长记录ID
+	Date         time.Time `json:"date"`          // 测量日期
+	AgeMonths    float64   `json:"age_months"`    // 测量时月龄
+	Length       float64   `json:"length"`        // 身长/身高(cm)，仅身长别体重使用
+	Value        float64   `json:"value"`         // 测量值(体重kg或身长cm)
+	ZScore       float64   `json:"z_score"`       // Z评分
+	Percentile   float64   `json:"percentile"`    // 百分位
+	Level        string    `json:"level"`         // 评价:严重偏低,偏低,正常,偏高,严重偏高,超出标准范围
+	OutOfRange   bool      `json:"out_of_range"`  // 身长/身高超出WHO标准表范围，此时不计算Z评分和百分位
+	CrossedLines int       `json:"crossed_lines"` // 相比上次测量跨越的主要百分位线数，正数为向上
+	IsCrossing   bool      `json:"is_crossing"`   // 是否跨越两条及以上主要百分位线
+}
+
+// CurvePoint 百分位曲线上的点
+type CurvePoint struct {
+	X float64 `json:"x"` // 月龄或身长(cm)
+	Y float64 `json:"y"` // 体重(kg)或身长(cm)
+}
+
+// PercentileCurve 百分位曲线
+type PercentileCurve struct {
+	Percentile float64      `json:"percentile"`
+	Points     []CurvePoint `json:"points"`
+}
+
+// GrowthIndicatorResponse 单项生长指标的评估及参考曲线
+type GrowthIndicatorResponse struct {
+	Indicator    string             `json:"indicator"`
+	Name         string             `json:"name"`
+	XUnit        string             `json:"x_unit"`
+	YUnit        string             `json:"y_unit"`
+	Latest       *GrowthAssessment  `json:"latest"`
+	Measurements []GrowthAssessment `json:"measurements"`
+	Curves       []PercentileCurve  `json:"curves"`
+}
+
+// GrowthStandardResponse WHO生长标准评估响应
+type GrowthStandardResponse struct {
+	BabyID     uint                      `json:"baby_id"`
+	BabyName   string                    `json:"baby_name"`
+	Gender     int                       `json:"gender"`
+	AgeMonths  float64                   `json:"age_months"`
+	Indicators []GrowthIndicatorResponse `json:"indicators"`
+}
@@ -0,0 +1,182 @@
+package response
+
+import (
+	"baby_admin/server/model/baby"
+	"time"
+)
+
+// DiaperRecordResponse 换尿布记录响应
+type DiaperRecordResponse struct {
+	ID             uint      `json:"id"`
+	BabyID         uint      `json:"baby_id"`
+	RecordTime     time.Time `json:"record_time"`
+	DiaperType     int       `json:"diaper_type"`
+	DiaperTypeText string    `json:"diaper_type_text"`
+	StoolColor     string    `json:"stool_color"`
+	Notes          string    `json:"notes"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// FromDiaperRecord 从DiaperRecord模型转换
+func (d *DiaperRecordResponse) FromDiaperRecord(record *baby.DiaperRecord) {
+	d.ID = record.ID
+	d.BabyID = record.BabyID
+	d.RecordTime = record.RecordTime
+	d.DiaperType = record.DiaperType
+	d.DiaperTypeText = record.GetDiaperTypeText()
+	d.StoolColor = record.StoolColor
+	d.Notes = record.Notes
+	d.CreatedAt = record.CreatedAt
+}
+
+// DiaperRecordListResponse 换尿布记录列表响应
+type DiaperRecordListResponse struct {
+	List     []DiaperRecordResponse `json:"list"`
+	Total    int64                  `json:"total"`
+	Page     int                    `json:"page"`
+	PageSize int                    `json:"page_size"`
+}
+
+// TemperatureRecordResponse 体温记录响应
+type TemperatureRecordResponse struct {
+	ID             uint      `json:"id"`
+	BabyID         uint      `json:"baby_id"`
+	MeasuredAt     time.Time `json:"measured_at"`
+	Temperature    float64   `json:"temperature"`
+	Site           int       `json:"site"`
+	SiteText       string    `json:"site_text"`
+	FeverLevel     int       `json:"fever_level"`
+	FeverLevelText string    `json:"fever_level_text"`
+	IsFever        bool      `json:"is_fever"`
+	AlertID        uint      `json:"alert_id"`
+	Notes          string    `json:"notes"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// FromTemperatureRecord 从TemperatureRecord模型转换
+func (t *TemperatureRecordResponse) FromTemperatureRecord(record *baby.TemperatureRecord) {
+	t.ID = record.ID
+	t.BabyID = record.BabyID
+	t.MeasuredAt = record.MeasuredAt
+	t.Temperature = record.Temperature
+	t.Site = record.Site
+	t.SiteText = record.GetSiteText()
+	t.FeverLevel = record.FeverLevel
+	t.FeverLevelText = record.GetFeverLevelText()
+	t.IsFever = record.FeverLevel > 0
+	t.AlertID = record.AlertID
+	t.Notes = record.Notes
+	t.CreatedAt = record.CreatedAt
+}
+
+// TemperatureRecordListResponse 体温记录列表响应
+type TemperatureRecordListResponse struct {
+	List     []TemperatureRecordResponse `json:"list"`
+	Total    int64                       `json:"total"`
+	Page     int                         `json:"page"`
+	PageSize int                         `json:"page_size"`
+}
+
+// MedicationRecordResponse 用药记录响应
+type MedicationRecordResponse struct {
+	ID            uint       `json:"id"`
+	BabyID        uint       `json:"baby_id"`
+	MedicineName  string     `json:"medicine_name"`
+	Dosage        float64    `json:"dosage"`
+	Unit          string     `json:"unit"`
+	TakenAt       time.Time  `json:"taken_at"`
+	IntervalHours int        `json:"interval_hours"`
+	MaxDailyDoses int        `json:"max_daily_doses"`
+	NextDoseAt    *time.Time `json:"next_dose_at"`
+	RemindStatus  int        `json:"remind_status"`
+	Reason        string     `json:"reason"`
+	Notes         string     `json:"notes"`
+	Warnings      []string   `json:"warnings,omitempty"` // 服药间隔不足、超过每日次数等提示
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// FromMedicationRecord 从MedicationRecord模型转换
+func (m *MedicationRecordResponse) FromMedicationRecord(record *baby.MedicationRecord) {
+	m.ID = record.ID
+	m.BabyID = record.BabyID
+	m.MedicineName = record.MedicineName
+	m.Dosage = record.Dosage
+	m.Unit = record.Unit
+	m.TakenAt = record.TakenAt
+	m.IntervalHours = record.IntervalHours
+	m.MaxDailyDoses = record.MaxDailyDoses
+	m.NextDoseAt = record.NextDoseAt
+	m.RemindStatus = record.RemindStatus
+	m.Reason = record.Reason
+	m.Notes = record.Notes
+	m.CreatedAt = record.CreatedAt
+}
+
+// MedicationRecordListResponse 用药记录列表响应
+type MedicationRecordListResponse struct {
+	List     []MedicationRecordResponse `json:"list"`
+	Total    int64                      `json:"total"`
+	Page     int                        `json:"page"`
+	PageSize int                        `json:"page_size"`
+}
+
+// MedicationReminderResponse 用药提醒，每种药品取最近一次服药
+type MedicationReminderResponse struct {
+	MedicineName     string     `json:"medicine_name"`
+	LastTakenAt      time.Time  `json:"last_taken_at"`
+	NextDoseAt       *time.Time `json:"next_dose_at"`
+	MinutesUntilNext int        `json:"minutes_until_next"` // 距下次可服药的分钟数，已到时间为0
+	DosesLast24h     int        `json:"doses_last_24h"`     // 最近24小时服药次数
+	MaxDailyDoses    int        `json:"max_daily_doses"`
+	CanTakeNow       bool       `json:"can_take_now"` // 已到服药间隔且未超过每日次数
+}
+
+// SymptomRecordResponse 症状记录响应
+type SymptomRecordResponse struct {
+	ID           uint      `json:"id"`
+	BabyID       uint      `json:"baby_id"`
+	RecordTime   time.Time `json:"record_time"`
+	Symptom      string    `json:"symptom"`
+	Severity     int       `json:"severity"`
+	SeverityText string    `json:"severity_text"`
+	Notes        string    `json:"notes"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// FromSymptomRecord 从SymptomRecord模型转换
+func (s *SymptomRecordResponse) FromSymptomRecord(record *baby.SymptomRecord) {
+	s.ID = record.ID
+	s.BabyID = record.BabyID
+	s.RecordTime = record.RecordTime
+	s.Symptom = record.Symptom
+	s.Severity = record.Severity
+	s.SeverityText = record.GetSeverityText()
+	s.Notes = record.Notes
+	s.CreatedAt = record.CreatedAt
+}
+
+// SymptomRecordListResponse 症状记录列表响应
+type SymptomRecordListResponse struct {
+	List     []SymptomRecordResponse `json:"list"`
+	Total    int64                   `json:"total"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
+
+// HealthDailySummary 健康日汇总
+type HealthDailySummary struct {
+	BabyID            uint                         `json:"baby_id"`
+	BabyName          string                       `json:"baby_name"`
+	Date              string                       `json:"date"`
+	DiaperCount       int                          `json:"diaper_count"`
+	WetCount          int                          `json:"wet_count"`   // 尿湿次数，含尿湿+便便
+	DirtyCount        int                          `json:"dirty_count"` // 便便次数，含尿湿+便便
+	LastDiaperAt      *time.Time                   `json:"last_diaper_at"`
+	TemperatureCount  int                          `json:"temperature_count"`
+	MaxTemperature    *TemperatureRecordResponse   `json:"max_temperature"`    // 当日最高体温（按折算腋温等级）
+	LatestTemperature *TemperatureRecordResponse   `json:"latest_temperature"` // 当日最近一次体温
+	HasFever          bool                         `json:"has_fever"`
+	Medications       []MedicationRecordResponse   `json:"medications"` // 当日服药
+	Symptoms          []SymptomRecordResponse      `json:"symptoms"`    // 当日症状
+	Reminders         []MedicationReminderResponse `json:"reminders"`   // 当前用药提醒
+}
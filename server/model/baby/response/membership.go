@@ -0,0 +1,92 @@
+package response
+
+import (
+	"baby_admin/server/model/baby"
+	"time"
+)
+
+// VipPlanResponse 会员套餐响应
+type VipPlanResponse struct {
+	ID            uint   `json:"id"`
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	DurationDays  int    `json:"duration_days"`
+	Price         int    `json:"price"`          // 售价(分)
+	OriginalPrice int    `json:"original_price"` // 原价(分)
+	Description   string `json:"description"`
+	IsActive      bool   `json:"is_active"`
+	SortOrder     int    `json:"sort_order"`
+}
+
+// FromVipPlan 从VipPlan模型转换
+func (p *VipPlanResponse) FromVipPlan(plan *baby.VipPlan) {
+	p.ID = plan.ID
+	p.Code = plan.Code
+	p.Name = plan.Name
+	p.DurationDays = plan.DurationDays
+	p.Price = plan.Price
+	p.OriginalPrice = plan.OriginalPrice
+	p.Description = plan.Description
+	p.IsActive = plan.IsActive
+	p.SortOrder = plan.SortOrder
+}
+
+// MembershipResponse 当前用户会员状态响应
+type MembershipResponse struct {
+	IsVIP         bool              `json:"is_vip"`
+	PlanID        uint              `json:"plan_id"`
+	PlanName      string            `json:"plan_name"`
+	StartedAt     *time.Time        `json:"started_at"`
+	ExpiredAt     *time.Time        `json:"expired_at"`
+	RemainingDays int               `json:"remaining_days"` // 剩余天数，不足一天按一天计
+	Plans         []VipPlanResponse `json:"plans"`          // 可开通的会员套餐
+}
+
+// MemberResponse 会员用户响应（管理端）
+type MemberResponse struct {
+	UserID        uint       `json:"user_id"`
+	Username      string     `json:"username"`
+	NickName      string     `json:"nick_name"`
+	Avatar        string     `json:"avatar"`
+	Phone         string     `json:"phone"`
+	IsVIP         bool       `json:"is_vip"`
+	PlanID        uint       `json:"plan_id"`
+	PlanName      string     `json:"plan_name"`
+	StartedAt     *time.Time `json:"started_at"`
+	ExpiredAt     *time.Time `json:"expired_at"`
+	RemainingDays int        `json:"remaining_days"`
+}
+
+// MembershipLogResponse 会员变更记录响应（管理端）
+type MembershipLogResponse struct {
+	ID            uint       `json:"id"`
+	UserID        uint       `json:"user_id"`
+	NickName      string     `json:"nick_name"`
+	PlanID        uint       `json:"plan_id"`
+	PlanName      string     `json:"plan_name"`
+	Action        int        `json:"action"`
+	ActionText    string     `json:"action_text"`
+	Days          int        `json:"days"`
+	ExpiredBefore *time.Time `json:"expired_before"`
+	ExpiredAfter  *time.Time `json:"expired_after"`
+	OperatorID    uint       `json:"operator_id"`
+	Reason        string     `json:"reason"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// FromVipMembershipLog 从VipMembershipLog模型转换
+func (l *MembershipLogResponse) FromVipMembershipLog(log *baby.VipMembershipLog, nickName string, planName string) {
+	l.ID = log.ID
+	l.UserID = log.UserID
+	l.NickName = nickName
+	l.PlanID = log.PlanID
+	l.PlanName = planName
+	l.Action = log.Action
+	l.ActionText = log.GetActionText()
+	l.Days = log.Days
+	l.ExpiredBefore = log.ExpiredBefore
+	l.ExpiredAfter = log.ExpiredAfter
+	l.OperatorID = log.OperatorID
+	l.Reason = log.Reason
+	l.CreatedAt = log.CreatedAt
+}
@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+// BabyMilestoneResponse 宝宝里程碑清单项
+type BabyMilestoneResponse struct {
+	MilestoneID    uint       `json:"milestone_id"`
+	AgeRange       string     `json:"age_range"`
+	Category       string     `json:"category"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Tips           string     `json:"tips"`
+	IsImportant    bool       `json:"is_important"`
+	IsAchieved     bool       `json:"is_achieved"`
+	AchievedAt     *time.Time `json:"achieved_at"`
+	GrowthRecordID uint       `json:"growth_record_id"` // 关联的成长记录，0表示未关联
+	Note           string     `json:"note"`
+	IsOverdue      bool       `json:"is_overdue"` // 重要里程碑已超过预期月龄仍未达成
+}
+
+// MilestoneChecklistResponse 宝宝里程碑清单响应
+type MilestoneChecklistResponse struct {
+	BabyID        uint                    `json:"baby_id"`
+	BabyName      string                  `json:"baby_name"`
+	AgeMonths     int                     `json:"age_months"`
+	AgeText       string                  `json:"age_text"`
+	Current       []BabyMilestoneResponse `json:"current"`        // 当前月龄应关注的里程碑
+	Overdue       []BabyMilestoneResponse `json:"overdue"`        // 逾期未达成的重要里程碑
+	AchievedCount int                     `json:"achieved_count"` // 已达成数量
+	TotalCount    int                     `json:"total_count"`    // 截至当前月龄应达成的里程碑数量
+}
@@ -38,6 +38,7 @@ type MusicResponse struct {
 	IsVIP        bool      `json:"is_vip"`
 	IsActive     bool      `json:"is_active"`
 	IsFavorited  bool      `json:"is_favorited"` // 用户是否收藏
+	IsLocked     bool      `json:"is_locked"`    // VIP专享且用户未开通会员，不返回音频地址
 	CreatedAt    time.Time `json:"created_at"`
 }
 
@@ -65,6 +66,12 @@ func (m *MusicResponse) FromMusic(music *baby.Music, categoryName string, isFavo
 	m.CreatedAt = music.CreatedAt
 }
 
+// Lock 锁定VIP专享音乐，仅保留介绍信息供预览
+func (m *MusicResponse) Lock() {
+	m.IsLocked = true
+	m.AudioURL = ""
+}
+
 // MusicListResponse 音乐列表响应
 type MusicListResponse struct {
 	List     []MusicResponse `json:"list"`
@@ -82,12 +89,32 @@ type PlaylistResponse struct {
 	CoverURL    string          `json:"cover_url"`
 	IsPublic    bool            `json:"is_public"`
 	MusicCount  int             `json:"music_count"`
+	SourceID    uint            `json:"source_id"`        // 复制来源播放列表ID
+	CloneCount  int64           `json:"clone_count"`      // 被复制次数
+	IsOwner     bool            `json:"is_owner"`         // 是否为当前用户创建
 	Musics      []MusicResponse `json:"musics,omitempty"` // 播放列表中的音乐
 	Duration    int             `json:"duration"`         // 总时长
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
 }
 
+// FromPlaylist 从Playlist模型转换
+func (p *PlaylistResponse) FromPlaylist(playlist *baby.Playlist, userID uint, duration int) {
+	p.ID = playlist.ID
+	p.UserID = playlist.UserID
+	p.Name = playlist.Name
+	p.Description = playlist.Description
+	p.CoverURL = playlist.CoverURL
+	p.IsPublic = playlist.IsPublic
+	p.MusicCount = playlist.MusicCount
+	p.SourceID = playlist.SourceID
+	p.CloneCount = playlist.CloneCount
+	p.IsOwner = playlist.UserID == userID
+	p.Duration = duration
+	p.CreatedAt = playlist.CreatedAt
+	p.UpdatedAt = playlist.UpdatedAt
+}
+
 // PlaylistListResponse 播放列表列表响应
 type PlaylistListResponse struct {
 	List     []PlaylistResponse `json:"list"`
@@ -100,6 +127,7 @@ type PlaylistListResponse struct {
 type MusicHistoryResponse struct {
 	ID         uint          `json:"id"`
 	Music      MusicResponse `json:"music"`
+	UserID     uint          `json:"user_id"` // 播放者用户ID
 	BabyID     uint          `json:"baby_id"`
 	BabyName   string        `json:"baby_name"`
 	PlayTime   int           `json:"play_time"`
@@ -109,8 +137,9 @@ type MusicHistoryResponse struct {
 
 // RecommendationResponse 音乐推荐响应
 type RecommendationResponse struct {
-	Title       string          `json:"title"`       // 推荐标题
-	Description string          `json:"description"` // 推荐描述
-	Musics      []MusicResponse `json:"musics"`      // 推荐音乐列表
-	Type        string          `json:"type"`        // 推荐类型：age_based, sleep, popular等
+	Title       string          `json:"title"`                   // 推荐标题
+	Description string          `json:"description"`             // 推荐描述
+	Musics      []MusicResponse `json:"musics"`                  // 推荐音乐列表
+	Type        string          `json:"type"`                    // 推荐类型：because_listened, age_based, sleep, popular等
+	SeedMusicID uint            `json:"seed_music_id,omitempty"` // because_listened推荐所依据的音乐ID
 }
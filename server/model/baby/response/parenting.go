@@ -45,6 +45,7 @@ type ParentingArticleResponse struct {
 	IsFavorited    bool      `json:"is_favorited"`  // 用户是否收藏
 	ReadProgress   int       `json:"read_progress"` // 用户阅读进度
 	IsRead         bool      `json:"is_read"`       // 用户是否已读
+	IsLocked       bool      `json:"is_locked"`     // VIP专享且用户未开通会员，只返回摘要
 	PublishedAt    time.Time `json:"published_at"`
 	CreatedAt      time.Time `json:"created_at"`
 }
@@ -81,6 +82,12 @@ func (p *ParentingArticleResponse) FromParentingArticle(article *baby.ParentingA
 	p.CreatedAt = article.CreatedAt
 }
 
+// Lock 锁定VIP专享文章，仅保留摘要供预览
+func (p *ParentingArticleResponse) Lock() {
+	p.IsLocked = true
+	p.Content = ""
+}
+
 // ParentingVideoResponse 育儿视频响应
 type ParentingVideoResponse struct {
 	ID            uint      `json:"id"`
@@ -106,7 +113,9 @@ type ParentingVideoResponse struct {
 	IsVIP         bool      `json:"is_vip"`
 	IsFavorited   bool      `json:"is_favorited"`   // 用户是否收藏
 	WatchProgress int       `json:"watch_progress"` // 用户观看进度
+	WatchPosition int       `json:"watch_position"` // 用户上次观看位置(秒)
 	IsWatched     bool      `json:"is_watched"`     // 用户是否已观看
+	IsLocked      bool      `json:"is_locked"`      // VIP专享且用户未开通会员，不返回视频地址
 	PublishedAt   time.Time `json:"published_at"`
 	CreatedAt     time.Time `json:"created_at"`
 }
@@ -141,6 +150,12 @@ func (p *ParentingVideoResponse) FromParentingVideo(video *baby.ParentingVideo,
 	p.CreatedAt = video.CreatedAt
 }
 
+// Lock 锁定VIP专享视频，仅保留介绍信息供预览
+func (p *ParentingVideoResponse) Lock() {
+	p.IsLocked = true
+	p.VideoURL = ""
+}
+
 // ParentingMilestoneResponse 成长里程碑响应
 type ParentingMilestoneResponse struct {
 	ID          uint      `json:"id"`
@@ -219,3 +234,38 @@ func formatReadTime(minutes int) string {
 		return fmt.Sprintf("%d小时%d分钟", hours, remainingMinutes)
 	}
 }
+
+// ArticleReadProgressResponse 文章阅读进度响应
+type ArticleReadProgressResponse struct {
+	Article    ParentingArticleResponse `json:"article"`
+	ReadTime   int                      `json:"read_time"`   // 累计阅读时长(秒)
+	Progress   int                      `json:"progress"`    // 阅读进度(百分比)
+	IsFinished bool                     `json:"is_finished"` // 是否读完
+	LastReadAt time.Time                `json:"last_read_at"`
+}
+
+// VideoWatchProgressResponse 视频观看进度响应
+type VideoWatchProgressResponse struct {
+	Video       ParentingVideoResponse `json:"video"`
+	WatchTime   int                    `json:"watch_time"`  // 累计观看时长(秒)
+	Progress    int                    `json:"progress"`    // 观看进度(百分比)
+	Position    int                    `json:"position"`    // 上次观看位置(秒)
+	IsFinished  bool                   `json:"is_finished"` // 是否看完
+	LastWatchAt time.Time              `json:"last_watch_at"`
+}
+
+// ArticleReadProgressListResponse 继续阅读列表响应
+type ArticleReadProgressListResponse struct {
+	List     []ArticleReadProgressResponse `json:"list"`
+	Total    int64                         `json:"total"`
+	Page     int                           `json:"page"`
+	PageSize int                           `json:"page_size"`
+}
+
+// VideoWatchProgressListResponse 继续观看列表响应
+type VideoWatchProgressListResponse struct {
+	List     []VideoWatchProgressResponse `json:"list"`
+	Total    int64                        `json:"total"`
+	Page     int                          `json:"page"`
+	PageSize int                          `json:"page_size"`
+}
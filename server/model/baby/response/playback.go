@@ -0,0 +1,64 @@
+package response
+
+import (
+	"baby_admin/server/model/baby"
+	"time"
+)
+
+// PlaybackSessionResponse 播放会话响应
+type PlaybackSessionResponse struct {
+	ID             uint            `json:"id"`
+	UserID         uint            `json:"user_id"`
+	BabyID         uint            `json:"baby_id"`
+	DeviceID       uint            `json:"device_id"`
+	DeviceName     string          `json:"device_name,omitempty"`
+	PlaylistID     uint            `json:"playlist_id"`
+	Queue          []MusicResponse `json:"queue"` // 播放队列，与会话中的位置一一对应，已下架或需开通会员的音乐为锁定状态
+	CurrentIndex   int             `json:"current_index"`
+	Position       int             `json:"position"`
+	LoopMode       int             `json:"loop_mode"`
+	LoopModeText   string          `json:"loop_mode_text"`
+	Status         int             `json:"status"`
+	StatusText     string          `json:"status_text"`
+	SleepMinutes   int             `json:"sleep_minutes"`
+	FadeOutSeconds int             `json:"fade_out_seconds"`
+	SleepAt        *time.Time      `json:"sleep_at"`        // 睡眠定时停止时间
+	FadeOutAt      *time.Time      `json:"fade_out_at"`     // 开始淡出的时间
+	SleepRemaining int             `json:"sleep_remaining"` // 距离定时停止的秒数
+	EndedAt        *time.Time      `json:"ended_at"`
+	EndReason      int             `json:"end_reason,omitempty"`
+	EndReasonText  string          `json:"end_reason_text,omitempty"`
+	StartedAt      time.Time       `json:"started_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// FromPlaybackSession 从PlaybackSession模型转换
+func (p *PlaybackSessionResponse) FromPlaybackSession(session *baby.PlaybackSession, queue []MusicResponse, now time.Time) {
+	p.ID = session.ID
+	p.UserID = session.UserID
+	p.BabyID = session.BabyID
+	p.DeviceID = session.DeviceID
+	p.PlaylistID = session.PlaylistID
+	p.Queue = queue
+	p.CurrentIndex = session.CurrentIndex
+	p.Position = session.Position
+	p.LoopMode = session.LoopMode
+	p.LoopModeText = session.GetLoopModeText()
+	p.Status = session.Status
+	p.StatusText = session.GetStatusText()
+	p.SleepMinutes = session.SleepMinutes
+	p.FadeOutSeconds = session.FadeOutSeconds
+	p.SleepAt = session.SleepAt
+	p.EndedAt = session.EndedAt
+	p.EndReason = session.EndReason
+	p.EndReasonText = session.GetEndReasonText()
+	p.StartedAt = session.CreatedAt
+	p.UpdatedAt = session.UpdatedAt
+	if session.SleepAt != nil {
+		fadeOutAt := session.SleepAt.Add(-time.Duration(session.FadeOutSeconds) * time.Second)
+		p.FadeOutAt = &fadeOutAt
+		if !session.IsEnded() && session.SleepAt.After(now) {
+			p.SleepRemaining = int(session.SleepAt.Sub(now).Seconds())
+		}
+	}
+}
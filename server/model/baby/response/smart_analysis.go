@@ -58,6 +58,14 @@ func (s *SleepRecordResponse) FromSleepRecord(record *baby.SleepRecord, babyName
 	s.UpdatedAt = record.UpdatedAt
 }
 
+// SleepRecordListResponse 睡眠记录列表响应
+type SleepRecordListResponse struct {
+	List     []SleepRecordResponse `json:"list"`
+	Total    int64                 `json:"total"`
+	Page     int                   `json:"page"`
+	PageSize int                   `json:"page_size"`
+}
+
 // CryDetectionResponse 哭声检测响应
 type CryDetectionResponse struct {
 	ID           uint      `json:"id"`
@@ -98,6 +106,53 @@ func (c *CryDetectionResponse) FromCryDetection(detection *baby.CryDetection, ba
 	c.CreatedAt = detection.CreatedAt
 }
 
+// CryDetectionListResponse 哭声检测列表响应
+type CryDetectionListResponse struct {
+	List     []CryDetectionResponse `json:"list"`
+	Total    int64                  `json:"total"`
+	Page     int                    `json:"page"`
+	PageSize int                    `json:"page_size"`
+}
+
+// CryAccuracyStat 哭声识别准确率统计项
+type CryAccuracyStat struct {
+	TotalCount        int     `json:"total_count"`        // 检测次数
+	FeedbackCount     int     `json:"feedback_count"`     // 已反馈次数
+	AccurateCount     int     `json:"accurate_count"`     // 反馈准确次数
+	InaccurateCount   int     `json:"inaccurate_count"`   // 反馈不准确次数
+	Accuracy          float64 `json:"accuracy"`           // 准确率(准确/已反馈)
+	FeedbackRate      float64 `json:"feedback_rate"`      // 反馈率(已反馈/检测)
+	AverageConfidence float64 `json:"average_confidence"` // 平均置信度
+}
+
+// DeviceCryAccuracy 按设备统计的识别准确率
+type DeviceCryAccuracy struct {
+	DeviceID   uint   `json:"device_id"`
+	DeviceName string `json:"device_name"`
+	CryAccuracyStat
+}
+
+// CryTypeAccuracy 按哭声类型统计的识别准确率
+type CryTypeAccuracy struct {
+	CryType     int    `json:"cry_type"`
+	CryTypeText string `json:"cry_type_text"`
+	CryAccuracyStat
+}
+
+// WeeklyCryAccuracy 按周统计的识别准确率，用于观察识别效果变化
+type WeeklyCryAccuracy struct {
+	WeekStart string `json:"week_start"` // 周一日期
+	CryAccuracyStat
+}
+
+// CryAccuracyReport 哭声识别准确率报告
+type CryAccuracyReport struct {
+	Overall   CryAccuracyStat     `json:"overall"`
+	ByDevice  []DeviceCryAccuracy `json:"by_device"`
+	ByCryType []CryTypeAccuracy   `json:"by_cry_type"`
+	Weekly    []WeeklyCryAccuracy `json:"weekly"`
+}
+
 // MovementDetectionResponse 动作检测响应
 type MovementDetectionResponse struct {
 	ID               uint      `json:"id"`
@@ -153,6 +208,7 @@ type SmartAlertResponse struct {
 	IsProcessed    bool       `json:"is_processed"`
 	ProcessedAt    *time.Time `json:"processed_at"`
 	UserAction     string     `json:"user_action"`
+	RuleID         uint       `json:"rule_id"`
 	CreatedAt      time.Time  `json:"created_at"`
 }
 
@@ -175,9 +231,64 @@ func (s *SmartAlertResponse) FromSmartAlert(alert *baby.SmartAlert, babyName str
 	s.IsProcessed = alert.IsProcessed
 	s.ProcessedAt = alert.ProcessedAt
 	s.UserAction = alert.UserAction
+	s.RuleID = alert.RuleID
 	s.CreatedAt = alert.CreatedAt
 }
 
+// SmartAlertListResponse 智能警报列表响应
+type SmartAlertListResponse struct {
+	List     []SmartAlertResponse `json:"list"`
+	Total    int64                `json:"total"`
+	Page     int                  `json:"page"`
+	PageSize int                  `json:"page_size"`
+}
+
+// AlertSummary 警报概况
+type AlertSummary struct {
+	UnreadCount      int64 `json:"unread_count"`      // 未读警报数
+	UnprocessedCount int64 `json:"unprocessed_count"` // 未处理警报数
+}
+
+// AlertRuleResponse 警报规则响应
+type AlertRuleResponse struct {
+	ID              uint       `json:"id"`
+	BabyID          uint       `json:"baby_id"`
+	BabyName        string     `json:"baby_name"`
+	DeviceID        uint       `json:"device_id"`
+	DeviceName      string     `json:"device_name"`
+	Name            string     `json:"name"`
+	RuleType        int        `json:"rule_type"`
+	RuleTypeText    string     `json:"rule_type_text"`
+	MinValue        *float64   `json:"min_value"`
+	MaxValue        *float64   `json:"max_value"`
+	DurationSeconds int        `json:"duration_seconds"`
+	AlertLevel      int        `json:"alert_level"`
+	CooldownMinutes int        `json:"cooldown_minutes"`
+	IsEnabled       bool       `json:"is_enabled"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// FromAlertRule 从AlertRule模型转换
+func (a *AlertRuleResponse) FromAlertRule(rule *baby.AlertRule, babyName, deviceName string) {
+	a.ID = rule.ID
+	a.BabyID = rule.BabyID
+	a.BabyName = babyName
+	a.DeviceID = rule.DeviceID
+	a.DeviceName = deviceName
+	a.Name = rule.Name
+	a.RuleType = rule.RuleType
+	a.RuleTypeText = rule.GetRuleTypeText()
+	a.MinValue = rule.MinValue
+	a.MaxValue = rule.MaxValue
+	a.DurationSeconds = rule.DurationSeconds
+	a.AlertLevel = rule.AlertLevel
+	a.CooldownMinutes = rule.CooldownMinutes
+	a.IsEnabled = rule.IsEnabled
+	a.LastTriggeredAt = rule.LastTriggeredAt
+	a.CreatedAt = rule.CreatedAt
+}
+
 // EnvironmentDataResponse 环境数据响应
 type EnvironmentDataResponse struct {
 	ID          uint      `json:"id"`
@@ -203,6 +314,32 @@ type SleepStatistics struct {
 	SleepTrend       string  `json:"sleep_trend"`        // 睡眠趋势
 }
 
+// SleepDailySummary 单日睡眠汇总，跨天的睡眠按实际时段拆分到各自日期
+type SleepDailySummary struct {
+	Date           string `json:"date"`            // 日期(2006-01-02)
+	TotalMinutes   int    `json:"total_minutes"`   // 总睡眠时长(分钟)
+	NightMinutes   int    `json:"night_minutes"`   // 夜间睡眠时长(分钟)
+	NapMinutes     int    `json:"nap_minutes"`     // 小睡时长(分钟)
+	LongestStretch int    `json:"longest_stretch"` // 当天开始的最长连续睡眠(分钟)
+	SessionCount   int    `json:"session_count"`   // 当天开始的睡眠次数
+}
+
+// SleepAnalytics 睡眠分析
+type SleepAnalytics struct {
+	BabyID            uint                 `json:"baby_id"`
+	StartDate         string               `json:"start_date"`
+	EndDate           string               `json:"end_date"`
+	Days              int                  `json:"days"`
+	AverageTotal      int                  `json:"average_total"`       // 日均睡眠时长(分钟)
+	AverageNight      int                  `json:"average_night"`       // 日均夜间睡眠(分钟)
+	AverageNap        int                  `json:"average_nap"`         // 日均小睡(分钟)
+	LongestStretch    int                  `json:"longest_stretch"`     // 区间内最长连续睡眠(分钟)
+	AverageQuality    float64              `json:"average_quality"`     // 平均睡眠质量
+	SleepTrend        string               `json:"sleep_trend"`         // 睡眠趋势:上升,下降,稳定
+	InProgressSession *SleepRecordResponse `json:"in_progress_session"` // 进行中的睡眠
+	Daily             []SleepDailySummary  `json:"daily"`
+}
+
 // CryStatistics 哭声统计
 type CryStatistics struct {
 	TotalCryCount    int            `json:"total_cry_count"`    // 总哭声次数
@@ -278,3 +415,84 @@ func formatMinutesToDuration(minutes int) string {
 	}
 	return fmt.Sprintf("%d分钟", remainingMinutes)
 }
+
+// ReportCryData 报告期内哭声统计
+type ReportCryData struct {
+	TotalCount       int            `json:"total_count"`        // 哭声次数
+	TotalSeconds     int            `json:"total_seconds"`      // 累计哭闹时长(秒)
+	NightCount       int            `json:"night_count"`        // 夜间(19:00-07:00)哭声次数
+	Frequency        float64        `json:"frequency"`          // 日均次数
+	AverageIntensity float64        `json:"average_intensity"`  // 平均强度
+	HighIntensity    int            `json:"high_intensity"`     // 强度8及以上的次数
+	CryTypeBreakdown map[string]int `json:"cry_type_breakdown"` // 哭声类型分布
+}
+
+// ReportEnvironmentData 报告期内环境统计
+type ReportEnvironmentData struct {
+	SampleCount        int     `json:"sample_count"`        // 采样数
+	AverageTemperature float64 `json:"average_temperature"` // 平均温度(℃)
+	MinTemperature     float64 `json:"min_temperature"`     // 最低温度(℃)
+	MaxTemperature     float64 `json:"max_temperature"`     // 最高温度(℃)
+	AverageHumidity    float64 `json:"average_humidity"`    // 平均湿度(%)
+	AverageNoiseLevel  float64 `json:"average_noise_level"` // 平均噪音(dB)
+	MaxNoiseLevel      float64 `json:"max_noise_level"`     // 最大噪音(dB)
+	AverageCO2Level    int     `json:"average_co2_level"`   // 平均二氧化碳(ppm)
+	MaxCO2Level        int     `json:"max_co2_level"`       // 最高二氧化碳(ppm)
+	ComfortRatio       float64 `json:"comfort_ratio"`       // 温湿度均在舒适区间的采样占比
+}
+
+// ReportContent 报告完整内容，序列化后存入AnalysisReport.Content
+type ReportContent struct {
+	BabyID      uint                   `json:"baby_id"`
+	BabyName    string                 `json:"baby_name"`
+	AgeMonths   int                    `json:"age_months"`
+	ReportType  int                    `json:"report_type"`
+	PeriodStart string                 `json:"period_start"` // 2006-01-02
+	PeriodEnd   string                 `json:"period_end"`   // 2006-01-02，含当天
+	Days        int                    `json:"days"`
+	Sleep       *SleepAnalytics        `json:"sleep"`
+	Cry         *ReportCryData         `json:"cry"`
+	Environment *ReportEnvironmentData `json:"environment"`
+	Suggestions []string               `json:"suggestions"`
+}
+
+// AnalysisReportResponse 分析报告响应，列表中不返回Content
+type AnalysisReportResponse struct {
+	ID             uint           `json:"id"`
+	BabyID         uint           `json:"baby_id"`
+	BabyName       string         `json:"baby_name"`
+	ReportType     int            `json:"report_type"`
+	ReportTypeText string         `json:"report_type_text"`
+	ReportDate     time.Time      `json:"report_date"`
+	PeriodEnd      time.Time      `json:"period_end"`
+	Title          string         `json:"title"`
+	Summary        string         `json:"summary"`
+	IsGenerated    bool           `json:"is_generated"`
+	Content        *ReportContent `json:"content,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// FromAnalysisReport 从AnalysisReport模型转换
+func (a *AnalysisReportResponse) FromAnalysisReport(report *baby.AnalysisReport, babyName string) {
+	a.ID = report.ID
+	a.BabyID = report.BabyID
+	a.BabyName = babyName
+	a.ReportType = report.ReportType
+	a.ReportTypeText = report.GetReportTypeText()
+	a.ReportDate = report.ReportDate
+	a.PeriodEnd = report.PeriodEnd
+	a.Title = report.Title
+	a.Summary = report.Summary
+	a.IsGenerated = report.IsGenerated
+	a.CreatedAt = report.CreatedAt
+	a.UpdatedAt = report.UpdatedAt
+}
+
+// AnalysisReportListResponse 分析报告列表响应
+type AnalysisReportListResponse struct {
+	List     []AnalysisReportResponse `json:"list"`
+	Total    int64                    `json:"total"`
+	Page     int                      `json:"page"`
+	PageSize int                      `json:"page_size"`
+}
@@ -0,0 +1,139 @@
+package response
+
+import (
+	"baby_admin/server/model/baby"
+	"time"
+)
+
+// VaccineResponse 疫苗响应
+type VaccineResponse struct {
+	ID           uint                      `json:"id"`
+	Code         string                    `json:"code"`
+	Name         string                    `json:"name"`
+	Category     int                       `json:"category"`
+	CategoryText string                    `json:"category_text"`
+	Prevents     string                    `json:"prevents"`
+	Description  string                    `json:"description"`
+	SortOrder    int                       `json:"sort_order"`
+	IsActive     bool                      `json:"is_active"`
+	Schedules    []VaccineScheduleResponse `json:"schedules"`
+}
+
+// FromVaccine 从Vaccine模型转换
+func (v *VaccineResponse) FromVaccine(vaccine *baby.Vaccine) {
+	v.ID = vaccine.ID
+	v.Code = vaccine.Code
+	v.Name = vaccine.Name
+	v.Category = vaccine.Category
+	v.CategoryText = vaccine.GetCategoryText()
+	v.Prevents = vaccine.Prevents
+	v.Description = vaccine.Description
+	v.SortOrder = vaccine.SortOrder
+	v.IsActive = vaccine.IsActive
+	v.Schedules = []VaccineScheduleResponse{}
+}
+
+// VaccineScheduleResponse 接种程序剂次响应
+type VaccineScheduleResponse struct {
+	ID          uint   `json:"id"`
+	VaccineID   uint   `json:"vaccine_id"`
+	VaccineName string `json:"vaccine_name"`
+	DoseNumber  int    `json:"dose_number"`
+	AgeMonths   int    `json:"age_months"`
+	AgeDays     int    `json:"age_days"`
+	AgeText     string `json:"age_text"`
+	GraceDays   int    `json:"grace_days"`
+	IsActive    bool   `json:"is_active"`
+	Notes       string `json:"notes"`
+}
+
+// FromVaccineSchedule 从VaccineSchedule模型转换
+func (v *VaccineScheduleResponse) FromVaccineSchedule(schedule *baby.VaccineSchedule, vaccineName string) {
+	v.ID = schedule.ID
+	v.VaccineID = schedule.VaccineID
+	v.VaccineName = vaccineName
+	v.DoseNumber = schedule.DoseNumber
+	v.AgeMonths = schedule.AgeMonths
+	v.AgeDays = schedule.AgeDays
+	v.AgeText = schedule.GetAgeText()
+	v.GraceDays = schedule.GraceDays
+	v.IsActive = schedule.IsActive
+	v.Notes = schedule.Notes
+}
+
+// VaccinationRecordResponse 接种记录响应
+type VaccinationRecordResponse struct {
+	ID           uint      `json:"id"`
+	BabyID       uint      `json:"baby_id"`
+	VaccineID    uint      `json:"vaccine_id"`
+	VaccineName  string    `json:"vaccine_name"`
+	DoseNumber   int       `json:"dose_number"`
+	VaccinatedAt time.Time `json:"vaccinated_at"`
+	BatchNumber  string    `json:"batch_number"`
+	Manufacturer string    `json:"manufacturer"`
+	Clinic       string    `json:"clinic"`
+	Reaction     string    `json:"reaction"`
+	Notes        string    `json:"notes"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// FromVaccinationRecord 从VaccinationRecord模型转换
+func (v *VaccinationRecordResponse) FromVaccinationRecord(record *baby.VaccinationRecord, vaccineName string) {
+	v.ID = record.ID
+	v.BabyID = record.BabyID
+	v.VaccineID = record.VaccineID
+	v.VaccineName = vaccineName
+	v.DoseNumber = record.DoseNumber
+	v.VaccinatedAt = record.VaccinatedAt
+	v.BatchNumber = record.BatchNumber
+	v.Manufacturer = record.Manufacturer
+	v.Clinic = record.Clinic
+	v.Reaction = record.Reaction
+	v.Notes = record.Notes
+	v.CreatedAt = record.CreatedAt
+}
+
+// VaccinationRecordListResponse 接种记录列表响应
+type VaccinationRecordListResponse struct {
+	List     []VaccinationRecordResponse `json:"list"`
+	Total    int64                       `json:"total"`
+	Page     int                         `json:"page"`
+	PageSize int                         `json:"page_size"`
+}
+
+// VaccinationPlanItem 接种计划中的一个剂次
+type VaccinationPlanItem struct {
+	ScheduleID   uint                       `json:"schedule_id"`
+	VaccineID    uint                       `json:"vaccine_id"`
+	VaccineCode  string                     `json:"vaccine_code"`
+	VaccineName  string                     `json:"vaccine_name"`
+	Category     int                        `json:"category"`
+	CategoryText string                     `json:"category_text"`
+	DoseNumber   int                        `json:"dose_number"`
+	TotalDoses   int                        `json:"total_doses"`
+	AgeText      string                     `json:"age_text"`
+	DueDate      time.Time                  `json:"due_date"`     // 推荐接种日期
+	OverdueDate  time.Time                  `json:"overdue_date"` // 超过该日期未接种视为逾期
+	DaysUntilDue int                        `json:"days_until_due"`
+	Status       int                        `json:"status"` // 1已接种,2未到期,3即将接种,4可接种,5已逾期
+	StatusText   string                     `json:"status_text"`
+	Record       *VaccinationRecordResponse `json:"record"`
+}
+
+// VaccinationPlanResponse 宝宝接种计划
+type VaccinationPlanResponse struct {
+	BabyID         uint                  `json:"baby_id"`
+	BabyName       string                `json:"baby_name"`
+	Birthday       time.Time             `json:"birthday"`
+	CompletedCount int                   `json:"completed_count"`
+	TotalCount     int                   `json:"total_count"`
+	Items          []VaccinationPlanItem `json:"items"`
+}
+
+// VaccinationUpcomingResponse 即将接种与逾期未接种的剂次
+type VaccinationUpcomingResponse struct {
+	BabyID   uint                  `json:"baby_id"`
+	BabyName string                `json:"baby_name"`
+	Upcoming []VaccinationPlanItem `json:"upcoming"` // 即将接种及已到接种时间
+	Overdue  []VaccinationPlanItem `json:"overdue"`
+}
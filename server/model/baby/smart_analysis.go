@@ -97,7 +97,7 @@ type SmartAlert struct {
 	UserID       uint      `json:"user_id" gorm:"not null;comment:用户ID"`
 	BabyID       uint      `json:"baby_id" gorm:"comment:宝宝ID"`
 	DeviceID     uint      `json:"device_id" gorm:"comment:设备ID"`
-	AlertType    int       `json:"alert_type" gorm:"not null;comment:警报类型:1哭声,2异常动作,3环境异常,4离开检测,5其他"`
+	AlertType    int       `json:"alert_type" gorm:"not null;comment:警报类型:1哭声,2异常动作,3环境异常,4离开检测,5其他,6体温异常,7用药提醒,8疫苗接种提醒"`
 	AlertLevel   int       `json:"alert_level" gorm:"not null;comment:警报等级:1低,2中,3高,4紧急"`
 	Title        string    `json:"title" gorm:"size:100;not null;comment:警报标题"`
 	Message      string    `json:"message" gorm:"type:text;comment:警报消息"`
@@ -107,6 +107,7 @@ type SmartAlert struct {
 	IsProcessed  bool      `json:"is_processed" gorm:"default:false;comment:是否已处理"`
 	ProcessedAt  *time.Time `json:"processed_at" gorm:"comment:处理时间"`
 	UserAction   string    `json:"user_action" gorm:"size:50;comment:用户处理动作"`
+	RuleID       uint      `json:"rule_id" gorm:"index;comment:触发规则ID"`
 }
 
 // TableName 指定表名
@@ -114,13 +115,36 @@ func (SmartAlert) TableName() string {
 	return "smart_alerts"
 }
 
+// AlertRule 智能警报规则表
+type AlertRule struct {
+	global.GVA_MODEL
+	UserID          uint       `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	BabyID          uint       `json:"baby_id" gorm:"default:0;comment:宝宝ID,0表示全部宝宝"`
+	DeviceID        uint       `json:"device_id" gorm:"default:0;comment:设备ID,0表示全部设备"`
+	Name            string     `json:"name" gorm:"size:50;not null;comment:规则名称"`
+	RuleType        int        `json:"rule_type" gorm:"not null;comment:规则类型:1温度,2湿度,3二氧化碳,4噪音,5空气质量,6哭声,7异常动作"`
+	MinValue        *float64   `json:"min_value" gorm:"type:decimal(10,2);comment:下限,环境规则低于该值触发,哭声和动作规则为最低强度"`
+	MaxValue        *float64   `json:"max_value" gorm:"type:decimal(10,2);comment:上限,环境规则高于该值触发"`
+	DurationSeconds int        `json:"duration_seconds" gorm:"default:0;comment:持续时长阈值(秒),哭声规则使用"`
+	AlertLevel      int        `json:"alert_level" gorm:"default:2;comment:警报等级:1低,2中,3高,4紧急"`
+	CooldownMinutes int        `json:"cooldown_minutes" gorm:"default:10;comment:冷却时间(分钟),期间同一对象不重复警报"`
+	IsEnabled       bool       `json:"is_enabled" gorm:"comment:是否启用"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at" gorm:"comment:最后触发时间"`
+}
+
+// TableName 指定表名
+func (AlertRule) TableName() string {
+	return "alert_rules"
+}
+
 // AnalysisReport 分析报告表
 type AnalysisReport struct {
 	global.GVA_MODEL
 	UserID      uint      `json:"user_id" gorm:"not null;comment:用户ID"`
-	BabyID      uint      `json:"baby_id" gorm:"not null;comment:宝宝ID"`
-	ReportType  int       `json:"report_type" gorm:"not null;comment:报告类型:1日报,2周报,3月报"`
-	ReportDate  time.Time `json:"report_date" gorm:"not null;comment:报告日期"`
+	BabyID      uint      `json:"baby_id" gorm:"not null;uniqueIndex:idx_report_period;comment:宝宝ID"`
+	ReportType  int       `json:"report_type" gorm:"not null;uniqueIndex:idx_report_period;comment:报告类型:1日报,2周报,3月报"`
+	ReportDate  time.Time `json:"report_date" gorm:"not null;uniqueIndex:idx_report_period;comment:报告日期(统计周期开始日)"`
+	PeriodEnd   time.Time `json:"period_end" gorm:"comment:统计周期结束时间(不含)"`
 	Title       string    `json:"title" gorm:"size:100;not null;comment:报告标题"`
 	Summary     string    `json:"summary" gorm:"type:text;comment:摘要"`
 	Content     string    `json:"content" gorm:"type:longtext;comment:报告内容(JSON格式)"`
@@ -136,6 +160,20 @@ func (AnalysisReport) TableName() string {
 	return "analysis_reports"
 }
 
+// GetReportTypeText 获取报告类型文本
+func (a *AnalysisReport) GetReportTypeText() string {
+	switch a.ReportType {
+	case 1:
+		return "日报"
+	case 2:
+		return "周报"
+	case 3:
+		return "月报"
+	default:
+		return "未知"
+	}
+}
+
 // GetCryTypeText 获取哭声类型文本
 func (c *CryDetection) GetCryTypeText() string {
 	switch c.CryType {
@@ -185,11 +223,44 @@ func (s *SmartAlert) GetAlertTypeText() string {
 		return "离开检测"
 	case 5:
 		return "其他警报"
+	case 6:
+		return "体温异常"
+	case 7:
+		return "用药提醒"
+	case 8:
+		return "疫苗接种提醒"
 	default:
 		return "未知警报"
 	}
 }
 
+// GetRuleTypeText 获取规则类型文本
+func (r *AlertRule) GetRuleTypeText() string {
+	switch r.RuleType {
+	case 1:
+		return "温度"
+	case 2:
+		return "湿度"
+	case 3:
+		return "二氧化碳"
+	case 4:
+		return "噪音"
+	case 5:
+		return "空气质量"
+	case 6:
+		return "哭声"
+	case 7:
+		return "异常动作"
+	default:
+		return "未知"
+	}
+}
+
+// IsEnvironmentRule 是否为环境数据规则
+func (r *AlertRule) IsEnvironmentRule() bool {
+	return r.RuleType >= 1 && r.RuleType <= 5
+}
+
 // GetAlertLevelText 获取警报等级文本
 func (s *SmartAlert) GetAlertLevelText() string {
 	switch s.AlertLevel {
@@ -0,0 +1,116 @@
+package baby
+
+import (
+	"strconv"
+	"time"
+	"baby_admin/server/global"
+)
+
+// Vaccine 疫苗目录表
+type Vaccine struct {
+	global.GVA_MODEL
+	Code        string `json:"code" gorm:"size:30;not null;index;comment:疫苗编码,如HepB"`
+	Name        string `json:"name" gorm:"size:100;not null;comment:疫苗名称"`
+	Category    int    `json:"category" gorm:"default:1;comment:类别:1免疫规划疫苗,2非免疫规划疫苗"`
+	Prevents    string `json:"prevents" gorm:"size:200;comment:预防的疾病"`
+	Description string `json:"description" gorm:"type:text;comment:疫苗说明及接种注意事项"`
+	SortOrder   int    `json:"sort_order" gorm:"default:0;comment:排序"`
+	IsActive    bool   `json:"is_active" gorm:"default:true;comment:是否启用"`
+}
+
+// TableName 指定表名
+func (Vaccine) TableName() string {
+	return "vaccines"
+}
+
+// GetCategoryText 获取疫苗类别文本
+func (v *Vaccine) GetCategoryText() string {
+	switch v.Category {
+	case 1:
+		return "免疫规划疫苗"
+	case 2:
+		return "非免疫规划疫苗"
+	default:
+		return "未知"
+	}
+}
+
+// VaccineSchedule 疫苗接种程序表，每条为某疫苗某一剂次的推荐接种月龄，由管理员按各地程序维护
+type VaccineSchedule struct {
+	global.GVA_MODEL
+	VaccineID  uint   `json:"vaccine_id" gorm:"not null;index:idx_schedule_vaccine_dose;comment:疫苗ID"`
+	DoseNumber int    `json:"dose_number" gorm:"not null;index:idx_schedule_vaccine_dose;comment:剂次"`
+	AgeMonths  int    `json:"age_months" gorm:"default:0;comment:推荐接种月龄"`
+	AgeDays    int    `json:"age_days" gorm:"default:0;comment:在推荐月龄基础上增加的天数"`
+	GraceDays  int    `json:"grace_days" gorm:"default:30;comment:超过推荐日期该天数仍未接种视为逾期"`
+	IsActive   bool   `json:"is_active" gorm:"default:true;comment:是否启用"`
+	Notes      string `json:"notes" gorm:"size:255;comment:备注"`
+}
+
+// TableName 指定表名
+func (VaccineSchedule) TableName() string {
+	return "vaccine_schedules"
+}
+
+// DueDate 按出生日期计算推荐接种日期，疫苗接种按实际月龄，早产儿不做矫正
+func (s *VaccineSchedule) DueDate(birthday time.Time) time.Time {
+	return addMonthsClamped(birthday, s.AgeMonths).AddDate(0, 0, s.AgeDays)
+}
+
+// GetAgeText 获取推荐接种年龄文本
+func (s *VaccineSchedule) GetAgeText() string {
+	return scheduleAgeText(s.AgeMonths, s.AgeDays)
+}
+
+// VaccinationRecord 宝宝疫苗接种记录表
+type VaccinationRecord struct {
+	global.GVA_MODEL
+	UserID       uint      `json:"user_id" gorm:"not null;comment:记录人用户ID"`
+	BabyID       uint      `json:"baby_id" gorm:"not null;index:idx_vaccination_baby_vaccine;comment:宝宝ID"`
+	VaccineID    uint      `json:"vaccine_id" gorm:"not null;index:idx_vaccination_baby_vaccine;comment:疫苗ID"`
+	DoseNumber   int       `json:"dose_number" gorm:"not null;comment:剂次"`
+	VaccinatedAt time.Time `json:"vaccinated_at" gorm:"not null;comment:接种日期"`
+	BatchNumber  string    `json:"batch_number" gorm:"size:50;comment:疫苗批号"`
+	Manufacturer string    `json:"manufacturer" gorm:"size:100;comment:生产企业"`
+	Clinic       string    `json:"clinic" gorm:"size:100;comment:接种单位"`
+	Reaction     string    `json:"reaction" gorm:"size:255;comment:接种后反应"`
+	Notes        string    `json:"notes" gorm:"type:text;comment:备注"`
+}
+
+// TableName 指定表名
+func (VaccinationRecord) TableName() string {
+	return "vaccination_records"
+}
+
+// VaccinationReminder 疫苗接种提醒发送记录表，同一剂次的同类提醒只发送一次
+type VaccinationReminder struct {
+	global.GVA_MODEL
+	BabyID       uint `json:"baby_id" gorm:"not null;uniqueIndex:idx_vaccination_reminder;comment:宝宝ID"`
+	ScheduleID   uint `json:"schedule_id" gorm:"not null;uniqueIndex:idx_vaccination_reminder;comment:接种程序ID"`
+	ReminderType int  `json:"reminder_type" gorm:"not null;uniqueIndex:idx_vaccination_reminder;comment:提醒类型:1即将接种,2已逾期"`
+	AlertID      uint `json:"alert_id" gorm:"default:0;comment:生成的警报ID"`
+}
+
+// TableName 指定表名
+func (VaccinationReminder) TableName() string {
+	return "vaccination_reminders"
+}
+
+// scheduleAgeText 推荐接种年龄文本，整岁显示为"X岁"
+func scheduleAgeText(months, days int) string {
+	if months == 0 && days == 0 {
+		return "出生时"
+	}
+
+	text := "出生"
+	switch {
+	case months > 0 && months%12 == 0:
+		text = strconv.Itoa(months/12) + "岁"
+	case months > 0:
+		text = strconv.Itoa(months) + "月龄"
+	}
+	if days > 0 {
+		text += "+" + strconv.Itoa(days) + "天"
+	}
+	return text
+}
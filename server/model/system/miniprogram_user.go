@@ -30,12 +30,22 @@ type MiniprogramUser struct {
 	LoginType  int            `json:"loginType" gorm:"default:0;comment:登录类型 0微信 1账号密码"`   // 登录类型 0微信 1账号密码
 	LastLogin  *time.Time     `json:"lastLogin" gorm:"comment:最后登录时间"`                     // 最后登录时间
 	SessionKey string         `json:"-" gorm:"comment:微信会话密钥"`                             // 微信会话密钥，不返回给前端
+
+	// 会员信息，由管理后台开通、延期和撤销，变更记录见 vip_membership_logs
+	VipPlanID    uint       `json:"vipPlanId" gorm:"default:0;comment:会员套餐ID"` // 最近一次开通的会员套餐
+	VipStartedAt *time.Time `json:"vipStartedAt" gorm:"comment:会员开始时间"`        // 当前会员周期开始时间
+	VipExpiredAt *time.Time `json:"vipExpiredAt" gorm:"index;comment:会员到期时间"`  // 会员到期时间，为空表示从未开通
 }
 
 func (MiniprogramUser) TableName() string {
 	return "miniprogram_users"
 }
 
+// IsVIP 会员是否在有效期内
+func (m *MiniprogramUser) IsVIP(now time.Time) bool {
+	return m.VipExpiredAt != nil && m.VipExpiredAt.After(now)
+}
+
 // Login 接口实现
 func (m *MiniprogramUser) GetUsername() string {
 	if m.Username != "" {
@@ -57,6 +57,8 @@ type UserInfo struct {
 	LoginType int       `json:"loginType"`
 	Enable    int       `json:"enable"`
 	LastLogin *time.Time `json:"lastLogin"`
+	IsVIP     bool      `json:"isVip"`
+	VipExpiredAt *time.Time `json:"vipExpiredAt"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -77,6 +79,8 @@ func (u *UserInfo) FromMiniprogramUser(user *system.MiniprogramUser) {
 	u.LoginType = user.LoginType
 	u.Enable = user.Enable
 	u.LastLogin = user.LastLogin
+	u.IsVIP = user.IsVIP(time.Now())
+	u.VipExpiredAt = user.VipExpiredAt
 	u.CreatedAt = user.CreatedAt
 	u.UpdatedAt = user.UpdatedAt
 }
\ No newline at end of file
@@ -0,0 +1,19 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type AnalysisReportRouter struct{}
+
+// InitAnalysisReportRouter 初始化分析报告路由
+func (a *AnalysisReportRouter) InitAnalysisReportRouter(Router *gin.RouterGroup) {
+	reportRouter := Router.Group("baby/report")
+	reportRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		reportRouter.GET("list", analysisReportApi.GetReportList)           // 获取报告列表
+		reportRouter.POST("regenerate", analysisReportApi.RegenerateReport) // 重新生成报告
+		reportRouter.GET(":id", analysisReportApi.GetReport)                // 获取报告详情
+	}
+}
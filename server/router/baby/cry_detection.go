@@ -0,0 +1,22 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type CryDetectionRouter struct{}
+
+// InitCryDetectionRouter 初始化哭声检测路由
+func (cr *CryDetectionRouter) InitCryDetectionRouter(Router *gin.RouterGroup) {
+	cryRouter := Router.Group("baby/cry")
+	cryRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		cryRouter.POST("", cryDetectionApi.CreateCryDetection)                  // 上报哭声事件
+		cryRouter.GET("list", cryDetectionApi.GetCryDetectionList)              // 获取哭声事件列表
+		cryRouter.GET("accuracy", cryDetectionApi.GetAccuracyReport)            // 获取识别准确率
+		cryRouter.GET(":id", cryDetectionApi.GetCryDetection)                   // 获取哭声事件详情
+		cryRouter.POST("acknowledge", cryDetectionApi.AcknowledgeCryDetections) // 批量确认哭声事件
+		cryRouter.POST("feedback", cryDetectionApi.SubmitFeedback)              // 反馈识别结果
+	}
+}
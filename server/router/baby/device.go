@@ -0,0 +1,32 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type DeviceRouter struct{}
+
+// InitDeviceRouter 初始化设备路由
+func (d *DeviceRouter) InitDeviceRouter(Router *gin.RouterGroup) {
+	deviceRouter := Router.Group("baby/device")
+	deviceRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		deviceRouter.POST("", deviceApi.AddDevice)                           // 绑定设备
+		deviceRouter.GET("list", deviceApi.GetDeviceList)                    // 获取设备列表
+		deviceRouter.PUT("configs", deviceApi.UpdateDeviceConfigs)           // 更新设备配置
+		deviceRouter.GET(":id", deviceApi.GetDevice)                         // 获取设备详情
+		deviceRouter.GET(":id/configs", deviceApi.GetDeviceConfigs)          // 获取设备配置
+		deviceRouter.PUT("", deviceApi.UpdateDevice)                         // 更新设备信息
+		deviceRouter.DELETE(":id", deviceApi.UnbindDevice)                   // 解绑设备
+		deviceRouter.GET(":id/shares", deviceApi.GetDeviceShares)            // 获取设备分享列表
+		deviceRouter.POST("share", deviceApi.ShareDevice)                    // 分享设备
+		deviceRouter.GET("share/invitations", deviceApi.GetShareInvitations) // 获取待处理的分享邀请
+		deviceRouter.POST("share/accept", deviceApi.AcceptShare)             // 接受分享
+		deviceRouter.POST("share/decline", deviceApi.DeclineShare)           // 拒绝或退出分享
+		deviceRouter.DELETE("share/:id", deviceApi.RevokeShare)              // 撤销分享
+		deviceRouter.POST("command", deviceCommandApi.SendCommand)           // 下发设备命令
+		deviceRouter.GET("command/list", deviceCommandApi.GetCommandList)    // 获取命令记录列表
+		deviceRouter.GET("command/:id", deviceCommandApi.GetCommand)         // 获取命令详情
+	}
+}
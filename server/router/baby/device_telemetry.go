@@ -0,0 +1,20 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type DeviceTelemetryRouter struct{}
+
+// InitDeviceTelemetryRouter 初始化设备上报路由，供设备端使用签名鉴权访问
+func (d *DeviceTelemetryRouter) InitDeviceTelemetryRouter(Router *gin.RouterGroup) {
+	iotRouter := Router.Group("baby/iot")
+	iotRouter.Use(middleware.DeviceSignAuth()) // 设备签名验证
+	{
+		iotRouter.POST("telemetry", deviceTelemetryApi.ReportTelemetry)          // 批量上报状态、日志和环境数据
+		iotRouter.POST("command/result", deviceTelemetryApi.ReportCommandResult) // 回传命令执行结果
+		iotRouter.POST("cry", deviceTelemetryApi.ReportCryDetection)             // 上报哭声检测事件
+		iotRouter.POST("movement", deviceTelemetryApi.ReportMovementDetection)   // 上报动作检测事件
+	}
+}
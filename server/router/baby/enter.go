@@ -3,11 +3,50 @@ package baby
 import "baby_admin/server/api/v1"
 
 type RouterGroup struct {
+	AnalysisReportRouter
 	BabyProfileRouter
+	CryDetectionRouter
+	DeviceRouter
+	DeviceTelemetryRouter
+	FamilyRouter
+	FavoriteRouter
+	FeedingRouter
+	GrowthRecordRouter
+	HealthRouter
+	MediaRouter
+	MembershipAdminRouter
+	MembershipRouter
+	MilestoneRouter
 	MusicRouter
+	ParentingRouter
+	PlaybackRouter
+	SleepRecordRouter
+	SmartAlertRouter
+	VaccineAdminRouter
+	VaccineRouter
 }
 
 var (
-	babyProfileApi = v1.ApiGroupApp.BabyApiGroup.BabyProfileApi
-	musicApi       = v1.ApiGroupApp.BabyApiGroup.MusicApi
-)
\ No newline at end of file
+	analysisReportApi  = v1.ApiGroupApp.BabyApiGroup.AnalysisReportApi
+	babyProfileApi     = v1.ApiGroupApp.BabyApiGroup.BabyProfileApi
+	cryDetectionApi    = v1.ApiGroupApp.BabyApiGroup.CryDetectionApi
+	deviceApi          = v1.ApiGroupApp.BabyApiGroup.DeviceApi
+	deviceCommandApi   = v1.ApiGroupApp.BabyApiGroup.DeviceCommandApi
+	deviceTelemetryApi = v1.ApiGroupApp.BabyApiGroup.DeviceTelemetryApi
+	familyApi          = v1.ApiGroupApp.BabyApiGroup.FamilyApi
+	favoriteApi        = v1.ApiGroupApp.BabyApiGroup.FavoriteApi
+	feedingApi         = v1.ApiGroupApp.BabyApiGroup.FeedingApi
+	growthRecordApi    = v1.ApiGroupApp.BabyApiGroup.GrowthRecordApi
+	healthApi          = v1.ApiGroupApp.BabyApiGroup.HealthApi
+	mediaApi           = v1.ApiGroupApp.BabyApiGroup.MediaApi
+	membershipAdminApi = v1.ApiGroupApp.BabyApiGroup.MembershipAdminApi
+	membershipApi      = v1.ApiGroupApp.BabyApiGroup.MembershipApi
+	milestoneApi       = v1.ApiGroupApp.BabyApiGroup.MilestoneApi
+	musicApi           = v1.ApiGroupApp.BabyApiGroup.MusicApi
+	parentingApi       = v1.ApiGroupApp.BabyApiGroup.ParentingApi
+	playbackApi        = v1.ApiGroupApp.BabyApiGroup.PlaybackApi
+	sleepRecordApi     = v1.ApiGroupApp.BabyApiGroup.SleepRecordApi
+	smartAlertApi      = v1.ApiGroupApp.BabyApiGroup.SmartAlertApi
+	vaccineAdminApi    = v1.ApiGroupApp.BabyApiGroup.VaccineAdminApi
+	vaccineApi         = v1.ApiGroupApp.BabyApiGroup.VaccineApi
+)
@@ -0,0 +1,28 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type FamilyRouter struct{}
+
+// InitFamilyRouter 初始化家庭组路由
+func (f *FamilyRouter) InitFamilyRouter(Router *gin.RouterGroup) {
+	familyRouter := Router.Group("baby/family")
+	familyRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		familyRouter.POST("", familyApi.CreateFamily)             // 创建家庭
+		familyRouter.GET("list", familyApi.GetMyFamilies)         // 获取我的家庭
+		familyRouter.GET(":id", familyApi.GetFamilyDetail)        // 获取家庭详情
+		familyRouter.PUT("", familyApi.UpdateFamily)              // 更新家庭信息
+		familyRouter.DELETE(":id", familyApi.DeleteFamily)        // 解散家庭
+		familyRouter.POST("invite", familyApi.RefreshInviteCode)  // 生成邀请码
+		familyRouter.POST("join", familyApi.JoinFamily)           // 申请加入家庭
+		familyRouter.POST("review", familyApi.ReviewMember)       // 审核加入申请
+		familyRouter.PUT("member", familyApi.UpdateMember)        // 更新家庭成员
+		familyRouter.DELETE("member/:id", familyApi.RemoveMember) // 移出家庭成员
+		familyRouter.POST("leave/:id", familyApi.LeaveFamily)     // 退出家庭
+		familyRouter.POST("transfer", familyApi.TransferFamily)   // 转让家庭
+	}
+}
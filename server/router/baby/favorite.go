@@ -0,0 +1,24 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type FavoriteRouter struct{}
+
+// InitFavoriteRouter 初始化收藏路由
+func (f *FavoriteRouter) InitFavoriteRouter(Router *gin.RouterGroup) {
+	favoriteRouter := Router.Group("baby/favorite")
+	favoriteRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		favoriteRouter.POST("toggle", favoriteApi.ToggleFavorite)     // 切换收藏状态
+		favoriteRouter.GET("list", favoriteApi.GetFavoriteList)       // 获取收藏列表
+		favoriteRouter.POST("check", favoriteApi.CheckFavorites)      // 批量查询收藏状态
+		favoriteRouter.POST("move", favoriteApi.MoveFavorites)        // 移动收藏到收藏夹
+		favoriteRouter.GET("folder/list", favoriteApi.GetFolderList)  // 获取收藏夹列表
+		favoriteRouter.POST("folder", favoriteApi.CreateFolder)       // 创建收藏夹
+		favoriteRouter.PUT("folder", favoriteApi.UpdateFolder)        // 更新收藏夹
+		favoriteRouter.DELETE("folder/:id", favoriteApi.DeleteFolder) // 删除收藏夹
+	}
+}
@@ -0,0 +1,28 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type FeedingRouter struct{}
+
+// InitFeedingRouter 初始化喂养记录路由
+func (f *FeedingRouter) InitFeedingRouter(Router *gin.RouterGroup) {
+	feedingRouter := Router.Group("baby/feeding")
+	feedingRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		feedingRouter.POST("breast/start", feedingApi.StartBreastFeeding)     // 开始母乳亲喂
+		feedingRouter.POST("breast/switch", feedingApi.SwitchBreastSide)      // 切换哺乳侧
+		feedingRouter.POST("breast/pause/:id", feedingApi.PauseBreastFeeding) // 暂停亲喂计时
+		feedingRouter.POST("end", feedingApi.EndFeeding)                      // 结束喂养
+		feedingRouter.POST("", feedingApi.CreateFeedingRecord)                // 补录喂养记录
+		feedingRouter.GET("list", feedingApi.GetFeedingRecordList)            // 获取喂养记录列表
+		feedingRouter.GET("current", feedingApi.GetCurrentFeeding)            // 获取进行中的喂养
+		feedingRouter.GET("summary", feedingApi.GetFeedingSummary)            // 获取喂养日汇总
+		feedingRouter.GET("allergens", feedingApi.GetAllergenHistory)         // 获取过敏原添加记录
+		feedingRouter.GET(":id", feedingApi.GetFeedingRecord)                 // 获取喂养记录详情
+		feedingRouter.PUT("", feedingApi.UpdateFeedingRecord)                 // 修正喂养记录
+		feedingRouter.DELETE(":id", feedingApi.DeleteFeedingRecord)           // 删除喂养记录
+	}
+}
@@ -0,0 +1,23 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type GrowthRecordRouter struct{}
+
+// InitGrowthRecordRouter 初始化成长记录路由
+func (g *GrowthRecordRouter) InitGrowthRecordRouter(Router *gin.RouterGroup) {
+	growthRouter := Router.Group("baby/growth")
+	growthRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		growthRouter.POST("", growthRecordApi.CreateGrowthRecord)           // 创建成长记录
+		growthRouter.GET("list", growthRecordApi.GetGrowthRecordList)       // 获取成长记录列表
+		growthRouter.GET("statistics", growthRecordApi.GetGrowthStatistics) // 获取成长统计
+		growthRouter.GET("standard", growthRecordApi.GetGrowthStandard)     // 获取WHO生长标准评估
+		growthRouter.GET(":id", growthRecordApi.GetGrowthRecord)            // 获取成长记录详情
+		growthRouter.PUT("", growthRecordApi.UpdateGrowthRecord)            // 更新成长记录
+		growthRouter.DELETE(":id", growthRecordApi.DeleteGrowthRecord)      // 删除成长记录
+	}
+}
@@ -0,0 +1,30 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthRouter struct{}
+
+// InitHealthRouter 初始化健康日记路由
+func (h *HealthRouter) InitHealthRouter(Router *gin.RouterGroup) {
+	healthRouter := Router.Group("baby/health")
+	healthRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		healthRouter.POST("diaper", healthApi.CreateDiaperRecord)                  // 记录换尿布
+		healthRouter.GET("diaper/list", healthApi.GetDiaperRecordList)             // 获取换尿布记录列表
+		healthRouter.DELETE("diaper/:id", healthApi.DeleteDiaperRecord)            // 删除换尿布记录
+		healthRouter.POST("temperature", healthApi.CreateTemperatureRecord)        // 记录体温
+		healthRouter.GET("temperature/list", healthApi.GetTemperatureRecordList)   // 获取体温记录列表
+		healthRouter.DELETE("temperature/:id", healthApi.DeleteTemperatureRecord)  // 删除体温记录
+		healthRouter.POST("medication", healthApi.CreateMedicationRecord)          // 记录服药
+		healthRouter.GET("medication/list", healthApi.GetMedicationRecordList)     // 获取用药记录列表
+		healthRouter.GET("medication/reminders", healthApi.GetMedicationReminders) // 获取用药提醒
+		healthRouter.DELETE("medication/:id", healthApi.DeleteMedicationRecord)    // 删除用药记录
+		healthRouter.POST("symptom", healthApi.CreateSymptomRecord)                // 记录症状
+		healthRouter.GET("symptom/list", healthApi.GetSymptomRecordList)           // 获取症状记录列表
+		healthRouter.DELETE("symptom/:id", healthApi.DeleteSymptomRecord)          // 删除症状记录
+		healthRouter.GET("summary", healthApi.GetHealthSummary)                    // 获取健康日汇总
+	}
+}
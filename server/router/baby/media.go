@@ -0,0 +1,16 @@
+package baby
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type MediaRouter struct{}
+
+// InitMediaRouter 初始化媒体网关路由，地址自带签名，不需要登录验证
+func (m *MediaRouter) InitMediaRouter(Router *gin.RouterGroup) {
+	mediaRouter := Router.Group("media")
+	{
+		mediaRouter.GET(":type/:id", mediaApi.ServeMedia)  // 播放音视频
+		mediaRouter.HEAD(":type/:id", mediaApi.ServeMedia) // 获取音视频文件信息
+	}
+}
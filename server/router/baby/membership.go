@@ -0,0 +1,18 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type MembershipRouter struct{}
+
+// InitMembershipRouter 初始化会员路由
+func (m *MembershipRouter) InitMembershipRouter(Router *gin.RouterGroup) {
+	membershipRouter := Router.Group("baby/membership")
+	membershipRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		membershipRouter.GET("", membershipApi.GetMyMembership)  // 获取会员状态
+		membershipRouter.GET("plans", membershipApi.GetVipPlans) // 获取会员套餐
+	}
+}
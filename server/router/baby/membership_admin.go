@@ -0,0 +1,27 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type MembershipAdminRouter struct{}
+
+// InitMembershipAdminRouter 初始化会员管理路由，挂载在管理后台鉴权路由组
+func (m *MembershipAdminRouter) InitMembershipAdminRouter(Router *gin.RouterGroup) {
+	membershipAdminRouter := Router.Group("membershipAdmin").Use(middleware.OperationRecord())
+	membershipAdminRouterWithoutRecord := Router.Group("membershipAdmin")
+	{
+		membershipAdminRouter.POST("createVipPlan", membershipAdminApi.CreateVipPlan)       // 创建会员套餐
+		membershipAdminRouter.PUT("updateVipPlan", membershipAdminApi.UpdateVipPlan)        // 更新会员套餐
+		membershipAdminRouter.DELETE("deleteVipPlan", membershipAdminApi.DeleteVipPlan)     // 删除会员套餐
+		membershipAdminRouter.POST("grantMembership", membershipAdminApi.GrantMembership)   // 开通会员
+		membershipAdminRouter.POST("extendMembership", membershipAdminApi.ExtendMembership) // 延长会员
+		membershipAdminRouter.POST("revokeMembership", membershipAdminApi.RevokeMembership) // 撤销会员
+	}
+	{
+		membershipAdminRouterWithoutRecord.GET("getVipPlanList", membershipAdminApi.GetVipPlanList)             // 分页获取会员套餐列表
+		membershipAdminRouterWithoutRecord.GET("getMemberList", membershipAdminApi.GetMemberList)               // 分页获取会员用户列表
+		membershipAdminRouterWithoutRecord.GET("getMembershipLogList", membershipAdminApi.GetMembershipLogList) // 分页获取会员变更记录
+	}
+}
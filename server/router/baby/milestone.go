@@ -0,0 +1,19 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type MilestoneRouter struct{}
+
+// InitMilestoneRouter 初始化里程碑路由
+func (m *MilestoneRouter) InitMilestoneRouter(Router *gin.RouterGroup) {
+	milestoneRouter := Router.Group("baby/milestone")
+	milestoneRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		milestoneRouter.GET("checklist", milestoneApi.GetMilestoneChecklist) // 获取里程碑清单
+		milestoneRouter.POST("achieve", milestoneApi.AchieveMilestone)       // 标记里程碑达成
+		milestoneRouter.DELETE("achieve", milestoneApi.CancelMilestone)      // 取消里程碑达成
+	}
+}
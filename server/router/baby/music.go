@@ -20,5 +20,17 @@ func (m *MusicRouter) InitMusicRouter(Router *gin.RouterGroup) {
 		musicRouter.GET(":id", musicApi.GetMusicDetail)                // 获取音乐详情
 		musicRouter.POST("play", musicApi.PlayMusic)                   // 播放音乐
 		musicRouter.POST(":id/favorite", musicApi.ToggleFavorite)      // 切换收藏状态
+
+		// 播放列表
+		musicRouter.POST("playlist", musicApi.CreatePlaylist)                   // 创建播放列表
+		musicRouter.PUT("playlist", musicApi.UpdatePlaylist)                    // 更新播放列表
+		musicRouter.GET("playlist/list", musicApi.GetMyPlaylists)               // 获取我的播放列表
+		musicRouter.GET("playlist/public", musicApi.GetPublicPlaylists)         // 浏览公开播放列表
+		musicRouter.GET("playlist/:id", musicApi.GetPlaylistDetail)             // 获取播放列表详情
+		musicRouter.DELETE("playlist/:id", musicApi.DeletePlaylist)             // 删除播放列表
+		musicRouter.POST("playlist/musics", musicApi.AddMusicToPlaylist)        // 添加音乐到播放列表
+		musicRouter.DELETE("playlist/musics", musicApi.RemoveMusicFromPlaylist) // 从播放列表移除音乐
+		musicRouter.PUT("playlist/reorder", musicApi.ReorderPlaylist)           // 调整播放列表顺序
+		musicRouter.POST("playlist/clone", musicApi.ClonePlaylist)              // 复制播放列表
 	}
 }
\ No newline at end of file
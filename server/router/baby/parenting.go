@@ -0,0 +1,26 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type ParentingRouter struct{}
+
+// InitParentingRouter 初始化育儿内容路由
+func (p *ParentingRouter) InitParentingRouter(Router *gin.RouterGroup) {
+	parentingRouter := Router.Group("baby/parenting")
+	parentingRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		parentingRouter.GET("categories", parentingApi.GetParentingCategories)       // 获取育儿分类
+		parentingRouter.GET("article/list", parentingApi.GetArticleList)             // 获取文章列表
+		parentingRouter.GET("article/continue", parentingApi.GetContinueReading)     // 获取继续阅读列表
+		parentingRouter.POST("article/progress", parentingApi.ReportArticleProgress) // 上报阅读进度
+		parentingRouter.GET("article/:id", parentingApi.GetArticleDetail)            // 获取文章详情
+		parentingRouter.GET("video/list", parentingApi.GetVideoList)                 // 获取视频列表
+		parentingRouter.GET("video/continue", parentingApi.GetContinueWatching)      // 获取继续观看列表
+		parentingRouter.POST("video/progress", parentingApi.ReportVideoProgress)     // 上报观看进度
+		parentingRouter.GET("video/:id", parentingApi.GetVideoDetail)                // 获取视频详情
+		parentingRouter.GET("feed", parentingApi.GetBabyFeed)                        // 获取适合宝宝月龄的内容
+	}
+}
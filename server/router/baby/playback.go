@@ -0,0 +1,32 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type PlaybackRouter struct{}
+
+// InitPlaybackRouter 初始化播放会话路由，小程序使用登录鉴权，音响设备使用签名鉴权
+func (p *PlaybackRouter) InitPlaybackRouter(Router *gin.RouterGroup) {
+	playbackRouter := Router.Group("baby/playback")
+	playbackRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		playbackRouter.POST("start", playbackApi.StartSession)       // 开始播放会话
+		playbackRouter.GET("current", playbackApi.GetCurrentSession) // 获取当前播放会话
+		playbackRouter.POST("progress", playbackApi.ReportProgress)  // 同步播放进度
+		playbackRouter.POST("pause", playbackApi.PauseSession)       // 暂停播放
+		playbackRouter.POST("resume", playbackApi.ResumeSession)     // 继续播放或切换播放设备
+		playbackRouter.POST("stop", playbackApi.StopSession)         // 停止播放
+		playbackRouter.PUT("settings", playbackApi.UpdateSettings)   // 更新循环模式和睡眠定时
+	}
+
+	iotPlaybackRouter := Router.Group("baby/iot/playback")
+	iotPlaybackRouter.Use(middleware.DeviceSignAuth()) // 设备签名验证
+	{
+		iotPlaybackRouter.POST("resume", playbackApi.DeviceResumeSession)    // 音响接续播放
+		iotPlaybackRouter.POST("progress", playbackApi.DeviceReportProgress) // 音响同步播放进度
+		iotPlaybackRouter.POST("pause", playbackApi.DevicePauseSession)      // 音响暂停播放
+		iotPlaybackRouter.POST("stop", playbackApi.DeviceStopSession)        // 音响停止播放
+	}
+}
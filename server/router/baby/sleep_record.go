@@ -0,0 +1,25 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type SleepRecordRouter struct{}
+
+// InitSleepRecordRouter 初始化睡眠记录路由
+func (s *SleepRecordRouter) InitSleepRecordRouter(Router *gin.RouterGroup) {
+	sleepRouter := Router.Group("baby/sleep")
+	sleepRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		sleepRouter.POST("start", sleepRecordApi.StartSleep)             // 开始睡眠
+		sleepRouter.POST("end", sleepRecordApi.EndSleep)                 // 结束睡眠
+		sleepRouter.POST("", sleepRecordApi.CreateSleepRecord)           // 补录睡眠记录
+		sleepRouter.GET("list", sleepRecordApi.GetSleepRecordList)       // 获取睡眠记录列表
+		sleepRouter.GET("current", sleepRecordApi.GetCurrentSleep)       // 获取进行中的睡眠
+		sleepRouter.GET("statistics", sleepRecordApi.GetSleepStatistics) // 获取睡眠统计
+		sleepRouter.GET(":id", sleepRecordApi.GetSleepRecord)            // 获取睡眠记录详情
+		sleepRouter.PUT("", sleepRecordApi.UpdateSleepRecord)            // 修正睡眠记录
+		sleepRouter.DELETE(":id", sleepRecordApi.DeleteSleepRecord)      // 删除睡眠记录
+	}
+}
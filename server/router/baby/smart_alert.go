@@ -0,0 +1,25 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type SmartAlertRouter struct{}
+
+// InitSmartAlertRouter 初始化智能警报路由
+func (s *SmartAlertRouter) InitSmartAlertRouter(Router *gin.RouterGroup) {
+	alertRouter := Router.Group("baby/alert")
+	alertRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		alertRouter.GET("list", smartAlertApi.GetAlertList)           // 获取警报列表
+		alertRouter.GET("summary", smartAlertApi.GetAlertSummary)     // 获取未读、未处理数量
+		alertRouter.POST("read", smartAlertApi.MarkAlertsRead)        // 标记警报已读
+		alertRouter.POST("process", smartAlertApi.ProcessAlert)       // 处理警报
+		alertRouter.GET("rule/list", smartAlertApi.GetAlertRuleList)  // 获取警报规则列表
+		alertRouter.POST("rule", smartAlertApi.CreateAlertRule)       // 创建警报规则
+		alertRouter.PUT("rule", smartAlertApi.UpdateAlertRule)        // 更新警报规则
+		alertRouter.DELETE("rule/:id", smartAlertApi.DeleteAlertRule) // 删除警报规则
+		alertRouter.GET(":id", smartAlertApi.GetAlert)                // 获取警报详情
+	}
+}
@@ -0,0 +1,23 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type VaccineRouter struct{}
+
+// InitVaccineRouter 初始化疫苗接种路由
+func (v *VaccineRouter) InitVaccineRouter(Router *gin.RouterGroup) {
+	vaccineRouter := Router.Group("baby/vaccine")
+	vaccineRouter.Use(middleware.MiniprogramJWTAuth()) // 需要登录验证
+	{
+		vaccineRouter.GET("catalog", vaccineApi.GetVaccineCatalog)             // 获取疫苗目录
+		vaccineRouter.GET("plan", vaccineApi.GetVaccinationPlan)               // 获取接种计划
+		vaccineRouter.GET("upcoming", vaccineApi.GetUpcomingVaccinations)      // 获取待接种疫苗
+		vaccineRouter.POST("record", vaccineApi.CreateVaccinationRecord)       // 记录接种
+		vaccineRouter.PUT("record", vaccineApi.UpdateVaccinationRecord)        // 更新接种记录
+		vaccineRouter.GET("record/list", vaccineApi.GetVaccinationRecordList)  // 获取接种记录列表
+		vaccineRouter.DELETE("record/:id", vaccineApi.DeleteVaccinationRecord) // 删除接种记录
+	}
+}
@@ -0,0 +1,26 @@
+package baby
+
+import (
+	"baby_admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type VaccineAdminRouter struct{}
+
+// InitVaccineAdminRouter 初始化疫苗管理路由，挂载在管理后台鉴权路由组
+func (v *VaccineAdminRouter) InitVaccineAdminRouter(Router *gin.RouterGroup) {
+	vaccineAdminRouter := Router.Group("vaccineAdmin").Use(middleware.OperationRecord())
+	vaccineAdminRouterWithoutRecord := Router.Group("vaccineAdmin")
+	{
+		vaccineAdminRouter.POST("createVaccine", vaccineAdminApi.CreateVaccine)     // 创建疫苗
+		vaccineAdminRouter.PUT("updateVaccine", vaccineAdminApi.UpdateVaccine)      // 更新疫苗
+		vaccineAdminRouter.DELETE("deleteVaccine", vaccineAdminApi.DeleteVaccine)   // 删除疫苗
+		vaccineAdminRouter.POST("createSchedule", vaccineAdminApi.CreateSchedule)   // 创建接种程序剂次
+		vaccineAdminRouter.PUT("updateSchedule", vaccineAdminApi.UpdateSchedule)    // 更新接种程序剂次
+		vaccineAdminRouter.DELETE("deleteSchedule", vaccineAdminApi.DeleteSchedule) // 删除接种程序剂次
+	}
+	{
+		vaccineAdminRouterWithoutRecord.GET("getVaccineList", vaccineAdminApi.GetVaccineList)   // 分页获取疫苗列表
+		vaccineAdminRouterWithoutRecord.GET("getScheduleList", vaccineAdminApi.GetScheduleList) // 获取接种程序列表
+	}
+}
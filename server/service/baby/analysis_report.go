@@ -0,0 +1,521 @@
+package baby
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AnalysisReportService struct{}
+
+// 报告类型，对应AnalysisReport.ReportType
+const (
+	reportTypeDaily   = 1 // 日报
+	reportTypeWeekly  = 2 // 周报
+	reportTypeMonthly = 3 // 月报
+)
+
+// 舒适环境区间
+const (
+	comfortTemperatureMin = 20.0
+	comfortTemperatureMax = 26.0
+	comfortHumidityMin    = 40.0
+	comfortHumidityMax    = 60.0
+	comfortCO2Max         = 1000
+	comfortNoiseMax       = 50.0
+)
+
+// GenerateReports 为所有宝宝生成上一个完整周期的报告，已生成的报告跳过，返回本次生成的数量
+func (s *AnalysisReportService) GenerateReports(reportType int, now time.Time) (int, error) {
+	periodStart, periodEnd := previousReportPeriod(reportType, now)
+
+	var generated int
+	var lastErr error
+	var babies []baby.BabyProfile
+	err := global.GVA_DB.Where("birthday < ?", periodEnd).
+		FindInBatches(&babies, 100, func(tx *gorm.DB, batch int) error {
+			for i := range babies {
+				created, err := s.generateReport(&babies[i], reportType, periodStart, false)
+				if err != nil {
+					lastErr = fmt.Errorf("宝宝%d报告生成失败: %w", babies[i].ID, err)
+					continue
+				}
+				if created {
+					generated++
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return generated, err
+	}
+	return generated, lastErr
+}
+
+// RegenerateReport 按需重新生成指定周期的报告，周期内数据有更新时覆盖原报告
+func (s *AnalysisReportService) RegenerateReport(userID uint, req *request.RegenerateReportRequest) (*response.AnalysisReportResponse, error) {
+	babyProfile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", req.ReportDate, time.Local)
+	if err != nil {
+		return nil, errors.New("报告日期格式错误")
+	}
+	periodStart, _ := reportPeriod(req.ReportType, date)
+	if periodStart.After(time.Now()) {
+		return nil, errors.New("报告周期尚未开始")
+	}
+
+	if _, err := s.generateReport(babyProfile, req.ReportType, periodStart, true); err != nil {
+		return nil, err
+	}
+
+	var report baby.AnalysisReport
+	err = global.GVA_DB.Where("baby_id = ? AND report_type = ? AND report_date = ?", babyProfile.ID, req.ReportType, periodStart).
+		First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+	return s.buildReportResponse(&report, babyProfile.Name)
+}
+
+// GetReport 获取报告详情
+func (s *AnalysisReportService) GetReport(id uint, userID uint) (*response.AnalysisReportResponse, error) {
+	var report baby.AnalysisReport
+	err := global.GVA_DB.Where("id = ? AND baby_id IN (?)", id, accessibleBabyIDs(userID, babyAccessRead)).First(&report).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("报告不存在")
+		}
+		return nil, err
+	}
+
+	// 获取宝宝姓名
+	var babyProfile baby.BabyProfile
+	global.GVA_DB.Where("id = ?", report.BabyID).First(&babyProfile)
+
+	return s.buildReportResponse(&report, babyProfile.Name)
+}
+
+// GetReportList 获取报告列表
+func (s *AnalysisReportService) GetReportList(userID uint, req *request.AnalysisReportSearch) (*response.AnalysisReportListResponse, error) {
+	db := global.GVA_DB.Model(&baby.AnalysisReport{}).Where("baby_id IN (?)", accessibleBabyIDs(userID, babyAccessRead))
+
+	// 搜索条件
+	if req.BabyID > 0 {
+		db = db.Where("baby_id = ?", req.BabyID)
+	}
+	if req.ReportType > 0 {
+		db = db.Where("report_type = ?", req.ReportType)
+	}
+	if req.StartDate != "" {
+		db = db.Where("report_date >= ?", req.StartDate)
+	}
+	if req.EndDate != "" {
+		// 仅传日期时包含结束当天开始的报告
+		if end, err := time.ParseInLocation("2006-01-02", req.EndDate, time.Local); err == nil {
+			db = db.Where("report_date < ?", end.AddDate(0, 0, 1))
+		} else {
+			db = db.Where("report_date <= ?", req.EndDate)
+		}
+	}
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var reports []baby.AnalysisReport
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order("report_date DESC, report_type ASC").Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取所有相关的宝宝信息
+	var babyIDs []uint
+	for _, report := range reports {
+		babyIDs = append(babyIDs, report.BabyID)
+	}
+
+	var babies []baby.BabyProfile
+	if len(babyIDs) > 0 {
+		global.GVA_DB.Where("id IN ?", babyIDs).Find(&babies)
+	}
+
+	babyNameMap := make(map[uint]string)
+	for _, babyProfile := range babies {
+		babyNameMap[babyProfile.ID] = babyProfile.Name
+	}
+
+	// 转换响应
+	list := make([]response.AnalysisReportResponse, 0, len(reports))
+	for _, report := range reports {
+		var resp response.AnalysisReportResponse
+		resp.FromAnalysisReport(&report, babyNameMap[report.BabyID])
+		list = append(list, resp)
+	}
+
+	return &response.AnalysisReportListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// generateReport 汇总宝宝在周期内的睡眠、哭声和环境数据并写入报告
+// 同一宝宝、类型和周期只保留一份报告；force为false时已生成的报告不再重复计算
+func (s *AnalysisReportService) generateReport(babyProfile *baby.BabyProfile, reportType int, periodStart time.Time, force bool) (bool, error) {
+	periodStart, periodEnd := reportPeriod(reportType, periodStart)
+
+	if !force {
+		var count int64
+		err := global.GVA_DB.Model(&baby.AnalysisReport{}).
+			Where("baby_id = ? AND report_type = ? AND report_date = ? AND is_generated = ?", babyProfile.ID, reportType, periodStart, true).
+			Count(&count).Error
+		if err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return false, nil
+		}
+	}
+
+	content, err := s.collectReportContent(babyProfile, reportType, periodStart, periodEnd)
+	if err != nil {
+		return false, err
+	}
+
+	sleepData, _ := json.Marshal(content.Sleep)
+	cryData, _ := json.Marshal(content.Cry)
+	environData, _ := json.Marshal(content.Environment)
+	suggestions, _ := json.Marshal(content.Suggestions)
+	contentData, _ := json.Marshal(content)
+
+	report := baby.AnalysisReport{
+		UserID:      babyProfile.UserID,
+		BabyID:      babyProfile.ID,
+		ReportType:  reportType,
+		ReportDate:  periodStart,
+		PeriodEnd:   periodEnd,
+		Title:       reportTitle(babyProfile.Name, reportType, periodStart),
+		Summary:     reportSummary(content),
+		Content:     string(contentData),
+		SleepData:   string(sleepData),
+		CryData:     string(cryData),
+		EnvironData: string(environData),
+		Suggestions: string(suggestions),
+		IsGenerated: true,
+	}
+
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var existing baby.AnalysisReport
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("baby_id = ? AND report_type = ? AND report_date = ?", babyProfile.ID, reportType, periodStart).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(&report).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&existing).Updates(map[string]interface{}{
+			"user_id":      report.UserID,
+			"period_end":   report.PeriodEnd,
+			"title":        report.Title,
+			"summary":      report.Summary,
+			"content":      report.Content,
+			"sleep_data":   report.SleepData,
+			"cry_data":     report.CryData,
+			"environ_data": report.EnvironData,
+			"suggestions":  report.Suggestions,
+			"is_generated": true,
+			"deleted_at":   nil,
+		}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// collectReportContent 查询周期内的原始数据并汇总
+func (s *AnalysisReportService) collectReportContent(babyProfile *baby.BabyProfile, reportType int, periodStart, periodEnd time.Time) (*response.ReportContent, error) {
+	days := int(math.Round(periodEnd.Sub(periodStart).Hours() / 24))
+
+	var sleepRecords []baby.SleepRecord
+	err := global.GVA_DB.Where("baby_id = ? AND start_time < ? AND (end_time IS NULL OR end_time > ?)", babyProfile.ID, periodEnd, periodStart).
+		Where("status <> ?", sleepStatusAbnormal).
+		Order("start_time ASC").Find(&sleepRecords).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var detections []baby.CryDetection
+	err = global.GVA_DB.Where("baby_id = ? AND detected_at >= ? AND detected_at < ?", babyProfile.ID, periodStart, periodEnd).
+		Find(&detections).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 环境数据按可为宝宝添加记录的家庭成员的设备统计
+	var environments []baby.EnvironmentData
+	err = global.GVA_DB.Where("user_id IN (?) AND recorded_at >= ? AND recorded_at < ?", babyMemberIDs(babyProfile, babyAccessRecord), periodStart, periodEnd).
+		Find(&environments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.After(periodEnd) {
+		now = periodEnd
+	}
+	sleep := summarizeSleep(sleepRecords, periodStart, days, now)
+	sleep.BabyID = babyProfile.ID
+
+	content := &response.ReportContent{
+		BabyID:      babyProfile.ID,
+		BabyName:    babyProfile.Name,
+		AgeMonths:   babyProfile.GetDevelopmentalAgeAt(periodEnd.AddDate(0, 0, -1)).Months,
+		ReportType:  reportType,
+		PeriodStart: periodStart.Format("2006-01-02"),
+		PeriodEnd:   periodEnd.AddDate(0, 0, -1).Format("2006-01-02"),
+		Days:        days,
+		Sleep:       sleep,
+		Cry:         summarizeCry(detections, days),
+		Environment: summarizeEnvironment(environments),
+	}
+	content.Suggestions = reportSuggestions(content.AgeMonths, len(sleepRecords) > 0, content.Sleep, content.Cry, content.Environment)
+	return content, nil
+}
+
+// buildReportResponse 组装报告详情响应
+func (s *AnalysisReportService) buildReportResponse(report *baby.AnalysisReport, babyName string) (*response.AnalysisReportResponse, error) {
+	var resp response.AnalysisReportResponse
+	resp.FromAnalysisReport(report, babyName)
+	if report.Content != "" {
+		var content response.ReportContent
+		if err := json.Unmarshal([]byte(report.Content), &content); err != nil {
+			return nil, err
+		}
+		resp.Content = &content
+	}
+	return &resp, nil
+}
+
+// reportPeriod 返回day所在报告周期的起止时间，结束时间不含
+func reportPeriod(reportType int, day time.Time) (time.Time, time.Time) {
+	day = day.In(time.Local)
+	switch reportType {
+	case reportTypeWeekly:
+		start := weekStart(day)
+		return start, start.AddDate(0, 0, 7)
+	case reportTypeMonthly:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.Local)
+		return start, start.AddDate(0, 1, 0)
+	default:
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+		return start, start.AddDate(0, 0, 1)
+	}
+}
+
+// previousReportPeriod 返回now之前最近一个完整的报告周期
+func previousReportPeriod(reportType int, now time.Time) (time.Time, time.Time) {
+	current, _ := reportPeriod(reportType, now)
+	return reportPeriod(reportType, current.Add(-time.Hour))
+}
+
+// reportTitle 生成报告标题
+func reportTitle(babyName string, reportType int, periodStart time.Time) string {
+	report := baby.AnalysisReport{ReportType: reportType}
+	switch reportType {
+	case reportTypeWeekly:
+		return fmt.Sprintf("%s %s起%s", babyName, periodStart.Format("2006-01-02"), report.GetReportTypeText())
+	case reportTypeMonthly:
+		return fmt.Sprintf("%s %s%s", babyName, periodStart.Format("2006年01月"), report.GetReportTypeText())
+	default:
+		return fmt.Sprintf("%s %s%s", babyName, periodStart.Format("2006-01-02"), report.GetReportTypeText())
+	}
+}
+
+// reportSummary 生成报告摘要
+func reportSummary(content *response.ReportContent) string {
+	parts := []string{
+		fmt.Sprintf("日均睡眠%s", formatReportMinutes(content.Sleep.AverageTotal)),
+		fmt.Sprintf("哭闹%d次", content.Cry.TotalCount),
+	}
+	if content.Environment.SampleCount > 0 {
+		parts = append(parts, fmt.Sprintf("平均室温%.1f℃、湿度%.0f%%", content.Environment.AverageTemperature, content.Environment.AverageHumidity))
+	}
+	return strings.Join(parts, "，")
+}
+
+// summarizeCry 汇总周期内的哭声事件
+func summarizeCry(detections []baby.CryDetection, days int) *response.ReportCryData {
+	data := &response.ReportCryData{CryTypeBreakdown: make(map[string]int)}
+	var intensitySum int
+	for i := range detections {
+		detection := &detections[i]
+		data.TotalCount++
+		data.TotalSeconds += detection.Duration
+		intensitySum += detection.Intensity
+		if detection.Intensity >= 8 {
+			data.HighIntensity++
+		}
+		hour := detection.DetectedAt.In(time.Local).Hour()
+		if hour >= nightStartHour || hour < nightEndHour {
+			data.NightCount++
+		}
+		data.CryTypeBreakdown[detection.GetCryTypeText()]++
+	}
+	if data.TotalCount > 0 {
+		data.AverageIntensity = math.Round(float64(intensitySum)/float64(data.TotalCount)*10) / 10
+	}
+	if days > 0 {
+		data.Frequency = math.Round(float64(data.TotalCount)/float64(days)*10) / 10
+	}
+	return data
+}
+
+// summarizeEnvironment 汇总周期内的环境数据
+func summarizeEnvironment(records []baby.EnvironmentData) *response.ReportEnvironmentData {
+	data := &response.ReportEnvironmentData{SampleCount: len(records)}
+	if len(records) == 0 {
+		return data
+	}
+
+	var temperatureSum, humiditySum, noiseSum float64
+	var co2Sum, comfortCount int
+	data.MinTemperature = records[0].Temperature
+	data.MaxTemperature = records[0].Temperature
+	for _, record := range records {
+		temperatureSum += record.Temperature
+		humiditySum += record.Humidity
+		noiseSum += record.NoiseLevel
+		co2Sum += record.CO2Level
+		data.MinTemperature = math.Min(data.MinTemperature, record.Temperature)
+		data.MaxTemperature = math.Max(data.MaxTemperature, record.Temperature)
+		data.MaxNoiseLevel = math.Max(data.MaxNoiseLevel, record.NoiseLevel)
+		if record.CO2Level > data.MaxCO2Level {
+			data.MaxCO2Level = record.CO2Level
+		}
+		if record.Temperature >= comfortTemperatureMin && record.Temperature <= comfortTemperatureMax &&
+			record.Humidity >= comfortHumidityMin && record.Humidity <= comfortHumidityMax {
+			comfortCount++
+		}
+	}
+
+	n := float64(len(records))
+	data.AverageTemperature = math.Round(temperatureSum/n*10) / 10
+	data.AverageHumidity = math.Round(humiditySum/n*10) / 10
+	data.AverageNoiseLevel = math.Round(noiseSum/n*10) / 10
+	data.AverageCO2Level = int(math.Round(float64(co2Sum) / n))
+	data.ComfortRatio = roundRatio(float64(comfortCount) / n)
+	return data
+}
+
+// recommendedSleepHours 各月龄推荐的每日总睡眠时长(小时)
+func recommendedSleepHours(ageMonths int) (int, int) {
+	switch {
+	case ageMonths < 4:
+		return 14, 17
+	case ageMonths < 12:
+		return 12, 15
+	case ageMonths < 24:
+		return 11, 14
+	case ageMonths < 60:
+		return 10, 13
+	default:
+		return 9, 12
+	}
+}
+
+// reportSuggestions 按规则生成建议
+func reportSuggestions(ageMonths int, hasSleep bool, sleep *response.SleepAnalytics, cry *response.ReportCryData, env *response.ReportEnvironmentData) []string {
+	var suggestions []string
+
+	if hasSleep {
+		minHours, maxHours := recommendedSleepHours(ageMonths)
+		switch {
+		case sleep.AverageTotal < minHours*60:
+			suggestions = append(suggestions, fmt.Sprintf("日均睡眠%s，低于该月龄推荐的%d-%d小时，建议固定作息时间并适当提前入睡",
+				formatReportMinutes(sleep.AverageTotal), minHours, maxHours))
+		case sleep.AverageTotal > maxHours*60:
+			suggestions = append(suggestions, fmt.Sprintf("日均睡眠%s，高于该月龄推荐的%d-%d小时，如伴有精神不振请咨询医生",
+				formatReportMinutes(sleep.AverageTotal), minHours, maxHours))
+		}
+		if ageMonths >= 4 && sleep.AverageTotal > 0 && sleep.AverageNight*2 < sleep.AverageTotal {
+			suggestions = append(suggestions, "夜间睡眠占比偏低，建议控制白天小睡时长，睡前保持环境安静昏暗")
+		}
+		if ageMonths >= 6 && sleep.LongestStretch > 0 && sleep.LongestStretch < 180 {
+			suggestions = append(suggestions, "最长连续睡眠不足3小时，可尝试建立固定的睡前程序帮助宝宝接觉")
+		}
+	}
+
+	if cry.TotalCount > 0 {
+		if cry.Frequency > 10 {
+			suggestions = append(suggestions, fmt.Sprintf("日均哭闹%.1f次，次数偏多，建议留意宝宝的饥饿和困倦信号", cry.Frequency))
+		}
+		if cry.CryTypeBreakdown["饥饿"]*10 >= cry.TotalCount*4 {
+			suggestions = append(suggestions, "饥饿性哭闹占比较高，建议检查喂养量和喂养间隔")
+		}
+		if cry.CryTypeBreakdown["疼痛"] > 0 || cry.HighIntensity*5 >= cry.TotalCount*2 {
+			suggestions = append(suggestions, "出现疼痛或高强度哭闹，如持续发生请及时就医检查")
+		}
+		if cry.TotalCount >= 3 && cry.NightCount*2 > cry.TotalCount {
+			suggestions = append(suggestions, "夜间哭闹较多，建议检查夜间室温、尿布和睡眠环境")
+		}
+	}
+
+	if env.SampleCount > 0 {
+		switch {
+		case env.AverageTemperature > comfortTemperatureMax:
+			suggestions = append(suggestions, fmt.Sprintf("平均室温%.1f℃偏高，建议保持在%.0f-%.0f℃", env.AverageTemperature, comfortTemperatureMin, comfortTemperatureMax))
+		case env.AverageTemperature < comfortTemperatureMin:
+			suggestions = append(suggestions, fmt.Sprintf("平均室温%.1f℃偏低，建议保持在%.0f-%.0f℃", env.AverageTemperature, comfortTemperatureMin, comfortTemperatureMax))
+		}
+		switch {
+		case env.AverageHumidity > comfortHumidityMax:
+			suggestions = append(suggestions, fmt.Sprintf("平均湿度%.0f%%偏高，建议通风或除湿", env.AverageHumidity))
+		case env.AverageHumidity < comfortHumidityMin:
+			suggestions = append(suggestions, fmt.Sprintf("平均湿度%.0f%%偏低，建议使用加湿器", env.AverageHumidity))
+		}
+		if env.MaxCO2Level > comfortCO2Max {
+			suggestions = append(suggestions, fmt.Sprintf("二氧化碳最高达%dppm，建议定时开窗通风", env.MaxCO2Level))
+		}
+		if env.AverageNoiseLevel > comfortNoiseMax {
+			suggestions = append(suggestions, fmt.Sprintf("平均噪音%.0fdB偏高，建议减少睡眠区域的噪音", env.AverageNoiseLevel))
+		}
+	}
+
+	if len(suggestions) == 0 {
+		suggestions = append(suggestions, "各项指标良好，请继续保持当前的作息和环境")
+	}
+	return suggestions
+}
+
+// formatReportMinutes 将分钟数格式化为X小时Y分钟
+func formatReportMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%d分钟", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d小时", minutes/60)
+	}
+	return fmt.Sprintf("%d小时%d分钟", minutes/60, minutes%60)
+}
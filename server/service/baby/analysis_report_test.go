@@ -0,0 +1,141 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	commonRequest "baby_admin/server/model/common/request"
+)
+
+func TestReportPeriod(t *testing.T) {
+	day := time.Date(2024, 5, 8, 15, 30, 0, 0, time.Local) // 周三
+
+	tests := []struct {
+		reportType int
+		start, end string
+	}{
+		{reportTypeDaily, "2024-05-08", "2024-05-09"},
+		{reportTypeWeekly, "2024-05-06", "2024-05-13"},
+		{reportTypeMonthly, "2024-05-01", "2024-06-01"},
+	}
+	for _, tt := range tests {
+		start, end := reportPeriod(tt.reportType, day)
+		if start.Format("2006-01-02") != tt.start || end.Format("2006-01-02") != tt.end {
+			t.Errorf("reportPeriod(%d) = %s ~ %s, want %s ~ %s", tt.reportType, start.Format("2006-01-02"), end.Format("2006-01-02"), tt.start, tt.end)
+		}
+	}
+
+	// 定时任务在周期开始后运行，生成上一个完整周期
+	now := time.Date(2024, 5, 1, 0, 30, 0, 0, time.Local)
+	if start, _ := previousReportPeriod(reportTypeDaily, now); start.Format("2006-01-02") != "2024-04-30" {
+		t.Errorf("上一日 = %s", start.Format("2006-01-02"))
+	}
+	if start, _ := previousReportPeriod(reportTypeWeekly, now); start.Format("2006-01-02") != "2024-04-22" {
+		t.Errorf("上一周 = %s", start.Format("2006-01-02"))
+	}
+	if start, end := previousReportPeriod(reportTypeMonthly, now); start.Format("2006-01-02") != "2024-04-01" || end.Format("2006-01-02") != "2024-05-01" {
+		t.Errorf("上一月 = %s ~ %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+}
+
+func TestSummarizeCryAndEnvironment(t *testing.T) {
+	day := time.Date(2024, 5, 8, 0, 0, 0, 0, time.Local)
+	detections := []baby.CryDetection{
+		{DetectedAt: day.Add(2 * time.Hour), Duration: 60, Intensity: 9, CryType: 1},
+		{DetectedAt: day.Add(10 * time.Hour), Duration: 30, Intensity: 4, CryType: 1},
+		{DetectedAt: day.Add(21 * time.Hour), Duration: 90, Intensity: 5, CryType: 2},
+	}
+	cry := summarizeCry(detections, 1)
+	if cry.TotalCount != 3 || cry.TotalSeconds != 180 || cry.NightCount != 2 || cry.HighIntensity != 1 {
+		t.Fatalf("哭声统计错误: %+v", cry)
+	}
+	if cry.AverageIntensity != 6 || cry.Frequency != 3 || cry.CryTypeBreakdown["饥饿"] != 2 {
+		t.Errorf("哭声比例错误: %+v", cry)
+	}
+
+	env := summarizeEnvironment([]baby.EnvironmentData{
+		{Temperature: 22, Humidity: 50, CO2Level: 800, NoiseLevel: 30},
+		{Temperature: 28, Humidity: 50, CO2Level: 1200, NoiseLevel: 40},
+	})
+	if env.SampleCount != 2 || env.AverageTemperature != 25 || env.MinTemperature != 22 || env.MaxTemperature != 28 {
+		t.Errorf("温度统计错误: %+v", env)
+	}
+	if env.MaxCO2Level != 1200 || env.AverageCO2Level != 1000 || env.ComfortRatio != 0.5 {
+		t.Errorf("环境统计错误: %+v", env)
+	}
+}
+
+func TestReportSuggestions(t *testing.T) {
+	sleep := &response.SleepAnalytics{AverageTotal: 600, AverageNight: 240, LongestStretch: 120}
+	cry := &response.ReportCryData{TotalCount: 4, NightCount: 3, Frequency: 4, CryTypeBreakdown: map[string]int{"饥饿": 2, "困倦": 2}}
+	env := &response.ReportEnvironmentData{SampleCount: 10, AverageTemperature: 27, AverageHumidity: 50, MaxCO2Level: 1500}
+
+	suggestions := reportSuggestions(8, true, sleep, cry, env)
+	// 睡眠不足、夜间占比低、连续睡眠短、饥饿哭闹多、夜哭多、室温高、二氧化碳高
+	if len(suggestions) != 7 {
+		t.Fatalf("建议条数 = %d: %v", len(suggestions), suggestions)
+	}
+
+	empty := reportSuggestions(8, false, &response.SleepAnalytics{}, &response.ReportCryData{}, &response.ReportEnvironmentData{})
+	if len(empty) != 1 {
+		t.Errorf("无异常时应只有一条默认建议: %v", empty)
+	}
+}
+
+func TestAnalysisReportFamilyAccess(t *testing.T) {
+	db := setupTestDB(t, &baby.FamilyGroup{}, &baby.FamilyMember{}, &baby.BabyProfile{}, &baby.SleepRecord{},
+		&baby.CryDetection{}, &baby.EnvironmentData{}, &baby.AnalysisReport{})
+	profile := seedFamilyBaby(t, db)
+	day := time.Now().AddDate(0, 0, -1)
+	periodStart, _ := reportPeriod(reportTypeDaily, day)
+
+	// 看护人设备的环境数据计入报告，观察者和家庭外用户的设备不计入
+	environments := []baby.EnvironmentData{
+		{UserID: 1, DeviceID: 1, RecordedAt: periodStart.Add(time.Hour), Temperature: 22, Humidity: 50},
+		{UserID: 3, DeviceID: 2, RecordedAt: periodStart.Add(2 * time.Hour), Temperature: 24, Humidity: 50},
+		{UserID: 4, DeviceID: 3, RecordedAt: periodStart.Add(3 * time.Hour), Temperature: 30, Humidity: 50},
+		{UserID: 9, DeviceID: 4, RecordedAt: periodStart.Add(4 * time.Hour), Temperature: 30, Humidity: 50},
+	}
+	if err := db.Create(&environments).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	service := new(AnalysisReportService)
+	report, err := service.RegenerateReport(1, &request.RegenerateReportRequest{
+		BabyID: profile.ID, ReportType: reportTypeDaily, ReportDate: day.Format("2006-01-02"),
+	})
+	if err != nil {
+		t.Fatalf("生成报告: %v", err)
+	}
+	if env := report.Content.Environment; env.SampleCount != 2 || env.AverageTemperature != 23 {
+		t.Errorf("环境数据统计 = %+v, want 2 samples averaging 23", env)
+	}
+
+	tests := []struct {
+		name   string
+		userID uint
+		found  bool
+	}{
+		{"创建者", 1, true},
+		{"看护人", 3, true},
+		{"观察者", 4, true},
+		{"家庭外用户", 9, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetReport(report.ID, tt.userID)
+			if (err == nil) != tt.found {
+				t.Errorf("GetReport err = %v, want found %v", err, tt.found)
+			}
+			list, err := service.GetReportList(tt.userID, &request.AnalysisReportSearch{PageInfo: commonRequest.PageInfo{Page: 1, PageSize: 10}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (list.Total == 1) != tt.found {
+				t.Errorf("GetReportList total = %d, want found %v", list.Total, tt.found)
+			}
+		})
+	}
+}
@@ -7,43 +7,58 @@ import (
 	"baby_admin/server/model/baby/request"
 	"baby_admin/server/model/baby/response"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BabyProfileService struct{}
 
-// CreateBabyProfile 创建宝宝档案
+// CreateBabyProfile 创建宝宝档案，未指定家庭时放入自己创建的家庭，没有则自动创建
 func (s *BabyProfileService) CreateBabyProfile(userID uint, req *request.CreateBabyProfileRequest) error {
-	// 如果设置为活跃宝宝，先将其他宝宝设置为非活跃
-	if req.IsActive {
-		if err := s.deactivateOtherBabies(userID); err != nil {
+	if req.FamilyID > 0 {
+		if _, _, err := authorizeFamily(req.FamilyID, userID, babyAccessManage); err != nil {
 			return err
 		}
 	}
 
-	profile := req.ToBabyProfile(userID)
-	return global.GVA_DB.Create(profile).Error
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		familyID := req.FamilyID
+		if familyID == 0 {
+			var err error
+			if familyID, err = defaultFamilyID(tx, userID); err != nil {
+				return err
+			}
+		}
+
+		profile := req.ToBabyProfile(userID, familyID)
+		if err := tx.Create(profile).Error; err != nil {
+			return err
+		}
+		// 设为当前宝宝，或者用户还没有选择当前宝宝
+		if req.IsActive || getActiveBabyID(userID) == 0 {
+			return setActiveBaby(tx, userID, profile.ID)
+		}
+		return nil
+	})
 }
 
 // GetBabyProfile 获取宝宝档案详情
 func (s *BabyProfileService) GetBabyProfile(id uint, userID uint) (*response.BabyProfileResponse, error) {
-	var profile baby.BabyProfile
-	err := global.GVA_DB.Where("id = ? AND user_id = ?", id, userID).First(&profile).Error
+	profile, _, err := authorizeBaby(id, userID, babyAccessRead)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("宝宝档案不存在")
-		}
 		return nil, err
 	}
 
-	var resp response.BabyProfileResponse
-	resp.FromBabyProfile(&profile)
+	role, err := babyFamilyRole(profile, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp := buildBabyProfileResponse(profile, role, getActiveBabyID(userID))
 	return &resp, nil
 }
 
-// GetActiveBabyProfile 获取当前活跃的宝宝档案
+// GetActiveBabyProfile 获取当前用户选择的宝宝档案，未选择或已无权访问时返回最近更新的宝宝
 func (s *BabyProfileService) GetActiveBabyProfile(userID uint) (*response.BabyProfileResponse, error) {
-	var profile baby.BabyProfile
-	err := global.GVA_DB.Where("user_id = ? AND is_active = ?", userID, true).First(&profile).Error
+	profile, err := getActiveBaby(userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("未找到活跃的宝宝档案")
@@ -51,14 +66,17 @@ func (s *BabyProfileService) GetActiveBabyProfile(userID uint) (*response.BabyPr
 		return nil, err
 	}
 
-	var resp response.BabyProfileResponse
-	resp.FromBabyProfile(&profile)
+	role, err := babyFamilyRole(profile, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp := buildBabyProfileResponse(profile, role, getActiveBabyID(userID))
 	return &resp, nil
 }
 
-// GetBabyProfileList 获取宝宝档案列表
+// GetBabyProfileList 获取当前用户可访问的宝宝档案列表
 func (s *BabyProfileService) GetBabyProfileList(userID uint, req *request.BabyProfileSearch) (*response.BabyProfileListResponse, error) {
-	db := global.GVA_DB.Model(&baby.BabyProfile{}).Where("user_id = ?", userID)
+	db := global.GVA_DB.Model(&baby.BabyProfile{}).Where("id IN (?)", accessibleBabyIDs(userID, babyAccessRead))
 
 	// 搜索条件
 	if req.Name != "" {
@@ -74,20 +92,30 @@ func (s *BabyProfileService) GetBabyProfileList(userID uint, req *request.BabyPr
 		return nil, err
 	}
 
-	// 分页查询
+	// 分页查询，当前宝宝排在最前
+	activeBabyID := getActiveBabyID(userID)
 	var profiles []baby.BabyProfile
 	offset := (req.Page - 1) * req.PageSize
-	err := db.Offset(offset).Limit(req.PageSize).Order("is_active DESC, updated_at DESC").Find(&profiles).Error
+	err := db.Offset(offset).Limit(req.PageSize).
+		Order(clause.Expr{SQL: "id = ? DESC, updated_at DESC", Vars: []interface{}{activeBabyID}}).
+		Find(&profiles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := userFamilyRoles(userID)
 	if err != nil {
 		return nil, err
 	}
 
 	// 转换响应
 	var list []response.BabyProfileResponse
-	for _, profile := range profiles {
-		var resp response.BabyProfileResponse
-		resp.FromBabyProfile(&profile)
-		list = append(list, resp)
+	for i := range profiles {
+		role := roles[profiles[i].FamilyID]
+		if profiles[i].FamilyID == 0 {
+			role = familyRoleOwner
+		}
+		list = append(list, buildBabyProfileResponse(&profiles[i], role, activeBabyID))
 	}
 
 	return &response.BabyProfileListResponse{
@@ -98,80 +126,118 @@ func (s *BabyProfileService) GetBabyProfileList(userID uint, req *request.BabyPr
 	}, nil
 }
 
-// UpdateBabyProfile 更新宝宝档案
+// UpdateBabyProfile 更新宝宝档案，家长及以上角色可编辑，移到其他家庭需创建者权限
 func (s *BabyProfileService) UpdateBabyProfile(userID uint, req *request.UpdateBabyProfileRequest) error {
-	// 检查宝宝档案是否存在
-	var profile baby.BabyProfile
-	err := global.GVA_DB.Where("id = ? AND user_id = ?", req.ID, userID).First(&profile).Error
+	profile, access, err := authorizeBaby(req.ID, userID, babyAccessManage)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("宝宝档案不存在")
-		}
 		return err
 	}
 
-	// 如果设置为活跃宝宝，先将其他宝宝设置为非活跃
-	if req.IsActive && !profile.IsActive {
-		if err := s.deactivateOtherBabies(userID); err != nil {
+	// 更新档案信息
+	updates := map[string]interface{}{
+		"name":              req.Name,
+		"gender":            req.Gender,
+		"birthday":          req.Birthday,
+		"gestational_weeks": req.GestationalWeeks,
+		"gestational_days":  req.GestationalDays,
+		"avatar":            req.Avatar,
+		"weight":            req.Weight,
+		"height":            req.Height,
+		"blood_type":        req.BloodType,
+		"remark":            req.Remark,
+	}
+	if req.FamilyID > 0 && req.FamilyID != profile.FamilyID {
+		if access < babyAccessOwner {
+			return errors.New("只有创建者可以将宝宝移到其他家庭")
+		}
+		if _, _, err := authorizeFamily(req.FamilyID, userID, babyAccessManage); err != nil {
 			return err
 		}
+		updates["family_id"] = req.FamilyID
 	}
 
-	// 更新档案信息
-	updates := map[string]interface{}{
-		"name":       req.Name,
-		"gender":     req.Gender,
-		"birthday":   req.Birthday,
-		"avatar":     req.Avatar,
-		"weight":     req.Weight,
-		"height":     req.Height,
-		"blood_type": req.BloodType,
-		"remark":     req.Remark,
-		"is_active":  req.IsActive,
-	}
-
-	return global.GVA_DB.Model(&profile).Updates(updates).Error
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(profile).Updates(updates).Error; err != nil {
+			return err
+		}
+		if req.IsActive {
+			return setActiveBaby(tx, userID, profile.ID)
+		}
+		// 取消当前宝宝
+		return tx.Unscoped().Where("user_id = ? AND baby_id = ?", userID, profile.ID).Delete(&baby.UserActiveBaby{}).Error
+	})
 }
 
-// DeleteBabyProfile 删除宝宝档案
+// DeleteBabyProfile 删除宝宝档案，仅创建者可操作
 func (s *BabyProfileService) DeleteBabyProfile(id uint, userID uint) error {
-	// 检查宝宝档案是否存在
-	var profile baby.BabyProfile
-	err := global.GVA_DB.Where("id = ? AND user_id = ?", id, userID).First(&profile).Error
+	profile, _, err := authorizeBaby(id, userID, babyAccessOwner)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("宝宝档案不存在")
-		}
 		return err
 	}
 
-	return global.GVA_DB.Delete(&profile).Error
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("baby_id = ?", profile.ID).Delete(&baby.UserActiveBaby{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(profile).Error
+	})
 }
 
-// SetActiveBaby 设置活跃宝宝
+// SetActiveBaby 设置当前用户的活跃宝宝，只影响当前用户
 func (s *BabyProfileService) SetActiveBaby(id uint, userID uint) error {
-	// 检查宝宝档案是否存在
-	var profile baby.BabyProfile
-	err := global.GVA_DB.Where("id = ? AND user_id = ?", id, userID).First(&profile).Error
+	profile, _, err := authorizeBaby(id, userID, babyAccessRead)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("宝宝档案不存在")
-		}
 		return err
 	}
+	return setActiveBaby(global.GVA_DB, userID, profile.ID)
+}
 
-	// 先将其他宝宝设置为非活跃
-	if err := s.deactivateOtherBabies(userID); err != nil {
-		return err
+// setActiveBaby 记录用户选择的当前宝宝，每个用户只保留一条
+func setActiveBaby(db *gorm.DB, userID uint, babyID uint) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"baby_id", "updated_at"}),
+	}).Create(&baby.UserActiveBaby{UserID: userID, BabyID: babyID}).Error
+}
+
+// getActiveBaby 用户选择的当前宝宝，未选择或已无权访问时取最近更新的可访问宝宝，都没有时返回gorm.ErrRecordNotFound
+func getActiveBaby(userID uint) (*baby.BabyProfile, error) {
+	if activeBabyID := getActiveBabyID(userID); activeBabyID > 0 {
+		if profile, _, err := authorizeBaby(activeBabyID, userID, babyAccessRead); err == nil {
+			return profile, nil
+		}
+	}
+
+	var profile baby.BabyProfile
+	err := global.GVA_DB.Where("id IN (?)", accessibleBabyIDs(userID, babyAccessRead)).
+		Order("updated_at DESC").First(&profile).Error
+	if err != nil {
+		return nil, err
 	}
+	return &profile, nil
+}
 
-	// 设置当前宝宝为活跃
-	return global.GVA_DB.Model(&profile).Update("is_active", true).Error
+// getActiveBabyID 用户选择的当前宝宝ID，未选择时返回0
+func getActiveBabyID(userID uint) uint {
+	var active baby.UserActiveBaby
+	if err := global.GVA_DB.Where("user_id = ?", userID).First(&active).Error; err != nil {
+		return 0
+	}
+	return active.BabyID
 }
 
-// deactivateOtherBabies 将其他宝宝设置为非活跃
-func (s *BabyProfileService) deactivateOtherBabies(userID uint) error {
-	return global.GVA_DB.Model(&baby.BabyProfile{}).
-		Where("user_id = ? AND is_active = ?", userID, true).
-		Update("is_active", false).Error
-}
\ No newline at end of file
+// buildBabyProfileResponse 组装宝宝档案响应，附带当前用户的家庭角色及是否为其当前宝宝
+func buildBabyProfileResponse(profile *baby.BabyProfile, role int, activeBabyID uint) response.BabyProfileResponse {
+	var resp response.BabyProfileResponse
+	resp.FromBabyProfile(profile)
+	resp.IsActive = profile.ID == activeBabyID
+	resp.Role = role
+	resp.RoleText = (&baby.FamilyMember{Role: role}).GetRoleText()
+	return resp
+}
+
+// getUserBaby 获取当前用户可添加记录的宝宝档案，供其他业务校验宝宝归属，家庭中看护人及以上角色可用
+func getUserBaby(babyID uint, userID uint) (*baby.BabyProfile, error) {
+	profile, _, err := authorizeBaby(babyID, userID, babyAccessRecord)
+	return profile, err
+}
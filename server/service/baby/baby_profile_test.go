@@ -0,0 +1,63 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+)
+
+func TestCalculateAge(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.Local) }
+
+	tests := []struct {
+		birthday, at      time.Time
+		days, weeks       int
+		months, monthDays int
+	}{
+		{date(2024, 1, 31), date(2024, 2, 1), 1, 0, 0, 1},
+		{date(2024, 1, 31), date(2024, 2, 28), 28, 4, 0, 28},
+		{date(2024, 1, 31), date(2024, 2, 29), 29, 4, 1, 0}, // 月末出生，次月月末满月
+		{date(2024, 1, 31), date(2024, 3, 30), 59, 8, 1, 30},
+		{date(2024, 1, 31), date(2024, 3, 31), 60, 8, 2, 0},
+		{date(2023, 5, 15), date(2024, 5, 14), 365, 52, 11, 29},
+		{date(2023, 5, 15), date(2024, 5, 15), 366, 52, 12, 0},
+		{date(2024, 5, 15), date(2024, 5, 1), 0, 0, 0, 0}, // 出生前
+	}
+	for _, tt := range tests {
+		age := baby.CalculateAge(tt.birthday, tt.at)
+		if age.Days != tt.days || age.Weeks != tt.weeks || age.Months != tt.months || age.MonthDays != tt.monthDays {
+			t.Errorf("CalculateAge(%s, %s) = %+v", tt.birthday.Format("2006-01-02"), tt.at.Format("2006-01-02"), age)
+		}
+	}
+}
+
+func TestCorrectedAge(t *testing.T) {
+	birthday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	profile := &baby.BabyProfile{Birthday: birthday, GestationalWeeks: 30, GestationalDays: 3}
+	if !profile.IsPremature() || profile.PrematureDays() != 67 {
+		t.Fatalf("早产天数 = %d", profile.PrematureDays())
+	}
+
+	at := time.Date(2024, 7, 1, 0, 0, 0, 0, time.Local)
+	if age := profile.GetAgeAt(at); age.Months != 6 {
+		t.Errorf("实际月龄 = %d", age.Months)
+	}
+	if age := profile.GetDevelopmentalAgeAt(at); age.Months != 3 || age.Days != 182-67 {
+		t.Errorf("矫正年龄 = %+v", age)
+	}
+
+	// 实际月龄满24个月后不再矫正
+	later := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	if profile.UsesCorrectedAge(later) || profile.GetDevelopmentalAgeAt(later).Months != 24 {
+		t.Errorf("24月龄后应使用实际年龄: %+v", profile.GetDevelopmentalAgeAt(later))
+	}
+
+	// 足月或未填写胎龄时与实际年龄一致
+	term := &baby.BabyProfile{Birthday: birthday, GestationalWeeks: 38}
+	if term.IsPremature() || term.GetDevelopmentalAgeAt(at) != term.GetAgeAt(at) {
+		t.Error("足月宝宝不应使用矫正年龄")
+	}
+	if baby.AgeRangeOfMonths(profile.GetDevelopmentalAgeAt(at).Months) != "0-6月" {
+		t.Error("早产儿年龄段应按矫正年龄计算")
+	}
+}
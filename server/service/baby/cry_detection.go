@@ -0,0 +1,380 @@
+package baby
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+type CryDetectionService struct{}
+
+// 哭声识别反馈，对应CryDetection.UserFeedback
+const (
+	cryFeedbackAccurate   = 1 // 准确
+	cryFeedbackInaccurate = 2 // 不准确
+)
+
+// CreateCryDetection 记录小程序端上报的哭声事件
+func (s *CryDetectionService) CreateCryDetection(userID uint, req *request.CreateCryDetectionRequest) (*response.CryDetectionResponse, error) {
+	babyProfile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.DeviceID > 0 {
+		if _, err := authorizeDevice(req.DeviceID, userID, deviceAccessRead); err != nil {
+			return nil, err
+		}
+	}
+	if req.DetectedAt.After(time.Now().Add(deviceSignWindow)) {
+		return nil, errors.New("检测时间不能晚于当前时间")
+	}
+
+	detection := &baby.CryDetection{
+		UserID:     userID,
+		BabyID:     babyProfile.ID,
+		DeviceID:   req.DeviceID,
+		DetectedAt: req.DetectedAt,
+		Duration:   req.Duration,
+		Intensity:  req.Intensity,
+		CryType:    req.CryType,
+		Confidence: req.Confidence,
+		AudioURL:   req.AudioURL,
+		VideoURL:   req.VideoURL,
+	}
+	if err := global.GVA_DB.Create(detection).Error; err != nil {
+		return nil, err
+	}
+	logAlertError(new(SmartAlertService).EvaluateCryDetection(detection))
+
+	var resp response.CryDetectionResponse
+	resp.FromCryDetection(detection, babyProfile.Name)
+	return &resp, nil
+}
+
+// RecordDeviceCryDetection 记录设备上报的哭声事件，事件归属设备主人
+func (s *CryDetectionService) RecordDeviceCryDetection(device *baby.Device, req *request.DeviceCryDetectionRequest) (*response.CryDetectionResponse, error) {
+	babyProfile, err := deviceBaby(device, req.BabyID)
+	if err != nil {
+		return nil, err
+	}
+
+	detectedAt, err := sampleTime(req.Timestamp, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	detection := &baby.CryDetection{
+		UserID:     device.UserID,
+		BabyID:     babyProfile.ID,
+		DeviceID:   device.ID,
+		DetectedAt: detectedAt,
+		Duration:   req.Duration,
+		Intensity:  req.Intensity,
+		CryType:    req.CryType,
+		Confidence: req.Confidence,
+		AudioURL:   req.AudioURL,
+		VideoURL:   req.VideoURL,
+	}
+	if err := global.GVA_DB.Create(detection).Error; err != nil {
+		return nil, err
+	}
+	logAlertError(new(SmartAlertService).EvaluateCryDetection(detection))
+
+	var resp response.CryDetectionResponse
+	resp.FromCryDetection(detection, babyProfile.Name)
+	return &resp, nil
+}
+
+// GetCryDetection 获取哭声事件详情
+func (s *CryDetectionService) GetCryDetection(id uint, userID uint) (*response.CryDetectionResponse, error) {
+	detection, err := getAccessibleCryDetection(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取宝宝姓名
+	var babyProfile baby.BabyProfile
+	global.GVA_DB.Where("id = ?", detection.BabyID).First(&babyProfile)
+
+	var resp response.CryDetectionResponse
+	resp.FromCryDetection(detection, babyProfile.Name)
+	return &resp, nil
+}
+
+// GetCryDetectionList 获取哭声事件列表
+func (s *CryDetectionService) GetCryDetectionList(userID uint, req *request.CryDetectionSearch) (*response.CryDetectionListResponse, error) {
+	db := s.searchScope(userID, req.BabyID, req.DeviceID, req.StartDate, req.EndDate)
+
+	// 搜索条件
+	if req.CryType > 0 {
+		db = db.Where("cry_type = ?", req.CryType)
+	}
+	if req.IsProcessed != nil {
+		db = db.Where("is_processed = ?", *req.IsProcessed)
+	}
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var detections []baby.CryDetection
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order("detected_at DESC").Find(&detections).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取所有相关的宝宝信息
+	var babyIDs []uint
+	for _, detection := range detections {
+		babyIDs = append(babyIDs, detection.BabyID)
+	}
+
+	var babies []baby.BabyProfile
+	if len(babyIDs) > 0 {
+		global.GVA_DB.Where("id IN ?", babyIDs).Find(&babies)
+	}
+
+	babyNameMap := make(map[uint]string)
+	for _, babyProfile := range babies {
+		babyNameMap[babyProfile.ID] = babyProfile.Name
+	}
+
+	// 转换响应
+	list := make([]response.CryDetectionResponse, 0, len(detections))
+	for _, detection := range detections {
+		var resp response.CryDetectionResponse
+		resp.FromCryDetection(&detection, babyNameMap[detection.BabyID])
+		list = append(list, resp)
+	}
+
+	return &response.CryDetectionListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// AcknowledgeCryDetections 批量确认哭声事件，返回本次确认的条数
+func (s *CryDetectionService) AcknowledgeCryDetections(userID uint, req *request.AcknowledgeCryRequest) (int64, error) {
+	result := global.GVA_DB.Model(&baby.CryDetection{}).
+		Scopes(accessibleCryDetections(userID)).
+		Where("id IN ? AND is_processed = ?", req.IDs, false).
+		Update("is_processed", true)
+	return result.RowsAffected, result.Error
+}
+
+// SubmitFeedback 反馈哭声识别是否准确，反馈后事件视为已处理，可重复提交以修改反馈
+func (s *CryDetectionService) SubmitFeedback(userID uint, req *request.CryFeedbackRequest) error {
+	detection, err := getAccessibleCryDetection(req.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	return global.GVA_DB.Model(detection).Updates(map[string]interface{}{
+		"user_feedback": req.Feedback,
+		"is_processed":  true,
+	}).Error
+}
+
+// GetAccuracyReport 统计哭声识别准确率，按设备、哭声类型和周汇总
+func (s *CryDetectionService) GetAccuracyReport(userID uint, req *request.CryAccuracySearch) (*response.CryAccuracyReport, error) {
+	var detections []baby.CryDetection
+	err := s.searchScope(userID, req.BabyID, req.DeviceID, req.StartDate, req.EndDate).
+		Select("device_id", "cry_type", "confidence", "user_feedback", "detected_at").
+		Order("detected_at ASC").Find(&detections).Error
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildCryAccuracyReport(detections)
+
+	// 补充设备名称
+	var deviceIDs []uint
+	for _, item := range report.ByDevice {
+		if item.DeviceID > 0 {
+			deviceIDs = append(deviceIDs, item.DeviceID)
+		}
+	}
+	if len(deviceIDs) > 0 {
+		var devices []baby.Device
+		global.GVA_DB.Unscoped().Where("id IN ?", deviceIDs).Find(&devices)
+		deviceNameMap := make(map[uint]string)
+		for _, device := range devices {
+			deviceNameMap[device.ID] = device.DeviceName
+		}
+		for i := range report.ByDevice {
+			report.ByDevice[i].DeviceName = deviceNameMap[report.ByDevice[i].DeviceID]
+		}
+	}
+
+	return report, nil
+}
+
+// searchScope 构造当前用户可见哭声记录的公共查询条件
+func (s *CryDetectionService) searchScope(userID, babyID, deviceID uint, startDate, endDate string) *gorm.DB {
+	db := global.GVA_DB.Model(&baby.CryDetection{}).Scopes(accessibleCryDetections(userID))
+	if babyID > 0 {
+		db = db.Where("baby_id = ?", babyID)
+	}
+	if deviceID > 0 {
+		db = db.Where("device_id = ?", deviceID)
+	}
+	if startDate != "" {
+		db = db.Where("detected_at >= ?", startDate)
+	}
+	if endDate != "" {
+		// 仅传日期时包含结束当天的全部记录
+		if end, err := time.ParseInLocation("2006-01-02", endDate, time.Local); err == nil {
+			db = db.Where("detected_at < ?", end.AddDate(0, 0, 1))
+		} else {
+			db = db.Where("detected_at <= ?", endDate)
+		}
+	}
+	return db
+}
+
+// accessibleCryDetections 用户可见的哭声事件：自己上报的，自己绑定或被分享的设备上报的，以及所在家庭宝宝的
+func accessibleCryDetections(userID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(user_id = ? OR (device_id > 0 AND device_id IN (?)) OR (baby_id > 0 AND baby_id IN (?)))",
+			userID, accessibleDeviceIDs(userID), accessibleBabyIDs(userID, babyAccessRead))
+	}
+}
+
+// getAccessibleCryDetection 获取用户可见的哭声事件，他人的事件需有权查看上报设备或所属宝宝
+func getAccessibleCryDetection(id uint, userID uint) (*baby.CryDetection, error) {
+	var detection baby.CryDetection
+	err := global.GVA_DB.Where("id = ?", id).First(&detection).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("哭声记录不存在")
+		}
+		return nil, err
+	}
+	if detection.UserID == userID {
+		return &detection, nil
+	}
+	if detection.DeviceID > 0 {
+		if _, err := authorizeDevice(detection.DeviceID, userID, deviceAccessRead); err == nil {
+			return &detection, nil
+		}
+	}
+	if detection.BabyID > 0 {
+		if _, _, err := authorizeBaby(detection.BabyID, userID, babyAccessRead); err == nil {
+			return &detection, nil
+		}
+	}
+	return nil, errors.New("哭声记录不存在")
+}
+
+// cryAccuracyCounter 准确率累加器
+type cryAccuracyCounter struct {
+	total, accurate, inaccurate int
+	confidenceSum               float64
+}
+
+func (c *cryAccuracyCounter) add(detection *baby.CryDetection) {
+	c.total++
+	c.confidenceSum += detection.Confidence
+	switch detection.UserFeedback {
+	case cryFeedbackAccurate:
+		c.accurate++
+	case cryFeedbackInaccurate:
+		c.inaccurate++
+	}
+}
+
+func (c *cryAccuracyCounter) stat() response.CryAccuracyStat {
+	stat := response.CryAccuracyStat{
+		TotalCount:      c.total,
+		FeedbackCount:   c.accurate + c.inaccurate,
+		AccurateCount:   c.accurate,
+		InaccurateCount: c.inaccurate,
+	}
+	if stat.FeedbackCount > 0 {
+		stat.Accuracy = roundRatio(float64(c.accurate) / float64(stat.FeedbackCount))
+	}
+	if c.total > 0 {
+		stat.FeedbackRate = roundRatio(float64(stat.FeedbackCount) / float64(c.total))
+		stat.AverageConfidence = roundRatio(c.confidenceSum / float64(c.total))
+	}
+	return stat
+}
+
+// buildCryAccuracyReport 汇总哭声记录的识别准确率
+func buildCryAccuracyReport(detections []baby.CryDetection) *response.CryAccuracyReport {
+	var overall cryAccuracyCounter
+	byDevice := make(map[uint]*cryAccuracyCounter)
+	byType := make(map[int]*cryAccuracyCounter)
+	byWeek := make(map[string]*cryAccuracyCounter)
+
+	for i := range detections {
+		detection := &detections[i]
+		overall.add(detection)
+
+		if byDevice[detection.DeviceID] == nil {
+			byDevice[detection.DeviceID] = &cryAccuracyCounter{}
+		}
+		byDevice[detection.DeviceID].add(detection)
+
+		if byType[detection.CryType] == nil {
+			byType[detection.CryType] = &cryAccuracyCounter{}
+		}
+		byType[detection.CryType].add(detection)
+
+		week := weekStart(detection.DetectedAt).Format("2006-01-02")
+		if byWeek[week] == nil {
+			byWeek[week] = &cryAccuracyCounter{}
+		}
+		byWeek[week].add(detection)
+	}
+
+	report := &response.CryAccuracyReport{
+		Overall:   overall.stat(),
+		ByDevice:  make([]response.DeviceCryAccuracy, 0, len(byDevice)),
+		ByCryType: make([]response.CryTypeAccuracy, 0, len(byType)),
+		Weekly:    make([]response.WeeklyCryAccuracy, 0, len(byWeek)),
+	}
+	for deviceID, counter := range byDevice {
+		report.ByDevice = append(report.ByDevice, response.DeviceCryAccuracy{DeviceID: deviceID, CryAccuracyStat: counter.stat()})
+	}
+	for cryType, counter := range byType {
+		typed := baby.CryDetection{CryType: cryType}
+		report.ByCryType = append(report.ByCryType, response.CryTypeAccuracy{
+			CryType:         cryType,
+			CryTypeText:     typed.GetCryTypeText(),
+			CryAccuracyStat: counter.stat(),
+		})
+	}
+	for week, counter := range byWeek {
+		report.Weekly = append(report.Weekly, response.WeeklyCryAccuracy{WeekStart: week, CryAccuracyStat: counter.stat()})
+	}
+
+	sort.Slice(report.ByDevice, func(i, j int) bool { return report.ByDevice[i].DeviceID < report.ByDevice[j].DeviceID })
+	sort.Slice(report.ByCryType, func(i, j int) bool { return report.ByCryType[i].CryType < report.ByCryType[j].CryType })
+	sort.Slice(report.Weekly, func(i, j int) bool { return report.Weekly[i].WeekStart < report.Weekly[j].WeekStart })
+	return report
+}
+
+// weekStart 返回t所在周的周一零点
+func weekStart(t time.Time) time.Time {
+	t = t.In(time.Local)
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.Local)
+}
+
+// roundRatio 比例保留四位小数
+func roundRatio(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
@@ -0,0 +1,75 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	commonRequest "baby_admin/server/model/common/request"
+)
+
+func TestBuildCryAccuracyReport(t *testing.T) {
+	monday := time.Date(2024, 5, 6, 10, 0, 0, 0, time.Local)
+	detections := []baby.CryDetection{
+		{DeviceID: 1, CryType: 1, Confidence: 0.9, UserFeedback: cryFeedbackAccurate, DetectedAt: monday},
+		{DeviceID: 1, CryType: 1, Confidence: 0.7, UserFeedback: cryFeedbackInaccurate, DetectedAt: monday.AddDate(0, 0, 6)},
+		{DeviceID: 1, CryType: 2, Confidence: 0.8, DetectedAt: monday.AddDate(0, 0, 7)},
+		{DeviceID: 2, CryType: 2, Confidence: 0.6, UserFeedback: cryFeedbackAccurate, DetectedAt: monday.AddDate(0, 0, 8)},
+	}
+
+	report := buildCryAccuracyReport(detections)
+
+	overall := report.Overall
+	if overall.TotalCount != 4 || overall.FeedbackCount != 3 || overall.AccurateCount != 2 || overall.InaccurateCount != 1 {
+		t.Fatalf("总体统计错误: %+v", overall)
+	}
+	if overall.Accuracy != 0.6667 || overall.FeedbackRate != 0.75 || overall.AverageConfidence != 0.75 {
+		t.Errorf("总体比例错误: %+v", overall)
+	}
+
+	if len(report.ByDevice) != 2 || report.ByDevice[0].DeviceID != 1 || report.ByDevice[0].Accuracy != 0.5 {
+		t.Errorf("按设备统计错误: %+v", report.ByDevice)
+	}
+	if len(report.ByCryType) != 2 || report.ByCryType[1].CryTypeText != "困倦" || report.ByCryType[1].Accuracy != 1 {
+		t.Errorf("按类型统计错误: %+v", report.ByCryType)
+	}
+	if len(report.Weekly) != 2 || report.Weekly[0].WeekStart != "2024-05-06" || report.Weekly[0].TotalCount != 2 {
+		t.Errorf("按周统计错误: %+v", report.Weekly)
+	}
+}
+
+func TestCryDetectionFamilyAccess(t *testing.T) {
+	db := setupTestDB(t, &baby.FamilyGroup{}, &baby.FamilyMember{}, &baby.BabyProfile{}, &baby.Device{}, &baby.DeviceShare{}, &baby.CryDetection{})
+	profile := seedFamilyBaby(t, db)
+	// 看护人手动记录的宝宝哭声
+	detection := baby.CryDetection{UserID: 3, BabyID: profile.ID, CryType: 1, Intensity: 5, DetectedAt: time.Now()}
+	if err := db.Create(&detection).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	service := new(CryDetectionService)
+	tests := []struct {
+		name   string
+		userID uint
+		found  bool
+	}{
+		{"创建者", 1, true},
+		{"观察者", 4, true},
+		{"家庭外用户", 9, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetCryDetection(detection.ID, tt.userID)
+			if (err == nil) != tt.found {
+				t.Errorf("GetCryDetection err = %v, want found %v", err, tt.found)
+			}
+			list, err := service.GetCryDetectionList(tt.userID, &request.CryDetectionSearch{PageInfo: commonRequest.PageInfo{Page: 1, PageSize: 10}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (list.Total == 1) != tt.found {
+				t.Errorf("GetCryDetectionList total = %d, want found %v", list.Total, tt.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupTestDB 使用内存SQLite替换全局数据库并建表，测试结束后恢复
+func setupTestDB(t *testing.T, models ...interface{}) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	// 内存库按连接隔离，限定单连接保证所有查询使用同一个库
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("sqlite conn: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	previous := global.GVA_DB
+	global.GVA_DB = db
+	t.Cleanup(func() {
+		global.GVA_DB = previous
+		sqlDB.Close()
+	})
+	return db
+}
+
+// seedFamilyBaby 创建一个家庭宝宝，用户1-4依次为创建者、家长、看护人、观察者
+func seedFamilyBaby(t *testing.T, db *gorm.DB) *baby.BabyProfile {
+	t.Helper()
+	family := baby.FamilyGroup{Name: "小满家", OwnerID: 1, InviteCode: "TESTCODE"}
+	if err := db.Create(&family).Error; err != nil {
+		t.Fatal(err)
+	}
+	roles := []int{familyRoleOwner, familyRoleParent, familyRoleCaregiver, familyRoleViewer}
+	for i, role := range roles {
+		member := baby.FamilyMember{FamilyID: family.ID, UserID: uint(i + 1), Role: role, Status: familyMemberApproved}
+		if err := db.Create(&member).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	profile := baby.BabyProfile{UserID: 1, FamilyID: family.ID, Name: "小满", Birthday: time.Now().AddDate(0, -6, 0)}
+	if err := db.Create(&profile).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &profile
+}
@@ -0,0 +1,342 @@
+package baby
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+type DeviceService struct{}
+
+// AddDevice 绑定设备
+func (s *DeviceService) AddDevice(userID uint, req *request.AddDeviceRequest) error {
+	// 同一产品下的设备密钥唯一标识一台设备
+	var existing baby.Device
+	err := global.GVA_DB.Where("product_id = ? AND device_secret = ?", req.ProductID, req.DeviceSecret).First(&existing).Error
+	if err == nil {
+		if existing.UserID == userID {
+			return errors.New("设备已绑定，请勿重复添加")
+		}
+		return errors.New("设备已被其他用户绑定")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	device := &baby.Device{
+		UserID:       userID,
+		DeviceName:   req.DeviceName,
+		DeviceType:   req.DeviceType,
+		ProductID:    req.ProductID,
+		DeviceSecret: req.DeviceSecret,
+		SerialNumber: req.SerialNumber,
+		MacAddress:   req.MacAddress,
+		Location:     req.Location,
+		Description:  req.Description,
+		Status:       2, // 首次上报心跳前视为离线
+		IsActive:     true,
+		ActivatedAt:  time.Now(),
+	}
+
+	return global.GVA_DB.Create(device).Error
+}
+
+// GetDevice 获取设备详情
+func (s *DeviceService) GetDevice(id uint, userID uint) (*response.DeviceResponse, error) {
+	device, err := authorizeDevice(id, userID, deviceAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp response.DeviceResponse
+	resp.FromDevice(device)
+	resp.IsOwner = device.UserID == userID
+	if !resp.IsOwner {
+		if share, err := getActiveShare(device.ID, userID); err == nil && share != nil {
+			resp.ShareType = share.ShareType
+		}
+	}
+
+	var shareCount int64
+	global.GVA_DB.Model(&baby.DeviceShare{}).Where("device_id = ? AND is_active = ?", device.ID, true).Count(&shareCount)
+	resp.ShareCount = int(shareCount)
+
+	var commandCount int64
+	global.GVA_DB.Model(&baby.DeviceCommand{}).Where("device_id = ?", device.ID).Count(&commandCount)
+	resp.CommandCount = int(commandCount)
+
+	return &resp, nil
+}
+
+// GetDeviceList 获取设备列表
+func (s *DeviceService) GetDeviceList(userID uint, req *request.DeviceSearch) (*response.DeviceListResponse, error) {
+	// 自己绑定的设备以及他人分享给自己的设备
+	db := global.GVA_DB.Model(&baby.Device{}).Where("id IN (?)", accessibleDeviceIDs(userID))
+
+	// 搜索条件
+	if req.DeviceType > 0 {
+		db = db.Where("device_type = ?", req.DeviceType)
+	}
+	if req.Status > 0 {
+		db = db.Where("status = ?", req.Status)
+	}
+	if req.Location != "" {
+		db = db.Where("location LIKE ?", "%"+req.Location+"%")
+	}
+	if req.Keyword != "" {
+		db = db.Where("(device_name LIKE ? OR serial_number LIKE ? OR description LIKE ?)",
+			"%"+req.Keyword+"%", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
+	}
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var devices []baby.Device
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order("status ASC, updated_at DESC").Find(&devices).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取分享给当前用户的权限类型
+	var sharedIDs []uint
+	for _, device := range devices {
+		if device.UserID != userID {
+			sharedIDs = append(sharedIDs, device.ID)
+		}
+	}
+
+	shareTypeMap := make(map[uint]int)
+	if len(sharedIDs) > 0 {
+		var shares []baby.DeviceShare
+		global.GVA_DB.Scopes(activeDeviceShares).Where("shared_user_id = ? AND device_id IN ?", userID, sharedIDs).Find(&shares)
+		for _, share := range shares {
+			if share.ShareType > shareTypeMap[share.DeviceID] {
+				shareTypeMap[share.DeviceID] = share.ShareType
+			}
+		}
+	}
+
+	// 转换响应
+	list := make([]response.DeviceResponse, 0, len(devices))
+	for _, device := range devices {
+		var resp response.DeviceResponse
+		resp.FromDevice(&device)
+		resp.IsOwner = device.UserID == userID
+		resp.ShareType = shareTypeMap[device.ID]
+		list = append(list, resp)
+	}
+
+	return &response.DeviceListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// UpdateDevice 更新设备信息
+func (s *DeviceService) UpdateDevice(userID uint, req *request.UpdateDeviceRequest) error {
+	device, err := authorizeDevice(req.ID, userID, deviceAccessManage)
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"device_name": req.DeviceName,
+		"location":    req.Location,
+		"description": req.Description,
+		"is_active":   req.IsActive,
+	}
+
+	return global.GVA_DB.Model(device).Updates(updates).Error
+}
+
+// UnbindDevice 解绑设备
+func (s *DeviceService) UnbindDevice(id uint, userID uint) error {
+	device, err := authorizeDevice(id, userID, deviceAccessOwner)
+	if err != nil {
+		return err
+	}
+
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		// 清理设备配置和分享关系，历史数据保留
+		if err := tx.Where("device_id = ?", device.ID).Delete(&baby.DeviceConfig{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("device_id = ?", device.ID).Delete(&baby.DeviceShare{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(device).Error
+	})
+}
+
+// GetDeviceConfigs 获取设备配置列表
+func (s *DeviceService) GetDeviceConfigs(deviceID uint, userID uint) ([]response.DeviceConfigResponse, error) {
+	device, err := authorizeDevice(deviceID, userID, deviceAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []baby.DeviceConfig
+	err = global.GVA_DB.Where("device_id = ?", device.ID).Order("config_key ASC").Find(&configs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.DeviceConfigResponse, 0, len(configs))
+	for _, config := range configs {
+		var resp response.DeviceConfigResponse
+		resp.FromDeviceConfig(&config)
+		result = append(result, resp)
+	}
+
+	return result, nil
+}
+
+// UpdateDeviceConfigs 批量写入设备配置，不存在的配置项会新建
+func (s *DeviceService) UpdateDeviceConfigs(userID uint, req *request.UpdateDeviceConfigRequest) error {
+	device, err := authorizeDevice(req.DeviceID, userID, deviceAccessManage)
+	if err != nil {
+		return err
+	}
+
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, item := range req.Configs {
+			key := strings.TrimSpace(item.ConfigKey)
+			if key == "" {
+				return errors.New("配置键不能为空")
+			}
+
+			var config baby.DeviceConfig
+			err := tx.Where("device_id = ? AND config_key = ?", device.ID, key).First(&config).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				config = baby.DeviceConfig{
+					DeviceID:     device.ID,
+					ConfigKey:    key,
+					ConfigValue:  item.ConfigValue,
+					ValueType:    "string",
+					IsEditable:   true,
+					DefaultValue: item.ConfigValue,
+				}
+				if err := tx.Create(&config).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if !config.IsEditable {
+				return errors.New("配置项 " + key + " 不允许修改")
+			}
+			if err := validateConfigValue(config.ValueType, item.ConfigValue); err != nil {
+				return errors.New("配置项 " + key + " " + err.Error())
+			}
+			if err := tx.Model(&config).Update("config_value", item.ConfigValue).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// 设备访问级别，与DeviceShare.ShareType对应，所有者拥有最高级别
+const (
+	deviceAccessRead    = 1 // 只读：查看设备、配置及监控数据
+	deviceAccessControl = 2 // 控制：下发设备命令
+	deviceAccessManage  = 3 // 管理：修改设备信息和配置
+	deviceAccessOwner   = 4 // 所有者：分享、解绑
+)
+
+// activeDeviceShares 已接受、未停用且未过期的分享
+func activeDeviceShares(db *gorm.DB) *gorm.DB {
+	return db.Where("is_active = ? AND accepted_at IS NOT NULL AND (expired_at IS NULL OR expired_at > ?)", true, time.Now())
+}
+
+// authorizeDevice 校验用户对设备的访问权限：所有者直接放行，其他用户需持有级别足够的有效分享
+func authorizeDevice(id uint, userID uint, level int) (*baby.Device, error) {
+	var device baby.Device
+	err := global.GVA_DB.Where("id = ?", id).First(&device).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("设备不存在")
+		}
+		return nil, err
+	}
+	if device.UserID == userID {
+		return &device, nil
+	}
+
+	share, err := getActiveShare(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if share == nil {
+		return nil, errors.New("设备不存在")
+	}
+	if level >= deviceAccessOwner {
+		return nil, errors.New("仅设备所有者可执行此操作")
+	}
+	if share.ShareType < level {
+		return nil, errors.New("分享权限不足")
+	}
+	return &device, nil
+}
+
+// accessibleDeviceIDs 用户可访问的设备ID子查询：自己绑定的设备及有效分享的设备
+func accessibleDeviceIDs(userID uint) *gorm.DB {
+	sharedDeviceIDs := global.GVA_DB.Model(&baby.DeviceShare{}).Scopes(activeDeviceShares).
+		Where("shared_user_id = ?", userID).Select("device_id")
+	return global.GVA_DB.Model(&baby.Device{}).Where("user_id = ? OR id IN (?)", userID, sharedDeviceIDs).Select("id")
+}
+
+// getActiveShare 获取用户对设备的有效分享，不存在时返回nil
+func getActiveShare(deviceID uint, userID uint) (*baby.DeviceShare, error) {
+	var share baby.DeviceShare
+	err := global.GVA_DB.Scopes(activeDeviceShares).
+		Where("device_id = ? AND shared_user_id = ?", deviceID, userID).
+		Order("share_type DESC").First(&share).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &share, nil
+}
+
+// validateConfigValue 按值类型校验配置值
+func validateConfigValue(valueType string, value string) error {
+	switch valueType {
+	case "int":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return errors.New("的值必须为整数")
+		}
+	case "float":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return errors.New("的值必须为数字")
+		}
+	case "bool":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return errors.New("的值必须为布尔值")
+		}
+	case "json":
+		if !json.Valid([]byte(value)) {
+			return errors.New("的值必须为合法的JSON")
+		}
+	}
+	return nil
+}
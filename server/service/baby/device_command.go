@@ -0,0 +1,399 @@
+package baby
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+type DeviceCommandService struct{}
+
+// 命令执行状态，对应DeviceCommand.Status
+const (
+	commandStatusPending   = 1 // 待执行
+	commandStatusExecuting = 2 // 执行中
+	commandStatusSuccess   = 3 // 成功
+	commandStatusFailure   = 4 // 失败
+)
+
+const (
+	deviceCommandTimeout     = 60 * time.Second // 命令排队或执行超过该时长视为超时
+	deviceCommandWorkers     = 4                // 并发下发的协程数
+	deviceCommandQueueLength = 256              // 内存队列长度，溢出的命令由定时任务补发
+)
+
+var (
+	deviceCommandQueue = make(chan uint, deviceCommandQueueLength)
+	dispatcherOnce     sync.Once
+)
+
+// SendCommand 创建设备命令并加入下发队列
+func (s *DeviceCommandService) SendCommand(userID uint, req *request.DeviceCommandRequest) (*response.DeviceCommandResponse, error) {
+	parameters, err := validateCommandParameters(req.CommandType, req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := authorizeDevice(req.DeviceID, userID, deviceAccessControl)
+	if err != nil {
+		return nil, err
+	}
+
+	// 被分享用户还需满足分享时限定的命令类型
+	if device.UserID != userID {
+		share, err := getActiveShare(device.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if share != nil {
+			permissions, err := share.GetPermissions()
+			if err != nil {
+				return nil, errors.New("分享权限配置错误")
+			}
+			if len(permissions.CommandTypes) > 0 && !containsInt(permissions.CommandTypes, req.CommandType) {
+				return nil, errors.New("分享权限不允许执行该命令")
+			}
+		}
+	}
+
+	if !device.IsActive {
+		return nil, errors.New("设备已停用")
+	}
+	if !device.IsOnline() {
+		return nil, errors.New("设备不在线，无法下发命令")
+	}
+
+	command := &baby.DeviceCommand{
+		UserID:      userID,
+		DeviceID:    device.ID,
+		CommandType: req.CommandType,
+		Command:     req.Command,
+		Parameters:  parameters,
+		Status:      commandStatusPending,
+	}
+	if err := global.GVA_DB.Create(command).Error; err != nil {
+		return nil, err
+	}
+
+	s.enqueue(command.ID)
+
+	var resp response.DeviceCommandResponse
+	resp.FromDeviceCommand(command, device.DeviceName)
+	return &resp, nil
+}
+
+// GetCommand 获取命令详情
+func (s *DeviceCommandService) GetCommand(id uint, userID uint) (*response.DeviceCommandResponse, error) {
+	var command baby.DeviceCommand
+	err := global.GVA_DB.Where("id = ?", id).First(&command).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("命令不存在")
+		}
+		return nil, err
+	}
+
+	device, err := authorizeDevice(command.DeviceID, userID, deviceAccessRead)
+	if err != nil {
+		return nil, errors.New("命令不存在")
+	}
+
+	var resp response.DeviceCommandResponse
+	resp.FromDeviceCommand(&command, device.DeviceName)
+	return &resp, nil
+}
+
+// GetCommandList 获取命令记录列表
+func (s *DeviceCommandService) GetCommandList(userID uint, req *request.DeviceCommandSearch) (*response.DeviceCommandListResponse, error) {
+	db := global.GVA_DB.Model(&baby.DeviceCommand{})
+	if req.DeviceID > 0 {
+		if _, err := authorizeDevice(req.DeviceID, userID, deviceAccessRead); err != nil {
+			return nil, err
+		}
+		db = db.Where("device_id = ?", req.DeviceID)
+	} else {
+		db = db.Where("device_id IN (?)", accessibleDeviceIDs(userID))
+	}
+
+	// 搜索条件
+	if req.CommandType > 0 {
+		db = db.Where("command_type = ?", req.CommandType)
+	}
+	if req.Status > 0 {
+		db = db.Where("status = ?", req.Status)
+	}
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var commands []baby.DeviceCommand
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order("created_at DESC").Find(&commands).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取设备名称
+	var deviceIDs []uint
+	for _, command := range commands {
+		deviceIDs = append(deviceIDs, command.DeviceID)
+	}
+
+	var devices []baby.Device
+	if len(deviceIDs) > 0 {
+		global.GVA_DB.Unscoped().Where("id IN ?", deviceIDs).Find(&devices)
+	}
+
+	deviceNameMap := make(map[uint]string)
+	for _, device := range devices {
+		deviceNameMap[device.ID] = device.DeviceName
+	}
+
+	// 转换响应
+	list := make([]response.DeviceCommandResponse, 0, len(commands))
+	for _, command := range commands {
+		var resp response.DeviceCommandResponse
+		resp.FromDeviceCommand(&command, deviceNameMap[command.DeviceID])
+		list = append(list, resp)
+	}
+
+	return &response.DeviceCommandListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// ReportCommandResult 回传异步执行的命令结果，只有执行中的命令可以被回传
+func (s *DeviceCommandService) ReportCommandResult(deviceID uint, commandID uint, result *DeviceCommandResult) error {
+	var command baby.DeviceCommand
+	err := global.GVA_DB.Where("id = ? AND device_id = ?", commandID, deviceID).First(&command).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("命令不存在")
+		}
+		return err
+	}
+
+	completed, err := s.completeCommand(command.ID, result)
+	if err != nil {
+		return err
+	}
+	if !completed {
+		return errors.New("命令已结束或尚未开始执行")
+	}
+	return nil
+}
+
+// SweepCommands 将超时未完成的命令标记为失败，并重新投递仍在排队的命令
+func (s *DeviceCommandService) SweepCommands() error {
+	now := time.Now()
+	deadline := now.Add(-deviceCommandTimeout)
+
+	err := global.GVA_DB.Model(&baby.DeviceCommand{}).
+		Where("status = ? AND executed_at < ?", commandStatusExecuting, deadline).
+		Updates(map[string]interface{}{
+			"status":       commandStatusFailure,
+			"error_msg":    "命令执行超时",
+			"completed_at": now,
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	err = global.GVA_DB.Model(&baby.DeviceCommand{}).
+		Where("status = ? AND created_at < ?", commandStatusPending, deadline).
+		Updates(map[string]interface{}{
+			"status":       commandStatusFailure,
+			"error_msg":    "命令排队超时",
+			"completed_at": now,
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	var pendingIDs []uint
+	err = global.GVA_DB.Model(&baby.DeviceCommand{}).
+		Where("status = ?", commandStatusPending).Order("id ASC").Pluck("id", &pendingIDs).Error
+	if err != nil {
+		return err
+	}
+	for _, id := range pendingIDs {
+		s.enqueue(id)
+	}
+	return nil
+}
+
+// enqueue 将命令加入下发队列，队列已满时命令保持待执行状态等待补发
+func (s *DeviceCommandService) enqueue(commandID uint) {
+	dispatcherOnce.Do(func() {
+		for i := 0; i < deviceCommandWorkers; i++ {
+			go func() {
+				for id := range deviceCommandQueue {
+					_ = s.dispatch(id)
+				}
+			}()
+		}
+	})
+
+	select {
+	case deviceCommandQueue <- commandID:
+	default:
+	}
+}
+
+// dispatch 下发单条命令：抢占待执行状态后交给下发通道
+func (s *DeviceCommandService) dispatch(commandID uint) error {
+	// 通过条件更新抢占命令，防止重复下发
+	now := time.Now()
+	claim := global.GVA_DB.Model(&baby.DeviceCommand{}).
+		Where("id = ? AND status = ?", commandID, commandStatusPending).
+		Updates(map[string]interface{}{"status": commandStatusExecuting, "executed_at": now})
+	if claim.Error != nil {
+		return claim.Error
+	}
+	if claim.RowsAffected == 0 {
+		return nil
+	}
+
+	var command baby.DeviceCommand
+	if err := global.GVA_DB.Where("id = ?", commandID).First(&command).Error; err != nil {
+		return err
+	}
+
+	var device baby.Device
+	if err := global.GVA_DB.Where("id = ?", command.DeviceID).First(&device).Error; err != nil {
+		_, err = s.completeCommand(command.ID, &DeviceCommandResult{ErrorMsg: "设备不存在"})
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deviceCommandTimeout)
+	defer cancel()
+
+	result, err := getDeviceTransport().Send(ctx, &device, &command)
+	if err != nil {
+		_, err = s.completeCommand(command.ID, &DeviceCommandResult{ErrorMsg: err.Error()})
+		return err
+	}
+	if result != nil {
+		_, err = s.completeCommand(command.ID, result)
+		return err
+	}
+	return nil
+}
+
+// completeCommand 记录命令执行结果，返回命令是否由执行中变为已完成
+func (s *DeviceCommandService) completeCommand(commandID uint, result *DeviceCommandResult) (bool, error) {
+	status := commandStatusFailure
+	if result.Success {
+		status = commandStatusSuccess
+	}
+
+	errorMsg := result.ErrorMsg
+	if runes := []rune(errorMsg); len(runes) > 255 {
+		errorMsg = string(runes[:255])
+	}
+
+	update := global.GVA_DB.Model(&baby.DeviceCommand{}).
+		Where("id = ? AND status = ?", commandID, commandStatusExecuting).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"response":     result.Response,
+			"error_msg":    errorMsg,
+			"completed_at": time.Now(),
+		})
+	return update.RowsAffected > 0, update.Error
+}
+
+// validateCommandParameters 按命令类型校验参数，返回补全默认值后的JSON
+func validateCommandParameters(commandType int, parameters string) (string, error) {
+	params := make(map[string]interface{})
+	if strings.TrimSpace(parameters) != "" {
+		if err := json.Unmarshal([]byte(parameters), &params); err != nil {
+			return "", errors.New("命令参数必须为JSON对象")
+		}
+	}
+
+	switch commandType {
+	case 1: // 云台控制
+		direction, _ := params["direction"].(string)
+		switch direction {
+		case "up", "down", "left", "right", "stop", "reset":
+		default:
+			return "", errors.New("云台方向必须为 up/down/left/right/stop/reset")
+		}
+		speed, err := intParam(params, "speed", 5)
+		if err != nil || speed < 1 || speed > 10 {
+			return "", errors.New("云台速度必须为1-10的整数")
+		}
+		params["speed"] = speed
+	case 2: // 夜视切换
+		mode, _ := params["mode"].(string)
+		switch mode {
+		case "on", "off", "auto":
+		default:
+			return "", errors.New("夜视模式必须为 on/off/auto")
+		}
+	case 3: // 音量调节
+		if _, ok := params["volume"]; !ok {
+			return "", errors.New("缺少音量参数")
+		}
+		volume, err := intParam(params, "volume", 0)
+		if err != nil || volume < 0 || volume > 100 {
+			return "", errors.New("音量必须为0-100的整数")
+		}
+		params["volume"] = volume
+	case 4: // 设备重启
+		delay, err := intParam(params, "delay", 0)
+		if err != nil || delay < 0 || delay > 300 {
+			return "", errors.New("重启延迟必须为0-300秒的整数")
+		}
+		params["delay"] = delay
+	case 5: // 其他命令，参数透传
+	default:
+		return "", errors.New("不支持的命令类型")
+	}
+
+	normalized, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+// intParam 读取整数参数，不存在时返回默认值
+func intParam(params map[string]interface{}, key string, defaultValue int) (int, error) {
+	value, ok := params[key]
+	if !ok {
+		return defaultValue, nil
+	}
+	number, ok := value.(float64)
+	if !ok || number != math.Trunc(number) {
+		return 0, errors.New(key + " 必须为整数")
+	}
+	return int(number), nil
+}
+
+// containsInt 判断切片中是否包含指定整数
+func containsInt(values []int, target int) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
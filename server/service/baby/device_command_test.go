@@ -0,0 +1,214 @@
+package baby
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+)
+
+func TestValidateCommandParameters(t *testing.T) {
+	tests := []struct {
+		name        string
+		commandType int
+		parameters  string
+		want        string
+		wantErr     bool
+	}{
+		{"云台默认速度", 1, `{"direction":"left"}`, `{"direction":"left","speed":5}`, false},
+		{"云台指定速度", 1, `{"direction":"up","speed":10}`, `{"direction":"up","speed":10}`, false},
+		{"云台方向非法", 1, `{"direction":"forward"}`, "", true},
+		{"云台速度越界", 1, `{"direction":"up","speed":11}`, "", true},
+		{"云台速度非整数", 1, `{"direction":"up","speed":2.5}`, "", true},
+		{"夜视自动", 2, `{"mode":"auto"}`, `{"mode":"auto"}`, false},
+		{"夜视缺少模式", 2, ``, "", true},
+		{"音量静音", 3, `{"volume":0}`, `{"volume":0}`, false},
+		{"音量缺失", 3, `{}`, "", true},
+		{"音量越界", 3, `{"volume":101}`, "", true},
+		{"重启默认延迟", 4, ``, `{"delay":0}`, false},
+		{"重启延迟越界", 4, `{"delay":301}`, "", true},
+		{"其他命令透传", 5, `{"foo":"bar"}`, `{"foo":"bar"}`, false},
+		{"参数非JSON对象", 5, `[1,2]`, "", true},
+		{"未知命令类型", 9, ``, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateCommandParameters(tt.commandType, tt.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateCommandParameters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("validateCommandParameters() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// waitCommandDone 等待异步下发的命令执行结束
+func waitCommandDone(t *testing.T, id uint) baby.DeviceCommand {
+	t.Helper()
+	var command baby.DeviceCommand
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if err := global.GVA_DB.Where("id = ?", id).First(&command).Error; err != nil {
+			t.Fatal(err)
+		}
+		if command.Status == commandStatusSuccess || command.Status == commandStatusFailure {
+			return command
+		}
+	}
+	t.Fatalf("命令%d未执行结束, status %d", id, command.Status)
+	return command
+}
+
+func TestDeviceCommandLifecycle(t *testing.T) {
+	db := setupTestDB(t, &baby.Device{}, &baby.DeviceShare{}, &baby.DeviceCommand{})
+	transport := NewMockDeviceTransport()
+	SetDeviceTransport(transport)
+	t.Cleanup(func() { SetDeviceTransport(unconfiguredDeviceTransport{}) })
+
+	device := baby.Device{UserID: 1, DeviceName: "婴儿房摄像头", DeviceType: 1, ProductID: "camera", DeviceSecret: "secret", Status: 1, IsActive: true}
+	if err := db.Create(&device).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 下发时命令已被抢占为执行中
+	var sendingStatus atomic.Int32
+	transport.Handler = func(device *baby.Device, command *baby.DeviceCommand) (*DeviceCommandResult, error) {
+		var current baby.DeviceCommand
+		if err := global.GVA_DB.Where("id = ?", command.ID).First(&current).Error; err != nil {
+			return nil, err
+		}
+		sendingStatus.Store(int32(current.Status))
+		switch command.CommandType {
+		case 2:
+			return &DeviceCommandResult{Success: false, ErrorMsg: "夜视模组故障"}, nil
+		case 4:
+			return nil, errors.New("设备连接已断开")
+		case 5:
+			return nil, nil // 异步执行，结果稍后回传
+		}
+		return &DeviceCommandResult{Success: true, Response: `{"volume":60}`}, nil
+	}
+
+	service := new(DeviceCommandService)
+	tests := []struct {
+		name        string
+		commandType int
+		parameters  string
+		status      int
+		response    string
+		errorMsg    string
+	}{
+		{"执行成功", 3, `{"volume":60}`, commandStatusSuccess, `{"volume":60}`, ""},
+		{"设备执行失败", 2, `{"mode":"on"}`, commandStatusFailure, "", "夜视模组故障"},
+		{"下发失败", 4, "", commandStatusFailure, "", "设备连接已断开"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.SendCommand(1, &request.DeviceCommandRequest{DeviceID: device.ID, CommandType: tt.commandType, Command: tt.name, Parameters: tt.parameters})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Status != commandStatusPending {
+				t.Errorf("创建后状态 = %d, want %d", resp.Status, commandStatusPending)
+			}
+			command := waitCommandDone(t, resp.ID)
+			if status := sendingStatus.Load(); status != commandStatusExecuting {
+				t.Errorf("下发时状态 = %d, want %d", status, commandStatusExecuting)
+			}
+			if command.Status != tt.status || command.Response != tt.response || command.ErrorMsg != tt.errorMsg {
+				t.Errorf("结果 = %d %q %q, want %d %q %q", command.Status, command.Response, command.ErrorMsg, tt.status, tt.response, tt.errorMsg)
+			}
+			if command.ExecutedAt == nil || command.CompletedAt == nil || command.CompletedAt.Before(*command.ExecutedAt) {
+				t.Errorf("执行时间 = %v, 完成时间 = %v", command.ExecutedAt, command.CompletedAt)
+			}
+		})
+	}
+
+	// 异步执行的命令保持执行中，直到设备回传结果
+	resp, err := service.SendCommand(1, &request.DeviceCommandRequest{DeviceID: device.ID, CommandType: 5, Command: "snapshot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var async baby.DeviceCommand
+	for deadline := time.Now().Add(2 * time.Second); async.Status != commandStatusExecuting && time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		db.Where("id = ?", resp.ID).First(&async)
+	}
+	if async.Status != commandStatusExecuting || async.ExecutedAt == nil || async.CompletedAt != nil {
+		t.Fatalf("异步命令 = status %d, executed %v, completed %v", async.Status, async.ExecutedAt, async.CompletedAt)
+	}
+	if err := service.ReportCommandResult(device.ID, async.ID, &DeviceCommandResult{Success: true, Response: "snapshot.jpg"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.ReportCommandResult(device.ID, async.ID, &DeviceCommandResult{Success: false}); err == nil {
+		t.Error("已完成的命令不应再次回传结果")
+	}
+	db.Where("id = ?", async.ID).First(&async)
+	if async.Status != commandStatusSuccess || async.Response != "snapshot.jpg" || async.CompletedAt == nil {
+		t.Errorf("回传后 = status %d, response %q", async.Status, async.Response)
+	}
+	if sent := transport.Sent(); len(sent) != 4 {
+		t.Errorf("下发命令数 = %d, want 4", len(sent))
+	}
+}
+
+func TestSweepCommandsTimeout(t *testing.T) {
+	db := setupTestDB(t, &baby.Device{}, &baby.DeviceCommand{})
+	// 补发的命令走默认通道，一律下发失败
+	SetDeviceTransport(unconfiguredDeviceTransport{})
+
+	device := baby.Device{UserID: 1, DeviceName: "婴儿房摄像头", DeviceType: 1, ProductID: "camera", DeviceSecret: "secret", Status: 1, IsActive: true}
+	if err := db.Create(&device).Error; err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * deviceCommandTimeout)
+	recent := time.Now().Add(-time.Second)
+	commands := []*baby.DeviceCommand{
+		{UserID: 1, DeviceID: device.ID, CommandType: 4, Command: "执行超时", Status: commandStatusExecuting, ExecutedAt: &stale},
+		{UserID: 1, DeviceID: device.ID, CommandType: 4, Command: "执行中", Status: commandStatusExecuting, ExecutedAt: &recent},
+		{UserID: 1, DeviceID: device.ID, CommandType: 4, Command: "排队超时", Status: commandStatusPending},
+		{UserID: 1, DeviceID: device.ID, CommandType: 4, Command: "排队中", Status: commandStatusPending},
+	}
+	for _, command := range commands {
+		if err := db.Create(command).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Model(commands[2]).Update("created_at", stale).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := new(DeviceCommandService).SweepCommands(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		status   int
+		errorMsg string
+	}{
+		{commandStatusFailure, "命令执行超时"},
+		{commandStatusExecuting, ""},
+		{commandStatusFailure, "命令排队超时"},
+	}
+	for i, command := range commands[:3] {
+		var got baby.DeviceCommand
+		if err := db.Where("id = ?", command.ID).First(&got).Error; err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != want[i].status || got.ErrorMsg != want[i].errorMsg {
+			t.Errorf("%s: status %d %q, want %d %q", command.Command, got.Status, got.ErrorMsg, want[i].status, want[i].errorMsg)
+		}
+		if (got.Status == commandStatusFailure) != (got.CompletedAt != nil) {
+			t.Errorf("%s: 完成时间 = %v", command.Command, got.CompletedAt)
+		}
+	}
+
+	// 未超时的排队命令重新投递
+	if got := waitCommandDone(t, commands[3].ID); got.ErrorMsg != "设备命令下发通道未配置" {
+		t.Errorf("补发命令结果 = %d %q", got.Status, got.ErrorMsg)
+	}
+}
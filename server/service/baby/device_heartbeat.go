@@ -0,0 +1,126 @@
+package baby
+
+import (
+	"encoding/json"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"gorm.io/gorm"
+)
+
+// DefaultHeartbeatTimeout 未配置时的心跳超时时间
+const DefaultHeartbeatTimeout = 3 * time.Minute
+
+// DetectOfflineDevices 将超过心跳超时时间未上报的在线设备标记为离线，返回本次离线的设备数
+// 离线变化写入设备状态和设备日志；监护设备在宝宝睡眠期间离线时向用户发出警报
+func (s *DeviceService) DetectOfflineDevices(timeout time.Duration) (int, error) {
+	if timeout <= 0 {
+		timeout = DefaultHeartbeatTimeout
+	}
+	now := time.Now()
+	deadline := now.Add(-timeout)
+
+	var devices []baby.Device
+	err := global.GVA_DB.Where("status = ?", 1).
+		Where("(last_online_at < ? OR (last_online_at IS NULL AND activated_at < ?))", deadline, deadline).
+		Find(&devices).Error
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := range devices {
+		offline, err := s.markOffline(&devices[i], now)
+		if err != nil {
+			return count, err
+		}
+		if offline {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// markOffline 记录单台设备的离线变化，设备在检测期间恢复上报时不做处理
+func (s *DeviceService) markOffline(device *baby.Device, now time.Time) (bool, error) {
+	offline := false
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&baby.Device{}).
+			Where("id = ? AND status = ? AND (last_online_at IS NULL OR last_online_at = ?)", device.ID, 1, device.LastOnlineAt).
+			Update("status", 2)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		offline = true
+
+		statusData, _ := json.Marshal(map[string]interface{}{
+			"last_online_at": device.LastOnlineAt,
+			"reason":         "heartbeat_timeout",
+		})
+		err := tx.Create(&baby.DeviceStatus{
+			DeviceID:    device.ID,
+			StatusType:  1,
+			StatusValue: "offline",
+			StatusData:  string(statusData),
+			RecordedAt:  now,
+			IsAlert:     true,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Create(&baby.DeviceLog{
+			DeviceID:   device.ID,
+			LogLevel:   3,
+			LogType:    1,
+			Message:    "设备心跳超时，已标记为离线",
+			Details:    string(statusData),
+			Source:     "heartbeat",
+			RecordedAt: now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return s.alertSleepMonitoringOffline(tx, device, now)
+	})
+	return offline, err
+}
+
+// alertSleepMonitoringOffline 摄像头、传感器等监护设备在睡眠记录进行中离线时发出警报
+func (s *DeviceService) alertSleepMonitoringOffline(tx *gorm.DB, device *baby.Device, now time.Time) error {
+	if device.DeviceType != 1 && device.DeviceType != 2 {
+		return nil
+	}
+
+	var sleeps []baby.SleepRecord
+	err := tx.Where("device_id = ? AND status = ? AND end_time IS NULL", device.ID, 1).Find(&sleeps).Error
+	if err != nil {
+		return err
+	}
+
+	for _, sleep := range sleeps {
+		triggerData, _ := json.Marshal(map[string]interface{}{
+			"sleep_record_id": sleep.ID,
+			"sleep_start":     sleep.StartTime,
+			"last_online_at":  device.LastOnlineAt,
+		})
+		alert := &baby.SmartAlert{
+			UserID:      sleep.UserID,
+			BabyID:      sleep.BabyID,
+			DeviceID:    device.ID,
+			AlertType:   5,
+			AlertLevel:  3,
+			Title:       "监护设备离线",
+			Message:     "宝宝睡眠期间，设备「" + device.DeviceName + "」已离线，请检查设备电源和网络",
+			TriggerData: string(triggerData),
+		}
+		if err := tx.Create(alert).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
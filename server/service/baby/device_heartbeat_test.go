@@ -0,0 +1,119 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+)
+
+func TestDetectOfflineDevices(t *testing.T) {
+	db := setupTestDB(t, &baby.Device{}, &baby.DeviceStatus{}, &baby.DeviceLog{}, &baby.SleepRecord{}, &baby.SmartAlert{})
+	now := time.Now()
+	stale := now.Add(-10 * time.Minute)
+	fresh := now.Add(-30 * time.Second)
+	newDevice := func(name string, deviceType, status int, lastOnlineAt *time.Time) *baby.Device {
+		device := &baby.Device{UserID: 1, DeviceName: name, DeviceType: deviceType, ProductID: "product", DeviceSecret: "secret",
+			Status: status, IsActive: true, LastOnlineAt: lastOnlineAt, ActivatedAt: stale}
+		if err := db.Create(device).Error; err != nil {
+			t.Fatal(err)
+		}
+		return device
+	}
+	sleeping := newDevice("睡眠中的摄像头", 1, 1, &stale)
+	idle := newDevice("空闲的摄像头", 1, 1, &stale)
+	speaker := newDevice("睡眠中的音响", deviceTypeSpeaker, 1, &stale)
+	never := newDevice("从未上报的传感器", 2, 1, nil)
+	online := newDevice("在线的摄像头", 1, 1, &fresh)
+	offline := newDevice("已离线的摄像头", 1, 2, &stale)
+
+	// 进行中的睡眠由摄像头和音响监护，空闲摄像头的睡眠已结束
+	ended := now.Add(-time.Hour)
+	sleeps := []baby.SleepRecord{
+		{UserID: 2, BabyID: 7, DeviceID: sleeping.ID, StartTime: now.Add(-time.Hour), Status: 1},
+		{UserID: 2, BabyID: 7, DeviceID: speaker.ID, StartTime: now.Add(-time.Hour), Status: 1},
+		{UserID: 2, BabyID: 7, DeviceID: idle.ID, StartTime: now.Add(-2 * time.Hour), EndTime: &ended, Status: 2},
+	}
+	if err := db.Create(&sleeps).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	service := new(DeviceService)
+	count, err := service.DetectOfflineDevices(3 * time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 {
+		t.Errorf("离线设备数 = %d, want 4", count)
+	}
+	// 再次检测不重复记录
+	if count, err := service.DetectOfflineDevices(3 * time.Minute); err != nil || count != 0 {
+		t.Errorf("再次检测 = %d, %v, want 0", count, err)
+	}
+
+	for _, tt := range []struct {
+		device *baby.Device
+		status int
+		events int64
+	}{
+		{sleeping, 2, 1},
+		{idle, 2, 1},
+		{speaker, 2, 1},
+		{never, 2, 1},
+		{online, 1, 0},
+		{offline, 2, 0},
+	} {
+		var device baby.Device
+		if err := db.Where("id = ?", tt.device.ID).First(&device).Error; err != nil {
+			t.Fatal(err)
+		}
+		if device.Status != tt.status {
+			t.Errorf("%s: status = %d, want %d", device.DeviceName, device.Status, tt.status)
+		}
+		var statuses, logs int64
+		db.Model(&baby.DeviceStatus{}).Where("device_id = ? AND status_value = ?", device.ID, "offline").Count(&statuses)
+		db.Model(&baby.DeviceLog{}).Where("device_id = ? AND source = ?", device.ID, "heartbeat").Count(&logs)
+		if statuses != tt.events || logs != tt.events {
+			t.Errorf("%s: 状态记录 %d, 日志 %d, want %d", device.DeviceName, statuses, logs, tt.events)
+		}
+	}
+
+	// 仅监护设备在睡眠进行中离线时发出警报
+	var alerts []baby.SmartAlert
+	if err := db.Find(&alerts).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("警报数 = %d, want 1", len(alerts))
+	}
+	if alerts[0].DeviceID != sleeping.ID || alerts[0].UserID != 2 || alerts[0].BabyID != 7 {
+		t.Errorf("警报 = device %d, user %d, baby %d", alerts[0].DeviceID, alerts[0].UserID, alerts[0].BabyID)
+	}
+}
+
+func TestMarkOfflineSkipsRecoveredDevice(t *testing.T) {
+	db := setupTestDB(t, &baby.Device{}, &baby.DeviceStatus{}, &baby.DeviceLog{}, &baby.SleepRecord{}, &baby.SmartAlert{})
+	stale := time.Now().Add(-10 * time.Minute)
+	device := baby.Device{UserID: 1, DeviceName: "婴儿房摄像头", DeviceType: 1, ProductID: "camera", DeviceSecret: "secret",
+		Status: 1, IsActive: true, LastOnlineAt: &stale}
+	if err := db.Create(&device).Error; err != nil {
+		t.Fatal(err)
+	}
+	// 检测查询之后设备恢复上报心跳
+	snapshot := device
+	if err := db.Model(&baby.Device{}).Where("id = ?", device.ID).Update("last_online_at", time.Now()).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	offline, err := new(DeviceService).markOffline(&snapshot, time.Now())
+	if err != nil || offline {
+		t.Fatalf("markOffline = %v, %v, want false", offline, err)
+	}
+	var logs int64
+	db.Model(&baby.DeviceLog{}).Count(&logs)
+	if err := db.Where("id = ?", device.ID).First(&device).Error; err != nil {
+		t.Fatal(err)
+	}
+	if device.Status != 1 || logs != 0 {
+		t.Errorf("status = %d, 日志 %d, want 1, 0", device.Status, logs)
+	}
+}
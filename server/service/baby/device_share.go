@@ -0,0 +1,195 @@
+package baby
+
+import (
+	"errors"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"baby_admin/server/model/system"
+	"gorm.io/gorm"
+)
+
+// ShareDevice 邀请其他用户共享设备，被邀请人接受后生效
+func (s *DeviceService) ShareDevice(userID uint, req *request.ShareDeviceRequest) error {
+	device, err := authorizeDevice(req.DeviceID, userID, deviceAccessOwner)
+	if err != nil {
+		return err
+	}
+
+	if req.SharedUserID == userID {
+		return errors.New("不能分享给自己")
+	}
+	if req.ExpiredAt != nil && !req.ExpiredAt.After(time.Now()) {
+		return errors.New("过期时间必须晚于当前时间")
+	}
+	if req.Permissions != "" {
+		share := baby.DeviceShare{Permissions: req.Permissions}
+		if _, err := share.GetPermissions(); err != nil {
+			return errors.New("权限配置格式错误")
+		}
+	}
+
+	// 验证被分享用户是否存在
+	var sharedUser system.MiniprogramUser
+	err = global.GVA_DB.Where("id = ?", req.SharedUserID).First(&sharedUser).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("被分享用户不存在")
+		}
+		return err
+	}
+
+	// 同一设备对同一用户只保留一条有效分享
+	var existing baby.DeviceShare
+	err = global.GVA_DB.Where("device_id = ? AND shared_user_id = ? AND is_active = ?", device.ID, req.SharedUserID, true).
+		First(&existing).Error
+	if err == nil && !existing.IsExpired() {
+		if existing.IsAccepted() {
+			return errors.New("该用户已共享此设备")
+		}
+		return errors.New("已向该用户发出邀请，请等待对方接受")
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		// 已过期的旧分享直接作废
+		if existing.ID > 0 {
+			if err := tx.Model(&existing).Update("is_active", false).Error; err != nil {
+				return err
+			}
+		}
+
+		share := &baby.DeviceShare{
+			DeviceID:     device.ID,
+			OwnerID:      userID,
+			SharedUserID: req.SharedUserID,
+			ShareType:    req.ShareType,
+			Permissions:  req.Permissions,
+			ExpiredAt:    req.ExpiredAt,
+			IsActive:     true,
+		}
+		return tx.Create(share).Error
+	})
+}
+
+// AcceptShare 接受设备分享邀请
+func (s *DeviceService) AcceptShare(userID uint, req *request.AcceptShareRequest) error {
+	var share baby.DeviceShare
+	err := global.GVA_DB.Where("id = ? AND shared_user_id = ? AND is_active = ?", req.ShareID, userID, true).First(&share).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("分享邀请不存在")
+		}
+		return err
+	}
+
+	if share.IsAccepted() {
+		return errors.New("已接受该分享")
+	}
+	if share.IsExpired() {
+		return errors.New("分享邀请已过期")
+	}
+
+	return global.GVA_DB.Model(&share).Update("accepted_at", time.Now()).Error
+}
+
+// DeclineShare 拒绝分享邀请，对已接受的分享则表示退出共享
+func (s *DeviceService) DeclineShare(userID uint, req *request.DeclineShareRequest) error {
+	var share baby.DeviceShare
+	err := global.GVA_DB.Where("id = ? AND shared_user_id = ? AND is_active = ?", req.ShareID, userID, true).First(&share).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("分享不存在")
+		}
+		return err
+	}
+
+	return global.GVA_DB.Model(&share).Update("is_active", false).Error
+}
+
+// RevokeShare 设备所有者撤销分享
+func (s *DeviceService) RevokeShare(shareID uint, userID uint) error {
+	var share baby.DeviceShare
+	err := global.GVA_DB.Where("id = ? AND owner_id = ?", shareID, userID).First(&share).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("分享不存在")
+		}
+		return err
+	}
+
+	return global.GVA_DB.Delete(&share).Error
+}
+
+// GetDeviceShares 获取设备的分享列表
+func (s *DeviceService) GetDeviceShares(deviceID uint, userID uint) ([]response.DeviceShareResponse, error) {
+	device, err := authorizeDevice(deviceID, userID, deviceAccessManage)
+	if err != nil {
+		return nil, err
+	}
+
+	var shares []baby.DeviceShare
+	err = global.GVA_DB.Where("device_id = ? AND is_active = ?", device.ID, true).Order("created_at DESC").Find(&shares).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildShareResponses(shares), nil
+}
+
+// GetShareInvitations 获取当前用户待处理的分享邀请
+func (s *DeviceService) GetShareInvitations(userID uint) ([]response.DeviceShareResponse, error) {
+	var shares []baby.DeviceShare
+	err := global.GVA_DB.Where("shared_user_id = ? AND is_active = ? AND accepted_at IS NULL", userID, true).
+		Where("(expired_at IS NULL OR expired_at > ?)", time.Now()).
+		Order("created_at DESC").Find(&shares).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildShareResponses(shares), nil
+}
+
+// buildShareResponses 组装分享响应，补充设备名称和用户昵称
+func (s *DeviceService) buildShareResponses(shares []baby.DeviceShare) []response.DeviceShareResponse {
+	result := make([]response.DeviceShareResponse, 0, len(shares))
+	if len(shares) == 0 {
+		return result
+	}
+
+	var deviceIDs []uint
+	var userIDs []uint
+	for _, share := range shares {
+		deviceIDs = append(deviceIDs, share.DeviceID)
+		userIDs = append(userIDs, share.OwnerID, share.SharedUserID)
+	}
+
+	var devices []baby.Device
+	global.GVA_DB.Where("id IN ?", deviceIDs).Find(&devices)
+	deviceNameMap := make(map[uint]string)
+	for _, device := range devices {
+		deviceNameMap[device.ID] = device.DeviceName
+	}
+
+	var users []system.MiniprogramUser
+	global.GVA_DB.Where("id IN ?", userIDs).Find(&users)
+	userNameMap := make(map[uint]string)
+	for _, user := range users {
+		name := user.NickName
+		if name == "" {
+			name = user.GetUsername()
+		}
+		userNameMap[user.ID] = name
+	}
+
+	for _, share := range shares {
+		var resp response.DeviceShareResponse
+		resp.FromDeviceShare(&share, deviceNameMap[share.DeviceID], userNameMap[share.OwnerID], userNameMap[share.SharedUserID])
+		result = append(result, resp)
+	}
+	return result
+}
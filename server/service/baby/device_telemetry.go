@@ -0,0 +1,330 @@
+package baby
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+type DeviceTelemetryService struct{}
+
+const (
+	deviceSignWindow     = 5 * time.Minute // 请求时间戳允许的偏差
+	deviceNonceMinLength = 8
+	deviceNonceMaxLength = 64
+)
+
+// deviceNonceStore 未配置redis时使用的进程内nonce存储
+var deviceNonceStore = &nonceStore{seen: make(map[string]time.Time)}
+
+type nonceStore struct {
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	lastPrune time.Time
+}
+
+// use 记录nonce，已存在且未过期时返回false
+func (n *nonceStore) use(key string, ttl time.Duration) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(n.lastPrune) > ttl {
+		for k, expireAt := range n.seen {
+			if now.After(expireAt) {
+				delete(n.seen, k)
+			}
+		}
+		n.lastPrune = now
+	}
+
+	if expireAt, ok := n.seen[key]; ok && now.Before(expireAt) {
+		return false
+	}
+	n.seen[key] = now.Add(ttl)
+	return true
+}
+
+// SignDeviceRequest 计算设备请求签名：
+// hex(HMAC-SHA256(DeviceSecret, deviceID + "\n" + timestamp + "\n" + nonce + "\n" + body))
+func SignDeviceRequest(secret string, deviceID uint, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatUint(uint64(deviceID), 10) + "\n" + timestamp + "\n" + nonce + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// AuthenticateDevice 校验设备请求签名，并通过时间戳和nonce防止重放
+func (s *DeviceTelemetryService) AuthenticateDevice(deviceID uint, timestamp, nonce, signature string, body []byte) (*baby.Device, error) {
+	if deviceID == 0 || timestamp == "" || nonce == "" || signature == "" {
+		return nil, errors.New("缺少签名参数")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, errors.New("时间戳格式错误")
+	}
+	skew := time.Since(time.Unix(ts, 0))
+	if skew > deviceSignWindow || skew < -deviceSignWindow {
+		return nil, errors.New("请求已过期，请校准设备时间")
+	}
+	if len(nonce) < deviceNonceMinLength || len(nonce) > deviceNonceMaxLength {
+		return nil, errors.New("nonce长度必须为8-64位")
+	}
+
+	var device baby.Device
+	err = global.GVA_DB.Where("id = ?", deviceID).First(&device).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("签名校验失败")
+		}
+		return nil, err
+	}
+
+	expected := SignDeviceRequest(device.DeviceSecret, device.ID, timestamp, nonce, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, errors.New("签名校验失败")
+	}
+	if !device.IsActive {
+		return nil, errors.New("设备已停用")
+	}
+
+	// 签名通过后再登记nonce，避免伪造请求占用合法nonce
+	fresh, err := s.useNonce(device.ID, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, errors.New("重复的请求")
+	}
+
+	return &device, nil
+}
+
+// useNonce 登记nonce，有效期覆盖时间戳允许的整个窗口
+func (s *DeviceTelemetryService) useNonce(deviceID uint, nonce string) (bool, error) {
+	key := "device_nonce:" + strconv.FormatUint(uint64(deviceID), 10) + ":" + nonce
+	ttl := 2 * deviceSignWindow
+	if global.GVA_REDIS != nil {
+		return global.GVA_REDIS.SetNX(context.Background(), key, 1, ttl).Result()
+	}
+	return deviceNonceStore.use(key, ttl), nil
+}
+
+// Ingest 批量写入设备上报的状态、日志和环境数据，并刷新设备在线状态
+func (s *DeviceTelemetryService) Ingest(device *baby.Device, req *request.DeviceTelemetryRequest) (*response.DeviceTelemetryResponse, error) {
+	now := time.Now()
+
+	statuses := make([]baby.DeviceStatus, 0, len(req.Statuses))
+	for _, sample := range req.Statuses {
+		recordedAt, err := sampleTime(sample.Timestamp, now)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, baby.DeviceStatus{
+			DeviceID:    device.ID,
+			StatusType:  sample.StatusType,
+			StatusValue: sample.StatusValue,
+			StatusData:  sample.StatusData,
+			RecordedAt:  recordedAt,
+			IsAlert:     sample.IsAlert,
+		})
+	}
+
+	logs := make([]baby.DeviceLog, 0, len(req.Logs))
+	for _, sample := range req.Logs {
+		recordedAt, err := sampleTime(sample.Timestamp, now)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, baby.DeviceLog{
+			DeviceID:   device.ID,
+			LogLevel:   sample.LogLevel,
+			LogType:    sample.LogType,
+			Message:    sample.Message,
+			Details:    sample.Details,
+			Source:     sample.Source,
+			RecordedAt: recordedAt,
+		})
+	}
+
+	environments := make([]baby.EnvironmentData, 0, len(req.Environment))
+	for _, sample := range req.Environment {
+		recordedAt, err := sampleTime(sample.Timestamp, now)
+		if err != nil {
+			return nil, err
+		}
+		environments = append(environments, baby.EnvironmentData{
+			UserID:      device.UserID,
+			DeviceID:    device.ID,
+			RecordedAt:  recordedAt,
+			Temperature: sample.Temperature,
+			Humidity:    sample.Humidity,
+			AirQuality:  sample.AirQuality,
+			NoiseLevel:  sample.NoiseLevel,
+			Brightness:  sample.Brightness,
+			CO2Level:    sample.CO2Level,
+		})
+	}
+
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if len(statuses) > 0 {
+			if err := tx.CreateInBatches(statuses, 100).Error; err != nil {
+				return err
+			}
+		}
+		if len(logs) > 0 {
+			if err := tx.CreateInBatches(logs, 100).Error; err != nil {
+				return err
+			}
+		}
+		if len(environments) > 0 {
+			if err := tx.CreateInBatches(environments, 100).Error; err != nil {
+				return err
+			}
+		}
+		return s.markOnline(tx, device, req.FirmwareVersion, req.HardwareVersion, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	logAlertError(new(SmartAlertService).EvaluateEnvironmentData(device, environments))
+
+	return &response.DeviceTelemetryResponse{
+		StatusCount:      len(statuses),
+		LogCount:         len(logs),
+		EnvironmentCount: len(environments),
+		ServerTime:       now.Unix(),
+	}, nil
+}
+
+// RecordMovementDetection 记录设备上报的动作事件，事件归属设备主人
+func (s *DeviceTelemetryService) RecordMovementDetection(device *baby.Device, req *request.DeviceMovementDetectionRequest) (*response.MovementDetectionResponse, error) {
+	babyProfile, err := deviceBaby(device, req.BabyID)
+	if err != nil {
+		return nil, err
+	}
+
+	detectedAt, err := sampleTime(req.Timestamp, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	detection := &baby.MovementDetection{
+		UserID:       device.UserID,
+		BabyID:       babyProfile.ID,
+		DeviceID:     device.ID,
+		DetectedAt:   detectedAt,
+		MovementType: req.MovementType,
+		Intensity:    req.Intensity,
+		Duration:     req.Duration,
+		VideoURL:     req.VideoURL,
+		ThumbnailURL: req.ThumbnailURL,
+		IsAlert:      req.IsAlert,
+	}
+	if err := global.GVA_DB.Create(detection).Error; err != nil {
+		return nil, err
+	}
+	logAlertError(new(SmartAlertService).EvaluateMovementDetection(detection))
+
+	var resp response.MovementDetectionResponse
+	resp.FromMovementDetection(detection, babyProfile.Name)
+	return &resp, nil
+}
+
+// ReportCommandResult 设备回传命令执行结果
+func (s *DeviceTelemetryService) ReportCommandResult(device *baby.Device, req *request.DeviceCommandResultRequest) error {
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		return s.markOnline(tx, device, "", "", time.Now())
+	})
+	if err != nil {
+		return err
+	}
+
+	var commandService DeviceCommandService
+	return commandService.ReportCommandResult(device.ID, req.CommandID, &DeviceCommandResult{
+		Success:  req.Success,
+		Response: req.Response,
+		ErrorMsg: req.ErrorMsg,
+	})
+}
+
+// markOnline 刷新设备最后在线时间；离线设备恢复在线时记录状态变化
+// 故障、维护状态由人工或设备状态上报维护，不在此处覆盖
+func (s *DeviceTelemetryService) markOnline(tx *gorm.DB, device *baby.Device, firmwareVersion, hardwareVersion string, now time.Time) error {
+	updates := map[string]interface{}{"last_online_at": now}
+	if firmwareVersion != "" {
+		updates["firmware_version"] = firmwareVersion
+	}
+	if hardwareVersion != "" {
+		updates["hardware_version"] = hardwareVersion
+	}
+	if err := tx.Model(&baby.Device{}).Where("id = ?", device.ID).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	// 条件更新保证并发上报时只记录一次上线
+	result := tx.Model(&baby.Device{}).Where("id = ? AND status = ?", device.ID, 2).Update("status", 1)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	err := tx.Create(&baby.DeviceStatus{
+		DeviceID:    device.ID,
+		StatusType:  1,
+		StatusValue: "online",
+		RecordedAt:  now,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&baby.DeviceLog{
+		DeviceID:   device.ID,
+		LogLevel:   2,
+		LogType:    1,
+		Message:    "设备恢复在线",
+		Source:     "heartbeat",
+		RecordedAt: now,
+	}).Error
+}
+
+// deviceBaby 查找设备上报事件归属的宝宝，未指定时取设备主人选择的当前宝宝，设备主人需有该宝宝的记录权限
+func deviceBaby(device *baby.Device, babyID uint) (*baby.BabyProfile, error) {
+	if babyID == 0 {
+		babyID = getActiveBabyID(device.UserID)
+		if babyID == 0 {
+			return nil, errors.New("设备主人未选择当前宝宝")
+		}
+	}
+	profile, _, err := authorizeBaby(babyID, device.UserID, babyAccessRecord)
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// sampleTime 转换采样时间，未填写时使用服务器时间，不接受未来时间
+func sampleTime(timestamp int64, now time.Time) (time.Time, error) {
+	if timestamp <= 0 {
+		return now, nil
+	}
+	recordedAt := time.Unix(timestamp, 0)
+	if recordedAt.After(now.Add(deviceSignWindow)) {
+		return time.Time{}, errors.New("采样时间不能晚于当前时间")
+	}
+	return recordedAt, nil
+}
@@ -0,0 +1,103 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+)
+
+func TestSignDeviceRequest(t *testing.T) {
+	body := []byte(`{"statuses":[{"status_type":2,"status_value":"80"}]}`)
+	sign := SignDeviceRequest("secret", 1, "1700000000", "abcdefgh", body)
+	if len(sign) != 64 {
+		t.Fatalf("签名长度错误: %d", len(sign))
+	}
+	if sign != SignDeviceRequest("secret", 1, "1700000000", "abcdefgh", body) {
+		t.Error("相同输入签名不一致")
+	}
+	if sign == SignDeviceRequest("secret", 2, "1700000000", "abcdefgh", body) {
+		t.Error("设备ID未参与签名")
+	}
+	if sign == SignDeviceRequest("secret", 1, "1700000000", "abcdefgi", body) {
+		t.Error("nonce未参与签名")
+	}
+	if sign == SignDeviceRequest("other", 1, "1700000000", "abcdefgh", body) {
+		t.Error("密钥未参与签名")
+	}
+}
+
+func TestNonceStore(t *testing.T) {
+	store := &nonceStore{seen: make(map[string]time.Time)}
+	if !store.use("1:abcdefgh", time.Minute) {
+		t.Fatal("首次使用nonce应成功")
+	}
+	if store.use("1:abcdefgh", time.Minute) {
+		t.Error("重复nonce未被拒绝")
+	}
+	if !store.use("2:abcdefgh", time.Minute) {
+		t.Error("不同设备的相同nonce应互不影响")
+	}
+	if !store.use("1:expired", -time.Second) || !store.use("1:expired", time.Minute) {
+		t.Error("过期nonce应可重新使用")
+	}
+}
+
+func TestSampleTime(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	if got, _ := sampleTime(0, now); !got.Equal(now) {
+		t.Error("未填写采样时间应使用服务器时间")
+	}
+	if got, _ := sampleTime(1699999000, now); got.Unix() != 1699999000 {
+		t.Error("采样时间转换错误")
+	}
+	if _, err := sampleTime(now.Add(time.Hour).Unix(), now); err == nil {
+		t.Error("未来采样时间未被拒绝")
+	}
+}
+
+func TestDeviceEventWithoutBabyID(t *testing.T) {
+	db := setupTestDB(t, &baby.FamilyGroup{}, &baby.FamilyMember{}, &baby.BabyProfile{}, &baby.UserActiveBaby{},
+		&baby.Device{}, &baby.DeviceShare{}, &baby.CryDetection{}, &baby.MovementDetection{}, &baby.AlertRule{}, &baby.SmartAlert{})
+	// 宝宝由用户1创建，设备属于家庭中的看护人用户3
+	profile := seedFamilyBaby(t, db)
+	device := baby.Device{UserID: 3, DeviceName: "婴儿房摄像头", DeviceType: 1, ProductID: "camera", DeviceSecret: "secret", Status: 1, IsActive: true}
+	if err := db.Create(&device).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := new(CryDetectionService).RecordDeviceCryDetection(&device, &request.DeviceCryDetectionRequest{CryType: 1, Intensity: 5}); err == nil {
+		t.Fatal("设备主人未选择当前宝宝时应拒绝")
+	}
+
+	if err := db.Create(&baby.UserActiveBaby{UserID: 3, BabyID: profile.ID}).Error; err != nil {
+		t.Fatal(err)
+	}
+	cry, err := new(CryDetectionService).RecordDeviceCryDetection(&device, &request.DeviceCryDetectionRequest{CryType: 1, Intensity: 5})
+	if err != nil {
+		t.Fatalf("未指定宝宝的哭声上报: %v", err)
+	}
+	if cry.BabyID != profile.ID {
+		t.Errorf("哭声归属宝宝 = %d, want %d", cry.BabyID, profile.ID)
+	}
+	movement, err := new(DeviceTelemetryService).RecordMovementDetection(&device, &request.DeviceMovementDetectionRequest{MovementType: 1, Intensity: 3})
+	if err != nil {
+		t.Fatalf("未指定宝宝的动作上报: %v", err)
+	}
+	if movement.BabyID != profile.ID {
+		t.Errorf("动作归属宝宝 = %d, want %d", movement.BabyID, profile.ID)
+	}
+	// 指定家庭中他人创建的宝宝
+	if _, err := new(CryDetectionService).RecordDeviceCryDetection(&device, &request.DeviceCryDetectionRequest{BabyID: profile.ID, CryType: 2, Intensity: 4}); err != nil {
+		t.Errorf("指定家庭宝宝的哭声上报: %v", err)
+	}
+
+	// 观察者的设备不能为宝宝记录事件
+	viewerDevice := baby.Device{UserID: 4, DeviceName: "外婆家摄像头", DeviceType: 1, ProductID: "camera", DeviceSecret: "secret", Status: 1, IsActive: true}
+	if err := db.Create(&viewerDevice).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := new(CryDetectionService).RecordDeviceCryDetection(&viewerDevice, &request.DeviceCryDetectionRequest{BabyID: profile.ID, CryType: 1}); err == nil {
+		t.Error("观察者的设备不应记录宝宝事件")
+	}
+}
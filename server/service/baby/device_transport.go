@@ -0,0 +1,104 @@
+package baby
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"baby_admin/server/model/baby"
+)
+
+// DeviceCommandResult 设备命令执行结果
+type DeviceCommandResult struct {
+	Success  bool   `json:"success"`
+	Response string `json:"response"`  // 设备响应内容
+	ErrorMsg string `json:"error_msg"` // 失败原因
+}
+
+// DeviceTransport 设备命令下发通道
+// Send 返回非nil结果表示设备已同步执行完毕；返回nil结果且无错误表示命令已送达，
+// 执行结果稍后通过 DeviceCommandService.ReportCommandResult 回传；返回错误表示下发失败。
+type DeviceTransport interface {
+	Send(ctx context.Context, device *baby.Device, command *baby.DeviceCommand) (*DeviceCommandResult, error)
+}
+
+// DeviceTransportMock 配置项device.transport取该值时使用进程内模拟通道
+const DeviceTransportMock = "mock"
+
+var (
+	deviceTransport   DeviceTransport = unconfiguredDeviceTransport{}
+	deviceTransportMu sync.RWMutex
+)
+
+// SetDeviceTransport 设置设备命令下发通道，接入腾讯云IoT等真实通道时在初始化阶段调用
+func SetDeviceTransport(transport DeviceTransport) {
+	deviceTransportMu.Lock()
+	defer deviceTransportMu.Unlock()
+	deviceTransport = transport
+}
+
+// NewDeviceTransport 按配置名称创建设备命令下发通道，为空时返回拒绝下发的默认通道
+func NewDeviceTransport(name string) (DeviceTransport, error) {
+	switch name {
+	case "":
+		return unconfiguredDeviceTransport{}, nil
+	case DeviceTransportMock:
+		return NewMockDeviceTransport(), nil
+	default:
+		return nil, errors.New("不支持的设备命令下发通道: " + name)
+	}
+}
+
+// getDeviceTransport 获取当前的设备命令下发通道
+func getDeviceTransport() DeviceTransport {
+	deviceTransportMu.RLock()
+	defer deviceTransportMu.RUnlock()
+	return deviceTransport
+}
+
+// unconfiguredDeviceTransport 未接入真实通道时的默认通道，命令一律下发失败，避免未送达的命令被记为执行成功
+type unconfiguredDeviceTransport struct{}
+
+// Send 拒绝下发
+func (unconfiguredDeviceTransport) Send(ctx context.Context, device *baby.Device, command *baby.DeviceCommand) (*DeviceCommandResult, error) {
+	return nil, errors.New("设备命令下发通道未配置")
+}
+
+// MockDeviceTransport 进程内模拟通道，不连接云端，命令立即同步执行完成，用于开发联调和测试
+type MockDeviceTransport struct {
+	mu   sync.Mutex
+	sent []baby.DeviceCommand
+	// Handler 自定义执行逻辑，为空时所有命令均执行成功
+	Handler func(device *baby.Device, command *baby.DeviceCommand) (*DeviceCommandResult, error)
+}
+
+// NewMockDeviceTransport 创建模拟通道
+func NewMockDeviceTransport() *MockDeviceTransport {
+	return &MockDeviceTransport{}
+}
+
+// Send 记录命令并模拟设备执行
+func (m *MockDeviceTransport) Send(ctx context.Context, device *baby.Device, command *baby.DeviceCommand) (*DeviceCommandResult, error) {
+	m.mu.Lock()
+	m.sent = append(m.sent, *command)
+	handler := m.Handler
+	m.mu.Unlock()
+
+	if handler != nil {
+		return handler(device, command)
+	}
+
+	resp, _ := json.Marshal(map[string]interface{}{
+		"code":    0,
+		"message": "ok",
+		"command": command.Command,
+	})
+	return &DeviceCommandResult{Success: true, Response: string(resp)}, nil
+}
+
+// Sent 返回已下发的命令
+func (m *MockDeviceTransport) Sent() []baby.DeviceCommand {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]baby.DeviceCommand(nil), m.sent...)
+}
@@ -0,0 +1,30 @@
+package baby
+
+import (
+	"context"
+	"testing"
+	"baby_admin/server/model/baby"
+)
+
+func TestUnconfiguredDeviceTransport(t *testing.T) {
+	result, err := unconfiguredDeviceTransport{}.Send(context.Background(), &baby.Device{}, &baby.DeviceCommand{})
+	if err == nil || result != nil {
+		t.Fatalf("Send() = %v, %v, want error", result, err)
+	}
+}
+
+func TestNewDeviceTransport(t *testing.T) {
+	if transport, err := NewDeviceTransport(""); err != nil {
+		t.Errorf("默认通道: %v", err)
+	} else if _, ok := transport.(unconfiguredDeviceTransport); !ok {
+		t.Errorf("默认通道 = %T, want unconfiguredDeviceTransport", transport)
+	}
+	if transport, err := NewDeviceTransport(DeviceTransportMock); err != nil {
+		t.Errorf("模拟通道: %v", err)
+	} else if _, ok := transport.(*MockDeviceTransport); !ok {
+		t.Errorf("模拟通道 = %T, want *MockDeviceTransport", transport)
+	}
+	if _, err := NewDeviceTransport("mqtt"); err == nil {
+		t.Error("未知通道应返回错误")
+	}
+}
@@ -1,7 +1,26 @@
 package baby
 
 type ServiceGroup struct {
+	AnalysisReportService
 	BabyProfileService
+	CryDetectionService
+	DeviceService
+	DeviceCommandService
+	DeviceTelemetryService
+	FamilyService
+	FavoriteService
+	FeedingService
 	GrowthRecordService
+	HealthService
+	MediaService
+	MembershipAdminService
+	MembershipService
+	MilestoneService
 	MusicService
+	ParentingService
+	PlaybackService
+	SleepRecordService
+	SmartAlertService
+	VaccineAdminService
+	VaccineService
 }
\ No newline at end of file
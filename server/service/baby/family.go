@@ -0,0 +1,706 @@
+package baby
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"baby_admin/server/model/system"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FamilyService struct{}
+
+// 家庭成员角色，对应FamilyMember.Role
+const (
+	familyRoleOwner     = 1
+	familyRoleParent    = 2
+	familyRoleCaregiver = 3
+	familyRoleViewer    = 4
+)
+
+// 家庭成员状态，对应FamilyMember.Status
+const (
+	familyMemberPending  = 1
+	familyMemberApproved = 2
+)
+
+// 宝宝访问级别，由成员在宝宝所属家庭中的角色决定
+const (
+	babyAccessRead   = 1 // 观察者：查看宝宝档案及公开的记录
+	babyAccessRecord = 2 // 看护人：添加记录，修改自己的记录
+	babyAccessManage = 3 // 家长：编辑宝宝档案及他人的记录，审核加入申请
+	babyAccessOwner  = 4 // 创建者：删除宝宝档案，管理家庭
+)
+
+const (
+	familyInviteValidDays  = 7
+	familyInviteCodeLength = 8
+	// familyInviteCodeChars 邀请码字符，去掉了易混淆的0、O、1、I
+	familyInviteCodeChars = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	defaultFamilyName     = "我的家庭"
+)
+
+// CreateFamily 创建家庭，创建者自动成为家庭成员
+func (s *FamilyService) CreateFamily(userID uint, req *request.CreateFamilyRequest) (*response.FamilyGroupResponse, error) {
+	var family *baby.FamilyGroup
+	var member *baby.FamilyMember
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		family, member, err = createFamily(tx, userID, req.Name, req.Relation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := buildFamilyResponse(family, member)
+	resp.MemberCount = 1
+	return &resp, nil
+}
+
+// GetMyFamilies 获取当前用户加入及申请中的家庭
+func (s *FamilyService) GetMyFamilies(userID uint) ([]response.FamilyGroupResponse, error) {
+	var members []baby.FamilyMember
+	if err := global.GVA_DB.Where("user_id = ?", userID).Order("id ASC").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return []response.FamilyGroupResponse{}, nil
+	}
+
+	familyIDs := make([]uint, len(members))
+	for i := range members {
+		familyIDs[i] = members[i].FamilyID
+	}
+	var families []baby.FamilyGroup
+	if err := global.GVA_DB.Where("id IN ?", familyIDs).Find(&families).Error; err != nil {
+		return nil, err
+	}
+	familyMap := make(map[uint]*baby.FamilyGroup, len(families))
+	for i := range families {
+		familyMap[families[i].ID] = &families[i]
+	}
+
+	memberCounts, err := countByFamily(&baby.FamilyMember{}, familyIDs, "status = ?", familyMemberApproved)
+	if err != nil {
+		return nil, err
+	}
+	babyCounts, err := countByFamily(&baby.BabyProfile{}, familyIDs, "")
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]response.FamilyGroupResponse, 0, len(members))
+	for i := range members {
+		family, ok := familyMap[members[i].FamilyID]
+		if !ok {
+			continue
+		}
+		resp := buildFamilyResponse(family, &members[i])
+		resp.MemberCount = memberCounts[family.ID]
+		resp.BabyCount = babyCounts[family.ID]
+		list = append(list, resp)
+	}
+	return list, nil
+}
+
+// GetFamilyDetail 获取家庭详情，包括成员、宝宝及待审核的申请
+func (s *FamilyService) GetFamilyDetail(userID uint, familyID uint) (*response.FamilyDetailResponse, error) {
+	family, member, err := authorizeFamily(familyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []baby.FamilyMember
+	if err := global.GVA_DB.Where("family_id = ?", family.ID).Order("role ASC, id ASC").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	userMap, err := familyUserMap(members)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.FamilyDetailResponse{
+		FamilyGroupResponse: buildFamilyResponse(family, member),
+		Members:             []response.FamilyMemberResponse{},
+		PendingMembers:      []response.FamilyMemberResponse{},
+		Babies:              []response.BabyProfileResponse{},
+	}
+	canReview := familyRoleAccess(member.Role) >= babyAccessManage
+	for i := range members {
+		var item response.FamilyMemberResponse
+		user := userMap[members[i].UserID]
+		item.FromFamilyMember(&members[i], user.NickName, user.Avatar)
+		switch {
+		case members[i].Status == familyMemberApproved:
+			result.Members = append(result.Members, item)
+		case canReview:
+			result.PendingMembers = append(result.PendingMembers, item)
+		}
+	}
+	result.MemberCount = int64(len(result.Members))
+
+	var babies []baby.BabyProfile
+	if err := global.GVA_DB.Where("family_id = ?", family.ID).Order("birthday ASC").Find(&babies).Error; err != nil {
+		return nil, err
+	}
+	activeBabyID := getActiveBabyID(userID)
+	for i := range babies {
+		result.Babies = append(result.Babies, buildBabyProfileResponse(&babies[i], member.Role, activeBabyID))
+	}
+	result.BabyCount = int64(len(babies))
+	return result, nil
+}
+
+// UpdateFamily 修改家庭名称
+func (s *FamilyService) UpdateFamily(userID uint, req *request.UpdateFamilyRequest) error {
+	family, _, err := authorizeFamily(req.ID, userID, babyAccessManage)
+	if err != nil {
+		return err
+	}
+	return global.GVA_DB.Model(family).Update("name", req.Name).Error
+}
+
+// RefreshInviteCode 生成新的邀请码，旧邀请码随即失效，只有创建者可以邀请家长
+func (s *FamilyService) RefreshInviteCode(userID uint, req *request.RefreshInviteCodeRequest) (*response.FamilyInviteResponse, error) {
+	family, member, err := authorizeFamily(req.FamilyID, userID, babyAccessManage)
+	if err != nil {
+		return nil, err
+	}
+	if req.Role == familyRoleParent && member.Role != familyRoleOwner {
+		return nil, errors.New("只有创建者可以邀请家长")
+	}
+
+	validDays := req.ValidDays
+	if validDays == 0 {
+		validDays = familyInviteValidDays
+	}
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, err
+	}
+	expiredAt := time.Now().AddDate(0, 0, validDays)
+	err = global.GVA_DB.Model(family).Updates(map[string]interface{}{
+		"invite_code":       code,
+		"invite_role":       req.Role,
+		"invite_expired_at": expiredAt,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	family.InviteRole = req.Role
+	return &response.FamilyInviteResponse{
+		FamilyID:       family.ID,
+		InviteCode:     code,
+		InviteRole:     req.Role,
+		InviteRoleText: family.GetInviteRoleText(),
+		ExpiredAt:      &expiredAt,
+	}, nil
+}
+
+// JoinFamily 通过邀请码申请加入家庭，需创建者或家长审核
+func (s *FamilyService) JoinFamily(userID uint, req *request.JoinFamilyRequest) (*response.FamilyGroupResponse, error) {
+	var family baby.FamilyGroup
+	var member *baby.FamilyMember
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		// 锁定家庭，串行化同一家庭的加入申请
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("invite_code = ?", req.InviteCode).First(&family).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("邀请码无效或已过期")
+			}
+			return err
+		}
+		if !family.IsInviteValid(time.Now()) {
+			return errors.New("邀请码无效或已过期")
+		}
+
+		existing, err := getFamilyMember(tx, family.ID, userID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if existing.Status == familyMemberApproved {
+				return errors.New("你已是该家庭成员")
+			}
+			return errors.New("已提交申请，请等待审核")
+		}
+
+		member = &baby.FamilyMember{
+			FamilyID: family.ID,
+			UserID:   userID,
+			Role:     family.InviteRole,
+			Status:   familyMemberPending,
+			Relation: req.Relation,
+		}
+		return tx.Create(member).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := buildFamilyResponse(&family, member)
+	return &resp, nil
+}
+
+// ReviewMember 审核加入申请，通过时可调整角色，拒绝时删除申请
+func (s *FamilyService) ReviewMember(userID uint, req *request.ReviewFamilyMemberRequest) error {
+	var member baby.FamilyMember
+	err := global.GVA_DB.Where("id = ? AND status = ?", req.MemberID, familyMemberPending).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("加入申请不存在")
+		}
+		return err
+	}
+	_, reviewer, err := authorizeFamily(member.FamilyID, userID, babyAccessManage)
+	if err != nil {
+		return err
+	}
+
+	if !req.Approve {
+		result := global.GVA_DB.Where("id = ? AND status = ?", member.ID, familyMemberPending).Delete(&baby.FamilyMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("该申请已处理")
+		}
+		return nil
+	}
+
+	role := member.Role
+	if req.Role > 0 {
+		role = req.Role
+	}
+	if role == familyRoleParent && reviewer.Role != familyRoleOwner {
+		return errors.New("只有创建者可以授予家长角色")
+	}
+
+	result := global.GVA_DB.Model(&baby.FamilyMember{}).
+		Where("id = ? AND status = ?", member.ID, familyMemberPending).
+		Updates(map[string]interface{}{
+			"role":        role,
+			"status":      familyMemberApproved,
+			"reviewed_by": userID,
+			"joined_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("该申请已处理")
+	}
+	return nil
+}
+
+// UpdateMember 修改成员角色及关系，只有创建者可以操作
+func (s *FamilyService) UpdateMember(userID uint, req *request.UpdateFamilyMemberRequest) error {
+	member, err := getApprovedMember(req.MemberID)
+	if err != nil {
+		return err
+	}
+	if _, _, err := authorizeFamily(member.FamilyID, userID, babyAccessOwner); err != nil {
+		return err
+	}
+	if member.Role == familyRoleOwner {
+		return errors.New("不能修改创建者的角色，请使用转让家庭")
+	}
+
+	return global.GVA_DB.Model(member).Updates(map[string]interface{}{
+		"role":     req.Role,
+		"relation": req.Relation,
+	}).Error
+}
+
+// RemoveMember 移出家庭成员，家长只能移出看护人和观察者
+func (s *FamilyService) RemoveMember(userID uint, memberID uint) error {
+	member, err := getApprovedMember(memberID)
+	if err != nil {
+		return err
+	}
+	_, operator, err := authorizeFamily(member.FamilyID, userID, babyAccessManage)
+	if err != nil {
+		return err
+	}
+	if member.UserID == userID {
+		return errors.New("不能移出自己，请使用退出家庭")
+	}
+	if member.Role == familyRoleOwner {
+		return errors.New("不能移出创建者")
+	}
+	if member.Role == familyRoleParent && operator.Role != familyRoleOwner {
+		return errors.New("只有创建者可以移出家长")
+	}
+
+	return removeFamilyMember(member)
+}
+
+// LeaveFamily 退出家庭或撤回加入申请，创建者需先转让家庭
+func (s *FamilyService) LeaveFamily(userID uint, familyID uint) error {
+	member, err := getFamilyMember(global.GVA_DB, familyID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return errors.New("你不是该家庭成员")
+	}
+	if member.Role == familyRoleOwner {
+		return errors.New("创建者不能退出家庭，请先转让家庭")
+	}
+
+	return removeFamilyMember(member)
+}
+
+// TransferFamily 将家庭转让给其他成员，原创建者成为家长
+func (s *FamilyService) TransferFamily(userID uint, req *request.TransferFamilyRequest) error {
+	family, owner, err := authorizeFamily(req.FamilyID, userID, babyAccessOwner)
+	if err != nil {
+		return err
+	}
+	member, err := getApprovedMember(req.MemberID)
+	if err != nil {
+		return err
+	}
+	if member.FamilyID != family.ID {
+		return errors.New("家庭成员不存在")
+	}
+	if member.UserID == userID {
+		return errors.New("不能转让给自己")
+	}
+
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(member).Update("role", familyRoleOwner).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(owner).Update("role", familyRoleParent).Error; err != nil {
+			return err
+		}
+		return tx.Model(family).Update("owner_id", member.UserID).Error
+	})
+}
+
+// DeleteFamily 解散家庭，家庭中仍有宝宝档案时不能解散
+func (s *FamilyService) DeleteFamily(userID uint, familyID uint) error {
+	family, _, err := authorizeFamily(familyID, userID, babyAccessOwner)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	if err := global.GVA_DB.Model(&baby.BabyProfile{}).Where("family_id = ?", family.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("请先删除家庭中的宝宝档案或移至其他家庭")
+	}
+
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("family_id = ?", family.ID).Delete(&baby.FamilyMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(family).Error
+	})
+}
+
+// createFamily 创建家庭及创建者成员记录
+func createFamily(tx *gorm.DB, userID uint, name string, relation string) (*baby.FamilyGroup, *baby.FamilyMember, error) {
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, nil, err
+	}
+	expiredAt := time.Now().AddDate(0, 0, familyInviteValidDays)
+	family := &baby.FamilyGroup{
+		Name:            name,
+		OwnerID:         userID,
+		InviteCode:      code,
+		InviteRole:      familyRoleCaregiver,
+		InviteExpiredAt: &expiredAt,
+	}
+	if err := tx.Create(family).Error; err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	member := &baby.FamilyMember{
+		FamilyID: family.ID,
+		UserID:   userID,
+		Role:     familyRoleOwner,
+		Status:   familyMemberApproved,
+		Relation: relation,
+		JoinedAt: &now,
+	}
+	if err := tx.Create(member).Error; err != nil {
+		return nil, nil, err
+	}
+	return family, member, nil
+}
+
+// defaultFamilyID 用户创建的第一个家庭，没有时自动创建
+func defaultFamilyID(tx *gorm.DB, userID uint) (uint, error) {
+	var family baby.FamilyGroup
+	err := tx.Where("owner_id = ?", userID).Order("id ASC").First(&family).Error
+	if err == nil {
+		return family.ID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	created, _, err := createFamily(tx, userID, defaultFamilyName, "")
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// removeFamilyMember 删除成员记录，并清除其在该家庭中选择的当前宝宝
+func removeFamilyMember(member *baby.FamilyMember) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(member).Error; err != nil {
+			return err
+		}
+		familyBabyIDs := tx.Model(&baby.BabyProfile{}).Where("family_id = ?", member.FamilyID).Select("id")
+		return tx.Unscoped().Where("user_id = ? AND baby_id IN (?)", member.UserID, familyBabyIDs).
+			Delete(&baby.UserActiveBaby{}).Error
+	})
+}
+
+// authorizeFamily 校验用户在家庭中的角色，返回家庭及当前用户的成员记录
+func authorizeFamily(familyID uint, userID uint, level int) (*baby.FamilyGroup, *baby.FamilyMember, error) {
+	var family baby.FamilyGroup
+	if err := global.GVA_DB.Where("id = ?", familyID).First(&family).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("家庭不存在")
+		}
+		return nil, nil, err
+	}
+
+	member, err := getFamilyMember(global.GVA_DB, family.ID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if member == nil || member.Status != familyMemberApproved {
+		return nil, nil, errors.New("家庭不存在")
+	}
+	if familyRoleAccess(member.Role) < level {
+		return nil, nil, errors.New("家庭角色权限不足")
+	}
+	return &family, member, nil
+}
+
+// getFamilyMember 获取用户在家庭中的成员记录，不存在时返回nil
+func getFamilyMember(db *gorm.DB, familyID uint, userID uint) (*baby.FamilyMember, error) {
+	var member baby.FamilyMember
+	err := db.Where("family_id = ? AND user_id = ?", familyID, userID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// getApprovedMember 根据ID获取已加入的成员记录
+func getApprovedMember(memberID uint) (*baby.FamilyMember, error) {
+	var member baby.FamilyMember
+	err := global.GVA_DB.Where("id = ? AND status = ?", memberID, familyMemberApproved).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("家庭成员不存在")
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// familyRoleAccess 家庭角色对应的宝宝访问级别
+func familyRoleAccess(role int) int {
+	switch role {
+	case familyRoleOwner:
+		return babyAccessOwner
+	case familyRoleParent:
+		return babyAccessManage
+	case familyRoleCaregiver:
+		return babyAccessRecord
+	case familyRoleViewer:
+		return babyAccessRead
+	default:
+		return 0
+	}
+}
+
+// familyRolesWithAccess 访问级别不低于level的家庭角色
+func familyRolesWithAccess(level int) []int {
+	var roles []int
+	for _, role := range []int{familyRoleOwner, familyRoleParent, familyRoleCaregiver, familyRoleViewer} {
+		if familyRoleAccess(role) >= level {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// babyFamilyRole 用户对宝宝的家庭角色，未加入家庭的宝宝创建者视为创建者，无权访问时返回0
+func babyFamilyRole(profile *baby.BabyProfile, userID uint) (int, error) {
+	if profile.FamilyID == 0 {
+		if profile.UserID == userID {
+			return familyRoleOwner, nil
+		}
+		return 0, nil
+	}
+
+	member, err := getFamilyMember(global.GVA_DB, profile.FamilyID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if member == nil || member.Status != familyMemberApproved {
+		return 0, nil
+	}
+	return member.Role, nil
+}
+
+// authorizeBaby 校验用户对宝宝档案的访问权限，返回宝宝档案及用户的访问级别
+func authorizeBaby(id uint, userID uint, level int) (*baby.BabyProfile, int, error) {
+	var profile baby.BabyProfile
+	err := global.GVA_DB.Where("id = ?", id).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, errors.New("宝宝档案不存在")
+		}
+		return nil, 0, err
+	}
+
+	role, err := babyFamilyRole(&profile, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	access := familyRoleAccess(role)
+	if access == 0 {
+		return nil, 0, errors.New("宝宝档案不存在")
+	}
+	if access < level {
+		return nil, 0, errors.New("家庭角色权限不足")
+	}
+	return &profile, access, nil
+}
+
+// accessibleBabyIDs 用户访问级别不低于level的宝宝ID子查询：所在家庭的宝宝及自己创建的未加入家庭的宝宝
+func accessibleBabyIDs(userID uint, level int) *gorm.DB {
+	familyIDs := global.GVA_DB.Model(&baby.FamilyMember{}).
+		Where("user_id = ? AND status = ? AND role IN ?", userID, familyMemberApproved, familyRolesWithAccess(level)).
+		Select("family_id")
+	return global.GVA_DB.Model(&baby.BabyProfile{}).
+		Where("family_id IN (?) OR (family_id = 0 AND user_id = ?)", familyIDs, userID).Select("id")
+}
+
+// babyMemberIDs 对宝宝访问级别不低于level的用户ID子查询：宝宝所在家庭的成员，未加入家庭时为创建者
+func babyMemberIDs(profile *baby.BabyProfile, level int) *gorm.DB {
+	if profile.FamilyID == 0 {
+		return global.GVA_DB.Model(&baby.BabyProfile{}).Where("id = ?", profile.ID).Select("user_id")
+	}
+	return global.GVA_DB.Model(&baby.FamilyMember{}).
+		Where("family_id = ? AND status = ? AND role IN ?", profile.FamilyID, familyMemberApproved, familyRolesWithAccess(level)).
+		Select("user_id")
+}
+
+// authorizeBabyRecord 校验用户对宝宝日常记录的访问权限，返回记录所属宝宝及用户的访问级别
+// 家庭成员均可查看宝宝的记录；level高于查看时，修改、删除他人添加的记录需要家长及以上角色
+func authorizeBabyRecord(babyID uint, authorID uint, userID uint, level int, notFound string) (*baby.BabyProfile, int, error) {
+	profile, access, err := authorizeBaby(babyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, 0, errors.New(notFound)
+	}
+	if access < level || (level > babyAccessRead && authorID != userID && access < babyAccessManage) {
+		return nil, 0, errors.New("家庭角色权限不足")
+	}
+	return profile, access, nil
+}
+
+// userFamilyRoles 用户在已加入家庭中的角色，key为家庭ID
+func userFamilyRoles(userID uint) (map[uint]int, error) {
+	var members []baby.FamilyMember
+	err := global.GVA_DB.Where("user_id = ? AND status = ?", userID, familyMemberApproved).Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[uint]int, len(members))
+	for _, member := range members {
+		roles[member.FamilyID] = member.Role
+	}
+	return roles, nil
+}
+
+// familyUserMap 成员对应的用户信息
+func familyUserMap(members []baby.FamilyMember) (map[uint]system.MiniprogramUser, error) {
+	userIDs := make([]uint, len(members))
+	for i := range members {
+		userIDs[i] = members[i].UserID
+	}
+	var users []system.MiniprogramUser
+	if len(userIDs) > 0 {
+		if err := global.GVA_DB.Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+			return nil, err
+		}
+	}
+	userMap := make(map[uint]system.MiniprogramUser, len(users))
+	for _, user := range users {
+		userMap[user.ID] = user
+	}
+	return userMap, nil
+}
+
+// countByFamily 按家庭统计记录数
+func countByFamily(model interface{}, familyIDs []uint, query string, args ...interface{}) (map[uint]int64, error) {
+	var rows []struct {
+		FamilyID uint
+		Count    int64
+	}
+	db := global.GVA_DB.Model(model).Select("family_id, COUNT(*) AS count").Where("family_id IN ?", familyIDs)
+	if query != "" {
+		db = db.Where(query, args...)
+	}
+	if err := db.Group("family_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.FamilyID] = row.Count
+	}
+	return counts, nil
+}
+
+// buildFamilyResponse 组装家庭响应，邀请码仅对创建者和家长可见
+func buildFamilyResponse(family *baby.FamilyGroup, member *baby.FamilyMember) response.FamilyGroupResponse {
+	var resp response.FamilyGroupResponse
+	resp.FromFamilyGroup(family, member)
+	if member.Status == familyMemberApproved && familyRoleAccess(member.Role) >= babyAccessManage {
+		resp.InviteCode = family.InviteCode
+		resp.InviteRole = family.InviteRole
+		resp.InviteRoleText = family.GetInviteRoleText()
+		resp.InviteExpiredAt = family.InviteExpiredAt
+	}
+	return resp
+}
+
+// generateInviteCode 生成随机邀请码
+func generateInviteCode() (string, error) {
+	max := big.NewInt(int64(len(familyInviteCodeChars)))
+	code := make([]byte, familyInviteCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = familyInviteCodeChars[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,59 @@
+package baby
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+)
+
+func TestFamilyRolesWithAccess(t *testing.T) {
+	tests := []struct {
+		level int
+		want  []int
+	}{
+		{babyAccessRead, []int{familyRoleOwner, familyRoleParent, familyRoleCaregiver, familyRoleViewer}},
+		{babyAccessRecord, []int{familyRoleOwner, familyRoleParent, familyRoleCaregiver}},
+		{babyAccessManage, []int{familyRoleOwner, familyRoleParent}},
+		{babyAccessOwner, []int{familyRoleOwner}},
+	}
+	for _, tt := range tests {
+		if got := familyRolesWithAccess(tt.level); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("familyRolesWithAccess(%d) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+	if got := familyRoleAccess(0); got != 0 {
+		t.Errorf("familyRoleAccess(0) = %d, want 0", got)
+	}
+}
+
+func TestBabyFamilyRoleWithoutFamily(t *testing.T) {
+	// 未加入家庭的宝宝仅创建者可访问
+	profile := &baby.BabyProfile{UserID: 7}
+	if role, err := babyFamilyRole(profile, 7); err != nil || role != familyRoleOwner {
+		t.Errorf("creator role = %d, %v, want %d", role, err, familyRoleOwner)
+	}
+	if role, err := babyFamilyRole(profile, 8); err != nil || role != 0 {
+		t.Errorf("other user role = %d, %v, want 0", role, err)
+	}
+}
+
+func TestFamilyInviteValid(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+	tests := []struct {
+		name   string
+		family baby.FamilyGroup
+		want   bool
+	}{
+		{"无邀请码", baby.FamilyGroup{}, false},
+		{"长期有效", baby.FamilyGroup{InviteCode: "ABCD2345"}, true},
+		{"未过期", baby.FamilyGroup{InviteCode: "ABCD2345", InviteExpiredAt: &future}, true},
+		{"已过期", baby.FamilyGroup{InviteCode: "ABCD2345", InviteExpiredAt: &past}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.family.IsInviteValid(now); got != tt.want {
+			t.Errorf("%s: IsInviteValid = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
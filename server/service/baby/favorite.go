@@ -0,0 +1,437 @@
+package baby
+
+import (
+	"errors"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FavoriteService struct{}
+
+// defaultFolderName 默认收藏夹名称，对应FolderID为0
+const defaultFolderName = "默认收藏夹"
+
+// favoriteContentTable 各收藏类型对应的内容表
+var favoriteContentTable = map[int]string{
+	contentTypeArticle: "parenting_articles",
+	contentTypeVideo:   "parenting_videos",
+	contentTypeMusic:   "musics",
+}
+
+// ToggleFavorite 切换收藏状态，同时维护内容的点赞数
+// 同一内容的收藏操作通过锁定内容行串行执行，连续点击既不会重复计数也不会把点赞数减成负数
+func (s *FavoriteService) ToggleFavorite(userID uint, req *request.ToggleFavoriteRequest) (*response.FavoriteToggleResponse, error) {
+	if req.FolderID > 0 {
+		if _, err := s.getUserFolder(req.FolderID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &response.FavoriteToggleResponse{ContentType: req.ContentType, ContentID: req.ContentID}
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		table := favoriteContentTable[req.ContentType]
+		var content struct {
+			ID        uint
+			LikeCount int64
+		}
+		err := tx.Table(table).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id, like_count").
+			Where("id = ? AND is_active = ? AND deleted_at IS NULL", req.ContentID, true).Take(&content).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(contentTypeText(req.ContentType) + "不存在")
+			}
+			return err
+		}
+
+		var count int64
+		err = s.favoriteScope(tx, userID, req.ContentType, []uint{req.ContentID}).Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			// 已收藏，取消收藏（历史重复记录一并删除）
+			if err := s.deleteFavorites(tx, userID, req.ContentType, []uint{req.ContentID}); err != nil {
+				return err
+			}
+			err = tx.Table(table).Where("id = ?", content.ID).
+				Update("like_count", gorm.Expr("CASE WHEN like_count > 0 THEN like_count - 1 ELSE 0 END")).Error
+			if err != nil {
+				return err
+			}
+			result.IsFavorited = false
+			if content.LikeCount > 0 {
+				result.LikeCount = content.LikeCount - 1
+			}
+			return nil
+		}
+
+		// 未收藏，添加收藏
+		if req.ContentType == contentTypeMusic {
+			err = tx.Create(&baby.UserMusicFavorite{UserID: userID, MusicID: content.ID, FolderID: req.FolderID}).Error
+		} else {
+			err = tx.Create(&baby.UserContentFavorite{UserID: userID, ContentType: req.ContentType, ContentID: content.ID, FolderID: req.FolderID}).Error
+		}
+		if err != nil {
+			return err
+		}
+		err = tx.Table(table).Where("id = ?", content.ID).Update("like_count", gorm.Expr("like_count + ?", 1)).Error
+		if err != nil {
+			return err
+		}
+		result.IsFavorited = true
+		result.LikeCount = content.LikeCount + 1
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CheckFavorites 批量查询内容的收藏状态，按请求的ID顺序返回
+func (s *FavoriteService) CheckFavorites(userID uint, req *request.CheckFavoriteRequest) ([]response.FavoriteStatus, error) {
+	var rows []struct {
+		ContentID uint
+		FolderID  uint
+	}
+	err := s.favoriteScope(global.GVA_DB, userID, req.ContentType, req.ContentIDs).
+		Select(s.contentColumn(req.ContentType) + " AS content_id, folder_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	folderMap := make(map[uint]uint, len(rows))
+	for _, row := range rows {
+		folderMap[row.ContentID] = row.FolderID
+	}
+
+	result := make([]response.FavoriteStatus, 0, len(req.ContentIDs))
+	for _, id := range req.ContentIDs {
+		folderID, ok := folderMap[id]
+		result = append(result, response.FavoriteStatus{ContentID: id, IsFavorited: ok, FolderID: folderID})
+	}
+	return result, nil
+}
+
+// GetFavoriteList 获取收藏列表，未指定类型时合并文章、视频和音乐收藏按时间倒序返回
+func (s *FavoriteService) GetFavoriteList(userID uint, req *request.FavoriteSearch) (*response.FavoriteListResponse, error) {
+	var queries []interface{}
+	if req.ContentType != contentTypeMusic {
+		db := global.GVA_DB.Model(&baby.UserContentFavorite{}).
+			Select("id, content_type, content_id, folder_id, created_at").Where("user_id = ?", userID)
+		if req.ContentType > 0 {
+			db = db.Where("content_type = ?", req.ContentType)
+		}
+		if req.FolderID != nil {
+			db = db.Where("folder_id = ?", *req.FolderID)
+		}
+		queries = append(queries, db)
+	}
+	if req.ContentType == 0 || req.ContentType == contentTypeMusic {
+		db := global.GVA_DB.Model(&baby.UserMusicFavorite{}).
+			Select("id, ? AS content_type, music_id AS content_id, folder_id, created_at", contentTypeMusic).Where("user_id = ?", userID)
+		if req.FolderID != nil {
+			db = db.Where("folder_id = ?", *req.FolderID)
+		}
+		queries = append(queries, db)
+	}
+
+	source := global.GVA_DB.Table("(?) AS favorites", queries[0])
+	if len(queries) > 1 {
+		source = global.GVA_DB.Table("(? UNION ALL ?) AS favorites", queries...)
+	}
+
+	// 获取总数
+	var total int64
+	if err := source.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var rows []favoriteRow
+	offset := (req.Page - 1) * req.PageSize
+	err := source.Select("id, content_type, content_id, folder_id, created_at").
+		Offset(offset).Limit(req.PageSize).Order("created_at DESC, id DESC").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.FavoriteListResponse{
+		List:     s.buildFavoriteItems(userID, rows),
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// MoveFavorites 将收藏移动到指定收藏夹，FolderID为0时移回默认收藏夹
+func (s *FavoriteService) MoveFavorites(userID uint, req *request.MoveFavoritesRequest) (int64, error) {
+	if req.FolderID > 0 {
+		if _, err := s.getUserFolder(req.FolderID, userID); err != nil {
+			return 0, err
+		}
+	}
+
+	result := s.favoriteScope(global.GVA_DB, userID, req.ContentType, req.ContentIDs).Update("folder_id", req.FolderID)
+	return result.RowsAffected, result.Error
+}
+
+// CreateFolder 创建收藏夹
+func (s *FavoriteService) CreateFolder(userID uint, req *request.CreateFavoriteFolderRequest) (*response.FavoriteFolderResponse, error) {
+	if err := s.checkFolderName(userID, 0, req.Name); err != nil {
+		return nil, err
+	}
+
+	folder := &baby.FavoriteFolder{
+		UserID:    userID,
+		Name:      req.Name,
+		SortOrder: req.SortOrder,
+	}
+	if err := global.GVA_DB.Create(folder).Error; err != nil {
+		return nil, err
+	}
+
+	return &response.FavoriteFolderResponse{
+		ID:        folder.ID,
+		Name:      folder.Name,
+		SortOrder: folder.SortOrder,
+		CreatedAt: folder.CreatedAt,
+	}, nil
+}
+
+// GetFolderList 获取收藏夹列表，第一项为默认收藏夹
+func (s *FavoriteService) GetFolderList(userID uint) ([]response.FavoriteFolderResponse, error) {
+	var folders []baby.FavoriteFolder
+	err := global.GVA_DB.Where("user_id = ?", userID).Order("sort_order ASC, id ASC").Find(&folders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 统计各收藏夹的收藏数量
+	counts := make(map[uint]int64)
+	for _, model := range []interface{}{&baby.UserContentFavorite{}, &baby.UserMusicFavorite{}} {
+		var rows []struct {
+			FolderID uint
+			Count    int64
+		}
+		err := global.GVA_DB.Model(model).Select("folder_id, COUNT(*) AS count").
+			Where("user_id = ?", userID).Group("folder_id").Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			counts[row.FolderID] += row.Count
+		}
+	}
+
+	result := make([]response.FavoriteFolderResponse, 0, len(folders)+1)
+	result = append(result, response.FavoriteFolderResponse{Name: defaultFolderName, ItemCount: counts[0]})
+	for _, folder := range folders {
+		result = append(result, response.FavoriteFolderResponse{
+			ID:        folder.ID,
+			Name:      folder.Name,
+			SortOrder: folder.SortOrder,
+			ItemCount: counts[folder.ID],
+			CreatedAt: folder.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// UpdateFolder 更新收藏夹
+func (s *FavoriteService) UpdateFolder(userID uint, req *request.UpdateFavoriteFolderRequest) error {
+	folder, err := s.getUserFolder(req.ID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkFolderName(userID, folder.ID, req.Name); err != nil {
+		return err
+	}
+
+	return global.GVA_DB.Model(folder).Updates(map[string]interface{}{
+		"name":       req.Name,
+		"sort_order": req.SortOrder,
+	}).Error
+}
+
+// DeleteFolder 删除收藏夹，其中的收藏移回默认收藏夹
+func (s *FavoriteService) DeleteFolder(id uint, userID uint) error {
+	folder, err := s.getUserFolder(id, userID)
+	if err != nil {
+		return err
+	}
+
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&baby.UserContentFavorite{}, &baby.UserMusicFavorite{}} {
+			err := tx.Model(model).Where("user_id = ? AND folder_id = ?", userID, folder.ID).Update("folder_id", 0).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Delete(folder).Error
+	})
+}
+
+// favoriteRow 合并查询的收藏记录
+type favoriteRow struct {
+	ID          uint
+	ContentType int
+	ContentID   uint
+	FolderID    uint
+	CreatedAt   time.Time
+}
+
+// buildFavoriteItems 按类型批量加载收藏的内容，已下架的内容不返回详情
+func (s *FavoriteService) buildFavoriteItems(userID uint, rows []favoriteRow) []response.FavoriteItemResponse {
+	idsByType := make(map[int][]uint)
+	for _, row := range rows {
+		idsByType[row.ContentType] = append(idsByType[row.ContentType], row.ContentID)
+	}
+
+	parentingService := new(ParentingService)
+	articleMap := make(map[uint]*response.ParentingArticleResponse)
+	if ids := idsByType[contentTypeArticle]; len(ids) > 0 {
+		var articles []baby.ParentingArticle
+		global.GVA_DB.Where("id IN ? AND is_active = ?", ids, true).Find(&articles)
+		list := parentingService.buildArticleResponses(userID, articles)
+		for i := range list {
+			articleMap[list[i].ID] = &list[i]
+		}
+	}
+
+	videoMap := make(map[uint]*response.ParentingVideoResponse)
+	if ids := idsByType[contentTypeVideo]; len(ids) > 0 {
+		var videos []baby.ParentingVideo
+		global.GVA_DB.Where("id IN ? AND is_active = ?", ids, true).Find(&videos)
+		list := parentingService.buildVideoResponses(userID, videos)
+		for i := range list {
+			videoMap[list[i].ID] = &list[i]
+		}
+	}
+
+	musicMap := make(map[uint]*response.MusicResponse)
+	if ids := idsByType[contentTypeMusic]; len(ids) > 0 {
+		var musics []baby.Music
+		global.GVA_DB.Where("id IN ? AND is_active = ?", ids, true).Find(&musics)
+
+		var categoryIDs []uint
+		for _, music := range musics {
+			categoryIDs = append(categoryIDs, music.CategoryID)
+		}
+		var categories []baby.MusicCategory
+		if len(categoryIDs) > 0 {
+			global.GVA_DB.Where("id IN ?", categoryIDs).Find(&categories)
+		}
+		categoryMap := make(map[uint]string)
+		for _, category := range categories {
+			categoryMap[category.ID] = category.Name
+		}
+
+		vip := newVipChecker(userID)
+		for i := range musics {
+			var resp response.MusicResponse
+			resp.FromMusic(&musics[i], categoryMap[musics[i].CategoryID], true)
+			vip.guardMusic(&resp, musics[i].IsVIP)
+			musicMap[musics[i].ID] = &resp
+		}
+	}
+
+	items := make([]response.FavoriteItemResponse, 0, len(rows))
+	for _, row := range rows {
+		item := response.FavoriteItemResponse{
+			ID:              row.ID,
+			ContentType:     row.ContentType,
+			ContentTypeText: contentTypeText(row.ContentType),
+			ContentID:       row.ContentID,
+			FolderID:        row.FolderID,
+			CreatedAt:       row.CreatedAt,
+		}
+		switch row.ContentType {
+		case contentTypeArticle:
+			item.Article = articleMap[row.ContentID]
+		case contentTypeVideo:
+			item.Video = videoMap[row.ContentID]
+		case contentTypeMusic:
+			item.Music = musicMap[row.ContentID]
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// favoriteScope 用户对指定类型内容的收藏记录
+func (s *FavoriteService) favoriteScope(db *gorm.DB, userID uint, contentType int, contentIDs []uint) *gorm.DB {
+	if contentType == contentTypeMusic {
+		return db.Model(&baby.UserMusicFavorite{}).Where("user_id = ? AND music_id IN ?", userID, contentIDs)
+	}
+	return db.Model(&baby.UserContentFavorite{}).
+		Where("user_id = ? AND content_type = ? AND content_id IN ?", userID, contentType, contentIDs)
+}
+
+// deleteFavorites 删除用户对指定内容的收藏
+func (s *FavoriteService) deleteFavorites(tx *gorm.DB, userID uint, contentType int, contentIDs []uint) error {
+	if contentType == contentTypeMusic {
+		return tx.Where("user_id = ? AND music_id IN ?", userID, contentIDs).Delete(&baby.UserMusicFavorite{}).Error
+	}
+	return tx.Where("user_id = ? AND content_type = ? AND content_id IN ?", userID, contentType, contentIDs).
+		Delete(&baby.UserContentFavorite{}).Error
+}
+
+// contentColumn 收藏表中的内容ID字段
+func (s *FavoriteService) contentColumn(contentType int) string {
+	if contentType == contentTypeMusic {
+		return "music_id"
+	}
+	return "content_id"
+}
+
+// getUserFolder 获取用户自己的收藏夹
+func (s *FavoriteService) getUserFolder(id uint, userID uint) (*baby.FavoriteFolder, error) {
+	var folder baby.FavoriteFolder
+	err := global.GVA_DB.Where("id = ? AND user_id = ?", id, userID).First(&folder).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("收藏夹不存在")
+		}
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// checkFolderName 校验收藏夹名称不与默认收藏夹及用户的其他收藏夹重复
+func (s *FavoriteService) checkFolderName(userID uint, excludeID uint, name string) error {
+	if name == defaultFolderName {
+		return errors.New("收藏夹名称已存在")
+	}
+
+	var count int64
+	err := global.GVA_DB.Model(&baby.FavoriteFolder{}).Where("user_id = ? AND name = ? AND id <> ?", userID, name, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("收藏夹名称已存在")
+	}
+	return nil
+}
+
+// contentTypeText 收藏内容类型文本
+func contentTypeText(contentType int) string {
+	switch contentType {
+	case contentTypeArticle:
+		return "文章"
+	case contentTypeVideo:
+		return "视频"
+	case contentTypeMusic:
+		return "音乐"
+	default:
+		return "内容"
+	}
+}
@@ -0,0 +1,60 @@
+package baby
+
+import (
+	"testing"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+)
+
+func TestToggleFavoriteLikeCount(t *testing.T) {
+	tests := []struct {
+		name       string
+		likeCount  int64
+		favorited  bool   // 用户是否已有收藏记录
+		toggles    int    // 连续切换次数
+		wantLiked  []bool // 每次切换后的收藏状态
+		wantCounts []int64
+	}{
+		{"收藏后取消恢复原值", 5, false, 2, []bool{true, false}, []int64{6, 5}},
+		{"重复切换不重复计数", 3, false, 4, []bool{true, false, true, false}, []int64{4, 3, 4, 3}},
+		{"点赞数为0时取消收藏保持0", 0, true, 1, []bool{false}, []int64{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t, &baby.Music{}, &baby.UserMusicFavorite{}, &baby.UserContentFavorite{})
+			music := baby.Music{Title: "摇篮曲", AudioURL: "/uploads/file/lullaby.mp3", IsActive: true}
+			if err := db.Create(&music).Error; err != nil {
+				t.Fatal(err)
+			}
+			// like_count 带默认值，零值需单独写入
+			if err := db.Model(&music).Update("like_count", tt.likeCount).Error; err != nil {
+				t.Fatal(err)
+			}
+			if tt.favorited {
+				if err := db.Create(&baby.UserMusicFavorite{UserID: 1, MusicID: music.ID}).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			service := new(FavoriteService)
+			for i := 0; i < tt.toggles; i++ {
+				result, err := service.ToggleFavorite(1, &request.ToggleFavoriteRequest{ContentType: contentTypeMusic, ContentID: music.ID})
+				if err != nil {
+					t.Fatalf("toggle %d: %v", i+1, err)
+				}
+				if result.IsFavorited != tt.wantLiked[i] || result.LikeCount != tt.wantCounts[i] {
+					t.Fatalf("toggle %d = (%v, %d), want (%v, %d)", i+1, result.IsFavorited, result.LikeCount, tt.wantLiked[i], tt.wantCounts[i])
+				}
+
+				var stored baby.Music
+				if err := db.First(&stored, music.ID).Error; err != nil {
+					t.Fatal(err)
+				}
+				if stored.LikeCount != tt.wantCounts[i] {
+					t.Fatalf("toggle %d stored like_count = %d, want %d", i+1, stored.LikeCount, tt.wantCounts[i])
+				}
+			}
+		})
+	}
+}
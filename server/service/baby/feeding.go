@@ -0,0 +1,662 @@
+package baby
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FeedingService struct{}
+
+// 喂养类型，对应FeedingRecord.FeedingType
+const (
+	feedingTypeBreast = 1 // 母乳亲喂
+	feedingTypeBottle = 2 // 瓶喂
+	feedingTypeSolid  = 3 // 辅食
+)
+
+// 喂养记录状态，对应FeedingRecord.Status
+const (
+	feedingStatusInProgress = 1 // 进行中
+	feedingStatusCompleted  = 2 // 已完成
+)
+
+// 哺乳侧
+const (
+	breastSideLeft  = "left"
+	breastSideRight = "right"
+)
+
+// maxAllergensLength 过敏原字段长度上限，与模型定义一致
+const maxAllergensLength = 255
+
+// StartBreastFeeding 开始母乳亲喂计时，同一宝宝同时只能有一次进行中的喂养
+func (s *FeedingService) StartBreastFeeding(userID uint, req *request.StartBreastFeedingRequest) (*response.FeedingRecordResponse, error) {
+	babyProfile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	startTime := now
+	if req.StartTime != nil {
+		startTime = *req.StartTime
+	}
+	if startTime.After(now) {
+		return nil, errors.New("开始时间不能晚于当前时间")
+	}
+
+	record := &baby.FeedingRecord{
+		UserID:        userID,
+		BabyID:        req.BabyID,
+		FeedingType:   feedingTypeBreast,
+		StartTime:     startTime,
+		ActiveSide:    req.Side,
+		SideStartedAt: &startTime,
+		LastSide:      req.Side,
+		Status:        feedingStatusInProgress,
+	}
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		// 锁定宝宝档案，串行化同一宝宝的计时操作
+		var profile baby.BabyProfile
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", record.BabyID).First(&profile).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		err := tx.Model(&baby.FeedingRecord{}).Where("baby_id = ? AND status = ?", record.BabyID, feedingStatusInProgress).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("宝宝有进行中的喂养，请先结束")
+		}
+		return tx.Create(record).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp response.FeedingRecordResponse
+	resp.FromFeedingRecord(record, babyProfile.Name, now)
+	return &resp, nil
+}
+
+// SwitchBreastSide 切换到另一侧哺乳，暂停状态下调用则从指定侧继续计时
+func (s *FeedingService) SwitchBreastSide(userID uint, req *request.SwitchBreastSideRequest) (*response.FeedingRecordResponse, error) {
+	return s.updateBreastTimer(userID, req.ID, func(record *baby.FeedingRecord, now time.Time) error {
+		if record.ActiveSide == req.Side {
+			return errors.New("当前已在该侧哺乳")
+		}
+		accumulateBreastSide(record, now)
+		record.ActiveSide = req.Side
+		record.SideStartedAt = &now
+		record.LastSide = req.Side
+		return nil
+	})
+}
+
+// PauseBreastFeeding 暂停哺乳计时，已计时长保留
+func (s *FeedingService) PauseBreastFeeding(userID uint, id uint) (*response.FeedingRecordResponse, error) {
+	return s.updateBreastTimer(userID, id, func(record *baby.FeedingRecord, now time.Time) error {
+		if record.ActiveSide == "" {
+			return errors.New("哺乳计时已暂停")
+		}
+		accumulateBreastSide(record, now)
+		return nil
+	})
+}
+
+// EndFeeding 结束进行中的喂养，正在计时的一侧计算到结束时间
+func (s *FeedingService) EndFeeding(userID uint, req *request.EndFeedingRequest) (*response.FeedingRecordResponse, error) {
+	record, babyProfile, err := s.authorizeFeedingTimer(req.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if record.Status != feedingStatusInProgress {
+		return nil, errors.New("该喂养已结束")
+	}
+
+	now := time.Now()
+	endTime := now
+	if req.EndTime != nil {
+		endTime = *req.EndTime
+	}
+	if !endTime.After(record.StartTime) {
+		return nil, errors.New("结束时间必须晚于开始时间")
+	}
+	if endTime.After(now.Add(time.Minute)) {
+		return nil, errors.New("结束时间不能晚于当前时间")
+	}
+
+	accumulateBreastSide(record, endTime)
+	record.EndTime = &endTime
+	record.Status = feedingStatusCompleted
+	if req.Notes != "" {
+		record.Notes = req.Notes
+	}
+	if err := global.GVA_DB.Save(record).Error; err != nil {
+		return nil, err
+	}
+
+	var resp response.FeedingRecordResponse
+	resp.FromFeedingRecord(record, babyProfile.Name, now)
+	return &resp, nil
+}
+
+// CreateFeedingRecord 补录喂养记录
+func (s *FeedingService) CreateFeedingRecord(userID uint, req *request.CreateFeedingRecordRequest) error {
+	if _, err := getUserBaby(req.BabyID, userID); err != nil {
+		return err
+	}
+
+	record := &baby.FeedingRecord{UserID: userID, Status: feedingStatusCompleted}
+	if err := fillFeedingRecord(record, req); err != nil {
+		return err
+	}
+	return global.GVA_DB.Create(record).Error
+}
+
+// GetFeedingRecord 获取喂养记录详情
+func (s *FeedingService) GetFeedingRecord(id uint, userID uint) (*response.FeedingRecordResponse, error) {
+	record, babyProfile, err := s.authorizeFeeding(id, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp response.FeedingRecordResponse
+	resp.FromFeedingRecord(record, babyProfile.Name, time.Now())
+	return &resp, nil
+}
+
+// GetCurrentFeeding 获取宝宝进行中的喂养，没有时返回nil
+func (s *FeedingService) GetCurrentFeeding(userID uint, babyID uint) (*response.FeedingRecordResponse, error) {
+	babyProfile, _, err := authorizeBaby(babyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.currentFeeding(babyID)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	var resp response.FeedingRecordResponse
+	resp.FromFeedingRecord(record, babyProfile.Name, time.Now())
+	return &resp, nil
+}
+
+// GetFeedingRecordList 获取喂养记录列表，包含家庭其他成员添加的记录
+func (s *FeedingService) GetFeedingRecordList(userID uint, req *request.FeedingRecordSearch) (*response.FeedingRecordListResponse, error) {
+	db := global.GVA_DB.Model(&baby.FeedingRecord{}).Where("baby_id IN (?)", accessibleBabyIDs(userID, babyAccessRead))
+
+	// 搜索条件
+	if req.BabyID > 0 {
+		db = db.Where("baby_id = ?", req.BabyID)
+	}
+	if req.FeedingType > 0 {
+		db = db.Where("feeding_type = ?", req.FeedingType)
+	}
+	if req.StartDate != "" {
+		db = db.Where("start_time >= ?", req.StartDate)
+	}
+	if req.EndDate != "" {
+		// 仅传日期时包含结束当天的全部记录
+		if endDate, err := time.ParseInLocation("2006-01-02", req.EndDate, time.Local); err == nil {
+			db = db.Where("start_time < ?", endDate.AddDate(0, 0, 1))
+		} else {
+			db = db.Where("start_time <= ?", req.EndDate)
+		}
+	}
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var records []baby.FeedingRecord
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order("start_time DESC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取所有相关的宝宝信息
+	var babyIDs []uint
+	for _, record := range records {
+		babyIDs = append(babyIDs, record.BabyID)
+	}
+
+	var babies []baby.BabyProfile
+	if len(babyIDs) > 0 {
+		global.GVA_DB.Where("id IN ?", babyIDs).Find(&babies)
+	}
+
+	babyNameMap := make(map[uint]string)
+	for _, babyProfile := range babies {
+		babyNameMap[babyProfile.ID] = babyProfile.Name
+	}
+
+	// 转换响应
+	now := time.Now()
+	list := make([]response.FeedingRecordResponse, 0, len(records))
+	for i := range records {
+		var resp response.FeedingRecordResponse
+		resp.FromFeedingRecord(&records[i], babyNameMap[records[i].BabyID], now)
+		list = append(list, resp)
+	}
+
+	return &response.FeedingRecordListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// UpdateFeedingRecord 修正喂养记录，进行中的喂养需先结束
+func (s *FeedingService) UpdateFeedingRecord(userID uint, req *request.UpdateFeedingRecordRequest) error {
+	record, _, err := s.authorizeFeeding(req.ID, userID, babyAccessRecord)
+	if err != nil {
+		return err
+	}
+	if record.Status == feedingStatusInProgress {
+		return errors.New("进行中的喂养请先结束")
+	}
+
+	// 改到其他宝宝时需对该宝宝有记录权限
+	if req.BabyID != record.BabyID {
+		if _, err := getUserBaby(req.BabyID, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := fillFeedingRecord(record, &req.CreateFeedingRecordRequest); err != nil {
+		return err
+	}
+	return global.GVA_DB.Save(record).Error
+}
+
+// DeleteFeedingRecord 删除喂养记录
+func (s *FeedingService) DeleteFeedingRecord(id uint, userID uint) error {
+	record, _, err := s.authorizeFeeding(id, userID, babyAccessRecord)
+	if err != nil {
+		return err
+	}
+
+	return global.GVA_DB.Delete(record).Error
+}
+
+// GetFeedingSummary 获取宝宝指定日期的喂养汇总，包括各类喂养的次数与总量、平均间隔及距上次喂养的时长
+func (s *FeedingService) GetFeedingSummary(userID uint, req *request.FeedingSummaryRequest) (*response.FeedingDailySummary, error) {
+	babyProfile, _, err := authorizeBaby(req.BabyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	day := startOfDay(now)
+	if req.Date != "" {
+		day, err = time.ParseInLocation("2006-01-02", req.Date, time.Local)
+		if err != nil {
+			return nil, errors.New("日期格式错误")
+		}
+	}
+
+	var records []baby.FeedingRecord
+	err = global.GVA_DB.Where("baby_id = ? AND start_time >= ? AND start_time < ?", req.BabyID, day, day.AddDate(0, 0, 1)).
+		Order("start_time ASC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	summary := summarizeFeeding(records, now)
+	summary.BabyID = req.BabyID
+	summary.Date = day.Format("2006-01-02")
+
+	// 距上次喂养按最近一次喂养的开始时间计算，不限于统计当天
+	var last baby.FeedingRecord
+	err = global.GVA_DB.Where("baby_id = ? AND start_time <= ?", req.BabyID, now).Order("start_time DESC").First(&last).Error
+	if err == nil {
+		lastAt := last.StartTime
+		summary.LastFeedingAt = &lastAt
+		summary.MinutesSinceLastFeed = int(now.Sub(lastAt).Minutes())
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// 建议下次从上次最后哺乳的另一侧开始
+	var lastBreast baby.FeedingRecord
+	err = global.GVA_DB.Where("baby_id = ? AND feeding_type = ? AND last_side <> ''", req.BabyID, feedingTypeBreast).
+		Order("start_time DESC").First(&lastBreast).Error
+	if err == nil {
+		summary.NextBreastSide = oppositeBreastSide(lastBreast.LastSide)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	current, err := s.currentFeeding(req.BabyID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		var resp response.FeedingRecordResponse
+		resp.FromFeedingRecord(current, babyProfile.Name, now)
+		summary.InProgress = &resp
+	}
+
+	return summary, nil
+}
+
+// GetAllergenHistory 获取宝宝辅食中各过敏原的添加记录，用于观察新引入的过敏原
+func (s *FeedingService) GetAllergenHistory(userID uint, babyID uint) ([]response.FeedingAllergenResponse, error) {
+	if _, _, err := authorizeBaby(babyID, userID, babyAccessRead); err != nil {
+		return nil, err
+	}
+
+	var records []baby.FeedingRecord
+	err := global.GVA_DB.Where("baby_id = ? AND feeding_type = ? AND allergens <> ''", babyID, feedingTypeSolid).
+		Order("start_time ASC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return summarizeAllergens(records), nil
+}
+
+// authorizeFeeding 校验用户对喂养记录的访问权限，返回记录及所属宝宝
+// 家庭成员均可查看；修改、删除他人添加的记录需要家长及以上角色
+func (s *FeedingService) authorizeFeeding(id uint, userID uint, level int) (*baby.FeedingRecord, *baby.BabyProfile, error) {
+	record, err := s.loadFeeding(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	profile, _, err := authorizeBabyRecord(record.BabyID, record.UserID, userID, level, "喂养记录不存在")
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, profile, nil
+}
+
+// authorizeFeedingTimer 校验用户能否操作进行中的计时，家庭中看护人及以上角色均可接续他人开始的计时
+func (s *FeedingService) authorizeFeedingTimer(id uint, userID uint) (*baby.FeedingRecord, *baby.BabyProfile, error) {
+	record, err := s.loadFeeding(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	profile, access, err := authorizeBabyRecord(record.BabyID, record.UserID, userID, babyAccessRead, "喂养记录不存在")
+	if err != nil {
+		return nil, nil, err
+	}
+	if access < babyAccessRecord {
+		return nil, nil, errors.New("家庭角色权限不足")
+	}
+	return record, profile, nil
+}
+
+// loadFeeding 按ID获取喂养记录
+func (s *FeedingService) loadFeeding(id uint) (*baby.FeedingRecord, error) {
+	var record baby.FeedingRecord
+	err := global.GVA_DB.Where("id = ?", id).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("喂养记录不存在")
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// currentFeeding 获取宝宝进行中的喂养，没有时返回nil
+func (s *FeedingService) currentFeeding(babyID uint) (*baby.FeedingRecord, error) {
+	var record baby.FeedingRecord
+	err := global.GVA_DB.Where("baby_id = ? AND status = ?", babyID, feedingStatusInProgress).
+		Order("start_time DESC").First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// updateBreastTimer 更新进行中的亲喂计时
+func (s *FeedingService) updateBreastTimer(userID uint, id uint, fn func(record *baby.FeedingRecord, now time.Time) error) (*response.FeedingRecordResponse, error) {
+	record, babyProfile, err := s.authorizeFeedingTimer(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if record.FeedingType != feedingTypeBreast || record.Status != feedingStatusInProgress {
+		return nil, errors.New("该喂养不是进行中的母乳亲喂")
+	}
+
+	now := time.Now()
+	if err := fn(record, now); err != nil {
+		return nil, err
+	}
+	if err := global.GVA_DB.Save(record).Error; err != nil {
+		return nil, err
+	}
+
+	var resp response.FeedingRecordResponse
+	resp.FromFeedingRecord(record, babyProfile.Name, now)
+	return &resp, nil
+}
+
+// accumulateBreastSide 将正在计时一侧到at为止的时长累加到该侧，并停止计时
+func accumulateBreastSide(record *baby.FeedingRecord, at time.Time) {
+	if record.SideStartedAt != nil && at.After(*record.SideStartedAt) {
+		elapsed := int(at.Sub(*record.SideStartedAt).Seconds())
+		switch record.ActiveSide {
+		case breastSideLeft:
+			record.LeftDuration += elapsed
+		case breastSideRight:
+			record.RightDuration += elapsed
+		}
+	}
+	record.ActiveSide = ""
+	record.SideStartedAt = nil
+}
+
+// oppositeBreastSide 返回另一侧
+func oppositeBreastSide(side string) string {
+	switch side {
+	case breastSideLeft:
+		return breastSideRight
+	case breastSideRight:
+		return breastSideLeft
+	default:
+		return ""
+	}
+}
+
+// fillFeedingRecord 按喂养类型校验补录内容并写入记录，与该类型无关的字段清空
+func fillFeedingRecord(record *baby.FeedingRecord, req *request.CreateFeedingRecordRequest) error {
+	now := time.Now()
+	if req.StartTime.After(now) {
+		return errors.New("开始时间不能晚于当前时间")
+	}
+	if req.EndTime != nil {
+		if !req.EndTime.After(req.StartTime) {
+			return errors.New("结束时间必须晚于开始时间")
+		}
+		if req.EndTime.After(now.Add(time.Minute)) {
+			return errors.New("结束时间不能晚于当前时间")
+		}
+	}
+
+	*record = baby.FeedingRecord{
+		GVA_MODEL:   record.GVA_MODEL,
+		UserID:      record.UserID,
+		BabyID:      req.BabyID,
+		FeedingType: req.FeedingType,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Status:      feedingStatusCompleted,
+		Notes:       req.Notes,
+	}
+
+	switch req.FeedingType {
+	case feedingTypeBreast:
+		if req.LeftDuration+req.RightDuration == 0 {
+			return errors.New("请填写哺乳时长")
+		}
+		if req.EndTime != nil && req.LeftDuration+req.RightDuration > int(req.EndTime.Sub(req.StartTime).Seconds()) {
+			return errors.New("哺乳时长不能超过喂养起止时间")
+		}
+		record.LeftDuration = req.LeftDuration
+		record.RightDuration = req.RightDuration
+		record.LastSide = req.LastSide
+		if record.LastSide == "" {
+			// 未指定时以时长不为零的一侧为最后哺乳侧，两侧都有时无法判断
+			switch {
+			case req.RightDuration == 0:
+				record.LastSide = breastSideLeft
+			case req.LeftDuration == 0:
+				record.LastSide = breastSideRight
+			}
+		}
+	case feedingTypeBottle:
+		if req.MilkType == 0 {
+			return errors.New("请选择奶类")
+		}
+		if req.Amount <= 0 {
+			return errors.New("请填写奶量")
+		}
+		record.MilkType = req.MilkType
+		record.Amount = req.Amount
+	case feedingTypeSolid:
+		if strings.TrimSpace(req.FoodName) == "" {
+			return errors.New("请填写辅食名称")
+		}
+		allergens := normalizeAllergens(req.Allergens)
+		if len(allergens) > maxAllergensLength {
+			return errors.New("过敏原内容过长")
+		}
+		record.FoodName = strings.TrimSpace(req.FoodName)
+		record.FoodAmount = req.FoodAmount
+		record.Allergens = allergens
+		record.Reaction = req.Reaction
+	}
+	return nil
+}
+
+// normalizeAllergens 规范化过敏原标签：支持中英文逗号和顿号分隔，去除空白与重复项
+func normalizeAllergens(allergens string) string {
+	parts := strings.FieldsFunc(allergens, func(r rune) bool { return r == ',' || r == '，' || r == '、' })
+	seen := make(map[string]bool, len(parts))
+	tags := make([]string, 0, len(parts))
+	for _, part := range parts {
+		tag := strings.TrimSpace(part)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return strings.Join(tags, ",")
+}
+
+// summarizeFeeding 汇总一天的喂养记录，records需按开始时间升序，进行中的亲喂计算到当前时间
+func summarizeFeeding(records []baby.FeedingRecord, now time.Time) *response.FeedingDailySummary {
+	summary := &response.FeedingDailySummary{
+		Foods:     []string{},
+		Allergens: []string{},
+	}
+	seenFoods := make(map[string]bool)
+	seenAllergens := make(map[string]bool)
+
+	for i := range records {
+		record := &records[i]
+		summary.TotalCount++
+		switch record.FeedingType {
+		case feedingTypeBreast:
+			var resp response.FeedingRecordResponse
+			resp.FromFeedingRecord(record, "", now)
+			summary.BreastCount++
+			summary.BreastLeftSeconds += resp.LeftDuration
+			summary.BreastRightSeconds += resp.RightDuration
+		case feedingTypeBottle:
+			summary.BottleCount++
+			switch record.MilkType {
+			case 1:
+				summary.BreastMilkAmount += record.Amount
+			case 2:
+				summary.FormulaAmount += record.Amount
+			}
+		case feedingTypeSolid:
+			summary.SolidCount++
+			summary.SolidAmount += record.FoodAmount
+			if record.FoodName != "" && !seenFoods[record.FoodName] {
+				seenFoods[record.FoodName] = true
+				summary.Foods = append(summary.Foods, record.FoodName)
+			}
+			if record.Allergens != "" {
+				for _, allergen := range strings.Split(record.Allergens, ",") {
+					if !seenAllergens[allergen] {
+						seenAllergens[allergen] = true
+						summary.Allergens = append(summary.Allergens, allergen)
+					}
+				}
+			}
+		}
+	}
+
+	// 平均间隔按相邻两次喂养的开始时间计算
+	if len(records) > 1 {
+		span := records[len(records)-1].StartTime.Sub(records[0].StartTime)
+		summary.AverageInterval = int(span.Minutes()) / (len(records) - 1)
+	}
+	return summary
+}
+
+// summarizeAllergens 按过敏原汇总辅食记录，records需按开始时间升序，结果即按首次添加时间排序
+func summarizeAllergens(records []baby.FeedingRecord) []response.FeedingAllergenResponse {
+	index := make(map[string]int)
+	result := []response.FeedingAllergenResponse{}
+	for _, record := range records {
+		if record.Allergens == "" {
+			continue
+		}
+		for _, allergen := range strings.Split(record.Allergens, ",") {
+			i, ok := index[allergen]
+			if !ok {
+				i = len(result)
+				index[allergen] = i
+				result = append(result, response.FeedingAllergenResponse{Allergen: allergen, FirstFedAt: record.StartTime})
+			}
+			item := &result[i]
+			item.Times++
+			item.LastFedAt = record.StartTime
+			if record.Reaction > item.MaxReaction {
+				item.MaxReaction = record.Reaction
+			}
+		}
+	}
+
+	for i := range result {
+		result[i].ReactionText = allergenReactionText(result[i].MaxReaction)
+	}
+	return result
+}
+
+// allergenReactionText 过敏反应文本
+func allergenReactionText(reaction int) string {
+	switch reaction {
+	case 1:
+		return "轻微反应"
+	case 2:
+		return "明显反应"
+	default:
+		return "无反应"
+	}
+}
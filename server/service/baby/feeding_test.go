@@ -0,0 +1,140 @@
+package baby
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	commonRequest "baby_admin/server/model/common/request"
+)
+
+func TestSummarizeFeeding(t *testing.T) {
+	loc := time.Local
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 1, hour, minute, 0, 0, loc)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	records := []baby.FeedingRecord{
+		{FeedingType: feedingTypeBreast, StartTime: at(6, 0), LeftDuration: 600, RightDuration: 480, Status: feedingStatusCompleted},
+		{FeedingType: feedingTypeBottle, StartTime: at(9, 0), MilkType: 2, Amount: 120, Status: feedingStatusCompleted},
+		{FeedingType: feedingTypeSolid, StartTime: at(11, 0), FoodName: "蛋黄泥", FoodAmount: 30, Allergens: "鸡蛋", Status: feedingStatusCompleted},
+		{FeedingType: feedingTypeBottle, StartTime: at(12, 0), MilkType: 1, Amount: 90, Status: feedingStatusCompleted},
+		{FeedingType: feedingTypeSolid, StartTime: at(14, 0), FoodName: "蛋黄泥", FoodAmount: 20, Allergens: "鸡蛋,牛奶", Status: feedingStatusCompleted},
+		// 进行中的亲喂：左侧已喂5分钟，右侧从15:05开始计时，统计到15:15
+		{FeedingType: feedingTypeBreast, StartTime: at(15, 0), LeftDuration: 300, ActiveSide: breastSideRight,
+			SideStartedAt: ptr(at(15, 5)), Status: feedingStatusInProgress},
+	}
+
+	summary := summarizeFeeding(records, at(15, 15))
+
+	if summary.TotalCount != 6 || summary.BreastCount != 2 || summary.BottleCount != 2 || summary.SolidCount != 2 {
+		t.Errorf("次数 = %d/%d/%d/%d", summary.TotalCount, summary.BreastCount, summary.BottleCount, summary.SolidCount)
+	}
+	if summary.BreastLeftSeconds != 900 || summary.BreastRightSeconds != 1080 {
+		t.Errorf("亲喂时长 = 左%d 右%d, want 左900 右1080", summary.BreastLeftSeconds, summary.BreastRightSeconds)
+	}
+	if summary.BreastMilkAmount != 90 || summary.FormulaAmount != 120 {
+		t.Errorf("奶量 = 母乳%d 配方奶%d", summary.BreastMilkAmount, summary.FormulaAmount)
+	}
+	if summary.SolidAmount != 50 || !reflect.DeepEqual(summary.Foods, []string{"蛋黄泥"}) {
+		t.Errorf("辅食 = %d %v", summary.SolidAmount, summary.Foods)
+	}
+	if !reflect.DeepEqual(summary.Allergens, []string{"鸡蛋", "牛奶"}) {
+		t.Errorf("过敏原 = %v", summary.Allergens)
+	}
+	// 06:00至15:00共5个间隔
+	if summary.AverageInterval != 108 {
+		t.Errorf("平均间隔 = %d, want 108", summary.AverageInterval)
+	}
+}
+
+func TestAccumulateBreastSide(t *testing.T) {
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local)
+	record := baby.FeedingRecord{LeftDuration: 60, ActiveSide: breastSideLeft, SideStartedAt: &start}
+
+	accumulateBreastSide(&record, start.Add(5*time.Minute))
+	if record.LeftDuration != 360 || record.ActiveSide != "" || record.SideStartedAt != nil {
+		t.Errorf("累计后 = %+v", record)
+	}
+
+	// 暂停状态下再次累计不改变时长
+	accumulateBreastSide(&record, start.Add(10*time.Minute))
+	if record.LeftDuration != 360 || record.RightDuration != 0 {
+		t.Errorf("暂停后累计 = 左%d 右%d", record.LeftDuration, record.RightDuration)
+	}
+}
+
+func TestNormalizeAllergens(t *testing.T) {
+	tests := map[string]string{
+		"":                 "",
+		"鸡蛋":               "鸡蛋",
+		" 鸡蛋 ，牛奶、鸡蛋,,花生 ": "鸡蛋,牛奶,花生",
+	}
+	for input, want := range tests {
+		if got := normalizeAllergens(input); got != want {
+			t.Errorf("normalizeAllergens(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestSummarizeAllergens(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 10, 0, 0, 0, time.Local) }
+	records := []baby.FeedingRecord{
+		{StartTime: day(1), Allergens: "鸡蛋"},
+		{StartTime: day(2), Allergens: "鸡蛋,牛奶", Reaction: 1},
+		{StartTime: day(4), Allergens: "鸡蛋"},
+	}
+
+	result := summarizeAllergens(records)
+	if len(result) != 2 {
+		t.Fatalf("过敏原数量 = %d, want 2", len(result))
+	}
+	egg, milk := result[0], result[1]
+	if egg.Allergen != "鸡蛋" || egg.Times != 3 || !egg.FirstFedAt.Equal(day(1)) || !egg.LastFedAt.Equal(day(4)) || egg.MaxReaction != 1 {
+		t.Errorf("鸡蛋 = %+v", egg)
+	}
+	if milk.Allergen != "牛奶" || milk.Times != 1 || milk.ReactionText != "轻微反应" {
+		t.Errorf("牛奶 = %+v", milk)
+	}
+}
+
+func TestFeedingRecordFamilyAccess(t *testing.T) {
+	db := setupTestDB(t, &baby.FamilyGroup{}, &baby.FamilyMember{}, &baby.BabyProfile{}, &baby.FeedingRecord{})
+	profile := seedFamilyBaby(t, db)
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(20 * time.Minute)
+	ownerRecord := baby.FeedingRecord{UserID: 1, BabyID: profile.ID, FeedingType: feedingTypeBottle, StartTime: start, EndTime: &end, Status: feedingStatusCompleted}
+	caregiverRecord := baby.FeedingRecord{UserID: 3, BabyID: profile.ID, FeedingType: feedingTypeBottle, StartTime: end, Status: feedingStatusInProgress}
+	for _, record := range []*baby.FeedingRecord{&ownerRecord, &caregiverRecord} {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	service := new(FeedingService)
+
+	list, err := service.GetFeedingRecordList(4, &request.FeedingRecordSearch{PageInfo: commonRequest.PageInfo{Page: 1, PageSize: 10}})
+	if err != nil || list.Total != 2 {
+		t.Fatalf("观察者应看到家庭全部记录: %v, %+v", err, list)
+	}
+	if _, err := service.GetFeedingRecord(ownerRecord.ID, 5); err == nil || err.Error() != "喂养记录不存在" {
+		t.Errorf("非家庭成员不应看到记录: %v", err)
+	}
+	if err := service.DeleteFeedingRecord(ownerRecord.ID, 3); err == nil || err.Error() != "家庭角色权限不足" {
+		t.Errorf("看护人不能删除他人的记录: %v", err)
+	}
+	if _, err := service.PauseBreastFeeding(4, caregiverRecord.ID); err == nil {
+		t.Error("观察者不能操作计时")
+	}
+	// 看护人开始的喂养，创建者可以结束
+	if _, err := service.EndFeeding(1, &request.EndFeedingRequest{ID: caregiverRecord.ID}); err != nil {
+		t.Errorf("家庭成员应能结束进行中的喂养: %v", err)
+	}
+	if err := service.DeleteFeedingRecord(caregiverRecord.ID, 3); err != nil {
+		t.Errorf("看护人应能删除自己的记录: %v", err)
+	}
+	if err := service.DeleteFeedingRecord(ownerRecord.ID, 2); err != nil {
+		t.Errorf("家长应能删除他人的记录: %v", err)
+	}
+}
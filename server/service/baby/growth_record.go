@@ -16,13 +16,8 @@ type GrowthRecordService struct{}
 
 // CreateGrowthRecord 创建成长记录
 func (s *GrowthRecordService) CreateGrowthRecord(userID uint, req *request.CreateGrowthRecordRequest) error {
-	// 验证宝宝是否属于当前用户
-	var babyProfile baby.BabyProfile
-	err := global.GVA_DB.Where("id = ? AND user_id = ?", req.BabyID, userID).First(&babyProfile).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("宝宝档案不存在")
-		}
+	// 看护人及以上角色可以添加成长记录
+	if _, _, err := authorizeBaby(req.BabyID, userID, babyAccessRecord); err != nil {
 		return err
 	}
 
@@ -73,27 +68,22 @@ func (s *GrowthRecordService) CreateGrowthRecord(userID uint, req *request.Creat
 
 // GetGrowthRecord 获取成长记录详情
 func (s *GrowthRecordService) GetGrowthRecord(id uint, userID uint) (*response.GrowthRecordResponse, error) {
-	var record baby.GrowthRecord
-	err := global.GVA_DB.Where("id = ? AND user_id = ?", id, userID).First(&record).Error
+	record, babyProfile, err := s.authorizeGrowthRecord(id, userID, babyAccessRead)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("成长记录不存在")
-		}
 		return nil, err
 	}
 
-	// 获取宝宝姓名
-	var babyProfile baby.BabyProfile
-	global.GVA_DB.Where("id = ?", record.BabyID).First(&babyProfile)
-
 	var resp response.GrowthRecordResponse
-	resp.FromGrowthRecord(&record, babyProfile.Name)
+	resp.FromGrowthRecord(record, babyProfile.Name)
 	return &resp, nil
 }
 
 // GetGrowthRecordList 获取成长记录列表
 func (s *GrowthRecordService) GetGrowthRecordList(userID uint, req *request.GrowthRecordSearch) (*response.GrowthRecordListResponse, error) {
-	db := global.GVA_DB.Model(&baby.GrowthRecord{}).Where("user_id = ?", userID)
+	// 家庭中所有宝宝的记录，私密记录仅作者及家长可见
+	db := global.GVA_DB.Model(&baby.GrowthRecord{}).
+		Where("baby_id IN (?)", accessibleBabyIDs(userID, babyAccessRead)).
+		Where("(is_private = ? OR user_id = ? OR baby_id IN (?))", false, userID, accessibleBabyIDs(userID, babyAccessManage))
 
 	// 搜索条件
 	if req.BabyID > 0 {
@@ -106,13 +96,31 @@ func (s *GrowthRecordService) GetGrowthRecordList(userID uint, req *request.Grow
 		db = db.Where("record_date >= ?", req.StartDate)
 	}
 	if req.EndDate != "" {
-		db = db.Where("record_date <= ?", req.EndDate)
+		// 仅传日期时包含结束当天的全部记录
+		if endDate, err := time.ParseInLocation("2006-01-02", req.EndDate, time.Local); err == nil {
+			db = db.Where("record_date < ?", endDate.AddDate(0, 0, 1))
+		} else {
+			db = db.Where("record_date <= ?", req.EndDate)
+		}
 	}
 	if req.Keyword != "" {
-		db = db.Where("title LIKE ? OR content LIKE ?", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
+		db = db.Where("(title LIKE ? OR content LIKE ?)", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
 	}
 	if req.Tags != "" {
-		db = db.Where("tags LIKE ?", "%"+req.Tags+"%")
+		// 多个标签以逗号分隔，命中任意一个即可
+		var conditions []string
+		var args []interface{}
+		for _, tag := range strings.Split(req.Tags, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "" {
+				continue
+			}
+			conditions = append(conditions, "tags LIKE ?")
+			args = append(args, "%"+tag+"%")
+		}
+		if len(conditions) > 0 {
+			db = db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+		}
 	}
 
 	// 获取总数
@@ -165,24 +173,16 @@ func (s *GrowthRecordService) GetGrowthRecordList(userID uint, req *request.Grow
 
 // UpdateGrowthRecord 更新成长记录
 func (s *GrowthRecordService) UpdateGrowthRecord(userID uint, req *request.UpdateGrowthRecordRequest) error {
-	// 检查记录是否存在
-	var record baby.GrowthRecord
-	err := global.GVA_DB.Where("id = ? AND user_id = ?", req.ID, userID).First(&record).Error
+	record, _, err := s.authorizeGrowthRecord(req.ID, userID, babyAccessRecord)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("成长记录不存在")
-		}
 		return err
 	}
 
-	// 验证宝宝是否属于当前用户
-	var babyProfile baby.BabyProfile
-	err = global.GVA_DB.Where("id = ? AND user_id = ?", req.BabyID, userID).First(&babyProfile).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("宝宝档案不存在")
+	// 移到其他宝宝时需要对目标宝宝有记录权限
+	if req.BabyID != record.BabyID {
+		if _, _, err := authorizeBaby(req.BabyID, userID, babyAccessRecord); err != nil {
+			return err
 		}
-		return err
 	}
 
 	// 序列化媒体文件
@@ -227,52 +227,53 @@ func (s *GrowthRecordService) UpdateGrowthRecord(userID uint, req *request.Updat
 		"is_private":  req.IsPrivate,
 	}
 
-	return global.GVA_DB.Model(&record).Updates(updates).Error
+	return global.GVA_DB.Model(record).Updates(updates).Error
 }
 
 // DeleteGrowthRecord 删除成长记录
 func (s *GrowthRecordService) DeleteGrowthRecord(id uint, userID uint) error {
-	var record baby.GrowthRecord
-	err := global.GVA_DB.Where("id = ? AND user_id = ?", id, userID).First(&record).Error
+	record, _, err := s.authorizeGrowthRecord(id, userID, babyAccessRecord)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("成长记录不存在")
-		}
 		return err
 	}
 
-	return global.GVA_DB.Delete(&record).Error
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		// 解除里程碑达成记录对该成长记录的关联
+		err := tx.Model(&baby.BabyMilestone{}).Where("growth_record_id = ?", record.ID).Update("growth_record_id", 0).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(record).Error
+	})
 }
 
 // GetGrowthStatistics 获取成长统计
 func (s *GrowthRecordService) GetGrowthStatistics(userID uint, babyID uint) (*response.GrowthStatistics, error) {
-	// 验证宝宝是否属于当前用户
-	var babyProfile baby.BabyProfile
-	err := global.GVA_DB.Where("id = ? AND user_id = ?", babyID, userID).First(&babyProfile).Error
+	babyProfile, access, err := authorizeBaby(babyID, userID, babyAccessRead)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("宝宝档案不存在")
-		}
 		return nil, err
 	}
+	records := func() *gorm.DB {
+		return global.GVA_DB.Model(&baby.GrowthRecord{}).Where("baby_id = ?", babyID).Scopes(visibleGrowthRecords(userID, access))
+	}
 
 	stats := &response.GrowthStatistics{}
 
 	// 总记录数
-	global.GVA_DB.Model(&baby.GrowthRecord{}).Where("user_id = ? AND baby_id = ?", userID, babyID).Count(&stats.TotalRecords)
+	records().Count(&stats.TotalRecords)
 
 	// 照片数量
-	global.GVA_DB.Model(&baby.GrowthRecord{}).Where("user_id = ? AND baby_id = ? AND record_type = ?", userID, babyID, 2).Count(&stats.PhotoCount)
+	records().Where("record_type = ?", 2).Count(&stats.PhotoCount)
 
 	// 视频数量
-	global.GVA_DB.Model(&baby.GrowthRecord{}).Where("user_id = ? AND baby_id = ? AND record_type = ?", userID, babyID, 3).Count(&stats.VideoCount)
+	records().Where("record_type = ?", 3).Count(&stats.VideoCount)
 
 	// 里程碑数量
-	global.GVA_DB.Model(&baby.GrowthRecord{}).Where("user_id = ? AND baby_id = ? AND record_type = ?", userID, babyID, 4).Count(&stats.MilestoneCount)
+	records().Where("record_type = ?", 4).Count(&stats.MilestoneCount)
 
 	// 最近记录（5条）
 	var recentRecords []baby.GrowthRecord
-	err = global.GVA_DB.Where("user_id = ? AND baby_id = ?", userID, babyID).Order("record_date DESC, created_at DESC").Limit(5).Find(&recentRecords).Error
+	err = records().Order("record_date DESC, created_at DESC").Limit(5).Find(&recentRecords).Error
 	if err == nil {
 		for _, record := range recentRecords {
 			var resp response.GrowthRecordResponse
@@ -284,7 +285,7 @@ func (s *GrowthRecordService) GetGrowthStatistics(userID uint, babyID uint) (*re
 	// 体重记录（最近30天）
 	thirtyDaysAgo := time.Now().AddDate(0, 0, -30)
 	var weightRecords []baby.GrowthRecord
-	err = global.GVA_DB.Where("user_id = ? AND baby_id = ? AND weight > 0 AND record_date >= ?", userID, babyID, thirtyDaysAgo).
+	err = records().Where("weight > 0 AND record_date >= ?", thirtyDaysAgo).
 		Order("record_date ASC").Find(&weightRecords).Error
 	if err == nil {
 		for _, record := range weightRecords {
@@ -297,7 +298,7 @@ func (s *GrowthRecordService) GetGrowthStatistics(userID uint, babyID uint) (*re
 
 	// 身高记录（最近30天）
 	var heightRecords []baby.GrowthRecord
-	err = global.GVA_DB.Where("user_id = ? AND baby_id = ? AND height > 0 AND record_date >= ?", userID, babyID, thirtyDaysAgo).
+	err = records().Where("height > 0 AND record_date >= ?", thirtyDaysAgo).
 		Order("record_date ASC").Find(&heightRecords).Error
 	if err == nil {
 		for _, record := range heightRecords {
@@ -308,5 +309,43 @@ func (s *GrowthRecordService) GetGrowthStatistics(userID uint, babyID uint) (*re
 		}
 	}
 
+	// WHO生长标准评估
+	stats.StandardAssessments = s.latestAssessments(babyProfile)
+
 	return stats, nil
-}
\ No newline at end of file
+}
+// authorizeGrowthRecord 校验用户对成长记录的访问权限，返回记录及所属宝宝
+// 私密记录仅作者及家长可见；修改、删除他人的记录需要家长及以上角色
+func (s *GrowthRecordService) authorizeGrowthRecord(id uint, userID uint, level int) (*baby.GrowthRecord, *baby.BabyProfile, error) {
+	var record baby.GrowthRecord
+	err := global.GVA_DB.Where("id = ?", id).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("成长记录不存在")
+		}
+		return nil, nil, err
+	}
+
+	profile, access, err := authorizeBaby(record.BabyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, nil, errors.New("成长记录不存在")
+	}
+	isAuthor := record.UserID == userID
+	if record.IsPrivate && !isAuthor && access < babyAccessManage {
+		return nil, nil, errors.New("成长记录不存在")
+	}
+	if access < level || (level > babyAccessRead && !isAuthor && access < babyAccessManage) {
+		return nil, nil, errors.New("家庭角色权限不足")
+	}
+	return &record, profile, nil
+}
+
+// visibleGrowthRecords 用户可见的成长记录：家长及以上角色可见全部，其他成员不可见他人的私密记录
+func visibleGrowthRecords(userID uint, access int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if access >= babyAccessManage {
+			return db
+		}
+		return db.Where("(is_private = ? OR user_id = ?)", false, userID)
+	}
+}
@@ -0,0 +1,359 @@
+package baby
+
+import (
+	"errors"
+	"math"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/response"
+)
+
+// WHO生长标准指标
+const (
+	growthIndicatorWFA  = "weight_for_age"    // 年龄别体重
+	growthIndicatorLHFA = "length_for_age"    // 年龄别身长/身高
+	growthIndicatorWFL  = "weight_for_length" // 身长/身高别体重
+)
+
+const (
+	daysPerMonth        = 30.4375 // WHO标准使用的平均每月天数
+	whoMaxAgeMonths     = 60
+	whoStandingAgeMonth = 24  // 24月龄起测量立位身高
+	whoMinLength        = 45  // 身长别体重表的身长下限(cm)
+	whoMaxLength        = 110 // 身长别体重表的身长上限(cm)
+	whoLengthStep       = 0.5
+	// 立位身高比卧位身长平均低0.7cm，WHO身高别体重表即身长别体重表平移0.7cm
+	whoStandingOffset = 0.7
+	// 跨越该数量及以上的主要百分位线视为生长偏离
+	crossingLineThreshold = 2
+)
+
+// whoMajorPercentiles 生长曲线上的主要百分位线及其Z值
+var whoMajorPercentiles = []struct {
+	Percentile float64
+	Z          float64
+}{
+	{3, -1.881},
+	{15, -1.036},
+	{50, 0},
+	{85, 1.036},
+	{97, 1.881},
+}
+
+// whoLMS Box-Cox变换参数：L为偏度，M为中位数，S为变异系数
+type whoLMS struct {
+	L, M, S float64
+}
+
+// zScore 计算测量值的Z评分
+// 体重类指标按WHO规则修正|Z|>3的尾部，避免偏态分布导致极端值被放大
+func (p whoLMS) zScore(x float64, adjustTails bool) float64 {
+	var z float64
+	if math.Abs(p.L) < 1e-9 {
+		z = math.Log(x/p.M) / p.S
+	} else {
+		z = (math.Pow(x/p.M, p.L) - 1) / (p.L * p.S)
+	}
+	if !adjustTails || math.Abs(z) <= 3 {
+		return z
+	}
+
+	if z > 3 {
+		sd3 := p.valueAt(3)
+		return 3 + (x-sd3)/(sd3-p.valueAt(2))
+	}
+	sd3 := p.valueAt(-3)
+	return -3 + (x-sd3)/(p.valueAt(-2)-sd3)
+}
+
+// valueAt 计算Z评分对应的测量值
+func (p whoLMS) valueAt(z float64) float64 {
+	if math.Abs(p.L) < 1e-9 {
+		return p.M * math.Exp(p.S*z)
+	}
+	return p.M * math.Pow(1+p.L*p.S*z, 1/p.L)
+}
+
+// interpolateLMS 在相邻两组参数间线性插值
+func interpolateLMS(a, b whoLMS, t float64) whoLMS {
+	return whoLMS{
+		L: a.L + (b.L-a.L)*t,
+		M: a.M + (b.M-a.M)*t,
+		S: a.S + (b.S-a.S)*t,
+	}
+}
+
+// lookupLMS 按等距表查找参数，x超出表范围时返回false
+func lookupLMS(table []whoLMS, start, step, x float64) (whoLMS, bool) {
+	pos := (x - start) / step
+	if pos < 0 || pos > float64(len(table)-1) {
+		return whoLMS{}, false
+	}
+	i := int(pos)
+	if i == len(table)-1 {
+		return table[i], true
+	}
+	return interpolateLMS(table[i], table[i+1], pos-float64(i)), true
+}
+
+// whoAgeLMS 获取年龄别指标在指定月龄的参数，gender:1男,2女
+func whoAgeLMS(indicator string, gender int, ageMonths float64) (whoLMS, bool) {
+	if gender != 1 && gender != 2 {
+		return whoLMS{}, false
+	}
+	boy := gender == 1
+	switch indicator {
+	case growthIndicatorWFA:
+		if boy {
+			return lookupLMS(whoWeightForAgeBoys, 0, 1, ageMonths)
+		}
+		return lookupLMS(whoWeightForAgeGirls, 0, 1, ageMonths)
+	case growthIndicatorLHFA:
+		if ageMonths < whoStandingAgeMonth {
+			if boy {
+				return lookupLMS(whoLengthForAgeBoys, 0, 1, ageMonths)
+			}
+			return lookupLMS(whoLengthForAgeGirls, 0, 1, ageMonths)
+		}
+		if boy {
+			return lookupLMS(whoHeightForAgeBoys, whoStandingAgeMonth, 1, ageMonths)
+		}
+		return lookupLMS(whoHeightForAgeGirls, whoStandingAgeMonth, 1, ageMonths)
+	}
+	return whoLMS{}, false
+}
+
+// whoLengthLMS 获取身长别体重在指定身长的参数，24月龄起的立位身高先换算为卧位身长
+func whoLengthLMS(gender int, ageMonths float64, length float64) (whoLMS, bool) {
+	if ageMonths >= whoStandingAgeMonth {
+		length += whoStandingOffset
+	}
+	switch gender {
+	case 1:
+		return lookupLMS(whoWeightForLengthBoys, whoMinLength, whoLengthStep, length)
+	case 2:
+		return lookupLMS(whoWeightForLengthGirls, whoMinLength, whoLengthStep, length)
+	}
+	return whoLMS{}, false
+}
+
+// measurementAgeMonths 测量日期时的月龄（按WHO平均每月天数折算），早产儿24月龄前按矫正年龄
+// 早产儿在预产期之前的测量不适用WHO标准，返回false
+func measurementAgeMonths(profile *baby.BabyProfile, date time.Time) (float64, bool) {
+	start := profile.AgeReferenceDate(date)
+	if startOfDay(date).Before(startOfDay(start)) {
+		return 0, false
+	}
+	return float64(baby.CalculateAge(start, date).Days) / daysPerMonth, true
+}
+
+// percentileFromZ 将Z评分换算为百分位
+func percentileFromZ(z float64) float64 {
+	return 50 * (1 + math.Erf(z/math.Sqrt2))
+}
+
+// percentileBand 返回Z评分所在的主要百分位区间，即低于该值的主要百分位线数量
+func percentileBand(z float64) int {
+	band := 0
+	for _, line := range whoMajorPercentiles {
+		if z >= line.Z {
+			band++
+		}
+	}
+	return band
+}
+
+// growthLevelOutOfRange 测量值超出WHO标准表范围时的评价
+const growthLevelOutOfRange = "超出标准范围"
+
+// growthLevel Z评分对应的评价
+func growthLevel(z float64) string {
+	switch {
+	case z < -3:
+		return "严重偏低"
+	case z < -2:
+		return "偏低"
+	case z > 3:
+		return "严重偏高"
+	case z > 2:
+		return "偏高"
+	default:
+		return "正常"
+	}
+}
+
+// assessGrowth 按WHO标准评估宝宝的历次测量，records需按测量日期升序
+// 超出0-5岁的测量不参与评估，身长/身高超出身长别体重表范围的测量标记为超出标准范围
+func assessGrowth(indicator string, profile *baby.BabyProfile, records []baby.GrowthRecord) []response.GrowthAssessment {
+	assessments := []response.GrowthAssessment{}
+	gender := profile.Gender
+	prevBand := -1
+	for _, record := range records {
+		ageMonths, applicable := measurementAgeMonths(profile, record.RecordDate)
+		if !applicable || ageMonths > whoMaxAgeMonths {
+			continue
+		}
+
+		var (
+			params whoLMS
+			ok     bool
+			value  float64
+			length float64
+		)
+		switch indicator {
+		case growthIndicatorWFA:
+			value = record.Weight
+			params, ok = whoAgeLMS(indicator, gender, ageMonths)
+		case growthIndicatorLHFA:
+			value = record.Height
+			params, ok = whoAgeLMS(indicator, gender, ageMonths)
+		case growthIndicatorWFL:
+			value, length = record.Weight, record.Height
+			if length > 0 {
+				params, ok = whoLengthLMS(gender, ageMonths, length)
+			}
+		}
+		if value <= 0 {
+			continue
+		}
+		if !ok {
+			// 身长/身高超出WHO表格范围时明确标记，不能静默跳过
+			if indicator == growthIndicatorWFL && length > 0 {
+				assessments = append(assessments, response.GrowthAssessment{
+					Indicator:  indicator,
+					RecordID:   record.ID,
+					Date:       record.RecordDate,
+					AgeMonths:  math.Round(ageMonths*10) / 10,
+					Length:     length,
+					Value:      value,
+					Level:      growthLevelOutOfRange,
+					OutOfRange: true,
+				})
+			}
+			continue
+		}
+
+		z := params.zScore(value, indicator != growthIndicatorLHFA)
+		assessment := response.GrowthAssessment{
+			Indicator:  indicator,
+			RecordID:   record.ID,
+			Date:       record.RecordDate,
+			AgeMonths:  math.Round(ageMonths*10) / 10,
+			Length:     length,
+			Value:      value,
+			ZScore:     math.Round(z*100) / 100,
+			Percentile: math.Round(percentileFromZ(z)*10) / 10,
+			Level:      growthLevel(z),
+		}
+
+		band := percentileBand(z)
+		if prevBand >= 0 {
+			assessment.CrossedLines = band - prevBand
+			assessment.IsCrossing = assessment.CrossedLines >= crossingLineThreshold ||
+				assessment.CrossedLines <= -crossingLineThreshold
+		}
+		prevBand = band
+		assessments = append(assessments, assessment)
+	}
+	return assessments
+}
+
+// percentileCurves 生成指标的主要百分位参考曲线，年龄别指标按月、身长别体重按厘米取点
+func percentileCurves(indicator string, gender int) []response.PercentileCurve {
+	curves := make([]response.PercentileCurve, 0, len(whoMajorPercentiles))
+	for _, line := range whoMajorPercentiles {
+		curve := response.PercentileCurve{Percentile: line.Percentile}
+		if indicator == growthIndicatorWFL {
+			for length := float64(whoMinLength); length <= whoMaxLength; length++ {
+				if params, ok := whoLengthLMS(gender, 0, length); ok {
+					curve.Points = append(curve.Points, response.CurvePoint{X: length, Y: math.Round(params.valueAt(line.Z)*100) / 100})
+				}
+			}
+		} else {
+			for month := 0; month <= whoMaxAgeMonths; month++ {
+				if params, ok := whoAgeLMS(indicator, gender, float64(month)); ok {
+					curve.Points = append(curve.Points, response.CurvePoint{X: float64(month), Y: math.Round(params.valueAt(line.Z)*100) / 100})
+				}
+			}
+		}
+		curves = append(curves, curve)
+	}
+	return curves
+}
+
+// GetGrowthStandard 按WHO 0-5岁生长标准评估宝宝的体重、身长，返回百分位、Z评分及参考曲线
+func (s *GrowthRecordService) GetGrowthStandard(userID uint, babyID uint) (*response.GrowthStandardResponse, error) {
+	profile, _, err := authorizeBaby(babyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Gender != 1 && profile.Gender != 2 {
+		return nil, errors.New("请先设置宝宝性别")
+	}
+
+	records, err := s.measurementRecords(profile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.GrowthStandardResponse{
+		BabyID:    profile.ID,
+		BabyName:  profile.Name,
+		Gender:    profile.Gender,
+		AgeMonths: math.Round(float64(profile.GetDevelopmentalAgeAt(time.Now()).Days)/daysPerMonth*10) / 10,
+	}
+	indicators := []struct {
+		indicator, name, xUnit, yUnit string
+	}{
+		{growthIndicatorWFA, "年龄别体重", "月", "kg"},
+		{growthIndicatorLHFA, "年龄别身长/身高", "月", "cm"},
+		{growthIndicatorWFL, "身长/身高别体重", "cm", "kg"},
+	}
+	for _, item := range indicators {
+		measurements := assessGrowth(item.indicator, profile, records)
+		indicator := response.GrowthIndicatorResponse{
+			Indicator:    item.indicator,
+			Name:         item.name,
+			XUnit:        item.xUnit,
+			YUnit:        item.yUnit,
+			Measurements: measurements,
+			Curves:       percentileCurves(item.indicator, profile.Gender),
+		}
+		if len(measurements) > 0 {
+			indicator.Latest = &measurements[len(measurements)-1]
+		}
+		result.Indicators = append(result.Indicators, indicator)
+	}
+	return result, nil
+}
+
+// latestAssessments 各项指标的最新评估，性别未知时返回空
+func (s *GrowthRecordService) latestAssessments(profile *baby.BabyProfile) []response.GrowthAssessment {
+	latest := []response.GrowthAssessment{}
+	if profile.Gender != 1 && profile.Gender != 2 {
+		return latest
+	}
+
+	records, err := s.measurementRecords(profile.ID)
+	if err != nil {
+		return latest
+	}
+	for _, indicator := range []string{growthIndicatorWFA, growthIndicatorLHFA, growthIndicatorWFL} {
+		if measurements := assessGrowth(indicator, profile, records); len(measurements) > 0 {
+			latest = append(latest, measurements[len(measurements)-1])
+		}
+	}
+	return latest
+}
+
+// measurementRecords 获取宝宝含体重或身长的成长记录，按测量日期升序
+func (s *GrowthRecordService) measurementRecords(babyID uint) ([]baby.GrowthRecord, error) {
+	var records []baby.GrowthRecord
+	err := global.GVA_DB.Where("baby_id = ? AND (weight > 0 OR height > 0)", babyID).
+		Order("record_date ASC, id ASC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
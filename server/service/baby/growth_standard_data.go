@@ -0,0 +1,543 @@
+package baby
+
+// WHO儿童生长标准（2006）0-5岁LMS参数表，数据来源于WHO官方发布的z-score表
+
+// whoWeightForAgeBoys 男童年龄别体重(kg)，按月龄0-60索引
+var whoWeightForAgeBoys = []whoLMS{
+	{0.3487, 3.3464, 0.14602},   // 0月
+	{0.2297, 4.4709, 0.13395},   // 1月
+	{0.1970, 5.5675, 0.12385},   // 2月
+	{0.1738, 6.3762, 0.11727},   // 3月
+	{0.1553, 7.0023, 0.11316},   // 4月
+	{0.1395, 7.5105, 0.11080},   // 5月
+	{0.1257, 7.9340, 0.10958},   // 6月
+	{0.1134, 8.2970, 0.10902},   // 7月
+	{0.1021, 8.6151, 0.10882},   // 8月
+	{0.0917, 8.9014, 0.10881},   // 9月
+	{0.0820, 9.1649, 0.10891},   // 10月
+	{0.0730, 9.4122, 0.10906},   // 11月
+	{0.0644, 9.6479, 0.10925},   // 12月
+	{0.0563, 9.8749, 0.10949},   // 13月
+	{0.0487, 10.0953, 0.10976},  // 14月
+	{0.0413, 10.3108, 0.11007},  // 15月
+	{0.0343, 10.5228, 0.11041},  // 16月
+	{0.0275, 10.7319, 0.11079},  // 17月
+	{0.0211, 10.9385, 0.11119},  // 18月
+	{0.0148, 11.1430, 0.11164},  // 19月
+	{0.0087, 11.3462, 0.11211},  // 20月
+	{0.0029, 11.5486, 0.11261},  // 21月
+	{-0.0028, 11.7504, 0.11314}, // 22月
+	{-0.0083, 11.9514, 0.11369}, // 23月
+	{-0.0137, 12.1515, 0.11426}, // 24月
+	{-0.0189, 12.3502, 0.11485}, // 25月
+	{-0.0240, 12.5466, 0.11544}, // 26月
+	{-0.0289, 12.7401, 0.11604}, // 27月
+	{-0.0337, 12.9303, 0.11664}, // 28月
+	{-0.0385, 13.1169, 0.11723}, // 29月
+	{-0.0431, 13.3000, 0.11781}, // 30月
+	{-0.0476, 13.4798, 0.11839}, // 31月
+	{-0.0520, 13.6567, 0.11896}, // 32月
+	{-0.0564, 13.8309, 0.11953}, // 33月
+	{-0.0606, 14.0031, 0.12008}, // 34月
+	{-0.0648, 14.1736, 0.12062}, // 35月
+	{-0.0689, 14.3429, 0.12116}, // 36月
+	{-0.0729, 14.5113, 0.12168}, // 37月
+	{-0.0769, 14.6791, 0.12220}, // 38月
+	{-0.0808, 14.8466, 0.12271}, // 39月
+	{-0.0846, 15.0140, 0.12322}, // 40月
+	{-0.0883, 15.1813, 0.12373}, // 41月
+	{-0.0920, 15.3486, 0.12425}, // 42月
+	{-0.0957, 15.5158, 0.12478}, // 43月
+	{-0.0993, 15.6828, 0.12531}, // 44月
+	{-0.1028, 15.8497, 0.12586}, // 45月
+	{-0.1063, 16.0163, 0.12643}, // 46月
+	{-0.1097, 16.1827, 0.12700}, // 47月
+	{-0.1131, 16.3489, 0.12759}, // 48月
+	{-0.1165, 16.5139, 0.12819}, // 49月
+	{-0.1198, 16.6802, 0.12880}, // 50月
+	{-0.1230, 16.8463, 0.12943}, // 51月
+	{-0.1262, 17.0121, 0.13007}, // 52月
+	{-0.1294, 17.1777, 0.13072}, // 53月
+	{-0.1325, 17.3431, 0.13138}, // 54月
+	{-0.1356, 17.5085, 0.13205}, // 55月
+	{-0.1387, 17.6738, 0.13274}, // 56月
+	{-0.1417, 17.8391, 0.13342}, // 57月
+	{-0.1447, 18.0045, 0.13412}, // 58月
+	{-0.1477, 18.1699, 0.13482}, // 59月
+	{-0.1506, 18.3366, 0.13552}, // 60月
+}
+
+// whoWeightForAgeGirls 女童年龄别体重(kg)，按月龄0-60索引
+var whoWeightForAgeGirls = []whoLMS{
+	{0.3809, 3.2322, 0.14171},   // 0月
+	{0.1714, 4.1873, 0.13724},   // 1月
+	{0.0962, 5.1282, 0.13000},   // 2月
+	{0.0402, 5.8458, 0.12619},   // 3月
+	{-0.0050, 6.4237, 0.12402},  // 4月
+	{-0.0430, 6.8985, 0.12274},  // 5月
+	{-0.0756, 7.2970, 0.12204},  // 6月
+	{-0.1039, 7.6422, 0.12178},  // 7月
+	{-0.1288, 7.9487, 0.12181},  // 8月
+	{-0.1507, 8.2254, 0.12199},  // 9月
+	{-0.1700, 8.4800, 0.12223},  // 10月
+	{-0.1872, 8.7192, 0.12247},  // 11月
+	{-0.2024, 8.9481, 0.12268},  // 12月
+	{-0.2158, 9.1699, 0.12283},  // 13月
+	{-0.2278, 9.3870, 0.12294},  // 14月
+	{-0.2384, 9.6008, 0.12299},  // 15月
+	{-0.2478, 9.8124, 0.12303},  // 16月
+	{-0.2562, 10.0226, 0.12306}, // 17月
+	{-0.2637, 10.2315, 0.12309}, // 18月
+	{-0.2703, 10.4393, 0.12315}, // 19月
+	{-0.2762, 10.6464, 0.12323}, // 20月
+	{-0.2815, 10.8534, 0.12335}, // 21月
+	{-0.2862, 11.0608, 0.12350}, // 22月
+	{-0.2903, 11.2688, 0.12369}, // 23月
+	{-0.2941, 11.4775, 0.12390}, // 24月
+	{-0.2975, 11.6864, 0.12414}, // 25月
+	{-0.3005, 11.8947, 0.12441}, // 26月
+	{-0.3032, 12.1015, 0.12472}, // 27月
+	{-0.3057, 12.3059, 0.12506}, // 28月
+	{-0.3080, 12.5073, 0.12545}, // 29月
+	{-0.3101, 12.7055, 0.12587}, // 30月
+	{-0.3120, 12.9006, 0.12633}, // 31月
+	{-0.3138, 13.0930, 0.12683}, // 32月
+	{-0.3155, 13.2837, 0.12737}, // 33月
+	{-0.3171, 13.4731, 0.12794}, // 34月
+	{-0.3186, 13.6618, 0.12855}, // 35月
+	{-0.3201, 13.8503, 0.12919}, // 36月
+	{-0.3216, 14.0385, 0.12988}, // 37月
+	{-0.3230, 14.2265, 0.13059}, // 38月
+	{-0.3243, 14.4140, 0.13135}, // 39月
+	{-0.3257, 14.6010, 0.13213}, // 40月
+	{-0.3270, 14.7873, 0.13293}, // 41月
+	{-0.3283, 14.9727, 0.13376}, // 42月
+	{-0.3296, 15.1573, 0.13460}, // 43月
+	{-0.3309, 15.3410, 0.13545}, // 44月
+	{-0.3322, 15.5240, 0.13630}, // 45月
+	{-0.3335, 15.7064, 0.13716}, // 46月
+	{-0.3348, 15.8882, 0.13800}, // 47月
+	{-0.3361, 16.0697, 0.13884}, // 48月
+	{-0.3374, 16.2511, 0.13968}, // 49月
+	{-0.3387, 16.4322, 0.14051}, // 50月
+	{-0.3400, 16.6133, 0.14132}, // 51月
+	{-0.3414, 16.7942, 0.14213}, // 52月
+	{-0.3427, 16.9748, 0.14293}, // 53月
+	{-0.3440, 17.1551, 0.14371}, // 54月
+	{-0.3453, 17.3347, 0.14448}, // 55月
+	{-0.3466, 17.5136, 0.14525}, // 56月
+	{-0.3479, 17.6916, 0.14600}, // 57月
+	{-0.3492, 17.8686, 0.14675}, // 58月
+	{-0.3505, 18.0445, 0.14748}, // 59月
+	{-0.3518, 18.2193, 0.14821}, // 60月
+}
+
+// whoLengthForAgeBoys 男童年龄别身长(cm，卧位)，按月龄0-24索引
+var whoLengthForAgeBoys = []whoLMS{
+	{1, 49.8842, 0.03795}, // 0月
+	{1, 54.7244, 0.03557}, // 1月
+	{1, 58.4249, 0.03424}, // 2月
+	{1, 61.4292, 0.03328}, // 3月
+	{1, 63.8860, 0.03257}, // 4月
+	{1, 65.9026, 0.03204}, // 5月
+	{1, 67.6236, 0.03165}, // 6月
+	{1, 69.1645, 0.03139}, // 7月
+	{1, 70.5994, 0.03124}, // 8月
+	{1, 71.9687, 0.03117}, // 9月
+	{1, 73.2812, 0.03118}, // 10月
+	{1, 74.5388, 0.03125}, // 11月
+	{1, 75.7488, 0.03137}, // 12月
+	{1, 76.9186, 0.03154}, // 13月
+	{1, 78.0497, 0.03174}, // 14月
+	{1, 79.1458, 0.03197}, // 15月
+	{1, 80.2113, 0.03222}, // 16月
+	{1, 81.2487, 0.03250}, // 17月
+	{1, 82.2587, 0.03279}, // 18月
+	{1, 83.2418, 0.03310}, // 19月
+	{1, 84.1996, 0.03342}, // 20月
+	{1, 85.1348, 0.03376}, // 21月
+	{1, 86.0477, 0.03410}, // 22月
+	{1, 86.9410, 0.03445}, // 23月
+	{1, 87.8161, 0.03479}, // 24月
+}
+
+// whoLengthForAgeGirls 女童年龄别身长(cm，卧位)，按月龄0-24索引
+var whoLengthForAgeGirls = []whoLMS{
+	{1, 49.1477, 0.03790}, // 0月
+	{1, 53.6872, 0.03640}, // 1月
+	{1, 57.0673, 0.03568}, // 2月
+	{1, 59.8029, 0.03520}, // 3月
+	{1, 62.0899, 0.03486}, // 4月
+	{1, 64.0301, 0.03463}, // 5月
+	{1, 65.7311, 0.03448}, // 6月
+	{1, 67.2873, 0.03441}, // 7月
+	{1, 68.7498, 0.03440}, // 8月
+	{1, 70.1435, 0.03444}, // 9月
+	{1, 71.4818, 0.03452}, // 10月
+	{1, 72.7710, 0.03464}, // 11月
+	{1, 74.0150, 0.03479}, // 12月
+	{1, 75.2176, 0.03496}, // 13月
+	{1, 76.3817, 0.03514}, // 14月
+	{1, 77.5099, 0.03534}, // 15月
+	{1, 78.6055, 0.03555}, // 16月
+	{1, 79.6710, 0.03576}, // 17月
+	{1, 80.7079, 0.03598}, // 18月
+	{1, 81.7182, 0.03620}, // 19月
+	{1, 82.7036, 0.03643}, // 20月
+	{1, 83.6654, 0.03666}, // 21月
+	{1, 84.6040, 0.03688}, // 22月
+	{1, 85.5202, 0.03711}, // 23月
+	{1, 86.4153, 0.03734}, // 24月
+}
+
+// whoHeightForAgeBoys 男童年龄别身高(cm，立位)，按月龄24-60排列
+var whoHeightForAgeBoys = []whoLMS{
+	{1, 87.1161, 0.03507},  // 24月
+	{1, 87.9720, 0.03542},  // 25月
+	{1, 88.8065, 0.03576},  // 26月
+	{1, 89.6197, 0.03610},  // 27月
+	{1, 90.4120, 0.03642},  // 28月
+	{1, 91.1828, 0.03674},  // 29月
+	{1, 91.9327, 0.03704},  // 30月
+	{1, 92.6631, 0.03733},  // 31月
+	{1, 93.3753, 0.03761},  // 32月
+	{1, 94.0711, 0.03787},  // 33月
+	{1, 94.7532, 0.03812},  // 34月
+	{1, 95.4236, 0.03836},  // 35月
+	{1, 96.0835, 0.03858},  // 36月
+	{1, 96.7337, 0.03879},  // 37月
+	{1, 97.3749, 0.03900},  // 38月
+	{1, 98.0073, 0.03919},  // 39月
+	{1, 98.6310, 0.03937},  // 40月
+	{1, 99.2459, 0.03954},  // 41月
+	{1, 99.8515, 0.03971},  // 42月
+	{1, 100.4485, 0.03986}, // 43月
+	{1, 101.0380, 0.04002}, // 44月
+	{1, 101.6219, 0.04016}, // 45月
+	{1, 102.2009, 0.04031}, // 46月
+	{1, 102.7755, 0.04045}, // 47月
+	{1, 103.3462, 0.04059}, // 48月
+	{1, 103.9127, 0.04073}, // 49月
+	{1, 104.4750, 0.04086}, // 50月
+	{1, 105.0337, 0.04100}, // 51月
+	{1, 105.5897, 0.04113}, // 52月
+	{1, 106.1429, 0.04126}, // 53月
+	{1, 106.6922, 0.04139}, // 54月
+	{1, 107.2369, 0.04152}, // 55月
+	{1, 107.7769, 0.04165}, // 56月
+	{1, 108.3120, 0.04177}, // 57月
+	{1, 108.8426, 0.04190}, // 58月
+	{1, 109.3694, 0.04202}, // 59月
+	{1, 109.8940, 0.04214}, // 60月
+}
+
+// whoHeightForAgeGirls 女童年龄别身高(cm，立位)，按月龄24-60排列
+var whoHeightForAgeGirls = []whoLMS{
+	{1, 85.7153, 0.03764},  // 24月
+	{1, 86.5904, 0.03786},  // 25月
+	{1, 87.4462, 0.03808},  // 26月
+	{1, 88.2830, 0.03830},  // 27月
+	{1, 89.1004, 0.03851},  // 28月
+	{1, 89.8991, 0.03872},  // 29月
+	{1, 90.6797, 0.03893},  // 30月
+	{1, 91.4430, 0.03913},  // 31月
+	{1, 92.1906, 0.03933},  // 32月
+	{1, 92.9239, 0.03952},  // 33月
+	{1, 93.6444, 0.03971},  // 34月
+	{1, 94.3533, 0.03989},  // 35月
+	{1, 95.0515, 0.04006},  // 36月
+	{1, 95.7399, 0.04024},  // 37月
+	{1, 96.4187, 0.04041},  // 38月
+	{1, 97.0885, 0.04057},  // 39月
+	{1, 97.7493, 0.04073},  // 40月
+	{1, 98.4015, 0.04089},  // 41月
+	{1, 99.0448, 0.04105},  // 42月
+	{1, 99.6795, 0.04120},  // 43月
+	{1, 100.3058, 0.04135}, // 44月
+	{1, 100.9238, 0.04150}, // 45月
+	{1, 101.5337, 0.04164}, // 46月
+	{1, 102.1360, 0.04179}, // 47月
+	{1, 102.7312, 0.04193}, // 48月
+	{1, 103.3197, 0.04206}, // 49月
+	{1, 103.9021, 0.04220}, // 50月
+	{1, 104.4786, 0.04233}, // 51月
+	{1, 105.0494, 0.04246}, // 52月
+	{1, 105.6148, 0.04259}, // 53月
+	{1, 106.1748, 0.04272}, // 54月
+	{1, 106.7295, 0.04285}, // 55月
+	{1, 107.2788, 0.04298}, // 56月
+	{1, 107.8227, 0.04310}, // 57月
+	{1, 108.3613, 0.04322}, // 58月
+	{1, 108.8948, 0.04334}, // 59月
+	{1, 109.4233, 0.04347}, // 60月
+}
+
+// whoWeightForLengthBoys 男童身长别体重(kg)，身长45-110cm，步长0.5cm
+var whoWeightForLengthBoys = []whoLMS{
+	{-0.3521, 2.4410, 0.09182},  // 45.0cm
+	{-0.3521, 2.5244, 0.09153},  // 45.5cm
+	{-0.3521, 2.6077, 0.09124},  // 46.0cm
+	{-0.3521, 2.6913, 0.09094},  // 46.5cm
+	{-0.3521, 2.7755, 0.09065},  // 47.0cm
+	{-0.3521, 2.8609, 0.09036},  // 47.5cm
+	{-0.3521, 2.9480, 0.09007},  // 48.0cm
+	{-0.3521, 3.0377, 0.08977},  // 48.5cm
+	{-0.3521, 3.1308, 0.08948},  // 49.0cm
+	{-0.3521, 3.2276, 0.08919},  // 49.5cm
+	{-0.3521, 3.3278, 0.08890},  // 50.0cm
+	{-0.3521, 3.4311, 0.08861},  // 50.5cm
+	{-0.3521, 3.5376, 0.08831},  // 51.0cm
+	{-0.3521, 3.6477, 0.08801},  // 51.5cm
+	{-0.3521, 3.7620, 0.08771},  // 52.0cm
+	{-0.3521, 3.8814, 0.08741},  // 52.5cm
+	{-0.3521, 4.0060, 0.08711},  // 53.0cm
+	{-0.3521, 4.1354, 0.08681},  // 53.5cm
+	{-0.3521, 4.2693, 0.08651},  // 54.0cm
+	{-0.3521, 4.4066, 0.08621},  // 54.5cm
+	{-0.3521, 4.5467, 0.08592},  // 55.0cm
+	{-0.3521, 4.6892, 0.08563},  // 55.5cm
+	{-0.3521, 4.8338, 0.08535},  // 56.0cm
+	{-0.3521, 4.9796, 0.08507},  // 56.5cm
+	{-0.3521, 5.1259, 0.08481},  // 57.0cm
+	{-0.3521, 5.2721, 0.08455},  // 57.5cm
+	{-0.3521, 5.4180, 0.08430},  // 58.0cm
+	{-0.3521, 5.5632, 0.08406},  // 58.5cm
+	{-0.3521, 5.7074, 0.08383},  // 59.0cm
+	{-0.3521, 5.8501, 0.08362},  // 59.5cm
+	{-0.3521, 5.9907, 0.08342},  // 60.0cm
+	{-0.3521, 6.1284, 0.08324},  // 60.5cm
+	{-0.3521, 6.2632, 0.08308},  // 61.0cm
+	{-0.3521, 6.3954, 0.08292},  // 61.5cm
+	{-0.3521, 6.5251, 0.08279},  // 62.0cm
+	{-0.3521, 6.6527, 0.08266},  // 62.5cm
+	{-0.3521, 6.7786, 0.08255},  // 63.0cm
+	{-0.3521, 6.9028, 0.08245},  // 63.5cm
+	{-0.3521, 7.0255, 0.08236},  // 64.0cm
+	{-0.3521, 7.1467, 0.08229},  // 64.5cm
+	{-0.3521, 7.2666, 0.08223},  // 65.0cm
+	{-0.3521, 7.3854, 0.08218},  // 65.5cm
+	{-0.3521, 7.5034, 0.08215},  // 66.0cm
+	{-0.3521, 7.6206, 0.08213},  // 66.5cm
+	{-0.3521, 7.7370, 0.08212},  // 67.0cm
+	{-0.3521, 7.8526, 0.08212},  // 67.5cm
+	{-0.3521, 7.9674, 0.08214},  // 68.0cm
+	{-0.3521, 8.0816, 0.08216},  // 68.5cm
+	{-0.3521, 8.1955, 0.08219},  // 69.0cm
+	{-0.3521, 8.3092, 0.08224},  // 69.5cm
+	{-0.3521, 8.4227, 0.08229},  // 70.0cm
+	{-0.3521, 8.5358, 0.08235},  // 70.5cm
+	{-0.3521, 8.6480, 0.08241},  // 71.0cm
+	{-0.3521, 8.7594, 0.08248},  // 71.5cm
+	{-0.3521, 8.8697, 0.08254},  // 72.0cm
+	{-0.3521, 8.9788, 0.08262},  // 72.5cm
+	{-0.3521, 9.0865, 0.08269},  // 73.0cm
+	{-0.3521, 9.1927, 0.08276},  // 73.5cm
+	{-0.3521, 9.2974, 0.08283},  // 74.0cm
+	{-0.3521, 9.4010, 0.08289},  // 74.5cm
+	{-0.3521, 9.5032, 0.08295},  // 75.0cm
+	{-0.3521, 9.6041, 0.08301},  // 75.5cm
+	{-0.3521, 9.7033, 0.08307},  // 76.0cm
+	{-0.3521, 9.8007, 0.08311},  // 76.5cm
+	{-0.3521, 9.8963, 0.08314},  // 77.0cm
+	{-0.3521, 9.9902, 0.08317},  // 77.5cm
+	{-0.3521, 10.0827, 0.08318}, // 78.0cm
+	{-0.3521, 10.1741, 0.08318}, // 78.5cm
+	{-0.3521, 10.2649, 0.08316}, // 79.0cm
+	{-0.3521, 10.3558, 0.08313}, // 79.5cm
+	{-0.3521, 10.4475, 0.08308}, // 80.0cm
+	{-0.3521, 10.5405, 0.08301}, // 80.5cm
+	{-0.3521, 10.6352, 0.08293}, // 81.0cm
+	{-0.3521, 10.7322, 0.08284}, // 81.5cm
+	{-0.3521, 10.8321, 0.08273}, // 82.0cm
+	{-0.3521, 10.9350, 0.08261}, // 82.5cm
+	{-0.3521, 11.0415, 0.08250}, // 83.0cm
+	{-0.3521, 11.1516, 0.08239}, // 83.5cm
+	{-0.3521, 11.2651, 0.08228}, // 84.0cm
+	{-0.3521, 11.3817, 0.08219}, // 84.5cm
+	{-0.3521, 11.5007, 0.08212}, // 85.0cm
+	{-0.3521, 11.6218, 0.08207}, // 85.5cm
+	{-0.3521, 11.7444, 0.08204}, // 86.0cm
+	{-0.3521, 11.8678, 0.08204}, // 86.5cm
+	{-0.3521, 11.9916, 0.08207}, // 87.0cm
+	{-0.3521, 12.1152, 0.08213}, // 87.5cm
+	{-0.3521, 12.2382, 0.08220}, // 88.0cm
+	{-0.3521, 12.3603, 0.08230}, // 88.5cm
+	{-0.3521, 12.4815, 0.08241}, // 89.0cm
+	{-0.3521, 12.6017, 0.08254}, // 89.5cm
+	{-0.3521, 12.7209, 0.08267}, // 90.0cm
+	{-0.3521, 12.8392, 0.08281}, // 90.5cm
+	{-0.3521, 12.9569, 0.08295}, // 91.0cm
+	{-0.3521, 13.0742, 0.08309}, // 91.5cm
+	{-0.3521, 13.1910, 0.08322}, // 92.0cm
+	{-0.3521, 13.3075, 0.08335}, // 92.5cm
+	{-0.3521, 13.4239, 0.08348}, // 93.0cm
+	{-0.3521, 13.5404, 0.08359}, // 93.5cm
+	{-0.3521, 13.6572, 0.08370}, // 94.0cm
+	{-0.3521, 13.7746, 0.08380}, // 94.5cm
+	{-0.3521, 13.8928, 0.08389}, // 95.0cm
+	{-0.3521, 14.0120, 0.08397}, // 95.5cm
+	{-0.3521, 14.1325, 0.08405}, // 96.0cm
+	{-0.3521, 14.2544, 0.08412}, // 96.5cm
+	{-0.3521, 14.3782, 0.08419}, // 97.0cm
+	{-0.3521, 14.5038, 0.08427}, // 97.5cm
+	{-0.3521, 14.6316, 0.08435}, // 98.0cm
+	{-0.3521, 14.7614, 0.08444}, // 98.5cm
+	{-0.3521, 14.8934, 0.08455}, // 99.0cm
+	{-0.3521, 15.0275, 0.08467}, // 99.5cm
+	{-0.3521, 15.1637, 0.08481}, // 100.0cm
+	{-0.3521, 15.3018, 0.08497}, // 100.5cm
+	{-0.3521, 15.4419, 0.08515}, // 101.0cm
+	{-0.3521, 15.5838, 0.08534}, // 101.5cm
+	{-0.3521, 15.7276, 0.08556}, // 102.0cm
+	{-0.3521, 15.8732, 0.08580}, // 102.5cm
+	{-0.3521, 16.0206, 0.08606}, // 103.0cm
+	{-0.3521, 16.1697, 0.08634}, // 103.5cm
+	{-0.3521, 16.3204, 0.08664}, // 104.0cm
+	{-0.3521, 16.4728, 0.08695}, // 104.5cm
+	{-0.3521, 16.6268, 0.08727}, // 105.0cm
+	{-0.3521, 16.7826, 0.08760}, // 105.5cm
+	{-0.3521, 16.9401, 0.08795}, // 106.0cm
+	{-0.3521, 17.0995, 0.08829}, // 106.5cm
+	{-0.3521, 17.2607, 0.08865}, // 107.0cm
+	{-0.3521, 17.4237, 0.08900}, // 107.5cm
+	{-0.3521, 17.5885, 0.08937}, // 108.0cm
+	{-0.3521, 17.7553, 0.08973}, // 108.5cm
+	{-0.3521, 17.9242, 0.09010}, // 109.0cm
+	{-0.3521, 18.0954, 0.09047}, // 109.5cm
+	{-0.3521, 18.2689, 0.09085}, // 110.0cm
+}
+
+// whoWeightForLengthGirls 女童身长别体重(kg)，身长45-110cm，步长0.5cm
+var whoWeightForLengthGirls = []whoLMS{
+	{-0.3833, 2.4607, 0.09029},  // 45.0cm
+	{-0.3833, 2.5457, 0.09033},  // 45.5cm
+	{-0.3833, 2.6306, 0.09037},  // 46.0cm
+	{-0.3833, 2.7155, 0.09040},  // 46.5cm
+	{-0.3833, 2.8007, 0.09044},  // 47.0cm
+	{-0.3833, 2.8867, 0.09048},  // 47.5cm
+	{-0.3833, 2.9741, 0.09052},  // 48.0cm
+	{-0.3833, 3.0636, 0.09056},  // 48.5cm
+	{-0.3833, 3.1560, 0.09060},  // 49.0cm
+	{-0.3833, 3.2520, 0.09064},  // 49.5cm
+	{-0.3833, 3.3518, 0.09068},  // 50.0cm
+	{-0.3833, 3.4557, 0.09072},  // 50.5cm
+	{-0.3833, 3.5636, 0.09076},  // 51.0cm
+	{-0.3833, 3.6754, 0.09080},  // 51.5cm
+	{-0.3833, 3.7911, 0.09085},  // 52.0cm
+	{-0.3833, 3.9105, 0.09089},  // 52.5cm
+	{-0.3833, 4.0332, 0.09093},  // 53.0cm
+	{-0.3833, 4.1591, 0.09098},  // 53.5cm
+	{-0.3833, 4.2875, 0.09102},  // 54.0cm
+	{-0.3833, 4.4179, 0.09106},  // 54.5cm
+	{-0.3833, 4.5498, 0.09110},  // 55.0cm
+	{-0.3833, 4.6827, 0.09114},  // 55.5cm
+	{-0.3833, 4.8162, 0.09118},  // 56.0cm
+	{-0.3833, 4.9500, 0.09121},  // 56.5cm
+	{-0.3833, 5.0837, 0.09125},  // 57.0cm
+	{-0.3833, 5.2173, 0.09128},  // 57.5cm
+	{-0.3833, 5.3507, 0.09130},  // 58.0cm
+	{-0.3833, 5.4834, 0.09132},  // 58.5cm
+	{-0.3833, 5.6151, 0.09134},  // 59.0cm
+	{-0.3833, 5.7454, 0.09135},  // 59.5cm
+	{-0.3833, 5.8742, 0.09136},  // 60.0cm
+	{-0.3833, 6.0014, 0.09137},  // 60.5cm
+	{-0.3833, 6.1270, 0.09137},  // 61.0cm
+	{-0.3833, 6.2511, 0.09136},  // 61.5cm
+	{-0.3833, 6.3738, 0.09135},  // 62.0cm
+	{-0.3833, 6.4948, 0.09133},  // 62.5cm
+	{-0.3833, 6.6144, 0.09131},  // 63.0cm
+	{-0.3833, 6.7328, 0.09129},  // 63.5cm
+	{-0.3833, 6.8501, 0.09126},  // 64.0cm
+	{-0.3833, 6.9662, 0.09123},  // 64.5cm
+	{-0.3833, 7.0812, 0.09119},  // 65.0cm
+	{-0.3833, 7.1950, 0.09115},  // 65.5cm
+	{-0.3833, 7.3076, 0.09110},  // 66.0cm
+	{-0.3833, 7.4189, 0.09106},  // 66.5cm
+	{-0.3833, 7.5288, 0.09101},  // 67.0cm
+	{-0.3833, 7.6375, 0.09096},  // 67.5cm
+	{-0.3833, 7.7448, 0.09090},  // 68.0cm
+	{-0.3833, 7.8509, 0.09085},  // 68.5cm
+	{-0.3833, 7.9559, 0.09079},  // 69.0cm
+	{-0.3833, 8.0599, 0.09074},  // 69.5cm
+	{-0.3833, 8.1630, 0.09068},  // 70.0cm
+	{-0.3833, 8.2651, 0.09062},  // 70.5cm
+	{-0.3833, 8.3666, 0.09056},  // 71.0cm
+	{-0.3833, 8.4676, 0.09050},  // 71.5cm
+	{-0.3833, 8.5679, 0.09043},  // 72.0cm
+	{-0.3833, 8.6674, 0.09037},  // 72.5cm
+	{-0.3833, 8.7661, 0.09031},  // 73.0cm
+	{-0.3833, 8.8638, 0.09025},  // 73.5cm
+	{-0.3833, 8.9601, 0.09018},  // 74.0cm
+	{-0.3833, 9.0552, 0.09012},  // 74.5cm
+	{-0.3833, 9.1490, 0.09005},  // 75.0cm
+	{-0.3833, 9.2418, 0.08999},  // 75.5cm
+	{-0.3833, 9.3337, 0.08992},  // 76.0cm
+	{-0.3833, 9.4252, 0.08985},  // 76.5cm
+	{-0.3833, 9.5166, 0.08979},  // 77.0cm
+	{-0.3833, 9.6086, 0.08972},  // 77.5cm
+	{-0.3833, 9.7015, 0.08965},  // 78.0cm
+	{-0.3833, 9.7957, 0.08959},  // 78.5cm
+	{-0.3833, 9.8915, 0.08952},  // 79.0cm
+	{-0.3833, 9.9892, 0.08946},  // 79.5cm
+	{-0.3833, 10.0891, 0.08940}, // 80.0cm
+	{-0.3833, 10.1916, 0.08934}, // 80.5cm
+	{-0.3833, 10.2965, 0.08928}, // 81.0cm
+	{-0.3833, 10.4041, 0.08923}, // 81.5cm
+	{-0.3833, 10.5140, 0.08918}, // 82.0cm
+	{-0.3833, 10.6263, 0.08914}, // 82.5cm
+	{-0.3833, 10.7410, 0.08910}, // 83.0cm
+	{-0.3833, 10.8578, 0.08906}, // 83.5cm
+	{-0.3833, 10.9767, 0.08903}, // 84.0cm
+	{-0.3833, 11.0974, 0.08900}, // 84.5cm
+	{-0.3833, 11.2198, 0.08898}, // 85.0cm
+	{-0.3833, 11.3435, 0.08897}, // 85.5cm
+	{-0.3833, 11.4684, 0.08895}, // 86.0cm
+	{-0.3833, 11.5940, 0.08895}, // 86.5cm
+	{-0.3833, 11.7201, 0.08895}, // 87.0cm
+	{-0.3833, 11.8461, 0.08895}, // 87.5cm
+	{-0.3833, 11.9720, 0.08896}, // 88.0cm
+	{-0.3833, 12.0976, 0.08898}, // 88.5cm
+	{-0.3833, 12.2229, 0.08900}, // 89.0cm
+	{-0.3833, 12.3477, 0.08903}, // 89.5cm
+	{-0.3833, 12.4723, 0.08906}, // 90.0cm
+	{-0.3833, 12.5965, 0.08909}, // 90.5cm
+	{-0.3833, 12.7205, 0.08913}, // 91.0cm
+	{-0.3833, 12.8443, 0.08918}, // 91.5cm
+	{-0.3833, 12.9681, 0.08923}, // 92.0cm
+	{-0.3833, 13.0920, 0.08928}, // 92.5cm
+	{-0.3833, 13.2158, 0.08934}, // 93.0cm
+	{-0.3833, 13.3399, 0.08941}, // 93.5cm
+	{-0.3833, 13.4643, 0.08948}, // 94.0cm
+	{-0.3833, 13.5892, 0.08955}, // 94.5cm
+	{-0.3833, 13.7146, 0.08963}, // 95.0cm
+	{-0.3833, 13.8408, 0.08972}, // 95.5cm
+	{-0.3833, 13.9676, 0.08981}, // 96.0cm
+	{-0.3833, 14.0953, 0.08990}, // 96.5cm
+	{-0.3833, 14.2239, 0.09000}, // 97.0cm
+	{-0.3833, 14.3537, 0.09010}, // 97.5cm
+	{-0.3833, 14.4848, 0.09021}, // 98.0cm
+	{-0.3833, 14.6174, 0.09033}, // 98.5cm
+	{-0.3833, 14.7519, 0.09044}, // 99.0cm
+	{-0.3833, 14.8882, 0.09057}, // 99.5cm
+	{-0.3833, 15.0267, 0.09069}, // 100.0cm
+	{-0.3833, 15.1676, 0.09083}, // 100.5cm
+	{-0.3833, 15.3108, 0.09096}, // 101.0cm
+	{-0.3833, 15.4564, 0.09110}, // 101.5cm
+	{-0.3833, 15.6046, 0.09125}, // 102.0cm
+	{-0.3833, 15.7553, 0.09139}, // 102.5cm
+	{-0.3833, 15.9087, 0.09155}, // 103.0cm
+	{-0.3833, 16.0645, 0.09170}, // 103.5cm
+	{-0.3833, 16.2229, 0.09186}, // 104.0cm
+	{-0.3833, 16.3837, 0.09203}, // 104.5cm
+	{-0.3833, 16.5470, 0.09219}, // 105.0cm
+	{-0.3833, 16.7129, 0.09236}, // 105.5cm
+	{-0.3833, 16.8814, 0.09254}, // 106.0cm
+	{-0.3833, 17.0527, 0.09271}, // 106.5cm
+	{-0.3833, 17.2269, 0.09289}, // 107.0cm
+	{-0.3833, 17.4039, 0.09307}, // 107.5cm
+	{-0.3833, 17.5839, 0.09326}, // 108.0cm
+	{-0.3833, 17.7668, 0.09344}, // 108.5cm
+	{-0.3833, 17.9526, 0.09363}, // 109.0cm
+	{-0.3833, 18.1412, 0.09382}, // 109.5cm
+	{-0.3833, 18.3324, 0.09401}, // 110.0cm
+}
@@ -0,0 +1,140 @@
+package baby
+
+import (
+	"math"
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+)
+
+func TestWhoLMSZScore(t *testing.T) {
+	// 中位数对应Z=0、第50百分位
+	params, ok := whoAgeLMS(growthIndicatorWFA, 1, 12)
+	if !ok {
+		t.Fatal("未找到12月龄男童年龄别体重参数")
+	}
+	if z := params.zScore(params.M, true); math.Abs(z) > 1e-9 {
+		t.Errorf("中位数Z评分 = %f", z)
+	}
+
+	// 由Z值反算的测量值再算回Z值应一致
+	for _, z := range []float64{-2.5, -1.881, 0, 1.036, 2.8} {
+		if got := params.zScore(params.valueAt(z), true); math.Abs(got-z) > 1e-6 {
+			t.Errorf("zScore(valueAt(%f)) = %f", z, got)
+		}
+	}
+	if p := percentileFromZ(1.881); math.Abs(p-97) > 0.1 {
+		t.Errorf("Z=1.881 百分位 = %f", p)
+	}
+
+	// 月龄之间线性插值
+	mid, _ := whoAgeLMS(growthIndicatorLHFA, 2, 0.5)
+	if want := (whoLengthForAgeGirls[0].M + whoLengthForAgeGirls[1].M) / 2; math.Abs(mid.M-want) > 1e-9 {
+		t.Errorf("0.5月龄女童身长中位数 = %f, want %f", mid.M, want)
+	}
+
+	// 24月龄起使用立位身高，身长别体重按+0.7cm换算
+	standing, _ := whoAgeLMS(growthIndicatorLHFA, 1, 24)
+	if standing.M != whoHeightForAgeBoys[0].M {
+		t.Errorf("24月龄应使用立位身高表: %f", standing.M)
+	}
+	lying, _ := whoLengthLMS(1, 12, 80.7)
+	upright, _ := whoLengthLMS(1, 30, 80)
+	if math.Abs(lying.M-upright.M) > 1e-9 {
+		t.Errorf("立位身高换算错误: %f != %f", upright.M, lying.M)
+	}
+
+	if _, ok := whoAgeLMS(growthIndicatorWFA, 0, 12); ok {
+		t.Error("性别未知时不应返回参数")
+	}
+	if _, ok := whoLengthLMS(2, 12, 120); ok {
+		t.Error("超出表格范围的身长不应返回参数")
+	}
+}
+
+func TestAssessGrowthCrossing(t *testing.T) {
+	birthday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	profile := &baby.BabyProfile{Gender: 2, Birthday: birthday}
+	at := func(months int) time.Time { return birthday.AddDate(0, months, 0) }
+	weight := func(months int, z float64) float64 {
+		ageMonths, _ := measurementAgeMonths(profile, at(months))
+		params, _ := whoAgeLMS(growthIndicatorWFA, 2, ageMonths)
+		return params.valueAt(z)
+	}
+
+	records := []baby.GrowthRecord{
+		{RecordDate: at(2), Weight: weight(2, 0.2)},    // 50-85之间
+		{RecordDate: at(4), Weight: weight(4, 0.5)},    // 同一区间
+		{RecordDate: at(6), Weight: weight(6, -1.5)},   // 跨越50、15两条线
+		{RecordDate: at(8), Height: 70},                // 无体重
+		{RecordDate: at(72), Weight: 20},               // 超出5岁
+		{RecordDate: at(10), Weight: weight(10, -2.5)}, // 跨越3百分位线
+	}
+	result := assessGrowth(growthIndicatorWFA, profile, records)
+	if len(result) != 4 {
+		t.Fatalf("评估条数 = %d", len(result))
+	}
+	if result[0].Percentile < 57 || result[0].Percentile > 58.5 || result[0].Level != "正常" {
+		t.Errorf("首次评估错误: %+v", result[0])
+	}
+	if result[1].CrossedLines != 0 || result[1].IsCrossing {
+		t.Errorf("同区间不应标记: %+v", result[1])
+	}
+	if result[2].CrossedLines != -2 || !result[2].IsCrossing {
+		t.Errorf("应标记向下跨越两条线: %+v", result[2])
+	}
+	if result[3].CrossedLines != -1 || result[3].IsCrossing || result[3].Level != "偏低" {
+		t.Errorf("跨越一条线不应标记: %+v", result[3])
+	}
+
+	curves := percentileCurves(growthIndicatorWFL, 1)
+	if len(curves) != len(whoMajorPercentiles) || len(curves[2].Points) != whoMaxLength-whoMinLength+1 {
+		t.Fatalf("身长别体重曲线错误: %d", len(curves))
+	}
+	if curves[2].Points[0].Y != math.Round(whoWeightForLengthBoys[0].M*100)/100 {
+		t.Errorf("中位数曲线起点 = %f", curves[2].Points[0].Y)
+	}
+}
+
+func TestAssessGrowthCorrectedAge(t *testing.T) {
+	// 32周早产，矫正年龄比实际年龄小8周
+	birthday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	profile := &baby.BabyProfile{Gender: 1, Birthday: birthday, GestationalWeeks: 32}
+
+	if _, ok := measurementAgeMonths(profile, birthday.AddDate(0, 0, 30)); ok {
+		t.Error("预产期前的测量不应评估")
+	}
+	ageMonths, ok := measurementAgeMonths(profile, birthday.AddDate(0, 0, 56+61))
+	if !ok || math.Abs(ageMonths-61/daysPerMonth) > 1e-9 {
+		t.Errorf("矫正月龄 = %f", ageMonths)
+	}
+
+	// 满24个月后恢复实际年龄
+	ageMonths, _ = measurementAgeMonths(profile, time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local))
+	if ageMonths < 24 {
+		t.Errorf("24月龄后应使用实际年龄: %f", ageMonths)
+	}
+}
+
+func TestAssessGrowthLengthOutOfRange(t *testing.T) {
+	birthday := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)
+	profile := &baby.BabyProfile{Gender: 1, Birthday: birthday}
+
+	records := []baby.GrowthRecord{
+		{RecordDate: birthday.AddDate(0, 0, 10), Weight: 2.1, Height: 44}, // 低于身长下限
+		{RecordDate: birthday.AddDate(3, 0, 0), Weight: 14.3, Height: 96},
+		{RecordDate: birthday.AddDate(4, 6, 0), Weight: 21, Height: 115}, // 立位身高换算后超出身长上限
+	}
+	result := assessGrowth(growthIndicatorWFL, profile, records)
+	if len(result) != 3 {
+		t.Fatalf("评估条数 = %d", len(result))
+	}
+	for _, i := range []int{0, 2} {
+		if !result[i].OutOfRange || result[i].Level != growthLevelOutOfRange || result[i].ZScore != 0 || result[i].Percentile != 0 {
+			t.Errorf("超出范围的测量应明确标记: %+v", result[i])
+		}
+	}
+	if result[1].OutOfRange || result[1].Level != "正常" || result[1].CrossedLines != 0 {
+		t.Errorf("范围内的测量评估错误: %+v", result[1])
+	}
+}
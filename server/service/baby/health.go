@@ -0,0 +1,785 @@
+package baby
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+type HealthService struct{}
+
+// 体温等级，对应TemperatureRecord.FeverLevel，按折算后的腋下温度划分
+const (
+	feverLevelLow      = -1 // 体温过低：低于36.0℃
+	feverLevelNormal   = 0  // 正常：36.0-37.2℃
+	feverLevelMild     = 1  // 低热：37.3-38.0℃
+	feverLevelModerate = 2  // 中度发热：38.1-39.0℃
+	feverLevelHigh     = 3  // 高热：39.1-41.0℃
+	feverLevelHyper    = 4  // 超高热：高于41.0℃
+)
+
+// temperatureSiteOffsets 各测量部位相对腋下温度的偏高值(℃)
+var temperatureSiteOffsets = map[int]float64{
+	1: 0,   // 腋下
+	2: 0.5, // 耳温
+	3: 0,   // 额温
+	4: 0.5, // 肛温
+	5: 0.3, // 口腔
+}
+
+// youngInfantMonths 该月龄以下的宝宝出现发热即按紧急警报处理
+const youngInfantMonths = 3
+
+// 用药提醒状态，对应MedicationRecord.RemindStatus
+const (
+	remindStatusNone       = 0 // 不提醒
+	remindStatusPending    = 1 // 待提醒
+	remindStatusSent       = 2 // 已提醒
+	remindStatusSuperseded = 3 // 已被后续服药取代
+)
+
+// medicationLookback 计算服药间隔、24小时次数及用药提醒时回看的时长，与服药间隔上限一致
+const medicationLookback = 72 * time.Hour
+
+// CreateDiaperRecord 记录换尿布
+func (s *HealthService) CreateDiaperRecord(userID uint, req *request.CreateDiaperRecordRequest) (*response.DiaperRecordResponse, error) {
+	profile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	recordTime, err := healthRecordTime(profile, req.RecordTime)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &baby.DiaperRecord{
+		UserID:     userID,
+		BabyID:     req.BabyID,
+		RecordTime: recordTime,
+		DiaperType: req.DiaperType,
+		StoolColor: req.StoolColor,
+		Notes:      req.Notes,
+	}
+	if err := global.GVA_DB.Create(record).Error; err != nil {
+		return nil, err
+	}
+
+	var resp response.DiaperRecordResponse
+	resp.FromDiaperRecord(record)
+	return &resp, nil
+}
+
+// GetDiaperRecordList 获取换尿布记录列表
+func (s *HealthService) GetDiaperRecordList(userID uint, req *request.HealthRecordSearch) (*response.DiaperRecordListResponse, error) {
+	db := healthRecordQuery(&baby.DiaperRecord{}, "record_time", userID, req)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var records []baby.DiaperRecord
+	offset := (req.Page - 1) * req.PageSize
+	if err := db.Offset(offset).Limit(req.PageSize).Order("record_time DESC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	list := make([]response.DiaperRecordResponse, len(records))
+	for i := range records {
+		list[i].FromDiaperRecord(&records[i])
+	}
+	return &response.DiaperRecordListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// DeleteDiaperRecord 删除换尿布记录
+func (s *HealthService) DeleteDiaperRecord(id uint, userID uint) error {
+	return deleteHealthRecord(&baby.DiaperRecord{}, id, userID, "换尿布记录不存在")
+}
+
+// CreateTemperatureRecord 记录体温，中度及以上发热或小月龄宝宝发热时创建体温异常警报
+func (s *HealthService) CreateTemperatureRecord(userID uint, req *request.CreateTemperatureRecordRequest) (*response.TemperatureRecordResponse, error) {
+	profile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	measuredAt, err := healthRecordTime(profile, req.MeasuredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	site := req.Site
+	if site == 0 {
+		site = 1
+	}
+	record := &baby.TemperatureRecord{
+		UserID:      userID,
+		BabyID:      req.BabyID,
+		MeasuredAt:  measuredAt,
+		Temperature: math.Round(req.Temperature*10) / 10,
+		Site:        site,
+		Notes:       req.Notes,
+	}
+	record.FeverLevel = feverLevel(record.Temperature, record.Site)
+
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+
+		alertLevel := feverAlertLevel(record.FeverLevel, profile.GetAgeAt(measuredAt).Months)
+		if alertLevel == 0 {
+			return nil
+		}
+		advice := "请注意观察并及时采取降温措施"
+		if alertLevel == 4 {
+			advice = "请尽快就医"
+		}
+		message := fmt.Sprintf("%s%s体温%.1f℃，%s，%s",
+			profile.Name, record.GetSiteText(), record.Temperature, record.GetFeverLevelText(), advice)
+		data, _ := json.Marshal(map[string]interface{}{
+			"temperature_record_id": record.ID,
+			"temperature":           record.Temperature,
+			"site":                  record.Site,
+			"fever_level":           record.FeverLevel,
+		})
+		alert := &baby.SmartAlert{
+			UserID:      profile.UserID,
+			BabyID:      profile.ID,
+			AlertType:   alertTypeFever,
+			AlertLevel:  alertLevel,
+			Title:       "宝宝体温异常",
+			Message:     message,
+			TriggerData: string(data),
+		}
+		if err := tx.Create(alert).Error; err != nil {
+			return err
+		}
+		record.AlertID = alert.ID
+		return tx.Model(record).Update("alert_id", alert.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp response.TemperatureRecordResponse
+	resp.FromTemperatureRecord(record)
+	return &resp, nil
+}
+
+// GetTemperatureRecordList 获取体温记录列表
+func (s *HealthService) GetTemperatureRecordList(userID uint, req *request.HealthRecordSearch) (*response.TemperatureRecordListResponse, error) {
+	db := healthRecordQuery(&baby.TemperatureRecord{}, "measured_at", userID, req)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var records []baby.TemperatureRecord
+	offset := (req.Page - 1) * req.PageSize
+	if err := db.Offset(offset).Limit(req.PageSize).Order("measured_at DESC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	list := make([]response.TemperatureRecordResponse, len(records))
+	for i := range records {
+		list[i].FromTemperatureRecord(&records[i])
+	}
+	return &response.TemperatureRecordListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// DeleteTemperatureRecord 删除体温记录，已产生的警报保留
+func (s *HealthService) DeleteTemperatureRecord(id uint, userID uint) error {
+	return deleteHealthRecord(&baby.TemperatureRecord{}, id, userID, "体温记录不存在")
+}
+
+// CreateMedicationRecord 记录服药，设置了服药间隔时在下次可服药时间提醒
+// 与同一药品之前的服药间隔不足或24小时内次数超限时仍然记录，并在响应中给出提示
+func (s *HealthService) CreateMedicationRecord(userID uint, req *request.CreateMedicationRecordRequest) (*response.MedicationRecordResponse, error) {
+	profile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	takenAt, err := healthRecordTime(profile, req.TakenAt)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &baby.MedicationRecord{
+		UserID:        userID,
+		BabyID:        req.BabyID,
+		MedicineName:  req.MedicineName,
+		Dosage:        req.Dosage,
+		Unit:          req.Unit,
+		TakenAt:       takenAt,
+		IntervalHours: req.IntervalHours,
+		MaxDailyDoses: req.MaxDailyDoses,
+		Reason:        req.Reason,
+		Notes:         req.Notes,
+	}
+	if record.IntervalHours > 0 {
+		next := takenAt.Add(time.Duration(record.IntervalHours) * time.Hour)
+		record.NextDoseAt = &next
+		record.RemindStatus = remindStatusPending
+		// 补录的服药已过下次服药时间时不再提醒
+		if !next.After(time.Now()) {
+			record.RemindStatus = remindStatusSent
+		}
+	}
+
+	var warnings []string
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var previous []baby.MedicationRecord
+		err := tx.Where("baby_id = ? AND medicine_name = ? AND taken_at > ? AND taken_at <= ?",
+			record.BabyID, record.MedicineName, takenAt.Add(-medicationLookback), takenAt).
+			Order("taken_at DESC").Find(&previous).Error
+		if err != nil {
+			return err
+		}
+		warnings = medicationWarnings(record, previous)
+
+		// 之后已有服药记录时本次无需提醒
+		var later int64
+		err = tx.Model(&baby.MedicationRecord{}).Where("baby_id = ? AND medicine_name = ? AND taken_at > ?",
+			record.BabyID, record.MedicineName, takenAt).Count(&later).Error
+		if err != nil {
+			return err
+		}
+		if later > 0 && record.RemindStatus != remindStatusNone {
+			record.RemindStatus = remindStatusSuperseded
+		}
+
+		err = tx.Model(&baby.MedicationRecord{}).
+			Where("baby_id = ? AND medicine_name = ? AND remind_status = ? AND taken_at <= ?",
+				record.BabyID, record.MedicineName, remindStatusPending, takenAt).
+			Update("remind_status", remindStatusSuperseded).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(record).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp response.MedicationRecordResponse
+	resp.FromMedicationRecord(record)
+	resp.Warnings = warnings
+	return &resp, nil
+}
+
+// GetMedicationRecordList 获取用药记录列表
+func (s *HealthService) GetMedicationRecordList(userID uint, req *request.HealthRecordSearch) (*response.MedicationRecordListResponse, error) {
+	db := healthRecordQuery(&baby.MedicationRecord{}, "taken_at", userID, req)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var records []baby.MedicationRecord
+	offset := (req.Page - 1) * req.PageSize
+	if err := db.Offset(offset).Limit(req.PageSize).Order("taken_at DESC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	list := make([]response.MedicationRecordResponse, len(records))
+	for i := range records {
+		list[i].FromMedicationRecord(&records[i])
+	}
+	return &response.MedicationRecordListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// DeleteMedicationRecord 删除用药记录，删除的是该药品最近一次服药时恢复上一次服药的提醒
+func (s *HealthService) DeleteMedicationRecord(id uint, userID uint) error {
+	var record baby.MedicationRecord
+	err := global.GVA_DB.Where("id = ?", id).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("用药记录不存在")
+		}
+		return err
+	}
+	if _, _, err := authorizeBabyRecord(record.BabyID, record.UserID, userID, babyAccessRecord, "用药记录不存在"); err != nil {
+		return err
+	}
+
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&record).Error; err != nil {
+			return err
+		}
+
+		var latest baby.MedicationRecord
+		err := tx.Where("baby_id = ? AND medicine_name = ?", record.BabyID, record.MedicineName).
+			Order("taken_at DESC").First(&latest).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if latest.RemindStatus != remindStatusSuperseded || latest.TakenAt.After(record.TakenAt) {
+			return nil
+		}
+
+		status := remindStatusSent
+		if latest.NextDoseAt != nil && latest.NextDoseAt.After(time.Now()) {
+			status = remindStatusPending
+		}
+		return tx.Model(&latest).Update("remind_status", status).Error
+	})
+}
+
+// GetMedicationReminders 获取宝宝正在使用的药品及下次可服药时间
+func (s *HealthService) GetMedicationReminders(userID uint, babyID uint) ([]response.MedicationReminderResponse, error) {
+	if _, _, err := authorizeBaby(babyID, userID, babyAccessRead); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var records []baby.MedicationRecord
+	err := global.GVA_DB.Where("baby_id = ? AND taken_at > ? AND taken_at <= ?", babyID, now.Add(-medicationLookback), now).
+		Order("taken_at DESC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return buildMedicationReminders(records, now), nil
+}
+
+// SendMedicationReminders 为已到下次服药时间的用药记录创建用药提醒，返回提醒数量，由定时任务调用
+func (s *HealthService) SendMedicationReminders(now time.Time) (int, error) {
+	var records []baby.MedicationRecord
+	err := global.GVA_DB.Where("remind_status = ? AND next_dose_at <= ?", remindStatusPending, now).
+		Order("next_dose_at ASC").Find(&records).Error
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range records {
+		record := &records[i]
+		err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+			// 条件更新保证多实例下每条记录只提醒一次
+			result := tx.Model(&baby.MedicationRecord{}).Where("id = ? AND remind_status = ?", record.ID, remindStatusPending).
+				Update("remind_status", remindStatusSent)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			var profile baby.BabyProfile
+			if err := tx.Where("id = ?", record.BabyID).First(&profile).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil
+				}
+				return err
+			}
+
+			data, _ := json.Marshal(map[string]interface{}{
+				"medication_record_id": record.ID,
+				"medicine_name":        record.MedicineName,
+				"last_taken_at":        record.TakenAt,
+			})
+			alert := &baby.SmartAlert{
+				UserID:      profile.UserID,
+				BabyID:      profile.ID,
+				AlertType:   alertTypeMedication,
+				AlertLevel:  1,
+				Title:       "用药提醒",
+				Message:     fmt.Sprintf("%s的%s已到下次服药时间，上次服药时间%s", profile.Name, record.MedicineName, record.TakenAt.Format("01-02 15:04")),
+				TriggerData: string(data),
+			}
+			if err := tx.Create(alert).Error; err != nil {
+				return err
+			}
+			sent++
+			return nil
+		})
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// CreateSymptomRecord 记录症状
+func (s *HealthService) CreateSymptomRecord(userID uint, req *request.CreateSymptomRecordRequest) (*response.SymptomRecordResponse, error) {
+	profile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	recordTime, err := healthRecordTime(profile, req.RecordTime)
+	if err != nil {
+		return nil, err
+	}
+
+	severity := req.Severity
+	if severity == 0 {
+		severity = 1
+	}
+	record := &baby.SymptomRecord{
+		UserID:     userID,
+		BabyID:     req.BabyID,
+		RecordTime: recordTime,
+		Symptom:    req.Symptom,
+		Severity:   severity,
+		Notes:      req.Notes,
+	}
+	if err := global.GVA_DB.Create(record).Error; err != nil {
+		return nil, err
+	}
+
+	var resp response.SymptomRecordResponse
+	resp.FromSymptomRecord(record)
+	return &resp, nil
+}
+
+// GetSymptomRecordList 获取症状记录列表
+func (s *HealthService) GetSymptomRecordList(userID uint, req *request.HealthRecordSearch) (*response.SymptomRecordListResponse, error) {
+	db := healthRecordQuery(&baby.SymptomRecord{}, "record_time", userID, req)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var records []baby.SymptomRecord
+	offset := (req.Page - 1) * req.PageSize
+	if err := db.Offset(offset).Limit(req.PageSize).Order("record_time DESC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	list := make([]response.SymptomRecordResponse, len(records))
+	for i := range records {
+		list[i].FromSymptomRecord(&records[i])
+	}
+	return &response.SymptomRecordListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// DeleteSymptomRecord 删除症状记录
+func (s *HealthService) DeleteSymptomRecord(id uint, userID uint) error {
+	return deleteHealthRecord(&baby.SymptomRecord{}, id, userID, "症状记录不存在")
+}
+
+// GetHealthSummary 获取宝宝指定日期的健康汇总：尿布次数、体温、服药、症状及当前用药提醒
+func (s *HealthService) GetHealthSummary(userID uint, req *request.HealthSummaryRequest) (*response.HealthDailySummary, error) {
+	profile, _, err := authorizeBaby(req.BabyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	day := startOfDay(now)
+	if req.Date != "" {
+		day, err = time.ParseInLocation("2006-01-02", req.Date, time.Local)
+		if err != nil {
+			return nil, errors.New("日期格式错误")
+		}
+	}
+	dayEnd := day.AddDate(0, 0, 1)
+
+	var diapers []baby.DiaperRecord
+	if err := global.GVA_DB.Where("baby_id = ? AND record_time >= ? AND record_time < ?", profile.ID, day, dayEnd).
+		Order("record_time ASC").Find(&diapers).Error; err != nil {
+		return nil, err
+	}
+	var temperatures []baby.TemperatureRecord
+	if err := global.GVA_DB.Where("baby_id = ? AND measured_at >= ? AND measured_at < ?", profile.ID, day, dayEnd).
+		Order("measured_at ASC").Find(&temperatures).Error; err != nil {
+		return nil, err
+	}
+	var medications []baby.MedicationRecord
+	if err := global.GVA_DB.Where("baby_id = ? AND taken_at >= ? AND taken_at < ?", profile.ID, day, dayEnd).
+		Order("taken_at ASC").Find(&medications).Error; err != nil {
+		return nil, err
+	}
+	var symptoms []baby.SymptomRecord
+	if err := global.GVA_DB.Where("baby_id = ? AND record_time >= ? AND record_time < ?", profile.ID, day, dayEnd).
+		Order("record_time ASC").Find(&symptoms).Error; err != nil {
+		return nil, err
+	}
+
+	summary := &response.HealthDailySummary{
+		BabyID:      profile.ID,
+		BabyName:    profile.Name,
+		Date:        day.Format("2006-01-02"),
+		Medications: make([]response.MedicationRecordResponse, len(medications)),
+		Symptoms:    make([]response.SymptomRecordResponse, len(symptoms)),
+	}
+	summary.DiaperCount, summary.WetCount, summary.DirtyCount = countDiapers(diapers)
+	if len(diapers) > 0 {
+		lastDiaperAt := diapers[len(diapers)-1].RecordTime
+		summary.LastDiaperAt = &lastDiaperAt
+	}
+
+	summary.TemperatureCount = len(temperatures)
+	if len(temperatures) > 0 {
+		var highest, latest response.TemperatureRecordResponse
+		highest.FromTemperatureRecord(highestTemperature(temperatures))
+		latest.FromTemperatureRecord(&temperatures[len(temperatures)-1])
+		summary.MaxTemperature = &highest
+		summary.LatestTemperature = &latest
+		summary.HasFever = highest.IsFever
+	}
+
+	for i := range medications {
+		summary.Medications[i].FromMedicationRecord(&medications[i])
+	}
+	for i := range symptoms {
+		summary.Symptoms[i].FromSymptomRecord(&symptoms[i])
+	}
+
+	summary.Reminders, err = s.GetMedicationReminders(userID, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// healthRecordTime 确定记录时间，为空时取当前时间；不能晚于当前时间，也不能早于宝宝出生日期
+func healthRecordTime(profile *baby.BabyProfile, t *time.Time) (time.Time, error) {
+	now := time.Now()
+	if t == nil {
+		return now, nil
+	}
+	if t.After(now.Add(time.Minute)) {
+		return time.Time{}, errors.New("记录时间不能晚于当前时间")
+	}
+	if t.Before(startOfDay(profile.Birthday)) {
+		return time.Time{}, errors.New("记录时间不能早于宝宝出生日期")
+	}
+	return *t, nil
+}
+
+// healthRecordQuery 构造健康记录列表查询，包含家庭其他成员添加的记录，timeColumn为按日期筛选的时间字段
+func healthRecordQuery(model interface{}, timeColumn string, userID uint, req *request.HealthRecordSearch) *gorm.DB {
+	db := global.GVA_DB.Model(model).Where("baby_id IN (?)", accessibleBabyIDs(userID, babyAccessRead))
+	if req.BabyID > 0 {
+		db = db.Where("baby_id = ?", req.BabyID)
+	}
+	if req.StartDate != "" {
+		db = db.Where(timeColumn+" >= ?", req.StartDate)
+	}
+	if req.EndDate != "" {
+		// 仅传日期时包含结束当天的全部记录
+		if endDate, err := time.ParseInLocation("2006-01-02", req.EndDate, time.Local); err == nil {
+			db = db.Where(timeColumn+" < ?", endDate.AddDate(0, 0, 1))
+		} else {
+			db = db.Where(timeColumn+" <= ?", req.EndDate)
+		}
+	}
+	return db
+}
+
+// deleteHealthRecord 删除健康记录，删除他人添加的记录需要家长及以上角色
+func deleteHealthRecord(model interface{}, id uint, userID uint, notFound string) error {
+	var record struct {
+		BabyID uint
+		UserID uint
+	}
+	err := global.GVA_DB.Model(model).Select("baby_id", "user_id").Where("id = ?", id).Take(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New(notFound)
+		}
+		return err
+	}
+	if _, _, err := authorizeBabyRecord(record.BabyID, record.UserID, userID, babyAccessRecord, notFound); err != nil {
+		return err
+	}
+
+	result := global.GVA_DB.Where("id = ?", id).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(notFound)
+	}
+	return nil
+}
+
+// axillaryTemperature 将各部位测得的体温折算为腋下温度
+func axillaryTemperature(temperature float64, site int) float64 {
+	return math.Round((temperature-temperatureSiteOffsets[site])*10) / 10
+}
+
+// feverLevel 按折算后的腋下温度判断体温等级
+func feverLevel(temperature float64, site int) int {
+	t := axillaryTemperature(temperature, site)
+	switch {
+	case t < 36.0:
+		return feverLevelLow
+	case t < 37.3:
+		return feverLevelNormal
+	case t <= 38.0:
+		return feverLevelMild
+	case t <= 39.0:
+		return feverLevelModerate
+	case t <= 41.0:
+		return feverLevelHigh
+	default:
+		return feverLevelHyper
+	}
+}
+
+// feverAlertLevel 体温对应的警报等级，0表示无需警报
+// 3月龄以下宝宝发热即为紧急；其余宝宝中度发热为中、高热为高、超高热为紧急
+func feverAlertLevel(level int, ageMonths int) int {
+	if level >= feverLevelMild && ageMonths < youngInfantMonths {
+		return 4
+	}
+	switch level {
+	case feverLevelModerate:
+		return 2
+	case feverLevelHigh:
+		return 3
+	case feverLevelHyper:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// highestTemperature 按折算后的腋下温度取最高的一次体温
+func highestTemperature(records []baby.TemperatureRecord) *baby.TemperatureRecord {
+	highest := &records[0]
+	for i := range records {
+		if axillaryTemperature(records[i].Temperature, records[i].Site) > axillaryTemperature(highest.Temperature, highest.Site) {
+			highest = &records[i]
+		}
+	}
+	return highest
+}
+
+// countDiapers 统计尿布总次数、尿湿次数和便便次数，尿湿+便便同时计入两者
+func countDiapers(records []baby.DiaperRecord) (total, wet, dirty int) {
+	for i := range records {
+		total++
+		if records[i].IsWet() {
+			wet++
+		}
+		if records[i].IsDirty() {
+			dirty++
+		}
+	}
+	return total, wet, dirty
+}
+
+// medicationWarnings 检查本次服药与同一药品此前服药的间隔及24小时内次数
+// previous为本次服药前回看时长内的服药记录，按服药时间倒序
+func medicationWarnings(record *baby.MedicationRecord, previous []baby.MedicationRecord) []string {
+	var warnings []string
+	if len(previous) == 0 {
+		return warnings
+	}
+
+	last := previous[0]
+	interval := record.IntervalHours
+	if interval == 0 {
+		interval = last.IntervalHours
+	}
+	if elapsed := record.TakenAt.Sub(last.TakenAt); interval > 0 && elapsed < time.Duration(interval)*time.Hour {
+		warnings = append(warnings, fmt.Sprintf("距上次服用仅%s，未达到%d小时的服药间隔",
+			formatElapsed(elapsed), interval))
+	}
+
+	maxDoses := record.MaxDailyDoses
+	if maxDoses == 0 {
+		maxDoses = last.MaxDailyDoses
+	}
+	if maxDoses > 0 {
+		doses := 1
+		for _, p := range previous {
+			if record.TakenAt.Sub(p.TakenAt) < 24*time.Hour {
+				doses++
+			}
+		}
+		if doses > maxDoses {
+			warnings = append(warnings, fmt.Sprintf("24小时内已服用%d次，超过每日最多%d次", doses, maxDoses))
+		}
+	}
+	return warnings
+}
+
+// buildMedicationReminders 按药品汇总用药提醒，records为回看时长内的服药记录，按服药时间倒序
+// 下次可服药时间取服药间隔和24小时次数限制中较晚的一个
+func buildMedicationReminders(records []baby.MedicationRecord, now time.Time) []response.MedicationReminderResponse {
+	reminders := []response.MedicationReminderResponse{}
+	seen := make(map[string]bool)
+	doses := make(map[string][]time.Time)
+	for _, record := range records {
+		if now.Sub(record.TakenAt) < 24*time.Hour {
+			doses[record.MedicineName] = append(doses[record.MedicineName], record.TakenAt)
+		}
+		if seen[record.MedicineName] {
+			continue
+		}
+		seen[record.MedicineName] = true
+		if record.IntervalHours == 0 && record.MaxDailyDoses == 0 {
+			continue
+		}
+		reminders = append(reminders, response.MedicationReminderResponse{
+			MedicineName:  record.MedicineName,
+			LastTakenAt:   record.TakenAt,
+			NextDoseAt:    record.NextDoseAt,
+			MaxDailyDoses: record.MaxDailyDoses,
+		})
+	}
+
+	for i := range reminders {
+		reminder := &reminders[i]
+		takenTimes := doses[reminder.MedicineName]
+		reminder.DosesLast24h = len(takenTimes)
+		if reminder.MaxDailyDoses > 0 && len(takenTimes) >= reminder.MaxDailyDoses {
+			// 24小时内的次数降到上限以下后才能再次服用
+			allowedAt := takenTimes[reminder.MaxDailyDoses-1].Add(24 * time.Hour)
+			if reminder.NextDoseAt == nil || allowedAt.After(*reminder.NextDoseAt) {
+				reminder.NextDoseAt = &allowedAt
+			}
+		}
+		if reminder.NextDoseAt != nil && reminder.NextDoseAt.After(now) {
+			reminder.MinutesUntilNext = int(math.Ceil(reminder.NextDoseAt.Sub(now).Minutes()))
+		} else {
+			reminder.CanTakeNow = true
+		}
+	}
+	return reminders
+}
+
+// formatElapsed 将时长格式化为"X小时Y分钟"
+func formatElapsed(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%d分钟", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d小时", minutes/60)
+	}
+	return fmt.Sprintf("%d小时%d分钟", minutes/60, minutes%60)
+}
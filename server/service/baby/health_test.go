@@ -0,0 +1,146 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+)
+
+func TestFeverLevel(t *testing.T) {
+	tests := []struct {
+		temperature float64
+		site        int
+		want        int
+	}{
+		{35.8, 1, feverLevelLow},
+		{36.5, 1, feverLevelNormal},
+		{37.2, 1, feverLevelNormal},
+		{37.3, 1, feverLevelMild},
+		{38.0, 1, feverLevelMild},
+		{38.1, 1, feverLevelModerate},
+		{39.5, 1, feverLevelHigh},
+		{41.2, 1, feverLevelHyper},
+		// 肛温、耳温比腋温高0.5℃
+		{37.7, 4, feverLevelNormal},
+		{38.6, 2, feverLevelModerate},
+		// 口腔温度比腋温高0.3℃
+		{37.6, 5, feverLevelMild},
+	}
+	for _, tt := range tests {
+		if got := feverLevel(tt.temperature, tt.site); got != tt.want {
+			t.Errorf("feverLevel(%.1f, %d) = %d, want %d", tt.temperature, tt.site, got, tt.want)
+		}
+	}
+}
+
+func TestFeverAlertLevel(t *testing.T) {
+	tests := []struct {
+		level, months, want int
+	}{
+		{feverLevelNormal, 1, 0},
+		{feverLevelMild, 6, 0},
+		{feverLevelMild, 2, 4},
+		{feverLevelModerate, 6, 2},
+		{feverLevelHigh, 12, 3},
+		{feverLevelHyper, 12, 4},
+		{feverLevelLow, 1, 0},
+	}
+	for _, tt := range tests {
+		if got := feverAlertLevel(tt.level, tt.months); got != tt.want {
+			t.Errorf("feverAlertLevel(%d, %d) = %d, want %d", tt.level, tt.months, got, tt.want)
+		}
+	}
+}
+
+func TestCountDiapers(t *testing.T) {
+	records := []baby.DiaperRecord{{DiaperType: 1}, {DiaperType: 2}, {DiaperType: 3}, {DiaperType: 4}, {DiaperType: 1}}
+	total, wet, dirty := countDiapers(records)
+	if total != 5 || wet != 3 || dirty != 2 {
+		t.Errorf("countDiapers = %d/%d/%d, want 5/3/2", total, wet, dirty)
+	}
+}
+
+func TestMedicationWarnings(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2024, 5, 2, hour, minute, 0, 0, time.Local) }
+
+	previous := []baby.MedicationRecord{
+		{TakenAt: at(10, 0), IntervalHours: 6, MaxDailyDoses: 3},
+		{TakenAt: at(4, 0), IntervalHours: 6, MaxDailyDoses: 3},
+		{TakenAt: at(0, 30)},
+	}
+
+	// 间隔4小时30分钟不足6小时，24小时内第4次超过3次上限
+	record := &baby.MedicationRecord{TakenAt: at(14, 30)}
+	warnings := medicationWarnings(record, previous)
+	want := []string{"距上次服用仅4小时30分钟，未达到6小时的服药间隔", "24小时内已服用4次，超过每日最多3次"}
+	if len(warnings) != len(want) {
+		t.Fatalf("warnings = %v, want %v", warnings, want)
+	}
+	for i := range want {
+		if warnings[i] != want[i] {
+			t.Errorf("warnings[%d] = %q, want %q", i, warnings[i], want[i])
+		}
+	}
+
+	// 满足间隔且次数未超限
+	record = &baby.MedicationRecord{TakenAt: at(16, 0), IntervalHours: 6, MaxDailyDoses: 4}
+	if warnings := medicationWarnings(record, previous); len(warnings) != 0 {
+		t.Errorf("warnings = %v, want none", warnings)
+	}
+}
+
+func TestBuildMedicationReminders(t *testing.T) {
+	now := time.Date(2024, 5, 2, 12, 0, 0, 0, time.Local)
+	ptr := func(t time.Time) *time.Time { return &t }
+	hoursAgo := func(h int) time.Time { return now.Add(-time.Duration(h) * time.Hour) }
+
+	records := []baby.MedicationRecord{
+		// 布洛芬：每6小时一次，最近一次2小时前，4小时后可再次服用
+		{MedicineName: "布洛芬", TakenAt: hoursAgo(2), IntervalHours: 6, NextDoseAt: ptr(hoursAgo(2).Add(6 * time.Hour))},
+		// 对乙酰氨基酚：每4小时一次，每日最多3次，24小时内已服3次，需等最早一次满24小时
+		{MedicineName: "对乙酰氨基酚", TakenAt: hoursAgo(5), IntervalHours: 4, MaxDailyDoses: 3, NextDoseAt: ptr(hoursAgo(1))},
+		{MedicineName: "对乙酰氨基酚", TakenAt: hoursAgo(10), IntervalHours: 4, MaxDailyDoses: 3},
+		{MedicineName: "布洛芬", TakenAt: hoursAgo(9), IntervalHours: 6},
+		{MedicineName: "对乙酰氨基酚", TakenAt: hoursAgo(20), IntervalHours: 4, MaxDailyDoses: 3},
+		// 益生菌：无间隔要求，不需要提醒
+		{MedicineName: "益生菌", TakenAt: hoursAgo(3)},
+	}
+
+	reminders := buildMedicationReminders(records, now)
+	if len(reminders) != 2 {
+		t.Fatalf("提醒数量 = %d, want 2", len(reminders))
+	}
+
+	ibuprofen := reminders[0]
+	if ibuprofen.MedicineName != "布洛芬" || ibuprofen.CanTakeNow || ibuprofen.MinutesUntilNext != 240 || ibuprofen.DosesLast24h != 2 {
+		t.Errorf("布洛芬 = %+v", ibuprofen)
+	}
+
+	paracetamol := reminders[1]
+	if paracetamol.CanTakeNow || paracetamol.DosesLast24h != 3 || !paracetamol.NextDoseAt.Equal(hoursAgo(20).Add(24*time.Hour)) {
+		t.Errorf("对乙酰氨基酚 = %+v", paracetamol)
+	}
+}
+
+func TestDeleteHealthRecordFamilyAccess(t *testing.T) {
+	db := setupTestDB(t, &baby.FamilyGroup{}, &baby.FamilyMember{}, &baby.BabyProfile{}, &baby.DiaperRecord{})
+	profile := seedFamilyBaby(t, db)
+	record := baby.DiaperRecord{UserID: 2, BabyID: profile.ID, RecordTime: time.Now(), DiaperType: 1}
+	if err := db.Create(&record).Error; err != nil {
+		t.Fatal(err)
+	}
+	service := new(HealthService)
+
+	if err := service.DeleteDiaperRecord(record.ID, 5); err == nil || err.Error() != "换尿布记录不存在" {
+		t.Errorf("非家庭成员删除: %v", err)
+	}
+	if err := service.DeleteDiaperRecord(record.ID, 3); err == nil || err.Error() != "家庭角色权限不足" {
+		t.Errorf("看护人删除他人的记录: %v", err)
+	}
+	if err := service.DeleteDiaperRecord(record.ID, 1); err != nil {
+		t.Fatalf("创建者删除: %v", err)
+	}
+	if err := service.DeleteDiaperRecord(record.ID, 1); err == nil || err.Error() != "换尿布记录不存在" {
+		t.Errorf("重复删除: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package baby
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+// MediaService 媒体网关，为音频和视频签发短期有效的签名地址，并在访问时校验签名
+type MediaService struct{}
+
+const (
+	mediaTypeAudio = "audio"
+	mediaTypeVideo = "video"
+
+	defaultMediaExpires = 3600 // 签名地址默认有效期(秒)
+)
+
+// MediaTarget 签名校验通过后的媒体源，本地存储返回文件路径，其他存储返回源地址由网关代理，源地址不下发给客户端
+type MediaTarget struct {
+	FilePath  string
+	SourceURL string
+	ExpiresAt time.Time
+}
+
+// ResolveMedia 校验签名、有效期和会员权限，返回媒体源
+func (s *MediaService) ResolveMedia(mediaType string, mediaID uint, req *request.MediaRequest) (*MediaTarget, error) {
+	if err := verifyMediaSign(mediaType, mediaID, req, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var sourceURL string
+	var vipOnly bool
+	switch mediaType {
+	case mediaTypeAudio:
+		var music baby.Music
+		err := global.GVA_DB.Select("id", "audio_url", vipColumn).Where("id = ? AND is_active = ?", mediaID, true).First(&music).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("音乐不存在")
+			}
+			return nil, err
+		}
+		sourceURL, vipOnly = music.AudioURL, music.IsVIP
+	case mediaTypeVideo:
+		var video baby.ParentingVideo
+		err := global.GVA_DB.Select("id", "video_url", vipColumn).
+			Where("id = ? AND is_active = ? AND published_at <= ?", mediaID, true, time.Now()).First(&video).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("视频不存在")
+			}
+			return nil, err
+		}
+		sourceURL, vipOnly = video.VideoURL, video.IsVIP
+	}
+	if sourceURL == "" {
+		return nil, errors.New("媒体文件不存在")
+	}
+	// 会员可能在签名有效期内到期，每次访问都重新校验
+	if vipOnly && !isVipUser(req.UID) {
+		return nil, errors.New("该内容为会员专享，开通会员后可访问")
+	}
+
+	target := &MediaTarget{ExpiresAt: time.Unix(req.Expires, 0)}
+	if isRemoteMediaURL(sourceURL) {
+		target.SourceURL = sourceURL
+		return target, nil
+	}
+	filePath, err := localMediaPath(sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	target.FilePath = filePath
+	return target, nil
+}
+
+// signMediaURL 生成带用户、过期时间和签名的媒体网关地址
+func signMediaURL(mediaType string, mediaID uint, userID uint) string {
+	expires := time.Now().Add(mediaExpires()).Unix()
+	query := url.Values{}
+	query.Set("uid", strconv.FormatUint(uint64(userID), 10))
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("sign", mediaSign(mediaType, mediaID, userID, expires))
+
+	baseURL := global.GVA_CONFIG.Media.BaseURL
+	if baseURL == "" {
+		baseURL = global.GVA_CONFIG.System.RouterPrefix
+	}
+	return fmt.Sprintf("%s/media/%s/%d?%s", strings.TrimRight(baseURL, "/"), mediaType, mediaID, query.Encode())
+}
+
+// verifyMediaSign 校验媒体地址签名及有效期
+func verifyMediaSign(mediaType string, mediaID uint, req *request.MediaRequest, now time.Time) error {
+	if mediaType != mediaTypeAudio && mediaType != mediaTypeVideo {
+		return errors.New("不支持的媒体类型")
+	}
+	expected := mediaSign(mediaType, mediaID, req.UID, req.Expires)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(req.Sign))) {
+		return errors.New("媒体地址签名无效")
+	}
+	if now.Unix() > req.Expires {
+		return errors.New("媒体地址已过期")
+	}
+	return nil
+}
+
+// mediaSign 对媒体类型、ID、用户和过期时间计算HMAC-SHA256签名
+func mediaSign(mediaType string, mediaID uint, userID uint, expires int64) string {
+	key := global.GVA_CONFIG.Media.SignKey
+	if key == "" {
+		key = global.GVA_CONFIG.JWT.SigningKey
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	fmt.Fprintf(mac, "%s:%d:%d:%d", mediaType, mediaID, userID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func mediaExpires() time.Duration {
+	expires := global.GVA_CONFIG.Media.Expires
+	if expires <= 0 {
+		expires = defaultMediaExpires
+	}
+	return time.Duration(expires) * time.Second
+}
+
+func isRemoteMediaURL(sourceURL string) bool {
+	lower := strings.ToLower(sourceURL)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// localMediaPath 将本地存储返回的访问路径映射为存储目录下的文件，禁止越出存储目录
+func localMediaPath(sourceURL string) (string, error) {
+	local := global.GVA_CONFIG.Local
+	rel := strings.TrimPrefix(sourceURL, "/")
+	switch {
+	case local.Path != "" && strings.HasPrefix(rel, strings.Trim(local.Path, "/")+"/"):
+		rel = strings.TrimPrefix(rel, strings.Trim(local.Path, "/")+"/")
+	case local.StorePath != "" && strings.HasPrefix(rel, strings.Trim(local.StorePath, "/")+"/"):
+		rel = strings.TrimPrefix(rel, strings.Trim(local.StorePath, "/")+"/")
+	default:
+		return "", errors.New("媒体文件不在本地存储目录中")
+	}
+	// 以根目录为基准清理路径，去掉所有 ../ 后再拼接存储目录
+	rel = path.Clean("/" + rel)
+	if rel == "/" {
+		return "", errors.New("媒体文件不存在")
+	}
+	return filepath.Join(local.StorePath, filepath.FromSlash(rel)), nil
+}
+
+// guardMusic VIP专享音乐对未开通会员的用户锁定，其余替换为签名播放地址
+func (c *vipChecker) guardMusic(resp *response.MusicResponse, vipOnly bool) {
+	if c.locked(vipOnly) {
+		resp.Lock()
+		return
+	}
+	if resp.AudioURL != "" {
+		resp.AudioURL = signMediaURL(mediaTypeAudio, resp.ID, c.userID)
+	}
+}
+
+// guardVideo VIP专享视频对未开通会员的用户锁定，其余替换为签名播放地址
+func (c *vipChecker) guardVideo(resp *response.ParentingVideoResponse, vipOnly bool) {
+	if c.locked(vipOnly) {
+		resp.Lock()
+		return
+	}
+	if resp.VideoURL != "" {
+		resp.VideoURL = signMediaURL(mediaTypeVideo, resp.ID, c.userID)
+	}
+}
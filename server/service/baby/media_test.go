@@ -0,0 +1,155 @@
+package baby
+
+import (
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/system"
+)
+
+func TestMediaSignRoundTrip(t *testing.T) {
+	global.GVA_CONFIG.Media.SignKey = "media-test-key"
+	global.GVA_CONFIG.Media.BaseURL = "https://api.example.com/api"
+	defer func() { global.GVA_CONFIG.Media.SignKey, global.GVA_CONFIG.Media.BaseURL = "", "" }()
+
+	signed, err := url.Parse(signMediaURL(mediaTypeAudio, 12, 34))
+	if err != nil {
+		t.Fatalf("parse signed url: %v", err)
+	}
+	if signed.Path != "/api/media/audio/12" {
+		t.Fatalf("path = %s, want /api/media/audio/12", signed.Path)
+	}
+	query := signed.Query()
+	expires, _ := strconv.ParseInt(query.Get("expires"), 10, 64)
+	req := request.MediaRequest{UID: 34, Expires: expires, Sign: query.Get("sign")}
+	now := time.Now()
+
+	if err := verifyMediaSign(mediaTypeAudio, 12, &req, now); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+	if err := verifyMediaSign(mediaTypeAudio, 12, &req, time.Unix(expires+1, 0)); err == nil {
+		t.Errorf("expired signature accepted")
+	}
+
+	tampered := []struct {
+		name      string
+		mediaType string
+		mediaID   uint
+		req       request.MediaRequest
+	}{
+		{"换成视频", mediaTypeVideo, 12, req},
+		{"换ID", mediaTypeAudio, 13, req},
+		{"换用户", mediaTypeAudio, 12, request.MediaRequest{UID: 35, Expires: req.Expires, Sign: req.Sign}},
+		{"延长有效期", mediaTypeAudio, 12, request.MediaRequest{UID: 34, Expires: req.Expires + 3600, Sign: req.Sign}},
+		{"篡改签名", mediaTypeAudio, 12, request.MediaRequest{UID: 34, Expires: req.Expires, Sign: strings.Repeat("0", 64)}},
+		{"未知类型", "image", 12, req},
+	}
+	for _, tt := range tampered {
+		if err := verifyMediaSign(tt.mediaType, tt.mediaID, &tt.req, now); err == nil {
+			t.Errorf("%s: tampered signature accepted", tt.name)
+		}
+	}
+
+	// 更换密钥后旧签名失效
+	global.GVA_CONFIG.Media.SignKey = "rotated-key"
+	if err := verifyMediaSign(mediaTypeAudio, 12, &req, now); err == nil {
+		t.Errorf("signature accepted after key rotation")
+	}
+}
+
+func TestLocalMediaPath(t *testing.T) {
+	global.GVA_CONFIG.Local.Path = "uploads/file"
+	global.GVA_CONFIG.Local.StorePath = "/data/uploads"
+	defer func() { global.GVA_CONFIG.Local.Path, global.GVA_CONFIG.Local.StorePath = "", "" }()
+
+	tests := []struct {
+		sourceURL string
+		want      string
+		wantErr   bool
+	}{
+		{"uploads/file/abc.mp3", "/data/uploads/abc.mp3", false},
+		{"/uploads/file/music/abc.mp3", "/data/uploads/music/abc.mp3", false},
+		{"/data/uploads/abc.mp4", "/data/uploads/abc.mp4", false},
+		{"uploads/file/../../etc/passwd", "/data/uploads/etc/passwd", false},
+		{"uploads/file/", "", true},
+		{"other/abc.mp3", "", true},
+	}
+	for _, tt := range tests {
+		got, err := localMediaPath(tt.sourceURL)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("localMediaPath(%q) error = %v, wantErr %v", tt.sourceURL, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != filepath.FromSlash(tt.want) {
+			t.Errorf("localMediaPath(%q) = %q, want %q", tt.sourceURL, got, tt.want)
+		}
+	}
+}
+
+func TestResolveMediaVipAccess(t *testing.T) {
+	db := setupTestDB(t, &baby.Music{}, &baby.ParentingVideo{}, &system.MiniprogramUser{})
+	global.GVA_CONFIG.Media.SignKey = "media-test-key"
+	defer func() { global.GVA_CONFIG.Media.SignKey = "" }()
+
+	expiredAt := time.Now().AddDate(0, 1, 0)
+	users := []system.MiniprogramUser{
+		{OpenID: "free", Username: "free", Email: "free@example.com"},
+		{OpenID: "vip", Username: "vip", Email: "vip@example.com", VipExpiredAt: &expiredAt},
+	}
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatal(err)
+	}
+	free, vip := users[0].ID, users[1].ID
+	freeMusic := baby.Music{CategoryID: 1, Title: "摇篮曲", AudioURL: "https://cdn.example.com/lullaby.mp3", IsActive: true}
+	vipMusic := baby.Music{CategoryID: 1, Title: "白噪音", AudioURL: "https://cdn.example.com/noise.mp3", IsActive: true, IsVIP: true}
+	for _, music := range []*baby.Music{&freeMusic, &vipMusic} {
+		if err := db.Create(music).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	vipVideo := baby.ParentingVideo{CategoryID: 1, Title: "抚触操", VideoURL: "https://cdn.example.com/touch.mp4", IsActive: true, IsVIP: true,
+		PublishedAt: time.Now().Add(-time.Hour)}
+	if err := db.Create(&vipVideo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		mediaType string
+		mediaID   uint
+		userID    uint
+		source    string // 为空表示拒绝访问
+	}{
+		{"免费音乐", mediaTypeAudio, freeMusic.ID, free, freeMusic.AudioURL},
+		{"非会员访问会员音乐", mediaTypeAudio, vipMusic.ID, free, ""},
+		{"会员访问会员音乐", mediaTypeAudio, vipMusic.ID, vip, vipMusic.AudioURL},
+		{"非会员访问会员视频", mediaTypeVideo, vipVideo.ID, free, ""},
+		{"会员访问会员视频", mediaTypeVideo, vipVideo.ID, vip, vipVideo.VideoURL},
+	}
+	service := new(MediaService)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expires := time.Now().Add(time.Minute).Unix()
+			req := request.MediaRequest{UID: tt.userID, Expires: expires, Sign: mediaSign(tt.mediaType, tt.mediaID, tt.userID, expires)}
+			target, err := service.ResolveMedia(tt.mediaType, tt.mediaID, &req)
+			if tt.source == "" {
+				if err == nil {
+					t.Errorf("ResolveMedia = %+v, want error", target)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if target.SourceURL != tt.source {
+				t.Errorf("SourceURL = %q, want %q", target.SourceURL, tt.source)
+			}
+		})
+	}
+}
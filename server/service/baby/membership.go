@@ -0,0 +1,109 @@
+package baby
+
+import (
+	"errors"
+	"math"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/response"
+	"baby_admin/server/model/system"
+	"gorm.io/gorm"
+)
+
+// vipColumn 内容表VIP专享字段的列名，GORM命名规则将IsVIP中的IP视为缩写，生成的列名为is_v_ip
+const vipColumn = "is_v_ip"
+
+// MembershipService 小程序端会员状态查询，会员的开通、延期和撤销由管理后台完成
+type MembershipService struct{}
+
+// GetMyMembership 获取当前用户的会员状态及可开通的套餐
+func (s *MembershipService) GetMyMembership(userID uint) (*response.MembershipResponse, error) {
+	var user system.MiniprogramUser
+	if err := global.GVA_DB.Where("id = ?", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("用户不存在")
+		}
+		return nil, err
+	}
+
+	plans, err := s.GetVipPlans()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resp := &response.MembershipResponse{
+		IsVIP:         user.IsVIP(now),
+		PlanID:        user.VipPlanID,
+		StartedAt:     user.VipStartedAt,
+		ExpiredAt:     user.VipExpiredAt,
+		RemainingDays: vipRemainingDays(user.VipExpiredAt, now),
+		Plans:         plans,
+	}
+	if user.VipPlanID > 0 {
+		var plan baby.VipPlan
+		if err := global.GVA_DB.Unscoped().Where("id = ?", user.VipPlanID).First(&plan).Error; err == nil {
+			resp.PlanName = plan.Name
+		}
+	}
+	return resp, nil
+}
+
+// GetVipPlans 获取启用的会员套餐
+func (s *MembershipService) GetVipPlans() ([]response.VipPlanResponse, error) {
+	var plans []baby.VipPlan
+	err := global.GVA_DB.Where("is_active = ?", true).Order("sort_order ASC, duration_days ASC").Find(&plans).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.VipPlanResponse, 0, len(plans))
+	for i := range plans {
+		var resp response.VipPlanResponse
+		resp.FromVipPlan(&plans[i])
+		result = append(result, resp)
+	}
+	return result, nil
+}
+
+// isVipUser 用户会员是否在有效期内，查询失败时按非会员处理
+func isVipUser(userID uint) bool {
+	var user system.MiniprogramUser
+	err := global.GVA_DB.Select("id", "vip_expired_at").Where("id = ?", userID).First(&user).Error
+	if err != nil {
+		return false
+	}
+	return user.IsVIP(time.Now())
+}
+
+// vipRemainingDays 会员剩余天数，不足一天按一天计，已过期或未开通返回0
+func vipRemainingDays(expiredAt *time.Time, now time.Time) int {
+	if expiredAt == nil || !expiredAt.After(now) {
+		return 0
+	}
+	return int(math.Ceil(expiredAt.Sub(now).Hours() / 24))
+}
+
+// vipChecker 判断VIP专享内容对当前用户是否锁定，同一次请求只在遇到VIP内容时查询一次会员状态
+type vipChecker struct {
+	userID uint
+	loaded bool
+	isVIP  bool
+}
+
+func newVipChecker(userID uint) *vipChecker {
+	return &vipChecker{userID: userID}
+}
+
+// locked VIP专享内容且用户未开通会员时返回true
+func (c *vipChecker) locked(vipOnly bool) bool {
+	if !vipOnly {
+		return false
+	}
+	if !c.loaded {
+		c.isVIP = isVipUser(c.userID)
+		c.loaded = true
+	}
+	return !c.isVIP
+}
@@ -0,0 +1,351 @@
+package baby
+
+import (
+	"errors"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"baby_admin/server/model/system"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 会员变更操作，对应VipMembershipLog.Action
+const (
+	membershipActionGrant  = 1 // 开通
+	membershipActionExtend = 2 // 延期
+	membershipActionRevoke = 3 // 撤销
+)
+
+// MembershipAdminService 会员套餐维护及用户会员开通、延期、撤销，供管理后台使用
+type MembershipAdminService struct{}
+
+// CreateVipPlan 创建会员套餐
+func (s *MembershipAdminService) CreateVipPlan(req *request.CreateVipPlanRequest) (*baby.VipPlan, error) {
+	if err := s.checkPlanCode(req.Code, 0); err != nil {
+		return nil, err
+	}
+
+	plan := &baby.VipPlan{IsActive: true}
+	fillVipPlan(plan, req)
+	if err := global.GVA_DB.Create(plan).Error; err != nil {
+		return nil, err
+	}
+	// IsActive为false时默认值会覆盖零值，单独更新
+	if !plan.IsActive {
+		if err := global.GVA_DB.Model(plan).Update("is_active", false).Error; err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// UpdateVipPlan 更新会员套餐，已开通的会员不受影响
+func (s *MembershipAdminService) UpdateVipPlan(req *request.UpdateVipPlanRequest) error {
+	var plan baby.VipPlan
+	if err := global.GVA_DB.Where("id = ?", req.ID).First(&plan).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("会员套餐不存在")
+		}
+		return err
+	}
+	if err := s.checkPlanCode(req.Code, plan.ID); err != nil {
+		return err
+	}
+
+	fillVipPlan(&plan, &req.CreateVipPlanRequest)
+	return global.GVA_DB.Save(&plan).Error
+}
+
+// DeleteVipPlan 删除会员套餐，已开通的会员不受影响
+func (s *MembershipAdminService) DeleteVipPlan(id uint) error {
+	result := global.GVA_DB.Where("id = ?", id).Delete(&baby.VipPlan{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("会员套餐不存在")
+	}
+	return nil
+}
+
+// GetVipPlanList 分页获取会员套餐列表
+func (s *MembershipAdminService) GetVipPlanList(req *request.VipPlanSearch) ([]response.VipPlanResponse, int64, error) {
+	db := global.GVA_DB.Model(&baby.VipPlan{})
+	if req.Name != "" {
+		db = db.Where("name LIKE ? OR code LIKE ?", "%"+req.Name+"%", "%"+req.Name+"%")
+	}
+	if req.IsActive != nil {
+		db = db.Where("is_active = ?", *req.IsActive)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var plans []baby.VipPlan
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order("sort_order ASC, id ASC").Find(&plans).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list := make([]response.VipPlanResponse, 0, len(plans))
+	for i := range plans {
+		var resp response.VipPlanResponse
+		resp.FromVipPlan(&plans[i])
+		list = append(list, resp)
+	}
+	return list, total, nil
+}
+
+// GrantMembership 为用户开通会员，从现在起计算有效期，会员有效期内的用户请使用延期
+func (s *MembershipAdminService) GrantMembership(operatorID uint, req *request.MembershipChangeRequest) error {
+	planID, days, err := s.resolveMembershipDays(req)
+	if err != nil {
+		return err
+	}
+
+	return s.changeMembership(req.UserID, func(user *system.MiniprogramUser, now time.Time) (*baby.VipMembershipLog, error) {
+		if user.IsVIP(now) {
+			return nil, errors.New("用户会员尚未到期，请使用延期")
+		}
+		expiredAt := now.AddDate(0, 0, days)
+		user.VipPlanID = planID
+		user.VipStartedAt = &now
+		user.VipExpiredAt = &expiredAt
+		return &baby.VipMembershipLog{PlanID: planID, Action: membershipActionGrant, Days: days, OperatorID: operatorID, Reason: req.Reason}, nil
+	})
+}
+
+// ExtendMembership 延长用户会员，有效期内从原到期时间顺延，已过期或未开通时从现在起计算
+func (s *MembershipAdminService) ExtendMembership(operatorID uint, req *request.MembershipChangeRequest) error {
+	planID, days, err := s.resolveMembershipDays(req)
+	if err != nil {
+		return err
+	}
+
+	return s.changeMembership(req.UserID, func(user *system.MiniprogramUser, now time.Time) (*baby.VipMembershipLog, error) {
+		from := now
+		if user.IsVIP(now) {
+			from = *user.VipExpiredAt
+		} else {
+			user.VipStartedAt = &now
+		}
+		expiredAt := from.AddDate(0, 0, days)
+		if planID > 0 {
+			user.VipPlanID = planID
+		}
+		user.VipExpiredAt = &expiredAt
+		return &baby.VipMembershipLog{PlanID: planID, Action: membershipActionExtend, Days: days, OperatorID: operatorID, Reason: req.Reason}, nil
+	})
+}
+
+// RevokeMembership 撤销用户会员，到期时间改为当前时间并保留开通记录
+func (s *MembershipAdminService) RevokeMembership(operatorID uint, req *request.RevokeMembershipRequest) error {
+	return s.changeMembership(req.UserID, func(user *system.MiniprogramUser, now time.Time) (*baby.VipMembershipLog, error) {
+		if !user.IsVIP(now) {
+			return nil, errors.New("用户当前不是会员")
+		}
+		user.VipExpiredAt = &now
+		return &baby.VipMembershipLog{PlanID: user.VipPlanID, Action: membershipActionRevoke, OperatorID: operatorID, Reason: req.Reason}, nil
+	})
+}
+
+// GetMemberList 分页获取开通过会员的用户
+func (s *MembershipAdminService) GetMemberList(req *request.MemberSearch) ([]response.MemberResponse, int64, error) {
+	now := time.Now()
+	db := global.GVA_DB.Model(&system.MiniprogramUser{}).Where("vip_expired_at IS NOT NULL")
+	switch req.Status {
+	case 1:
+		db = db.Where("vip_expired_at > ?", now)
+	case 2:
+		db = db.Where("vip_expired_at <= ?", now)
+	}
+	if req.Keyword != "" {
+		db = db.Where("nick_name LIKE ? OR username LIKE ? OR phone LIKE ?",
+			"%"+req.Keyword+"%", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []system.MiniprogramUser
+	offset := (req.Page - 1) * req.PageSize
+	if err := db.Offset(offset).Limit(req.PageSize).Order("vip_expired_at DESC").Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var planIDs []uint
+	for _, user := range users {
+		planIDs = append(planIDs, user.VipPlanID)
+	}
+	planMap := vipPlanNameMap(planIDs)
+
+	list := make([]response.MemberResponse, 0, len(users))
+	for i := range users {
+		user := &users[i]
+		list = append(list, response.MemberResponse{
+			UserID:        user.ID,
+			Username:      user.Username,
+			NickName:      user.NickName,
+			Avatar:        user.Avatar,
+			Phone:         user.Phone,
+			IsVIP:         user.IsVIP(now),
+			PlanID:        user.VipPlanID,
+			PlanName:      planMap[user.VipPlanID],
+			StartedAt:     user.VipStartedAt,
+			ExpiredAt:     user.VipExpiredAt,
+			RemainingDays: vipRemainingDays(user.VipExpiredAt, now),
+		})
+	}
+	return list, total, nil
+}
+
+// GetMembershipLogList 分页获取会员变更记录
+func (s *MembershipAdminService) GetMembershipLogList(req *request.MembershipLogSearch) ([]response.MembershipLogResponse, int64, error) {
+	db := global.GVA_DB.Model(&baby.VipMembershipLog{})
+	if req.UserID > 0 {
+		db = db.Where("user_id = ?", req.UserID)
+	}
+	if req.Action > 0 {
+		db = db.Where("action = ?", req.Action)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []baby.VipMembershipLog
+	offset := (req.Page - 1) * req.PageSize
+	if err := db.Offset(offset).Limit(req.PageSize).Order("id DESC").Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var userIDs, planIDs []uint
+	for _, log := range logs {
+		userIDs = append(userIDs, log.UserID)
+		planIDs = append(planIDs, log.PlanID)
+	}
+	planMap := vipPlanNameMap(planIDs)
+
+	nickMap := make(map[uint]string)
+	if len(userIDs) > 0 {
+		var users []system.MiniprogramUser
+		global.GVA_DB.Select("id", "nick_name").Where("id IN ?", userIDs).Find(&users)
+		for _, user := range users {
+			nickMap[user.ID] = user.NickName
+		}
+	}
+
+	list := make([]response.MembershipLogResponse, 0, len(logs))
+	for i := range logs {
+		var resp response.MembershipLogResponse
+		resp.FromVipMembershipLog(&logs[i], nickMap[logs[i].UserID], planMap[logs[i].PlanID])
+		list = append(list, resp)
+	}
+	return list, total, nil
+}
+
+// changeMembership 锁定用户记录后执行会员变更，并记录变更前后的到期时间
+func (s *MembershipAdminService) changeMembership(userID uint, change func(user *system.MiniprogramUser, now time.Time) (*baby.VipMembershipLog, error)) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var user system.MiniprogramUser
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", userID).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("用户不存在")
+			}
+			return err
+		}
+
+		expiredBefore := user.VipExpiredAt
+		log, err := change(&user, time.Now())
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&user).Updates(map[string]interface{}{
+			"vip_plan_id":    user.VipPlanID,
+			"vip_started_at": user.VipStartedAt,
+			"vip_expired_at": user.VipExpiredAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		log.UserID = user.ID
+		log.ExpiredBefore = expiredBefore
+		log.ExpiredAfter = user.VipExpiredAt
+		return tx.Create(log).Error
+	})
+}
+
+// resolveMembershipDays 计算开通或延期的天数，指定天数时以天数为准，否则使用套餐天数
+func (s *MembershipAdminService) resolveMembershipDays(req *request.MembershipChangeRequest) (uint, int, error) {
+	if req.PlanID == 0 {
+		if req.Days <= 0 {
+			return 0, 0, errors.New("请选择会员套餐或填写天数")
+		}
+		return 0, req.Days, nil
+	}
+
+	var plan baby.VipPlan
+	if err := global.GVA_DB.Where("id = ?", req.PlanID).First(&plan).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, 0, errors.New("会员套餐不存在")
+		}
+		return 0, 0, err
+	}
+	if req.Days > 0 {
+		return plan.ID, req.Days, nil
+	}
+	return plan.ID, plan.DurationDays, nil
+}
+
+// checkPlanCode 校验套餐编码唯一
+func (s *MembershipAdminService) checkPlanCode(code string, excludeID uint) error {
+	var count int64
+	err := global.GVA_DB.Model(&baby.VipPlan{}).Where("code = ? AND id <> ?", code, excludeID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("套餐编码已存在")
+	}
+	return nil
+}
+
+// fillVipPlan 将请求写入会员套餐
+func fillVipPlan(plan *baby.VipPlan, req *request.CreateVipPlanRequest) {
+	plan.Code = req.Code
+	plan.Name = req.Name
+	plan.DurationDays = req.DurationDays
+	plan.Price = req.Price
+	plan.OriginalPrice = req.OriginalPrice
+	plan.Description = req.Description
+	plan.SortOrder = req.SortOrder
+	if req.IsActive != nil {
+		plan.IsActive = *req.IsActive
+	}
+}
+
+// vipPlanNameMap 套餐ID到名称的映射，包含已删除的套餐
+func vipPlanNameMap(planIDs []uint) map[uint]string {
+	planMap := make(map[uint]string)
+	if len(planIDs) == 0 {
+		return planMap
+	}
+
+	var plans []baby.VipPlan
+	global.GVA_DB.Unscoped().Where("id IN ?", planIDs).Find(&plans)
+	for _, plan := range plans {
+		planMap[plan.ID] = plan.Name
+	}
+	return planMap
+}
@@ -0,0 +1,232 @@
+package baby
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/system"
+	"gorm.io/gorm"
+)
+
+func TestVipRemainingDays(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	tests := []struct {
+		name      string
+		expiredAt *time.Time
+		want      int
+	}{
+		{"未开通", nil, 0},
+		{"已过期", at(-time.Hour), 0},
+		{"刚好到期", at(0), 0},
+		{"不足一天", at(time.Hour), 1},
+		{"整30天", at(30 * 24 * time.Hour), 30},
+		{"30天零1分钟", at(30*24*time.Hour + time.Minute), 31},
+	}
+	for _, tt := range tests {
+		if got := vipRemainingDays(tt.expiredAt, now); got != tt.want {
+			t.Errorf("%s: vipRemainingDays = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestVipCheckerFreeContent(t *testing.T) {
+	// 非VIP内容不查询会员状态，始终不锁定
+	vip := newVipChecker(1)
+	if vip.locked(false) || vip.loaded {
+		t.Errorf("free content locked = true or membership loaded")
+	}
+}
+
+// seedMiniprogramUsers 创建小程序用户，vipDays大于0时为会员，返回用户ID
+func seedMiniprogramUsers(t *testing.T, db *gorm.DB, vipDays ...int) []uint {
+	t.Helper()
+	ids := make([]uint, 0, len(vipDays))
+	for i, days := range vipDays {
+		name := "user" + string(rune('a'+i))
+		user := system.MiniprogramUser{OpenID: name, Username: name, Email: name + "@example.com"}
+		if days > 0 {
+			expiredAt := time.Now().AddDate(0, 0, days)
+			user.VipExpiredAt = &expiredAt
+		}
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+func TestMembershipChanges(t *testing.T) {
+	db := setupTestDB(t, &system.MiniprogramUser{}, &baby.VipPlan{}, &baby.VipMembershipLog{})
+	userID := seedMiniprogramUsers(t, db, 0)[0]
+	plan := baby.VipPlan{Code: "month", Name: "月卡", DurationDays: 30, IsActive: true}
+	if err := db.Create(&plan).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	service := new(MembershipAdminService)
+	loadUser := func() system.MiniprogramUser {
+		t.Helper()
+		var user system.MiniprogramUser
+		if err := db.Where("id = ?", userID).First(&user).Error; err != nil {
+			t.Fatal(err)
+		}
+		return user
+	}
+	near := func(got *time.Time, want time.Time) bool {
+		return got != nil && got.Sub(want).Abs() < 5*time.Second
+	}
+
+	// 开通：从现在起按套餐天数计算
+	if err := service.GrantMembership(99, &request.MembershipChangeRequest{UserID: userID, PlanID: plan.ID, Reason: "活动赠送"}); err != nil {
+		t.Fatalf("开通会员: %v", err)
+	}
+	granted := loadUser()
+	if granted.VipPlanID != plan.ID || !near(granted.VipStartedAt, time.Now()) || !near(granted.VipExpiredAt, time.Now().AddDate(0, 0, 30)) {
+		t.Errorf("开通后 = plan %d, started %v, expired %v", granted.VipPlanID, granted.VipStartedAt, granted.VipExpiredAt)
+	}
+	err := service.GrantMembership(99, &request.MembershipChangeRequest{UserID: userID, Days: 7})
+	if err == nil || !strings.Contains(err.Error(), "延期") {
+		t.Errorf("会员有效期内重复开通 err = %v, want 请使用延期", err)
+	}
+
+	// 有效期内延期：从原到期时间顺延
+	if err := service.ExtendMembership(99, &request.MembershipChangeRequest{UserID: userID, Days: 10}); err != nil {
+		t.Fatalf("延期会员: %v", err)
+	}
+	extended := loadUser()
+	if extended.VipExpiredAt == nil || !extended.VipExpiredAt.Equal(granted.VipExpiredAt.AddDate(0, 0, 10)) {
+		t.Errorf("延期后到期时间 = %v, want %v", extended.VipExpiredAt, granted.VipExpiredAt.AddDate(0, 0, 10))
+	}
+	if extended.VipPlanID != plan.ID || !extended.VipStartedAt.Equal(*granted.VipStartedAt) {
+		t.Errorf("按天数延期不应改变套餐和开始时间: plan %d, started %v", extended.VipPlanID, extended.VipStartedAt)
+	}
+
+	// 撤销：到期时间改为当前时间
+	if err := service.RevokeMembership(99, &request.RevokeMembershipRequest{UserID: userID, Reason: "退款"}); err != nil {
+		t.Fatalf("撤销会员: %v", err)
+	}
+	revoked := loadUser()
+	if !near(revoked.VipExpiredAt, time.Now()) || revoked.IsVIP(time.Now()) {
+		t.Errorf("撤销后到期时间 = %v", revoked.VipExpiredAt)
+	}
+	if err := service.RevokeMembership(99, &request.RevokeMembershipRequest{UserID: userID}); err == nil {
+		t.Error("非会员不应再次撤销")
+	}
+
+	// 已过期时延期：从现在起计算
+	if err := service.ExtendMembership(99, &request.MembershipChangeRequest{UserID: userID, Days: 7}); err != nil {
+		t.Fatalf("过期后延期: %v", err)
+	}
+	renewed := loadUser()
+	if !near(renewed.VipExpiredAt, time.Now().AddDate(0, 0, 7)) || !near(renewed.VipStartedAt, time.Now()) {
+		t.Errorf("过期后延期 = started %v, expired %v", renewed.VipStartedAt, renewed.VipExpiredAt)
+	}
+
+	if err := service.GrantMembership(99, &request.MembershipChangeRequest{UserID: userID + 100, Days: 7}); err == nil {
+		t.Error("不存在的用户不应开通会员")
+	}
+
+	var logs []baby.VipMembershipLog
+	if err := db.Order("id ASC").Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		action int
+		planID uint
+		days   int
+		before *time.Time
+		after  *time.Time
+	}{
+		{membershipActionGrant, plan.ID, 30, nil, granted.VipExpiredAt},
+		{membershipActionExtend, 0, 10, granted.VipExpiredAt, extended.VipExpiredAt},
+		{membershipActionRevoke, plan.ID, 0, extended.VipExpiredAt, revoked.VipExpiredAt},
+		{membershipActionExtend, 0, 7, revoked.VipExpiredAt, renewed.VipExpiredAt},
+	}
+	if len(logs) != len(want) {
+		t.Fatalf("变更记录数 = %d, want %d", len(logs), len(want))
+	}
+	sameTime := func(a, b *time.Time) bool {
+		return (a == nil && b == nil) || (a != nil && b != nil && a.Equal(*b))
+	}
+	for i, log := range logs {
+		w := want[i]
+		if log.UserID != userID || log.OperatorID != 99 || log.Action != w.action || log.PlanID != w.planID || log.Days != w.days {
+			t.Errorf("记录%d = %+v", i, log)
+		}
+		if !sameTime(log.ExpiredBefore, w.before) || !sameTime(log.ExpiredAfter, w.after) {
+			t.Errorf("记录%d 到期时间 = %v -> %v, want %v -> %v", i, log.ExpiredBefore, log.ExpiredAfter, w.before, w.after)
+		}
+	}
+}
+
+func TestVipContentEntitlement(t *testing.T) {
+	db := setupTestDB(t, &system.MiniprogramUser{}, &baby.Music{}, &baby.MusicCategory{}, &baby.UserMusicFavorite{}, &baby.UserMusicHistory{},
+		&baby.ParentingCategory{}, &baby.ParentingArticle{}, &baby.ParentingVideo{},
+		&baby.UserArticleRead{}, &baby.UserVideoWatch{}, &baby.UserContentFavorite{})
+	ids := seedMiniprogramUsers(t, db, 0, 30)
+	free, vip := ids[0], ids[1]
+
+	published := time.Now().Add(-time.Hour)
+	music := baby.Music{CategoryID: 1, Title: "白噪音", AudioURL: "music/noise.mp3", Duration: 600, IsActive: true, IsVIP: true}
+	article := baby.ParentingArticle{CategoryID: 1, Title: "睡眠训练", Summary: "摘要", Content: "正文", IsActive: true, IsVIP: true, PublishedAt: published}
+	video := baby.ParentingVideo{CategoryID: 1, Title: "抚触操", VideoURL: "videos/touch.mp4", IsActive: true, IsVIP: true, PublishedAt: published}
+	for _, record := range []interface{}{&music, &article, &video} {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		userID uint
+		locked bool
+	}{
+		{"非会员", free, true},
+		{"会员", vip, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			musicResp, err := new(MusicService).GetMusicDetail(tt.userID, music.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if musicResp.IsLocked != tt.locked || (musicResp.AudioURL == "") != tt.locked {
+				t.Errorf("音乐详情 locked %v, audio_url %q", musicResp.IsLocked, musicResp.AudioURL)
+			}
+			err = new(MusicService).PlayMusic(tt.userID, &request.PlayMusicRequest{MusicID: music.ID, PlayTime: 60})
+			if (err != nil) != tt.locked {
+				t.Errorf("播放音乐 err = %v, want locked %v", err, tt.locked)
+			}
+
+			articleResp, err := new(ParentingService).GetArticleDetail(tt.userID, article.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if articleResp.IsLocked != tt.locked || (articleResp.Content == "") != tt.locked || articleResp.Summary != "摘要" {
+				t.Errorf("文章详情 locked %v, content %q, summary %q", articleResp.IsLocked, articleResp.Content, articleResp.Summary)
+			}
+
+			videoResp, err := new(ParentingService).GetVideoDetail(tt.userID, video.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if videoResp.IsLocked != tt.locked || (videoResp.VideoURL == "") != tt.locked {
+				t.Errorf("视频详情 locked %v, video_url %q", videoResp.IsLocked, videoResp.VideoURL)
+			}
+		})
+	}
+
+	var histories int64
+	db.Model(&baby.UserMusicHistory{}).Where("music_id = ?", music.ID).Count(&histories)
+	if histories != 1 {
+		t.Errorf("播放记录数 = %d, want 1", histories)
+	}
+}
@@ -0,0 +1,284 @@
+package baby
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+type MilestoneService struct{}
+
+// growthRecordTypeMilestone 里程碑类型的成长记录
+const growthRecordTypeMilestone = 4
+
+// GetMilestoneChecklist 获取宝宝的里程碑清单：当前月龄应关注的里程碑及逾期未达成的重要里程碑
+func (s *MilestoneService) GetMilestoneChecklist(userID uint, req *request.MilestoneChecklistRequest) (*response.MilestoneChecklistResponse, error) {
+	profile, _, err := authorizeBaby(req.BabyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	db := global.GVA_DB.Where("is_active = ?", true)
+	if req.Category != "" {
+		db = db.Where("category = ?", req.Category)
+	}
+	var milestones []baby.ParentingMilestone
+	if err := db.Order("sort_order ASC, id ASC").Find(&milestones).Error; err != nil {
+		return nil, err
+	}
+
+	var records []baby.BabyMilestone
+	if err := global.GVA_DB.Where("baby_id = ?", profile.ID).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	recordMap := make(map[uint]*baby.BabyMilestone, len(records))
+	for i := range records {
+		recordMap[records[i].MilestoneID] = &records[i]
+	}
+
+	ageMonths := profile.GetDevelopmentalAge()
+	result := &response.MilestoneChecklistResponse{
+		BabyID:    profile.ID,
+		BabyName:  profile.Name,
+		AgeMonths: ageMonths,
+		AgeText:   profile.GetDevelopmentalAgeText(),
+		Current:   []response.BabyMilestoneResponse{},
+		Overdue:   []response.BabyMilestoneResponse{},
+	}
+	for i := range milestones {
+		milestone := &milestones[i]
+		minMonths, maxMonths, ok := parseAgeRangeMonths(milestone.AgeRange)
+		if !ok {
+			continue
+		}
+
+		record := recordMap[milestone.ID]
+		item := buildBabyMilestoneResponse(milestone, record)
+		if record != nil {
+			result.AchievedCount++
+		}
+		if minMonths <= ageMonths {
+			result.TotalCount++
+		}
+
+		switch {
+		case minMonths <= ageMonths && ageMonths <= maxMonths:
+			result.Current = append(result.Current, item)
+		case maxMonths < ageMonths && milestone.IsImportant && record == nil:
+			item.IsOverdue = true
+			result.Overdue = append(result.Overdue, item)
+		}
+	}
+	return result, nil
+}
+
+// AchieveMilestone 标记里程碑达成，已达成时更新达成日期
+// 达成记录关联一条里程碑类型的成长记录，未指定时自动创建，使里程碑同时出现在成长时间线中
+func (s *MilestoneService) AchieveMilestone(userID uint, req *request.AchieveMilestoneRequest) (*response.BabyMilestoneResponse, error) {
+	profile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var milestone baby.ParentingMilestone
+	err = global.GVA_DB.Where("id = ? AND is_active = ?", req.MilestoneID, true).First(&milestone).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("里程碑不存在")
+		}
+		return nil, err
+	}
+
+	if req.AchievedAt.After(time.Now()) {
+		return nil, errors.New("达成日期不能晚于今天")
+	}
+	if req.AchievedAt.Before(startOfDay(profile.Birthday)) {
+		return nil, errors.New("达成日期不能早于宝宝出生日期")
+	}
+
+	var record baby.BabyMilestone
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		record = baby.BabyMilestone{UserID: userID, BabyID: profile.ID, MilestoneID: milestone.ID, AchievedAt: req.AchievedAt}
+		if _, err := lockProgressRecord(tx, &record, "baby_id = ? AND milestone_id = ?", profile.ID, milestone.ID); err != nil {
+			return err
+		}
+
+		growthRecordID, err := s.linkGrowthRecord(tx, userID, profile.ID, &milestone, &record, req)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Model(&record).Updates(map[string]interface{}{
+			"user_id":          userID,
+			"achieved_at":      req.AchievedAt,
+			"growth_record_id": growthRecordID,
+			"note":             req.Note,
+			"deleted_at":       nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id = ?", record.ID).First(&record).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := buildBabyMilestoneResponse(&milestone, &record)
+	return &item, nil
+}
+
+// CancelMilestone 取消里程碑达成，已关联的成长记录保留在时间线中
+func (s *MilestoneService) CancelMilestone(userID uint, req *request.CancelMilestoneRequest) error {
+	if _, err := getUserBaby(req.BabyID, userID); err != nil {
+		return err
+	}
+
+	result := global.GVA_DB.Where("baby_id = ? AND milestone_id = ?", req.BabyID, req.MilestoneID).Delete(&baby.BabyMilestone{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("该里程碑尚未达成")
+	}
+	return nil
+}
+
+// linkGrowthRecord 确定达成记录关联的成长记录ID
+// 指定了成长记录时校验其为该宝宝的里程碑记录；否则沿用已关联的记录并同步日期，没有则新建
+func (s *MilestoneService) linkGrowthRecord(tx *gorm.DB, userID uint, babyID uint, milestone *baby.ParentingMilestone, record *baby.BabyMilestone, req *request.AchieveMilestoneRequest) (uint, error) {
+	if req.GrowthRecordID > 0 {
+		var growthRecord baby.GrowthRecord
+		err := tx.Where("id = ? AND baby_id = ?", req.GrowthRecordID, babyID).First(&growthRecord).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, errors.New("成长记录不存在")
+			}
+			return 0, err
+		}
+		if growthRecord.RecordType != growthRecordTypeMilestone {
+			return 0, errors.New("只能关联里程碑类型的成长记录")
+		}
+		return growthRecord.ID, nil
+	}
+
+	if record.GrowthRecordID > 0 {
+		result := tx.Model(&baby.GrowthRecord{}).Where("id = ? AND baby_id = ?", record.GrowthRecordID, babyID).
+			Update("record_date", req.AchievedAt)
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		if result.RowsAffected > 0 {
+			return record.GrowthRecordID, nil
+		}
+	}
+
+	content := req.Note
+	if content == "" {
+		content = milestone.Description
+	}
+	growthRecord := &baby.GrowthRecord{
+		UserID:     userID,
+		BabyID:     babyID,
+		Title:      milestone.Title,
+		Content:    content,
+		RecordType: growthRecordTypeMilestone,
+		RecordDate: req.AchievedAt,
+		Milestone:  milestone.Category,
+		IsPrivate:  req.IsPrivate,
+	}
+	if err := tx.Create(growthRecord).Error; err != nil {
+		return 0, err
+	}
+	return growthRecord.ID, nil
+}
+
+// buildBabyMilestoneResponse 组装里程碑清单项，record为nil表示尚未达成
+func buildBabyMilestoneResponse(milestone *baby.ParentingMilestone, record *baby.BabyMilestone) response.BabyMilestoneResponse {
+	item := response.BabyMilestoneResponse{
+		MilestoneID: milestone.ID,
+		AgeRange:    milestone.AgeRange,
+		Category:    milestone.Category,
+		Title:       milestone.Title,
+		Description: milestone.Description,
+		Tips:        milestone.Tips,
+		IsImportant: milestone.IsImportant,
+	}
+	if record != nil {
+		achievedAt := record.AchievedAt
+		item.IsAchieved = true
+		item.AchievedAt = &achievedAt
+		item.GrowthRecordID = record.GrowthRecordID
+		item.Note = record.Note
+	}
+	return item
+}
+
+// parseAgeRangeMonths 将年龄范围解析为月龄区间（闭区间）
+// 支持"0-6月"、"6-12个月"、"1-2岁"、"6月-1岁"、"3岁+"等写法，"+"表示不设上限
+func parseAgeRangeMonths(ageRange string) (int, int, bool) {
+	s := strings.TrimSpace(ageRange)
+	if s == "" {
+		return 0, 0, false
+	}
+
+	openEnded := strings.HasSuffix(s, "+")
+	s = strings.TrimSuffix(s, "+")
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '~' || r == '～' })
+	if len(parts) == 0 || len(parts) > 2 {
+		return 0, 0, false
+	}
+
+	// 未写单位的部分沿用末尾的单位，如"1-2岁"中的1
+	unit := 1
+	if strings.HasSuffix(parts[len(parts)-1], "岁") {
+		unit = 12
+	}
+	months := make([]int, len(parts))
+	for i, part := range parts {
+		value, ok := parseAgeMonths(part, unit)
+		if !ok {
+			return 0, 0, false
+		}
+		months[i] = value
+	}
+
+	minMonths, maxMonths := months[0], months[len(months)-1]
+	if openEnded {
+		maxMonths = math.MaxInt32
+	}
+	if minMonths > maxMonths {
+		return 0, 0, false
+	}
+	return minMonths, maxMonths, true
+}
+
+// parseAgeMonths 解析单个年龄值为月数，无单位时按defaultUnit换算
+func parseAgeMonths(part string, defaultUnit int) (int, bool) {
+	part = strings.TrimSpace(part)
+	unit := defaultUnit
+	switch {
+	case strings.HasSuffix(part, "岁"):
+		unit = 12
+		part = strings.TrimSuffix(part, "岁")
+	case strings.HasSuffix(part, "个月"):
+		unit = 1
+		part = strings.TrimSuffix(part, "个月")
+	case strings.HasSuffix(part, "月"):
+		unit = 1
+		part = strings.TrimSuffix(part, "月")
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(part))
+	if err != nil || value < 0 {
+		return 0, false
+	}
+	return value * unit, true
+}
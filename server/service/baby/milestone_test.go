@@ -0,0 +1,64 @@
+package baby
+
+import (
+	"math"
+	"testing"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+)
+
+func TestParseAgeRangeMonths(t *testing.T) {
+	tests := []struct {
+		ageRange string
+		min, max int
+		ok       bool
+	}{
+		{"0-6月", 0, 6, true},
+		{"6-12个月", 6, 12, true},
+		{"1-2岁", 12, 24, true},
+		{"6月-1岁", 6, 12, true},
+		{"3岁+", 36, math.MaxInt32, true},
+		{"18月", 18, 18, true},
+		{"", 0, 0, false},
+		{"2-1岁", 0, 0, false},
+		{"新生儿", 0, 0, false},
+	}
+	for _, tt := range tests {
+		min, max, ok := parseAgeRangeMonths(tt.ageRange)
+		if ok != tt.ok || (ok && (min != tt.min || max != tt.max)) {
+			t.Errorf("parseAgeRangeMonths(%q) = %d, %d, %v, want %d, %d, %v", tt.ageRange, min, max, ok, tt.min, tt.max, tt.ok)
+		}
+	}
+
+	// 与音乐、育儿内容推荐使用的年龄段保持一致
+	for _, months := range []int{0, 6, 7, 12, 24, 36, 40} {
+		min, max, ok := parseAgeRangeMonths(baby.AgeRangeOfMonths(months))
+		if !ok || months < min || months > max {
+			t.Errorf("月龄%d不在年龄段%q内", months, baby.AgeRangeOfMonths(months))
+		}
+	}
+}
+
+func TestMilestoneChecklistFamilyAccess(t *testing.T) {
+	db := setupTestDB(t, &baby.FamilyGroup{}, &baby.FamilyMember{}, &baby.BabyProfile{}, &baby.ParentingMilestone{}, &baby.BabyMilestone{})
+	profile := seedFamilyBaby(t, db)
+	milestone := baby.ParentingMilestone{AgeRange: "6-12个月", Category: "大运动", Title: "独坐", IsImportant: true, IsActive: true}
+	if err := db.Create(&milestone).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	service := new(MilestoneService)
+	checklist, err := service.GetMilestoneChecklist(4, &request.MilestoneChecklistRequest{BabyID: profile.ID})
+	if err != nil {
+		t.Fatalf("观察者查看里程碑清单: %v", err)
+	}
+	if len(checklist.Current) != 1 || checklist.Current[0].Title != "独坐" {
+		t.Errorf("当前里程碑 = %+v", checklist.Current)
+	}
+	if _, err := service.GetMilestoneChecklist(9, &request.MilestoneChecklistRequest{BabyID: profile.ID}); err == nil {
+		t.Error("家庭外用户不应查看里程碑清单")
+	}
+	if err := service.CancelMilestone(4, &request.CancelMilestoneRequest{BabyID: profile.ID, MilestoneID: milestone.ID}); err == nil {
+		t.Error("观察者不应修改里程碑")
+	}
+}
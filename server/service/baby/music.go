@@ -3,6 +3,7 @@ package baby
 import (
 	"errors"
 	"fmt"
+	"time"
 	"baby_admin/server/global"
 	"baby_admin/server/model/baby"
 	"baby_admin/server/model/baby/request"
@@ -110,13 +111,15 @@ func (s *MusicService) GetMusicList(userID uint, req *request.MusicSearch) (*res
 		favoriteMap[favorite.MusicID] = true
 	}
 
-	// 转换响应
+	// 转换响应，未开通会员时锁定VIP音乐
+	vip := newVipChecker(userID)
 	var list []response.MusicResponse
 	for _, music := range musics {
 		var resp response.MusicResponse
 		categoryName := categoryMap[music.CategoryID]
 		isFavorited := favoriteMap[music.ID]
 		resp.FromMusic(&music, categoryName, isFavorited)
+		vip.guardMusic(&resp, music.IsVIP)
 		list = append(list, resp)
 	}
 
@@ -153,6 +156,8 @@ func (s *MusicService) GetMusicDetail(userID uint, musicID uint) (*response.Musi
 
 	var resp response.MusicResponse
 	resp.FromMusic(&music, category.Name, isFavorited)
+	// VIP专享音乐对未开通会员的用户只返回介绍信息，其余返回签名播放地址
+	newVipChecker(userID).guardMusic(&resp, music.IsVIP)
 	return &resp, nil
 }
 
@@ -167,15 +172,13 @@ func (s *MusicService) PlayMusic(userID uint, req *request.PlayMusicRequest) err
 		}
 		return err
 	}
+	if music.IsVIP && !isVipUser(userID) {
+		return errors.New("该音乐为会员专享，开通会员后可收听")
+	}
 
-	// 如果指定了宝宝ID，验证宝宝是否属于当前用户
+	// 如果指定了宝宝ID，验证当前用户在宝宝所在家庭中可添加记录
 	if req.BabyID > 0 {
-		var babyProfile baby.BabyProfile
-		err = global.GVA_DB.Where("id = ? AND user_id = ?", req.BabyID, userID).First(&babyProfile).Error
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("宝宝档案不存在")
-			}
+		if _, _, err = authorizeBaby(req.BabyID, userID, babyAccessRecord); err != nil {
 			return err
 		}
 	}
@@ -197,45 +200,14 @@ func (s *MusicService) PlayMusic(userID uint, req *request.PlayMusicRequest) err
 
 // ToggleFavorite 切换收藏状态
 func (s *MusicService) ToggleFavorite(userID uint, musicID uint) (bool, error) {
-	// 检查音乐是否存在
-	var music baby.Music
-	err := global.GVA_DB.Where("id = ? AND is_active = ?", musicID, true).First(&music).Error
+	result, err := new(FavoriteService).ToggleFavorite(userID, &request.ToggleFavoriteRequest{
+		ContentType: contentTypeMusic,
+		ContentID:   musicID,
+	})
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return false, errors.New("音乐不存在")
-		}
 		return false, err
 	}
-
-	// 检查是否已收藏
-	var favorite baby.UserMusicFavorite
-	err = global.GVA_DB.Where("user_id = ? AND music_id = ?", userID, musicID).First(&favorite).Error
-	
-	if err == nil {
-		// 已收藏，取消收藏
-		err = global.GVA_DB.Delete(&favorite).Error
-		if err != nil {
-			return false, err
-		}
-		// 更新音乐点赞数
-		global.GVA_DB.Model(&music).Update("like_count", gorm.Expr("like_count - ?", 1))
-		return false, nil
-	} else if errors.Is(err, gorm.ErrRecordNotFound) {
-		// 未收藏，添加收藏
-		newFavorite := &baby.UserMusicFavorite{
-			UserID:  userID,
-			MusicID: musicID,
-		}
-		err = global.GVA_DB.Create(newFavorite).Error
-		if err != nil {
-			return false, err
-		}
-		// 更新音乐点赞数
-		global.GVA_DB.Model(&music).Update("like_count", gorm.Expr("like_count + ?", 1))
-		return true, nil
-	}
-
-	return false, err
+	return result.IsFavorited, nil
 }
 
 // GetUserFavorites 获取用户收藏的音乐
@@ -295,11 +267,13 @@ func (s *MusicService) GetUserFavorites(userID uint, req *commonRequest.PageInfo
 	}
 
 	// 转换响应
+	vip := newVipChecker(userID)
 	var list []response.MusicResponse
 	for _, music := range musics {
 		var resp response.MusicResponse
 		categoryName := categoryMap[music.CategoryID]
 		resp.FromMusic(&music, categoryName, true) // 收藏列表中的都是已收藏的
+		vip.guardMusic(&resp, music.IsVIP)
 		list = append(list, resp)
 	}
 
@@ -311,11 +285,17 @@ func (s *MusicService) GetUserFavorites(userID uint, req *commonRequest.PageInfo
 	}, nil
 }
 
-// GetPlayHistory 获取播放历史
-func (s *MusicService) GetPlayHistory(userID uint, req *commonRequest.PageInfo) ([]response.MusicHistoryResponse, error) {
+// GetPlayHistory 获取播放历史，指定宝宝时返回家庭成员为该宝宝播放的全部记录，否则返回自己的记录
+func (s *MusicService) GetPlayHistory(userID uint, req *request.MusicHistorySearch) ([]response.MusicHistoryResponse, error) {
 	var histories []baby.UserMusicHistory
 	db := global.GVA_DB.Where("user_id = ?", userID)
-	
+	if req.BabyID > 0 {
+		if _, _, err := authorizeBaby(req.BabyID, userID, babyAccessRead); err != nil {
+			return nil, err
+		}
+		db = global.GVA_DB.Where("baby_id = ?", req.BabyID)
+	}
+
 	offset := (req.Page - 1) * req.PageSize
 	err := db.Offset(offset).Limit(req.PageSize).Order("created_at DESC").Find(&histories).Error
 	if err != nil {
@@ -375,6 +355,7 @@ func (s *MusicService) GetPlayHistory(userID uint, req *commonRequest.PageInfo)
 	}
 
 	// 转换响应
+	vip := newVipChecker(userID)
 	var result []response.MusicHistoryResponse
 	for _, history := range histories {
 		music, exists := musicMap[history.MusicID]
@@ -385,6 +366,7 @@ func (s *MusicService) GetPlayHistory(userID uint, req *commonRequest.PageInfo)
 		var musicResp response.MusicResponse
 		categoryName := categoryMap[music.CategoryID]
 		musicResp.FromMusic(&music, categoryName, false) // 这里不需要检查收藏状态
+		vip.guardMusic(&musicResp, music.IsVIP)
 
 		babyName := ""
 		if history.BabyID > 0 {
@@ -394,6 +376,7 @@ func (s *MusicService) GetPlayHistory(userID uint, req *commonRequest.PageInfo)
 		result = append(result, response.MusicHistoryResponse{
 			ID:         history.ID,
 			Music:      musicResp,
+			UserID:     history.UserID,
 			BabyID:     history.BabyID,
 			BabyName:   babyName,
 			PlayTime:   history.PlayTime,
@@ -407,15 +390,15 @@ func (s *MusicService) GetPlayHistory(userID uint, req *commonRequest.PageInfo)
 
 // GetRecommendations 获取音乐推荐
 func (s *MusicService) GetRecommendations(userID uint, babyID uint) ([]response.RecommendationResponse, error) {
-	var recommendations []response.RecommendationResponse
+	vip := newVipChecker(userID)
+	// 有收听记录的用户优先展示个性化推荐，新用户只展示下面的固定推荐
+	recommendations := s.becauseListenedShelves(userID, vip, time.Now())
 
 	// 如果指定了宝宝ID，获取宝宝信息进行基于年龄的推荐
 	if babyID > 0 {
-		var babyProfile baby.BabyProfile
-		err := global.GVA_DB.Where("id = ? AND user_id = ?", babyID, userID).First(&babyProfile).Error
+		babyProfile, _, err := authorizeBaby(babyID, userID, babyAccessRead)
 		if err == nil {
-			ageInMonths := babyProfile.GetAge()
-			ageRange := getAgeRange(ageInMonths)
+			ageRange := getAgeRange(babyProfile)
 			
 			var ageBasedMusics []baby.Music
 			global.GVA_DB.Where("age_range = ? AND is_active = ?", ageRange, true).
@@ -426,11 +409,12 @@ func (s *MusicService) GetRecommendations(userID uint, babyID uint) ([]response.
 				for _, music := range ageBasedMusics {
 					var resp response.MusicResponse
 					resp.FromMusic(&music, "", false)
+					vip.guardMusic(&resp, music.IsVIP)
 					musicResponses = append(musicResponses, resp)
 				}
 
 				recommendations = append(recommendations, response.RecommendationResponse{
-					Title:       fmt.Sprintf("适合%s的音乐", babyProfile.GetAgeText()),
+					Title:       fmt.Sprintf("适合%s的音乐", babyProfile.GetDevelopmentalAgeText()),
 					Description: "根据宝宝年龄推荐的音乐",
 					Musics:      musicResponses,
 					Type:        "age_based",
@@ -449,6 +433,7 @@ func (s *MusicService) GetRecommendations(userID uint, babyID uint) ([]response.
 		for _, music := range sleepMusics {
 			var resp response.MusicResponse
 			resp.FromMusic(&music, "", false)
+			vip.guardMusic(&resp, music.IsVIP)
 			musicResponses = append(musicResponses, resp)
 		}
 
@@ -470,6 +455,7 @@ func (s *MusicService) GetRecommendations(userID uint, babyID uint) ([]response.
 		for _, music := range popularMusics {
 			var resp response.MusicResponse
 			resp.FromMusic(&music, "", false)
+			vip.guardMusic(&resp, music.IsVIP)
 			musicResponses = append(musicResponses, resp)
 		}
 
@@ -484,17 +470,7 @@ func (s *MusicService) GetRecommendations(userID uint, babyID uint) ([]response.
 	return recommendations, nil
 }
 
-// getAgeRange 根据月龄获取年龄段
-func getAgeRange(ageInMonths int) string {
-	if ageInMonths <= 6 {
-		return "0-6月"
-	} else if ageInMonths <= 12 {
-		return "6-12月"
-	} else if ageInMonths <= 24 {
-		return "1-2岁"
-	} else if ageInMonths <= 36 {
-		return "2-3岁"
-	} else {
-		return "3岁+"
-	}
+// getAgeRange 根据宝宝的发育年龄获取年龄段，早产儿24月龄前按矫正年龄
+func getAgeRange(profile *baby.BabyProfile) string {
+	return profile.GetAgeRange()
 }
\ No newline at end of file
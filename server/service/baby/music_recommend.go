@@ -0,0 +1,322 @@
+package baby
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+// 基于物品的协同过滤参数
+const (
+	musicSimilarityWindowDays = 180 // 参与计算的播放历史时间范围(天)
+	musicSimilarityTopK       = 20  // 每首音乐保留的相似音乐数
+	musicSimilarityMinCoUsers = 2   // 至少被这么多用户共同收听才认为相似
+	musicSimilarityShrink     = 5.0 // 共同收听用户数较少时按 co/(co+shrink) 压低相似度
+	musicUserMaxItems         = 200 // 单个用户参与计算的音乐数上限，避免重度用户的组合数爆炸
+	musicSkipSeconds          = 30  // 未完成且累计不足该时长视为跳过，不计入偏好
+	musicFavoriteWeight       = 1.0 // 收藏额外增加的偏好权重
+
+	musicSeedWindowDays   = 30 // 从最近多少天的完整播放和收藏中选取推荐依据
+	musicSeedCount        = 3  // 最多生成几个“因为你听过”推荐
+	musicRecentPlayedDays = 7  // 最近播放过的音乐不再推荐
+	musicBecauseShelfSize = 8  // 每个推荐的音乐数量
+	musicBecauseShelfMin  = 3  // 可推荐的音乐少于该数量时不展示
+)
+
+// musicInteraction 用户对单首音乐的播放汇总
+type musicInteraction struct {
+	UserID      uint
+	MusicID     uint
+	Plays       int64
+	Finished    int64
+	PartialTime int64 // 未完成播放的累计时长(秒)
+}
+
+// RebuildMusicSimilarities 根据播放历史和收藏重新计算音乐相似度，全量替换旧数据，返回写入的记录数
+func (s *MusicService) RebuildMusicSimilarities(now time.Time) (int, error) {
+	var interactions []musicInteraction
+	err := global.GVA_DB.Model(&baby.UserMusicHistory{}).
+		Select("user_id, music_id, COUNT(*) AS plays, "+
+			"SUM(CASE WHEN is_finished = ? THEN 1 ELSE 0 END) AS finished, "+
+			"SUM(CASE WHEN is_finished = ? THEN 0 ELSE play_time END) AS partial_time", true, true).
+		Where("created_at >= ?", now.AddDate(0, 0, -musicSimilarityWindowDays)).
+		Group("user_id, music_id").
+		Scan(&interactions).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var favorites []baby.UserMusicFavorite
+	if err := global.GVA_DB.Select("user_id", "music_id").Find(&favorites).Error; err != nil {
+		return 0, err
+	}
+
+	var musics []baby.Music
+	if err := global.GVA_DB.Select("id", "duration").Where("is_active = ?", true).Find(&musics).Error; err != nil {
+		return 0, err
+	}
+	durations := make(map[uint]int, len(musics))
+	for _, music := range musics {
+		durations[music.ID] = music.Duration
+	}
+
+	// 用户 -> 音乐 -> 偏好权重，只统计仍在上架的音乐
+	preferences := make(map[uint]map[uint]float64)
+	prefer := func(userID, musicID uint, weight float64) {
+		if weight <= 0 {
+			return
+		}
+		if _, ok := durations[musicID]; !ok {
+			return
+		}
+		if preferences[userID] == nil {
+			preferences[userID] = make(map[uint]float64)
+		}
+		preferences[userID][musicID] += weight
+	}
+	for _, item := range interactions {
+		prefer(item.UserID, item.MusicID, musicPlayWeight(item, durations[item.MusicID]))
+	}
+	for _, favorite := range favorites {
+		prefer(favorite.UserID, favorite.MusicID, musicFavoriteWeight)
+	}
+
+	similarities := buildMusicSimilarities(preferences)
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(&baby.MusicSimilarity{}).Error; err != nil {
+			return err
+		}
+		if len(similarities) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(similarities, 500).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(similarities), nil
+}
+
+// musicPlayWeight 播放行为的隐式偏好：完整播放计1次，未完成按播放时长折算，
+// 跳过的不计；取对数避免睡前单曲循环的音乐权重过大
+func musicPlayWeight(item musicInteraction, duration int) float64 {
+	count := float64(item.Finished)
+	unfinished := item.Plays - item.Finished
+	if unfinished > 0 && item.PartialTime >= musicSkipSeconds && duration > 0 {
+		count += math.Min(float64(item.PartialTime)/float64(duration), float64(unfinished))
+	}
+	return math.Log1p(count)
+}
+
+// buildMusicSimilarities 按用户偏好计算音乐间的余弦相似度，每首音乐保留最相似的 musicSimilarityTopK 首
+func buildMusicSimilarities(preferences map[uint]map[uint]float64) []baby.MusicSimilarity {
+	type pairKey struct{ a, b uint }
+	type pairStat struct {
+		dot     float64
+		coUsers int
+	}
+
+	norms := make(map[uint]float64)
+	pairs := make(map[pairKey]*pairStat)
+	for _, items := range preferences {
+		musicIDs := topPreferredMusics(items, musicUserMaxItems)
+		for _, id := range musicIDs {
+			norms[id] += items[id] * items[id]
+		}
+		for i := 0; i < len(musicIDs); i++ {
+			for j := i + 1; j < len(musicIDs); j++ {
+				a, b := musicIDs[i], musicIDs[j]
+				if a > b {
+					a, b = b, a
+				}
+				stat := pairs[pairKey{a, b}]
+				if stat == nil {
+					stat = &pairStat{}
+					pairs[pairKey{a, b}] = stat
+				}
+				stat.dot += items[a] * items[b]
+				stat.coUsers++
+			}
+		}
+	}
+
+	neighbors := make(map[uint][]baby.MusicSimilarity)
+	for key, stat := range pairs {
+		if stat.coUsers < musicSimilarityMinCoUsers {
+			continue
+		}
+		score := stat.dot / math.Sqrt(norms[key.a]*norms[key.b])
+		score *= float64(stat.coUsers) / (float64(stat.coUsers) + musicSimilarityShrink)
+		neighbors[key.a] = append(neighbors[key.a], baby.MusicSimilarity{MusicID: key.a, SimilarMusicID: key.b, Score: score, CoUsers: stat.coUsers})
+		neighbors[key.b] = append(neighbors[key.b], baby.MusicSimilarity{MusicID: key.b, SimilarMusicID: key.a, Score: score, CoUsers: stat.coUsers})
+	}
+
+	musicIDs := make([]uint, 0, len(neighbors))
+	for id := range neighbors {
+		musicIDs = append(musicIDs, id)
+	}
+	sort.Slice(musicIDs, func(i, j int) bool { return musicIDs[i] < musicIDs[j] })
+
+	var result []baby.MusicSimilarity
+	for _, id := range musicIDs {
+		list := neighbors[id]
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Score != list[j].Score {
+				return list[i].Score > list[j].Score
+			}
+			return list[i].SimilarMusicID < list[j].SimilarMusicID
+		})
+		if len(list) > musicSimilarityTopK {
+			list = list[:musicSimilarityTopK]
+		}
+		result = append(result, list...)
+	}
+	return result
+}
+
+// topPreferredMusics 按偏好权重从高到低取用户的音乐，权重相同按ID排序保证结果稳定
+func topPreferredMusics(items map[uint]float64, limit int) []uint {
+	musicIDs := make([]uint, 0, len(items))
+	for id := range items {
+		musicIDs = append(musicIDs, id)
+	}
+	sort.Slice(musicIDs, func(i, j int) bool {
+		if items[musicIDs[i]] != items[musicIDs[j]] {
+			return items[musicIDs[i]] > items[musicIDs[j]]
+		}
+		return musicIDs[i] < musicIDs[j]
+	})
+	if len(musicIDs) > limit {
+		musicIDs = musicIDs[:limit]
+	}
+	return musicIDs
+}
+
+// becauseListenedShelves “因为你听过X”推荐：以用户最近完整听过或收藏的音乐为依据，
+// 推荐与之相似且最近没有播放过的音乐；没有收听记录或相似度数据时返回空，由固定推荐兜底
+func (s *MusicService) becauseListenedShelves(userID uint, vip *vipChecker, now time.Time) []response.RecommendationResponse {
+	seedIDs := s.recommendationSeeds(userID, now)
+	if len(seedIDs) == 0 {
+		return nil
+	}
+
+	// 最近播放过的和作为推荐依据的音乐都不再推荐，已经出现在前面推荐中的也不重复
+	excluded := make(map[uint]bool)
+	var recentIDs []uint
+	global.GVA_DB.Model(&baby.UserMusicHistory{}).
+		Where("user_id = ? AND created_at >= ?", userID, now.AddDate(0, 0, -musicRecentPlayedDays)).
+		Distinct("music_id").Pluck("music_id", &recentIDs)
+	for _, id := range recentIDs {
+		excluded[id] = true
+	}
+	for _, id := range seedIDs {
+		excluded[id] = true
+	}
+
+	var seeds []baby.Music
+	global.GVA_DB.Where("id IN ? AND is_active = ?", seedIDs, true).Find(&seeds)
+	seedMap := make(map[uint]*baby.Music, len(seeds))
+	for i := range seeds {
+		seedMap[seeds[i].ID] = &seeds[i]
+	}
+
+	var shelves []response.RecommendationResponse
+	for _, seedID := range seedIDs {
+		seed, ok := seedMap[seedID]
+		if !ok {
+			continue
+		}
+
+		var similarIDs []uint
+		global.GVA_DB.Model(&baby.MusicSimilarity{}).Where("music_id = ?", seedID).
+			Order("score DESC").Limit(musicSimilarityTopK).Pluck("similar_music_id", &similarIDs)
+		var candidateIDs []uint
+		for _, id := range similarIDs {
+			if !excluded[id] {
+				candidateIDs = append(candidateIDs, id)
+			}
+		}
+		if len(candidateIDs) < musicBecauseShelfMin {
+			continue
+		}
+
+		var musics []baby.Music
+		global.GVA_DB.Where("id IN ? AND is_active = ?", candidateIDs, true).Find(&musics)
+		musicMap := make(map[uint]*baby.Music, len(musics))
+		for i := range musics {
+			musicMap[musics[i].ID] = &musics[i]
+		}
+
+		// 按相似度顺序输出
+		var musicResponses []response.MusicResponse
+		for _, id := range candidateIDs {
+			music, ok := musicMap[id]
+			if !ok {
+				continue
+			}
+			var resp response.MusicResponse
+			resp.FromMusic(music, "", false)
+			vip.guardMusic(&resp, music.IsVIP)
+			musicResponses = append(musicResponses, resp)
+			if len(musicResponses) >= musicBecauseShelfSize {
+				break
+			}
+		}
+		if len(musicResponses) < musicBecauseShelfMin {
+			continue
+		}
+		for _, resp := range musicResponses {
+			excluded[resp.ID] = true
+		}
+
+		shelves = append(shelves, response.RecommendationResponse{
+			Title:       fmt.Sprintf("因为你听过《%s》", seed.Title),
+			Description: "听过这首的家庭也喜欢",
+			Musics:      musicResponses,
+			Type:        "because_listened",
+			SeedMusicID: seed.ID,
+		})
+	}
+	return shelves
+}
+
+// recommendationSeeds 推荐依据：最近完整播放过的音乐优先，不足时补充最近收藏的音乐
+func (s *MusicService) recommendationSeeds(userID uint, now time.Time) []uint {
+	type seedRow struct {
+		MusicID    uint
+		LastPlayed time.Time
+	}
+	var rows []seedRow
+	global.GVA_DB.Model(&baby.UserMusicHistory{}).
+		Select("music_id, MAX(created_at) AS last_played").
+		Where("user_id = ? AND is_finished = ? AND created_at >= ?", userID, true, now.AddDate(0, 0, -musicSeedWindowDays)).
+		Group("music_id").Order("last_played DESC").Limit(musicSeedCount).
+		Scan(&rows)
+
+	seen := make(map[uint]bool)
+	var seedIDs []uint
+	for _, row := range rows {
+		seen[row.MusicID] = true
+		seedIDs = append(seedIDs, row.MusicID)
+	}
+	if len(seedIDs) < musicSeedCount {
+		var favoriteIDs []uint
+		global.GVA_DB.Model(&baby.UserMusicFavorite{}).Where("user_id = ?", userID).
+			Order("created_at DESC").Limit(musicSeedCount*2).Pluck("music_id", &favoriteIDs)
+		for _, id := range favoriteIDs {
+			if len(seedIDs) >= musicSeedCount {
+				break
+			}
+			if !seen[id] {
+				seen[id] = true
+				seedIDs = append(seedIDs, id)
+			}
+		}
+	}
+	return seedIDs
+}
@@ -0,0 +1,68 @@
+package baby
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMusicPlayWeight(t *testing.T) {
+	tests := []struct {
+		name string
+		item musicInteraction
+		want float64
+	}{
+		{"完整播放一次", musicInteraction{Plays: 1, Finished: 1}, math.Log1p(1)},
+		{"跳过", musicInteraction{Plays: 3, PartialTime: 20}, 0},
+		{"听了一半", musicInteraction{Plays: 1, PartialTime: 90}, math.Log1p(0.5)},
+		{"未完成时长不超过未完成次数", musicInteraction{Plays: 2, Finished: 1, PartialTime: 900}, math.Log1p(2)},
+		{"循环播放取对数", musicInteraction{Plays: 30, Finished: 30}, math.Log1p(30)},
+	}
+	for _, tt := range tests {
+		if got := musicPlayWeight(tt.item, 180); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: musicPlayWeight = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildMusicSimilarities(t *testing.T) {
+	// 1和2总是一起听，3只和1被一个用户共同收听，4被所有人听
+	preferences := map[uint]map[uint]float64{
+		1: {1: 1, 2: 1, 4: 1},
+		2: {1: 1, 2: 1, 4: 1},
+		3: {1: 1, 2: 1, 3: 1, 4: 1},
+		4: {4: 1},
+	}
+	neighbors := make(map[uint][]uint)
+	scores := make(map[[2]uint]float64)
+	for _, sim := range buildMusicSimilarities(preferences) {
+		neighbors[sim.MusicID] = append(neighbors[sim.MusicID], sim.SimilarMusicID)
+		scores[[2]uint{sim.MusicID, sim.SimilarMusicID}] = sim.Score
+	}
+
+	if len(neighbors[3]) != 0 {
+		t.Errorf("music 3 has neighbors %v, want none below min co-users", neighbors[3])
+	}
+	if got := neighbors[1]; len(got) != 2 || got[0] != 2 || got[1] != 4 {
+		t.Errorf("music 1 neighbors = %v, want [2 4]", got)
+	}
+	if scores[[2]uint{1, 2}] != scores[[2]uint{2, 1}] {
+		t.Errorf("similarity is not symmetric: %v vs %v", scores[[2]uint{1, 2}], scores[[2]uint{2, 1}])
+	}
+	if scores[[2]uint{1, 2}] <= scores[[2]uint{1, 4}] {
+		t.Errorf("sim(1,2)=%v should exceed sim(1,4)=%v", scores[[2]uint{1, 2}], scores[[2]uint{1, 4}])
+	}
+}
+
+func TestTopPreferredMusics(t *testing.T) {
+	items := map[uint]float64{5: 0.5, 3: 2, 9: 2, 1: 1}
+	got := topPreferredMusics(items, 3)
+	want := []uint{3, 9, 1}
+	if len(got) != len(want) {
+		t.Fatalf("topPreferredMusics = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("topPreferredMusics = %v, want %v", got, want)
+		}
+	}
+}
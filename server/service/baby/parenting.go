@@ -0,0 +1,379 @@
+package baby
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+type ParentingService struct{}
+
+// 收藏内容类型，文章和视频对应UserContentFavorite.ContentType，音乐收藏存于UserMusicFavorite
+const (
+	contentTypeArticle = 1 // 文章
+	contentTypeVideo   = 2 // 视频
+	contentTypeMusic   = 3 // 音乐
+)
+
+// parentingContentOrder 育儿内容默认排序：推荐优先，其次按排序权重和发布时间
+const parentingContentOrder = "is_recommend DESC, sort_order ASC, published_at DESC, id DESC"
+
+// GetParentingCategories 获取育儿分类列表
+func (s *ParentingService) GetParentingCategories() ([]response.ParentingCategoryResponse, error) {
+	var categories []baby.ParentingCategory
+	err := global.GVA_DB.Where("is_active = ?", true).Order("sort_order ASC, id ASC").Find(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 统计各分类下已发布的文章和视频数量
+	articleCounts, err := s.countByCategory(&baby.ParentingArticle{})
+	if err != nil {
+		return nil, err
+	}
+	videoCounts, err := s.countByCategory(&baby.ParentingVideo{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.ParentingCategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, response.ParentingCategoryResponse{
+			ID:           category.ID,
+			Name:         category.Name,
+			Description:  category.Description,
+			Icon:         category.Icon,
+			AgeRange:     category.AgeRange,
+			SortOrder:    category.SortOrder,
+			ArticleCount: articleCounts[category.ID],
+			VideoCount:   videoCounts[category.ID],
+			IsActive:     category.IsActive,
+		})
+	}
+
+	return result, nil
+}
+
+// GetArticleList 获取育儿文章列表
+func (s *ParentingService) GetArticleList(userID uint, req *request.ParentingArticleSearch) (*response.ParentingArticleListResponse, error) {
+	db := s.publishedScope(&baby.ParentingArticle{})
+	db = s.applyContentFilters(db, req.CategoryID, req.AgeRange, req.Tags, req.IsRecommend, req.IsVIP)
+	if req.Keyword != "" {
+		db = db.Where("title LIKE ? OR summary LIKE ? OR author LIKE ?",
+			"%"+req.Keyword+"%", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
+	}
+	if req.Difficulty > 0 {
+		db = db.Where("difficulty = ?", req.Difficulty)
+	}
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var articles []baby.ParentingArticle
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order(parentingContentOrder).Find(&articles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.ParentingArticleListResponse{
+		List:     s.buildArticleResponses(userID, articles),
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// GetArticleDetail 获取育儿文章详情
+func (s *ParentingService) GetArticleDetail(userID uint, articleID uint) (*response.ParentingArticleResponse, error) {
+	var article baby.ParentingArticle
+	err := s.publishedScope(&baby.ParentingArticle{}).Where("id = ?", articleID).First(&article).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("文章不存在")
+		}
+		return nil, err
+	}
+
+	// 获取分类名称
+	var category baby.ParentingCategory
+	global.GVA_DB.Where("id = ?", article.CategoryID).First(&category)
+
+	favorites := s.favoriteMap(userID, contentTypeArticle, []uint{article.ID})
+	var read baby.UserArticleRead
+	global.GVA_DB.Where("user_id = ? AND article_id = ?", userID, article.ID).First(&read)
+
+	var resp response.ParentingArticleResponse
+	resp.FromParentingArticle(&article, category.Name, favorites[article.ID], read.Progress, read.IsFinished, true)
+	// VIP专享文章对未开通会员的用户只返回摘要
+	if article.IsVIP && !isVipUser(userID) {
+		resp.Lock()
+	}
+	return &resp, nil
+}
+
+// GetVideoList 获取育儿视频列表
+func (s *ParentingService) GetVideoList(userID uint, req *request.ParentingVideoSearch) (*response.ParentingVideoListResponse, error) {
+	db := s.publishedScope(&baby.ParentingVideo{})
+	db = s.applyContentFilters(db, req.CategoryID, req.AgeRange, req.Tags, req.IsRecommend, req.IsVIP)
+	if req.Keyword != "" {
+		db = db.Where("title LIKE ? OR description LIKE ? OR author LIKE ?",
+			"%"+req.Keyword+"%", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
+	}
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var videos []baby.ParentingVideo
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order(parentingContentOrder).Find(&videos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.ParentingVideoListResponse{
+		List:     s.buildVideoResponses(userID, videos),
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// GetVideoDetail 获取育儿视频详情
+func (s *ParentingService) GetVideoDetail(userID uint, videoID uint) (*response.ParentingVideoResponse, error) {
+	var video baby.ParentingVideo
+	err := s.publishedScope(&baby.ParentingVideo{}).Where("id = ?", videoID).First(&video).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("视频不存在")
+		}
+		return nil, err
+	}
+
+	// 获取分类名称
+	var category baby.ParentingCategory
+	global.GVA_DB.Where("id = ?", video.CategoryID).First(&category)
+
+	favorites := s.favoriteMap(userID, contentTypeVideo, []uint{video.ID})
+	var watch baby.UserVideoWatch
+	global.GVA_DB.Where("user_id = ? AND video_id = ?", userID, video.ID).First(&watch)
+
+	var resp response.ParentingVideoResponse
+	resp.FromParentingVideo(&video, category.Name, favorites[video.ID], watch.Progress, watch.IsFinished)
+	resp.WatchPosition = watch.Position
+	// VIP专享视频对未开通会员的用户不返回视频地址，其余返回签名播放地址
+	newVipChecker(userID).guardVideo(&resp, video.IsVIP)
+	return &resp, nil
+}
+
+// GetBabyFeed 获取适合宝宝当前月龄的文章和视频，未指定宝宝时使用当前活跃宝宝
+func (s *ParentingService) GetBabyFeed(userID uint, req *request.GetRecommendationsRequest) (*response.ParentingRecommendationResponse, error) {
+	var babyProfile *baby.BabyProfile
+	if req.BabyID > 0 {
+		profile, _, err := authorizeBaby(req.BabyID, userID, babyAccessRead)
+		if err != nil {
+			return nil, err
+		}
+		babyProfile = profile
+	} else {
+		profile, err := getActiveBaby(userID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("请先添加宝宝档案")
+			}
+			return nil, err
+		}
+		babyProfile = profile
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	ageRange := getAgeRange(babyProfile)
+
+	// 匹配月龄的内容优先，其次是不限年龄的通用内容
+	ageOrder := "age_range = '' ASC"
+
+	var articles []baby.ParentingArticle
+	err := s.publishedScope(&baby.ParentingArticle{}).Where("age_range = ? OR age_range = ''", ageRange).
+		Order(ageOrder).Order(parentingContentOrder).Limit(limit).Find(&articles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var videos []baby.ParentingVideo
+	err = s.publishedScope(&baby.ParentingVideo{}).Where("age_range = ? OR age_range = ''", ageRange).
+		Order(ageOrder).Order(parentingContentOrder).Limit(limit).Find(&videos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.ParentingRecommendationResponse{
+		Title:       fmt.Sprintf("适合%s的育儿内容", babyProfile.Name),
+		Description: fmt.Sprintf("根据宝宝%s的月龄推荐（%s）", babyProfile.GetDevelopmentalAgeText(), ageRange),
+		Articles:    s.buildArticleResponses(userID, articles),
+		Videos:      s.buildVideoResponses(userID, videos),
+		Type:        "age_based",
+	}, nil
+}
+
+// publishedScope 已启用且已到发布时间的内容
+func (s *ParentingService) publishedScope(model interface{}) *gorm.DB {
+	return global.GVA_DB.Model(model).Where("is_active = ? AND published_at <= ?", true, time.Now())
+}
+
+// applyContentFilters 文章和视频通用的筛选条件；多个标签以逗号分隔，需同时包含
+func (s *ParentingService) applyContentFilters(db *gorm.DB, categoryID uint, ageRange, tags string, isRecommend, isVIP *bool) *gorm.DB {
+	if categoryID > 0 {
+		db = db.Where("category_id = ?", categoryID)
+	}
+	if ageRange != "" {
+		db = db.Where("age_range = ? OR age_range = ''", ageRange)
+	}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			db = db.Where("tags LIKE ?", "%"+tag+"%")
+		}
+	}
+	if isRecommend != nil {
+		db = db.Where("is_recommend = ?", *isRecommend)
+	}
+	if isVIP != nil {
+		db = db.Where(vipColumn+" = ?", *isVIP)
+	}
+	return db
+}
+
+// countByCategory 按分类统计已发布内容数量
+func (s *ParentingService) countByCategory(model interface{}) (map[uint]int64, error) {
+	var rows []struct {
+		CategoryID uint
+		Count      int64
+	}
+	err := s.publishedScope(model).Select("category_id, COUNT(*) AS count").Group("category_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
+
+// categoryNameMap 批量获取分类名称
+func (s *ParentingService) categoryNameMap(categoryIDs []uint) map[uint]string {
+	categoryMap := make(map[uint]string)
+	if len(categoryIDs) == 0 {
+		return categoryMap
+	}
+
+	var categories []baby.ParentingCategory
+	global.GVA_DB.Where("id IN ?", categoryIDs).Find(&categories)
+	for _, category := range categories {
+		categoryMap[category.ID] = category.Name
+	}
+	return categoryMap
+}
+
+// favoriteMap 批量查询用户是否收藏
+func (s *ParentingService) favoriteMap(userID uint, contentType int, contentIDs []uint) map[uint]bool {
+	favoriteMap := make(map[uint]bool)
+	if len(contentIDs) == 0 {
+		return favoriteMap
+	}
+
+	var favorites []baby.UserContentFavorite
+	global.GVA_DB.Where("user_id = ? AND content_type = ? AND content_id IN ?", userID, contentType, contentIDs).Find(&favorites)
+	for _, favorite := range favorites {
+		favoriteMap[favorite.ContentID] = true
+	}
+	return favoriteMap
+}
+
+// buildArticleResponses 组装文章列表响应，补充分类名称和用户的收藏、阅读状态
+func (s *ParentingService) buildArticleResponses(userID uint, articles []baby.ParentingArticle) []response.ParentingArticleResponse {
+	var articleIDs, categoryIDs []uint
+	for _, article := range articles {
+		articleIDs = append(articleIDs, article.ID)
+		categoryIDs = append(categoryIDs, article.CategoryID)
+	}
+
+	categoryMap := s.categoryNameMap(categoryIDs)
+	favoriteMap := s.favoriteMap(userID, contentTypeArticle, articleIDs)
+
+	readMap := make(map[uint]baby.UserArticleRead)
+	if len(articleIDs) > 0 {
+		var reads []baby.UserArticleRead
+		global.GVA_DB.Where("user_id = ? AND article_id IN ?", userID, articleIDs).Find(&reads)
+		for _, read := range reads {
+			readMap[read.ArticleID] = read
+		}
+	}
+
+	vip := newVipChecker(userID)
+	list := make([]response.ParentingArticleResponse, 0, len(articles))
+	for i := range articles {
+		read := readMap[articles[i].ID]
+		var resp response.ParentingArticleResponse
+		resp.FromParentingArticle(&articles[i], categoryMap[articles[i].CategoryID], favoriteMap[articles[i].ID], read.Progress, read.IsFinished, false)
+		if vip.locked(articles[i].IsVIP) {
+			resp.Lock()
+		}
+		list = append(list, resp)
+	}
+	return list
+}
+
+// buildVideoResponses 组装视频列表响应，补充分类名称和用户的收藏、观看状态
+func (s *ParentingService) buildVideoResponses(userID uint, videos []baby.ParentingVideo) []response.ParentingVideoResponse {
+	var videoIDs, categoryIDs []uint
+	for _, video := range videos {
+		videoIDs = append(videoIDs, video.ID)
+		categoryIDs = append(categoryIDs, video.CategoryID)
+	}
+
+	categoryMap := s.categoryNameMap(categoryIDs)
+	favoriteMap := s.favoriteMap(userID, contentTypeVideo, videoIDs)
+
+	watchMap := make(map[uint]baby.UserVideoWatch)
+	if len(videoIDs) > 0 {
+		var watches []baby.UserVideoWatch
+		global.GVA_DB.Where("user_id = ? AND video_id IN ?", userID, videoIDs).Find(&watches)
+		for _, watch := range watches {
+			watchMap[watch.VideoID] = watch
+		}
+	}
+
+	vip := newVipChecker(userID)
+	list := make([]response.ParentingVideoResponse, 0, len(videos))
+	for i := range videos {
+		watch := watchMap[videos[i].ID]
+		var resp response.ParentingVideoResponse
+		resp.FromParentingVideo(&videos[i], categoryMap[videos[i].CategoryID], favoriteMap[videos[i].ID], watch.Progress, watch.IsFinished)
+		resp.WatchPosition = watch.Position
+		vip.guardVideo(&resp, videos[i].IsVIP)
+		list = append(list, resp)
+	}
+	return list
+}
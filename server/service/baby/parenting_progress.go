@@ -0,0 +1,289 @@
+package baby
+
+import (
+	"errors"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	commonRequest "baby_admin/server/model/common/request"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReportArticleProgress 上报文章阅读进度，每个用户每天首次阅读时计入浏览次数
+func (s *ParentingService) ReportArticleProgress(userID uint, req *request.ReadArticleRequest) (*response.ArticleReadProgressResponse, error) {
+	var article baby.ParentingArticle
+	err := s.publishedScope(&baby.ParentingArticle{}).Where("id = ?", req.ArticleID).First(&article).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("文章不存在")
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	var record baby.UserArticleRead
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		record = baby.UserArticleRead{UserID: userID, ArticleID: article.ID, LastReadAt: now}
+		created, err := lockProgressRecord(tx, &record, "user_id = ? AND article_id = ?", userID, article.ID)
+		if err != nil {
+			return err
+		}
+		firstViewToday := created || record.LastReadAt.Before(startOfDay(now))
+
+		isFinished := record.IsFinished || req.IsFinished || req.Progress >= 100
+		err = tx.Unscoped().Model(&record).Updates(map[string]interface{}{
+			"read_time":    gorm.Expr("read_time + ?", req.ReadTime),
+			"progress":     req.Progress,
+			"is_finished":  isFinished,
+			"last_read_at": now,
+			"deleted_at":   nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if firstViewToday {
+			err = tx.Model(&baby.ParentingArticle{}).Where("id = ?", article.ID).
+				Update("view_count", gorm.Expr("view_count + ?", 1)).Error
+			if err != nil {
+				return err
+			}
+			article.ViewCount++
+		}
+		return tx.Where("id = ?", record.ID).First(&record).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	articles := s.buildArticleResponses(userID, []baby.ParentingArticle{article})
+	return &response.ArticleReadProgressResponse{
+		Article:    articles[0],
+		ReadTime:   record.ReadTime,
+		Progress:   record.Progress,
+		IsFinished: record.IsFinished,
+		LastReadAt: record.LastReadAt,
+	}, nil
+}
+
+// ReportVideoProgress 上报视频观看进度，记录播放位置用于续播，每个用户每天首次观看时计入观看次数
+func (s *ParentingService) ReportVideoProgress(userID uint, req *request.WatchVideoRequest) (*response.VideoWatchProgressResponse, error) {
+	var video baby.ParentingVideo
+	err := s.publishedScope(&baby.ParentingVideo{}).Where("id = ?", req.VideoID).First(&video).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("视频不存在")
+		}
+		return nil, err
+	}
+
+	position, progress := req.Position, req.Progress
+	if video.Duration > 0 {
+		if position > video.Duration {
+			position = video.Duration
+		}
+		if progress == 0 && position > 0 {
+			progress = position * 100 / video.Duration
+		}
+	}
+	isFinished := req.IsFinished || progress >= 100
+	if isFinished {
+		// 看完后下次从头播放
+		position = 0
+	}
+
+	now := time.Now()
+	var record baby.UserVideoWatch
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		record = baby.UserVideoWatch{UserID: userID, VideoID: video.ID, LastWatchAt: now}
+		created, err := lockProgressRecord(tx, &record, "user_id = ? AND video_id = ?", userID, video.ID)
+		if err != nil {
+			return err
+		}
+		firstViewToday := created || record.LastWatchAt.Before(startOfDay(now))
+
+		err = tx.Unscoped().Model(&record).Updates(map[string]interface{}{
+			"watch_time":    gorm.Expr("watch_time + ?", req.WatchTime),
+			"progress":      progress,
+			"position":      position,
+			"is_finished":   record.IsFinished || isFinished,
+			"last_watch_at": now,
+			"deleted_at":    nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if firstViewToday {
+			err = tx.Model(&baby.ParentingVideo{}).Where("id = ?", video.ID).
+				Update("view_count", gorm.Expr("view_count + ?", 1)).Error
+			if err != nil {
+				return err
+			}
+			video.ViewCount++
+		}
+		return tx.Where("id = ?", record.ID).First(&record).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	videos := s.buildVideoResponses(userID, []baby.ParentingVideo{video})
+	return &response.VideoWatchProgressResponse{
+		Video:       videos[0],
+		WatchTime:   record.WatchTime,
+		Progress:    record.Progress,
+		Position:    record.Position,
+		IsFinished:  record.IsFinished,
+		LastWatchAt: record.LastWatchAt,
+	}, nil
+}
+
+// GetContinueReading 获取继续阅读列表：读过但尚未读完的文章，最近阅读的在前
+func (s *ParentingService) GetContinueReading(userID uint, req *commonRequest.PageInfo) (*response.ArticleReadProgressListResponse, error) {
+	db := global.GVA_DB.Model(&baby.UserArticleRead{}).
+		Joins("JOIN parenting_articles ON parenting_articles.id = user_article_reads.article_id AND parenting_articles.deleted_at IS NULL").
+		Where("user_article_reads.user_id = ? AND user_article_reads.is_finished = ? AND user_article_reads.progress > ?", userID, false, 0).
+		Where("parenting_articles.is_active = ?", true)
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var records []baby.UserArticleRead
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Select("user_article_reads.*").Offset(offset).Limit(req.PageSize).
+		Order("user_article_reads.last_read_at DESC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var articleIDs []uint
+	for _, record := range records {
+		articleIDs = append(articleIDs, record.ArticleID)
+	}
+
+	var articles []baby.ParentingArticle
+	if len(articleIDs) > 0 {
+		global.GVA_DB.Where("id IN ?", articleIDs).Find(&articles)
+	}
+
+	articleMap := make(map[uint]response.ParentingArticleResponse)
+	for _, resp := range s.buildArticleResponses(userID, articles) {
+		articleMap[resp.ID] = resp
+	}
+
+	// 转换响应
+	list := make([]response.ArticleReadProgressResponse, 0, len(records))
+	for _, record := range records {
+		article, exists := articleMap[record.ArticleID]
+		if !exists {
+			continue
+		}
+		list = append(list, response.ArticleReadProgressResponse{
+			Article:    article,
+			ReadTime:   record.ReadTime,
+			Progress:   record.Progress,
+			IsFinished: record.IsFinished,
+			LastReadAt: record.LastReadAt,
+		})
+	}
+
+	return &response.ArticleReadProgressListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// GetContinueWatching 获取继续观看列表：停在中途的视频（含看完后重看的），最近观看的在前
+func (s *ParentingService) GetContinueWatching(userID uint, req *commonRequest.PageInfo) (*response.VideoWatchProgressListResponse, error) {
+	db := global.GVA_DB.Model(&baby.UserVideoWatch{}).
+		Joins("JOIN parenting_videos ON parenting_videos.id = user_video_watches.video_id AND parenting_videos.deleted_at IS NULL").
+		Where("user_video_watches.user_id = ? AND user_video_watches.position > ?", userID, 0).
+		Where("parenting_videos.is_active = ?", true)
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var records []baby.UserVideoWatch
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Select("user_video_watches.*").Offset(offset).Limit(req.PageSize).
+		Order("user_video_watches.last_watch_at DESC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var videoIDs []uint
+	for _, record := range records {
+		videoIDs = append(videoIDs, record.VideoID)
+	}
+
+	var videos []baby.ParentingVideo
+	if len(videoIDs) > 0 {
+		global.GVA_DB.Where("id IN ?", videoIDs).Find(&videos)
+	}
+
+	videoMap := make(map[uint]response.ParentingVideoResponse)
+	for _, resp := range s.buildVideoResponses(userID, videos) {
+		videoMap[resp.ID] = resp
+	}
+
+	// 转换响应
+	list := make([]response.VideoWatchProgressResponse, 0, len(records))
+	for _, record := range records {
+		video, exists := videoMap[record.VideoID]
+		if !exists {
+			continue
+		}
+		list = append(list, response.VideoWatchProgressResponse{
+			Video:       video,
+			WatchTime:   record.WatchTime,
+			Progress:    record.Progress,
+			Position:    record.Position,
+			IsFinished:  record.IsFinished,
+			LastWatchAt: record.LastWatchAt,
+		})
+	}
+
+	return &response.VideoWatchProgressListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// lockProgressRecord 锁定用户对某个内容的进度记录，不存在时先创建，返回是否为新建
+// record需预先填好用户、内容ID和访问时间；并发创建时以唯一索引为准，冲突后重新读取
+func lockProgressRecord(tx *gorm.DB, record interface{}, query string, args ...interface{}) (bool, error) {
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where(query, args...).First(record).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return true, nil
+	}
+	return false, tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where(query, args...).First(record).Error
+}
+
+// startOfDay 返回t当天零点
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,161 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	commonRequest "baby_admin/server/model/common/request"
+)
+
+func TestReportArticleProgress(t *testing.T) {
+	db := setupTestDB(t, parentingTestModels...)
+	article := baby.ParentingArticle{CategoryID: 1, Title: "宝宝睡眠指南", IsActive: true, PublishedAt: time.Now().Add(-time.Hour)}
+	if err := db.Create(&article).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	service := new(ParentingService)
+	yesterday := time.Now().AddDate(0, 0, -1)
+	steps := []struct {
+		name      string
+		lastRead  *time.Time // 上报前把最后阅读时间改到该时刻
+		deleted   bool       // 上报前软删除阅读记录
+		req       request.ReadArticleRequest
+		viewCount int64
+		readTime  int
+		finished  bool
+		reading   bool // 是否出现在继续阅读列表
+	}{
+		{name: "首次阅读", req: request.ReadArticleRequest{ReadTime: 30, Progress: 20}, viewCount: 1, readTime: 30, reading: true},
+		{name: "当天再次阅读", req: request.ReadArticleRequest{ReadTime: 40, Progress: 50}, viewCount: 1, readTime: 70, reading: true},
+		{name: "次日阅读", lastRead: &yesterday, req: request.ReadArticleRequest{ReadTime: 10, Progress: 60}, viewCount: 2, readTime: 80, reading: true},
+		{name: "删除记录后再次阅读", deleted: true, req: request.ReadArticleRequest{ReadTime: 20, Progress: 100}, viewCount: 2, readTime: 100, finished: true},
+		{name: "读完后回看不重置", req: request.ReadArticleRequest{ReadTime: 5, Progress: 10}, viewCount: 2, readTime: 105, finished: true},
+	}
+	var recordID uint
+	for _, step := range steps {
+		if step.lastRead != nil {
+			db.Model(&baby.UserArticleRead{}).Where("id = ?", recordID).Update("last_read_at", *step.lastRead)
+		}
+		if step.deleted {
+			db.Where("id = ?", recordID).Delete(&baby.UserArticleRead{})
+		}
+		step.req.ArticleID = article.ID
+		resp, err := service.ReportArticleProgress(1, &step.req)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		var record baby.UserArticleRead
+		if err := db.Where("user_id = ? AND article_id = ?", 1, article.ID).First(&record).Error; err != nil {
+			t.Fatalf("%s: 阅读记录 %v", step.name, err)
+		}
+		if recordID != 0 && record.ID != recordID {
+			t.Errorf("%s: 阅读记录ID = %d, want %d", step.name, record.ID, recordID)
+		}
+		recordID = record.ID
+		db.Where("id = ?", article.ID).First(&article)
+		if article.ViewCount != step.viewCount {
+			t.Errorf("%s: view_count = %d, want %d", step.name, article.ViewCount, step.viewCount)
+		}
+		if resp.ReadTime != step.readTime || resp.IsFinished != step.finished || resp.Progress != step.req.Progress {
+			t.Errorf("%s: 进度 = %+v", step.name, resp)
+		}
+		list, err := service.GetContinueReading(1, &commonRequest.PageInfo{Page: 1, PageSize: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (list.Total == 1) != step.reading {
+			t.Errorf("%s: 继续阅读 = %d, want reading %v", step.name, list.Total, step.reading)
+		}
+	}
+}
+
+func TestReportVideoProgress(t *testing.T) {
+	db := setupTestDB(t, parentingTestModels...)
+	published := time.Now().Add(-time.Hour)
+	video := baby.ParentingVideo{CategoryID: 1, Title: "抚触操", VideoURL: "videos/touch.mp4", Duration: 600, IsActive: true, PublishedAt: published}
+	other := baby.ParentingVideo{CategoryID: 1, Title: "辅食制作", VideoURL: "videos/food.mp4", Duration: 300, IsActive: true, PublishedAt: published}
+	for _, v := range []*baby.ParentingVideo{&video, &other} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service := new(ParentingService)
+	continueWatching := func() []uint {
+		t.Helper()
+		list, err := service.GetContinueWatching(1, &commonRequest.PageInfo{Page: 1, PageSize: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]uint, 0, len(list.List))
+		for _, item := range list.List {
+			ids = append(ids, item.Video.ID)
+		}
+		return ids
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	steps := []struct {
+		name      string
+		lastWatch *time.Time
+		deleted   bool
+		req       request.WatchVideoRequest
+		viewCount int64
+		position  int
+		progress  int
+		finished  bool
+		watching  bool // 是否出现在继续观看列表
+	}{
+		{name: "首次观看", req: request.WatchVideoRequest{WatchTime: 120, Position: 120}, viewCount: 1, position: 120, progress: 20, watching: true},
+		{name: "当天继续观看", req: request.WatchVideoRequest{WatchTime: 180, Position: 300}, viewCount: 1, position: 300, progress: 50, watching: true},
+		{name: "位置超过时长", req: request.WatchVideoRequest{WatchTime: 10, Position: 900, Progress: 99}, viewCount: 1, position: 600, progress: 99, watching: true},
+		{name: "看完后位置归零", req: request.WatchVideoRequest{WatchTime: 5, IsFinished: true, Progress: 100}, viewCount: 1, position: 0, progress: 100, finished: true},
+		{name: "次日重看", lastWatch: &yesterday, req: request.WatchVideoRequest{WatchTime: 60, Position: 60}, viewCount: 2, position: 60, progress: 10, finished: true, watching: true},
+		{name: "删除记录后再次观看", deleted: true, req: request.WatchVideoRequest{WatchTime: 30, Position: 90}, viewCount: 2, position: 90, progress: 15, finished: true, watching: true},
+	}
+	var recordID uint
+	for _, step := range steps {
+		if step.lastWatch != nil {
+			db.Model(&baby.UserVideoWatch{}).Where("id = ?", recordID).Update("last_watch_at", *step.lastWatch)
+		}
+		if step.deleted {
+			db.Where("id = ?", recordID).Delete(&baby.UserVideoWatch{})
+		}
+		step.req.VideoID = video.ID
+		resp, err := service.ReportVideoProgress(1, &step.req)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		var record baby.UserVideoWatch
+		if err := db.Where("user_id = ? AND video_id = ?", 1, video.ID).First(&record).Error; err != nil {
+			t.Fatalf("%s: 观看记录 %v", step.name, err)
+		}
+		if recordID != 0 && record.ID != recordID {
+			t.Errorf("%s: 观看记录ID = %d, want %d", step.name, record.ID, recordID)
+		}
+		recordID = record.ID
+		db.Where("id = ?", video.ID).First(&video)
+		if video.ViewCount != step.viewCount {
+			t.Errorf("%s: view_count = %d, want %d", step.name, video.ViewCount, step.viewCount)
+		}
+		if resp.Position != step.position || resp.Progress != step.progress || resp.IsFinished != step.finished {
+			t.Errorf("%s: position %d progress %d finished %v, want %d %d %v",
+				step.name, resp.Position, resp.Progress, resp.IsFinished, step.position, step.progress, step.finished)
+		}
+		if ids := continueWatching(); (len(ids) == 1 && ids[0] == video.ID) != step.watching {
+			t.Errorf("%s: 继续观看 = %v, want watching %v", step.name, ids, step.watching)
+		}
+	}
+
+	// 最近观看的在前
+	if _, err := service.ReportVideoProgress(1, &request.WatchVideoRequest{VideoID: other.ID, WatchTime: 30, Position: 30}); err != nil {
+		t.Fatal(err)
+	}
+	if ids := continueWatching(); len(ids) != 2 || ids[0] != other.ID || ids[1] != video.ID {
+		t.Errorf("继续观看 = %v, want [%d %d]", ids, other.ID, video.ID)
+	}
+}
@@ -0,0 +1,229 @@
+package baby
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	commonRequest "baby_admin/server/model/common/request"
+	"gorm.io/gorm"
+)
+
+// parentingTestModels 育儿内容相关测试需要的表
+var parentingTestModels = []interface{}{
+	&baby.FamilyGroup{}, &baby.FamilyMember{}, &baby.BabyProfile{}, &baby.UserActiveBaby{},
+	&baby.ParentingCategory{}, &baby.ParentingArticle{}, &baby.ParentingVideo{},
+	&baby.UserArticleRead{}, &baby.UserVideoWatch{}, &baby.UserContentFavorite{},
+}
+
+func TestGetBabyFeedFamilyAccess(t *testing.T) {
+	db := setupTestDB(t, parentingTestModels...)
+	profile := seedFamilyBaby(t, db)
+	article := baby.ParentingArticle{CategoryID: 1, Title: "辅食添加指南", IsActive: true, PublishedAt: time.Now().Add(-time.Hour)}
+	if err := db.Create(&article).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	service := new(ParentingService)
+	feed, err := service.GetBabyFeed(4, &request.GetRecommendationsRequest{BabyID: profile.ID})
+	if err != nil {
+		t.Fatalf("观察者获取宝宝推荐: %v", err)
+	}
+	if len(feed.Articles) != 1 {
+		t.Errorf("推荐文章数 = %d, want 1", len(feed.Articles))
+	}
+	if _, err := service.GetBabyFeed(9, &request.GetRecommendationsRequest{BabyID: profile.ID}); err == nil {
+		t.Error("家庭外用户不应获取宝宝推荐")
+	}
+}
+
+// seedParentingArticles 创建测试文章，inactive为true时创建后下架
+func seedParentingArticles(t *testing.T, db *gorm.DB, articles []baby.ParentingArticle, inactive ...string) []baby.ParentingArticle {
+	t.Helper()
+	for i := range articles {
+		articles[i].IsActive = true
+		if articles[i].PublishedAt.IsZero() {
+			articles[i].PublishedAt = time.Now().Add(-time.Hour)
+		}
+		if err := db.Create(&articles[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, title := range inactive {
+		if err := db.Model(&baby.ParentingArticle{}).Where("title = ?", title).Update("is_active", false).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return articles
+}
+
+// articleTitles 文章标题，sorted为true时按标题排序便于比较集合
+func articleTitles(list []response.ParentingArticleResponse, sorted bool) []string {
+	titles := make([]string, 0, len(list))
+	for _, item := range list {
+		titles = append(titles, item.Title)
+	}
+	if sorted {
+		sort.Strings(titles)
+	}
+	return titles
+}
+
+func TestParentingArticleFilters(t *testing.T) {
+	db := setupTestDB(t, parentingTestModels...)
+	articles := seedParentingArticles(t, db, []baby.ParentingArticle{
+		{CategoryID: 1, Title: "A新生儿睡眠", AgeRange: "0-6月", Tags: `["睡眠","新生儿"]`, Difficulty: 1, IsRecommend: true},
+		{CategoryID: 2, Title: "B辅食添加", AgeRange: "6-12月", Tags: `["辅食"]`, Difficulty: 2},
+		{CategoryID: 1, Title: "C夜醒安抚", AgeRange: "6-12月", Tags: `["睡眠","夜醒"]`, Difficulty: 2, IsVIP: true},
+		{CategoryID: 3, Title: "D居家安全", Tags: `["安全"]`, Difficulty: 1},
+		{CategoryID: 1, Title: "E未发布", AgeRange: "6-12月", Tags: `["睡眠"]`, Difficulty: 2, PublishedAt: time.Now().Add(time.Hour)},
+		{CategoryID: 1, Title: "F已下架", AgeRange: "6-12月", Tags: `["睡眠"]`, Difficulty: 2},
+	}, "F已下架")
+
+	yes := true
+	tests := []struct {
+		name string
+		req  request.ParentingArticleSearch
+		want []string
+	}{
+		{"不筛选", request.ParentingArticleSearch{}, []string{"A新生儿睡眠", "B辅食添加", "C夜醒安抚", "D居家安全"}},
+		{"分类", request.ParentingArticleSearch{CategoryID: 1}, []string{"A新生儿睡眠", "C夜醒安抚"}},
+		{"年龄段含通用内容", request.ParentingArticleSearch{AgeRange: "6-12月"}, []string{"B辅食添加", "C夜醒安抚", "D居家安全"}},
+		{"难度", request.ParentingArticleSearch{Difficulty: 2}, []string{"B辅食添加", "C夜醒安抚"}},
+		{"单个标签", request.ParentingArticleSearch{Tags: "睡眠"}, []string{"A新生儿睡眠", "C夜醒安抚"}},
+		{"多个标签同时包含", request.ParentingArticleSearch{Tags: "睡眠, 夜醒"}, []string{"C夜醒安抚"}},
+		{"分类和年龄段", request.ParentingArticleSearch{CategoryID: 1, AgeRange: "6-12月"}, []string{"C夜醒安抚"}},
+		{"年龄段和难度", request.ParentingArticleSearch{AgeRange: "6-12月", Difficulty: 1}, []string{"D居家安全"}},
+		{"标签和年龄段", request.ParentingArticleSearch{Tags: "睡眠", AgeRange: "0-6月"}, []string{"A新生儿睡眠"}},
+		{"分类、标签和难度", request.ParentingArticleSearch{CategoryID: 1, Tags: "睡眠", Difficulty: 1}, []string{"A新生儿睡眠"}},
+		{"推荐", request.ParentingArticleSearch{IsRecommend: &yes}, []string{"A新生儿睡眠"}},
+		{"VIP专享", request.ParentingArticleSearch{IsVIP: &yes}, []string{"C夜醒安抚"}},
+		{"无匹配", request.ParentingArticleSearch{CategoryID: 2, Tags: "睡眠"}, []string{}},
+	}
+	service := new(ParentingService)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.PageInfo = commonRequest.PageInfo{Page: 1, PageSize: 10}
+			list, err := service.GetArticleList(1, &tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := articleTitles(list.List, true); !reflect.DeepEqual(got, tt.want) || list.Total != int64(len(tt.want)) {
+				t.Errorf("文章 = %v (total %d), want %v", got, list.Total, tt.want)
+			}
+		})
+	}
+
+	// 详情同样只返回已发布的内容
+	for _, article := range articles {
+		_, err := service.GetArticleDetail(1, article.ID)
+		hidden := article.Title == "E未发布" || article.Title == "F已下架"
+		if (err != nil) != hidden {
+			t.Errorf("%s: GetArticleDetail err = %v, want hidden %v", article.Title, err, hidden)
+		}
+	}
+}
+
+func TestParentingVideoFilters(t *testing.T) {
+	db := setupTestDB(t, parentingTestModels...)
+	published := time.Now().Add(-time.Hour)
+	videos := []baby.ParentingVideo{
+		{CategoryID: 1, Title: "A抚触操", VideoURL: "videos/a.mp4", AgeRange: "0-6月", Tags: `["抚触","互动"]`, IsActive: true, PublishedAt: published},
+		{CategoryID: 2, Title: "B辅食制作", VideoURL: "videos/b.mp4", AgeRange: "6-12月", Tags: `["辅食"]`, IsActive: true, PublishedAt: published},
+		{CategoryID: 1, Title: "C亲子游戏", VideoURL: "videos/c.mp4", AgeRange: "6-12月", Tags: `["互动","游戏"]`, IsActive: true, PublishedAt: published},
+		{CategoryID: 3, Title: "D急救常识", VideoURL: "videos/d.mp4", Tags: `["安全"]`, IsActive: true, PublishedAt: published},
+		{CategoryID: 1, Title: "E未发布", VideoURL: "videos/e.mp4", AgeRange: "6-12月", Tags: `["互动"]`, IsActive: true, PublishedAt: time.Now().Add(time.Hour)},
+	}
+	for i := range videos {
+		if err := db.Create(&videos[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		req  request.ParentingVideoSearch
+		want []string
+	}{
+		{"不筛选", request.ParentingVideoSearch{}, []string{"A抚触操", "B辅食制作", "C亲子游戏", "D急救常识"}},
+		{"分类", request.ParentingVideoSearch{CategoryID: 1}, []string{"A抚触操", "C亲子游戏"}},
+		{"年龄段含通用内容", request.ParentingVideoSearch{AgeRange: "0-6月"}, []string{"A抚触操", "D急救常识"}},
+		{"标签", request.ParentingVideoSearch{Tags: "互动"}, []string{"A抚触操", "C亲子游戏"}},
+		{"分类、年龄段和标签", request.ParentingVideoSearch{CategoryID: 1, AgeRange: "6-12月", Tags: "互动,游戏"}, []string{"C亲子游戏"}},
+	}
+	service := new(ParentingService)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.PageInfo = commonRequest.PageInfo{Page: 1, PageSize: 10}
+			list, err := service.GetVideoList(1, &tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			titles := make([]string, 0, len(list.List))
+			for _, item := range list.List {
+				titles = append(titles, item.Title)
+			}
+			sort.Strings(titles)
+			if !reflect.DeepEqual(titles, tt.want) || list.Total != int64(len(tt.want)) {
+				t.Errorf("视频 = %v (total %d), want %v", titles, list.Total, tt.want)
+			}
+		})
+	}
+
+	if _, err := service.GetVideoDetail(1, videos[4].ID); err == nil {
+		t.Error("未发布的视频不应返回详情")
+	}
+}
+
+func TestGetBabyFeedAgeMatching(t *testing.T) {
+	db := setupTestDB(t, parentingTestModels...)
+	// 未加入家庭的用户5有两个宝宝，后创建的新生儿最近更新
+	older := baby.BabyProfile{UserID: 5, Name: "大宝", Birthday: time.Now().AddDate(0, -9, 0)}
+	newborn := baby.BabyProfile{UserID: 5, Name: "二宝", Birthday: time.Now().AddDate(0, 0, -20)}
+	for _, profile := range []*baby.BabyProfile{&older, &newborn} {
+		if err := db.Create(profile).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	seedParentingArticles(t, db, []baby.ParentingArticle{
+		{CategoryID: 1, Title: "新生儿护理", AgeRange: "0-6月", IsRecommend: true},
+		{CategoryID: 1, Title: "辅食添加", AgeRange: "6-12月"},
+		{CategoryID: 1, Title: "学步准备", AgeRange: "6-12月", SortOrder: 1},
+		{CategoryID: 1, Title: "居家安全", IsRecommend: true},
+		{CategoryID: 1, Title: "幼儿语言", AgeRange: "1-2岁"},
+	})
+
+	service := new(ParentingService)
+	feedTitles := func() []string {
+		t.Helper()
+		feed, err := service.GetBabyFeed(5, &request.GetRecommendationsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return articleTitles(feed.Articles, false)
+	}
+
+	// 未选择当前宝宝时使用最近更新的宝宝；匹配月龄的内容在通用内容之前
+	if got, want := feedTitles(), []string{"新生儿护理", "居家安全"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("默认宝宝推荐 = %v, want %v", got, want)
+	}
+
+	if err := db.Create(&baby.UserActiveBaby{UserID: 5, BabyID: older.ID}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got, want := feedTitles(), []string{"辅食添加", "学步准备", "居家安全"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("当前宝宝推荐 = %v, want %v", got, want)
+	}
+
+	// 指定宝宝时不使用当前宝宝
+	feed, err := service.GetBabyFeed(5, &request.GetRecommendationsRequest{BabyID: newborn.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := articleTitles(feed.Articles, false), []string{"新生儿护理", "居家安全"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("指定宝宝推荐 = %v, want %v", got, want)
+	}
+}
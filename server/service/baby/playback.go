@@ -0,0 +1,750 @@
+package baby
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PlaybackService 播放会话：队列、循环模式和睡眠定时，小程序和音响设备可以互相接续播放，
+// 会话结束时每首音乐按实际播放时长写入一条播放历史
+type PlaybackService struct{}
+
+// 会话状态，对应PlaybackSession.Status
+const (
+	playbackStatusPlaying = 1 // 播放中
+	playbackStatusPaused  = 2 // 已暂停
+	playbackStatusEnded   = 3 // 已结束
+)
+
+// 循环模式，对应PlaybackSession.LoopMode
+const (
+	playbackLoopSequential = 1 // 顺序播放，播完队列结束会话
+	playbackLoopList       = 2 // 列表循环
+	playbackLoopSingle     = 3 // 单曲循环
+)
+
+// 结束原因，对应PlaybackSession.EndReason
+const (
+	playbackEndStopped       = 1 // 手动停止
+	playbackEndSleepTimer    = 2 // 睡眠定时
+	playbackEndQueueFinished = 3 // 队列播完
+	playbackEndReplaced      = 4 // 开始新会话或设备被其他会话占用
+	playbackEndIdle          = 5 // 长时间未同步
+)
+
+const (
+	deviceTypeSpeaker   = 3                // 音响设备，对应Device.DeviceType
+	playbackIdleTimeout = 6 * time.Hour    // 超过该时长未同步进度的会话自动结束
+	playbackReportSlack = 10 * time.Second // 上报的播放时长允许超出实际间隔的误差
+)
+
+// playbackActor 操作会话的一方：小程序用户或音响设备，二者只有一个非0
+type playbackActor struct {
+	userID   uint
+	deviceID uint
+}
+
+// StartSession 开始播放会话，用户未结束的会话会被替换
+func (s *PlaybackService) StartSession(userID uint, req *request.StartPlaybackRequest) (*response.PlaybackSessionResponse, error) {
+	if req.BabyID > 0 {
+		if _, _, err := authorizeBaby(req.BabyID, userID, babyAccessRecord); err != nil {
+			return nil, err
+		}
+	}
+	if req.DeviceID > 0 {
+		if _, err := authorizeSpeaker(req.DeviceID, userID); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateSleepTimer(req.SleepMinutes, req.FadeOutSeconds); err != nil {
+		return nil, err
+	}
+
+	queue, err := s.buildQueue(userID, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.StartIndex >= len(queue) {
+		return nil, errors.New("起始位置超出播放队列范围")
+	}
+
+	now := time.Now()
+	session := &baby.PlaybackSession{
+		UserID:         userID,
+		BabyID:         req.BabyID,
+		DeviceID:       req.DeviceID,
+		PlaylistID:     req.PlaylistID,
+		CurrentIndex:   req.StartIndex,
+		LoopMode:       req.LoopMode,
+		Status:         playbackStatusPlaying,
+		SleepMinutes:   req.SleepMinutes,
+		FadeOutSeconds: req.FadeOutSeconds,
+		SleepAt:        playbackSleepAt(now, req.SleepMinutes),
+		LastActiveAt:   now,
+	}
+	if session.LoopMode == 0 {
+		session.LoopMode = playbackLoopSequential
+	}
+	if err := session.SetQueue(queue); err != nil {
+		return nil, err
+	}
+
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := s.replaceSessions(tx, "user_id", userID, 0, now); err != nil {
+			return err
+		}
+		if session.DeviceID > 0 {
+			if err := s.replaceSessions(tx, "device_id", session.DeviceID, 0, now); err != nil {
+				return err
+			}
+		}
+		return tx.Create(session).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if session.DeviceID > 0 {
+		notifySpeaker(userID, session.DeviceID, "playback_start", session.ID)
+	}
+	return s.buildSessionResponse(session, now)
+}
+
+// GetCurrentSession 获取用户未结束的播放会话，用于切换页面或换端后继续播放，没有时返回nil
+func (s *PlaybackService) GetCurrentSession(userID uint) (*response.PlaybackSessionResponse, error) {
+	var session baby.PlaybackSession
+	err := global.GVA_DB.Where("user_id = ? AND status <> ?", userID, playbackStatusEnded).
+		Order("updated_at DESC").First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s.buildSessionResponse(&session, time.Now())
+}
+
+// ReportProgress 小程序同步播放进度
+func (s *PlaybackService) ReportProgress(userID uint, req *request.PlaybackProgressRequest) (*response.PlaybackSessionResponse, error) {
+	return s.reportProgress(playbackActor{userID: userID}, req)
+}
+
+// PauseSession 小程序暂停播放，会话在音响上播放时通知音响暂停
+func (s *PlaybackService) PauseSession(userID uint, req *request.PlaybackProgressRequest) (*response.PlaybackSessionResponse, error) {
+	return s.changeStatus(playbackActor{userID: userID}, req, playbackStatusPaused)
+}
+
+// StopSession 小程序停止播放并结束会话
+func (s *PlaybackService) StopSession(userID uint, req *request.PlaybackProgressRequest) (*response.PlaybackSessionResponse, error) {
+	return s.changeStatus(playbackActor{userID: userID}, req, playbackStatusEnded)
+}
+
+// ResumeSession 继续播放，可从小程序切换到音响设备，或从音响切回小程序
+func (s *PlaybackService) ResumeSession(userID uint, req *request.ResumePlaybackRequest) (*response.PlaybackSessionResponse, error) {
+	if req.DeviceID > 0 {
+		if _, err := authorizeSpeaker(req.DeviceID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	var session *baby.PlaybackSession
+	var previousDeviceID uint
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		session, err = lockPlaybackSession(tx, req.SessionID, playbackActor{userID: userID})
+		if err != nil {
+			return err
+		}
+		previousDeviceID = session.DeviceID
+		return s.resume(tx, session, req.DeviceID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if previousDeviceID > 0 && previousDeviceID != session.DeviceID {
+		notifySpeaker(userID, previousDeviceID, "playback_stop", session.ID)
+	}
+	if session.DeviceID > 0 {
+		notifySpeaker(userID, session.DeviceID, "playback_resume", session.ID)
+	}
+	return s.buildSessionResponse(session, now)
+}
+
+// UpdateSettings 修改循环模式和睡眠定时，睡眠定时从修改时重新计时
+func (s *PlaybackService) UpdateSettings(userID uint, req *request.UpdatePlaybackSettingsRequest) (*response.PlaybackSessionResponse, error) {
+	now := time.Now()
+	var session *baby.PlaybackSession
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		session, err = lockPlaybackSession(tx, req.SessionID, playbackActor{userID: userID})
+		if err != nil {
+			return err
+		}
+
+		if req.LoopMode > 0 {
+			session.LoopMode = req.LoopMode
+		}
+		if req.FadeOutSeconds != nil {
+			session.FadeOutSeconds = *req.FadeOutSeconds
+		}
+		if req.SleepMinutes != nil {
+			session.SleepMinutes = *req.SleepMinutes
+			session.SleepAt = playbackSleepAt(now, session.SleepMinutes)
+		}
+		if err := validateSleepTimer(session.SleepMinutes, session.FadeOutSeconds); err != nil {
+			return err
+		}
+		return tx.Save(session).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if session.DeviceID > 0 {
+		notifySpeaker(userID, session.DeviceID, "playback_settings", session.ID)
+	}
+	return s.buildSessionResponse(session, now)
+}
+
+// DeviceResumeSession 音响设备接续播放：优先继续已在本设备上的会话，其次接手设备所有者或被分享用户最近的会话
+func (s *PlaybackService) DeviceResumeSession(device *baby.Device) (*response.PlaybackSessionResponse, error) {
+	if device.DeviceType != deviceTypeSpeaker {
+		return nil, errors.New("仅音响设备支持播放音乐")
+	}
+
+	var session baby.PlaybackSession
+	err := global.GVA_DB.Where("device_id = ? AND status <> ?", device.ID, playbackStatusEnded).
+		Order("updated_at DESC").First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 音响上没有会话时，接续设备主人或有控制权限的被分享用户最近的会话
+		sharedUserIDs := global.GVA_DB.Model(&baby.DeviceShare{}).Scopes(activeDeviceShares).
+			Where("device_id = ? AND share_type >= ?", device.ID, deviceAccessControl).Select("shared_user_id")
+		err = global.GVA_DB.Where("(user_id = ? OR user_id IN (?)) AND status <> ?", device.UserID, sharedUserIDs, playbackStatusEnded).
+			Order("updated_at DESC").First(&session).Error
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("没有可继续播放的会话")
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	var locked *baby.PlaybackSession
+	var previousDeviceID uint
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		locked, err = lockPlaybackSession(tx, session.ID, playbackActor{userID: session.UserID})
+		if err != nil {
+			return err
+		}
+		previousDeviceID = locked.DeviceID
+		return s.resume(tx, locked, device.ID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 会话从另一台音响接续过来时通知原音响停止
+	if previousDeviceID > 0 && previousDeviceID != device.ID {
+		notifySpeaker(locked.UserID, previousDeviceID, "playback_stop", locked.ID)
+	}
+	return s.buildSessionResponse(locked, now)
+}
+
+// DeviceReportProgress 音响设备同步播放进度
+func (s *PlaybackService) DeviceReportProgress(device *baby.Device, req *request.PlaybackProgressRequest) (*response.PlaybackSessionResponse, error) {
+	return s.reportProgress(playbackActor{deviceID: device.ID}, req)
+}
+
+// DevicePauseSession 音响设备暂停播放
+func (s *PlaybackService) DevicePauseSession(device *baby.Device, req *request.PlaybackProgressRequest) (*response.PlaybackSessionResponse, error) {
+	return s.changeStatus(playbackActor{deviceID: device.ID}, req, playbackStatusPaused)
+}
+
+// DeviceStopSession 音响设备停止播放并结束会话
+func (s *PlaybackService) DeviceStopSession(device *baby.Device, req *request.PlaybackProgressRequest) (*response.PlaybackSessionResponse, error) {
+	return s.changeStatus(playbackActor{deviceID: device.ID}, req, playbackStatusEnded)
+}
+
+// SweepSessions 结束睡眠定时已到或长时间未同步的会话，返回结束的会话数
+func (s *PlaybackService) SweepSessions(now time.Time) (int, error) {
+	var sessionIDs []uint
+	err := global.GVA_DB.Model(&baby.PlaybackSession{}).
+		Where("(status = ? AND sleep_at <= ?) OR (status IN ? AND last_active_at < ?)",
+			playbackStatusPlaying, now, []int{playbackStatusPlaying, playbackStatusPaused}, now.Add(-playbackIdleTimeout)).
+		Pluck("id", &sessionIDs).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var ended int
+	var lastErr error
+	for _, id := range sessionIDs {
+		var closed bool
+		err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+			var session baby.PlaybackSession
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&session).Error
+			if err != nil || session.IsEnded() {
+				return err
+			}
+			// 查询后会话可能刚同步过进度，按锁定后的数据重新判断
+			if playbackSleepDue(&session, now) {
+				// 最后一次同步之后到定时停止之间仍在播放当前曲目
+				if err := s.creditCurrentTrack(tx, &session, *session.SleepAt); err != nil {
+					return err
+				}
+				closed = true
+				return s.endSession(tx, &session, playbackEndSleepTimer, now)
+			}
+			if session.LastActiveAt.Before(now.Add(-playbackIdleTimeout)) {
+				closed = true
+				return s.endSession(tx, &session, playbackEndIdle, now)
+			}
+			return nil
+		})
+		if err != nil {
+			lastErr = fmt.Errorf("播放会话%d结束失败: %w", id, err)
+			continue
+		}
+		if closed {
+			ended++
+		}
+	}
+	return ended, lastErr
+}
+
+// reportProgress 记录播放进度，顺序播放播完最后一首或睡眠定时已到时结束会话
+func (s *PlaybackService) reportProgress(actor playbackActor, req *request.PlaybackProgressRequest) (*response.PlaybackSessionResponse, error) {
+	now := time.Now()
+	var session *baby.PlaybackSession
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		session, err = lockPlaybackSession(tx, req.SessionID, actor)
+		if err != nil {
+			return err
+		}
+		if session.DeviceID != actor.deviceID {
+			return errors.New("该会话正在其他设备上播放")
+		}
+
+		queueFinished, err := s.applyProgress(tx, session, req, now)
+		if err != nil {
+			return err
+		}
+		switch {
+		case queueFinished:
+			return s.endSession(tx, session, playbackEndQueueFinished, now)
+		case playbackSleepDue(session, now):
+			return s.endSession(tx, session, playbackEndSleepTimer, now)
+		}
+		return tx.Save(session).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.buildSessionResponse(session, now)
+}
+
+// changeStatus 暂停或停止会话；操作方正在播放时先记录最后的进度，小程序操作音响上的会话时通知音响
+func (s *PlaybackService) changeStatus(actor playbackActor, req *request.PlaybackProgressRequest, status int) (*response.PlaybackSessionResponse, error) {
+	now := time.Now()
+	var session *baby.PlaybackSession
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		session, err = lockPlaybackSession(tx, req.SessionID, actor)
+		if err != nil {
+			return err
+		}
+
+		if session.DeviceID == actor.deviceID {
+			queueFinished, err := s.applyProgress(tx, session, req, now)
+			if err != nil {
+				return err
+			}
+			if queueFinished {
+				return s.endSession(tx, session, playbackEndQueueFinished, now)
+			}
+		}
+		if status == playbackStatusEnded {
+			return s.endSession(tx, session, playbackEndStopped, now)
+		}
+		session.Status = status
+		session.LastActiveAt = now
+		return tx.Save(session).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if actor.userID > 0 && session.DeviceID > 0 {
+		command := "playback_pause"
+		if session.IsEnded() {
+			command = "playback_stop"
+		}
+		notifySpeaker(actor.userID, session.DeviceID, command, session.ID)
+	}
+	return s.buildSessionResponse(session, now)
+}
+
+// resume 将会话切换到指定设备继续播放，deviceID为0表示小程序播放；
+// 切换到音响时该音响上的其他会话被替换
+func (s *PlaybackService) resume(tx *gorm.DB, session *baby.PlaybackSession, deviceID uint, now time.Time) error {
+	if deviceID > 0 && deviceID != session.DeviceID {
+		if err := s.replaceSessions(tx, "device_id", deviceID, session.ID, now); err != nil {
+			return err
+		}
+	}
+	session.DeviceID = deviceID
+	session.Status = playbackStatusPlaying
+	session.LastActiveAt = now
+	// 定时已过但会话尚未被清理时重新计时
+	if session.SleepAt != nil && !session.SleepAt.After(now) {
+		session.SleepAt = playbackSleepAt(now, session.SleepMinutes)
+	}
+	return tx.Save(session).Error
+}
+
+// applyProgress 校验并记录进度，返回顺序播放模式下队列是否已播完
+func (s *PlaybackService) applyProgress(tx *gorm.DB, session *baby.PlaybackSession, req *request.PlaybackProgressRequest, now time.Time) (bool, error) {
+	queue, err := session.GetQueue()
+	if err != nil {
+		return false, errors.New("播放队列数据错误")
+	}
+	if req.CurrentIndex >= len(queue) {
+		return false, errors.New("播放位置超出播放队列范围")
+	}
+
+	var queueFinished bool
+	if req.MusicID > 0 {
+		index := playbackQueueIndex(queue, req.MusicID)
+		if index < 0 {
+			return false, errors.New("音乐不在播放队列中")
+		}
+		seconds := clampPlayedSeconds(req.PlayedSeconds, session, now)
+		if seconds > 0 || req.Finished {
+			if err := addTrackPlayTime(tx, session.ID, req.MusicID, seconds, req.Finished); err != nil {
+				return false, err
+			}
+		}
+		queueFinished = req.Finished && session.LoopMode == playbackLoopSequential && index == len(queue)-1
+	}
+
+	session.CurrentIndex = req.CurrentIndex
+	session.Position = req.Position
+	session.LastActiveAt = now
+	return queueFinished, nil
+}
+
+// creditCurrentTrack 将最后一次同步到 until 之间的时长计入当前曲目，不超过曲目剩余时长
+func (s *PlaybackService) creditCurrentTrack(tx *gorm.DB, session *baby.PlaybackSession, until time.Time) error {
+	queue, err := session.GetQueue()
+	if err != nil || session.CurrentIndex >= len(queue) {
+		return nil
+	}
+	seconds := int(until.Sub(session.LastActiveAt).Seconds())
+	if seconds <= 0 {
+		return nil
+	}
+
+	musicID := queue[session.CurrentIndex]
+	var music baby.Music
+	if err := tx.Select("id", "duration").Where("id = ?", musicID).First(&music).Error; err != nil {
+		return nil
+	}
+	if music.Duration > 0 && session.LoopMode != playbackLoopSingle {
+		remaining := music.Duration - session.Position
+		if remaining < 0 {
+			remaining = 0
+		}
+		if seconds > remaining {
+			seconds = remaining
+		}
+	}
+	if seconds == 0 {
+		return nil
+	}
+	return addTrackPlayTime(tx, session.ID, musicID, seconds, false)
+}
+
+// endSession 结束会话，并为每首播放过的音乐写入一条播放历史
+func (s *PlaybackService) endSession(tx *gorm.DB, session *baby.PlaybackSession, reason int, now time.Time) error {
+	session.Status = playbackStatusEnded
+	session.EndReason = reason
+	session.EndedAt = &now
+	if err := tx.Save(session).Error; err != nil {
+		return err
+	}
+
+	var tracks []baby.PlaybackSessionTrack
+	if err := tx.Where("session_id = ?", session.ID).Order("id ASC").Find(&tracks).Error; err != nil {
+		return err
+	}
+	var histories []baby.UserMusicHistory
+	var musicIDs []uint
+	for _, track := range tracks {
+		if track.PlayTime <= 0 && !track.IsFinished {
+			continue
+		}
+		histories = append(histories, baby.UserMusicHistory{
+			UserID:     session.UserID,
+			MusicID:    track.MusicID,
+			BabyID:     session.BabyID,
+			PlayTime:   track.PlayTime,
+			IsFinished: track.IsFinished,
+		})
+		musicIDs = append(musicIDs, track.MusicID)
+	}
+	if len(histories) == 0 {
+		return nil
+	}
+	if err := tx.Create(&histories).Error; err != nil {
+		return err
+	}
+	return tx.Model(&baby.Music{}).Where("id IN ?", musicIDs).
+		Update("play_count", gorm.Expr("play_count + ?", 1)).Error
+}
+
+// replaceSessions 结束用户或设备(column为user_id或device_id)除 exceptID 外未结束的会话
+func (s *PlaybackService) replaceSessions(tx *gorm.DB, column string, value uint, exceptID uint, now time.Time) error {
+	var sessions []baby.PlaybackSession
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(column+" = ? AND status <> ? AND id <> ?", value, playbackStatusEnded, exceptID).Find(&sessions).Error
+	if err != nil {
+		return err
+	}
+	for i := range sessions {
+		if err := s.endSession(tx, &sessions[i], playbackEndReplaced, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildQueue 根据播放列表或音乐ID生成播放队列，去掉重复、已下架和需开通会员的音乐
+func (s *PlaybackService) buildQueue(userID uint, req *request.StartPlaybackRequest) ([]uint, error) {
+	musicIDs := req.MusicIDs
+	if req.PlaylistID > 0 {
+		var playlist baby.Playlist
+		err := global.GVA_DB.Where("id = ? AND (user_id = ? OR is_public = ?)", req.PlaylistID, userID, true).First(&playlist).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("播放列表不存在")
+			}
+			return nil, err
+		}
+		items, err := getPlaylistItems(global.GVA_DB, playlist.ID)
+		if err != nil {
+			return nil, err
+		}
+		musicIDs = make([]uint, 0, len(items))
+		for _, item := range items {
+			musicIDs = append(musicIDs, item.MusicID)
+		}
+	}
+	if len(musicIDs) == 0 {
+		return nil, errors.New("请选择播放列表或音乐")
+	}
+
+	var musics []baby.Music
+	if err := global.GVA_DB.Select("id", vipColumn).Where("id IN ? AND is_active = ?", musicIDs, true).Find(&musics).Error; err != nil {
+		return nil, err
+	}
+	vip := newVipChecker(userID)
+	playable := make(map[uint]bool, len(musics))
+	for _, music := range musics {
+		playable[music.ID] = !vip.locked(music.IsVIP)
+	}
+
+	queue := make([]uint, 0, len(musicIDs))
+	seen := make(map[uint]bool, len(musicIDs))
+	for _, id := range musicIDs {
+		if playable[id] && !seen[id] {
+			seen[id] = true
+			queue = append(queue, id)
+		}
+	}
+	if len(queue) == 0 {
+		return nil, errors.New("没有可播放的音乐")
+	}
+	return queue, nil
+}
+
+// buildSessionResponse 组装会话响应，队列与会话中的位置一一对应，已下架或需开通会员的音乐锁定后返回
+func (s *PlaybackService) buildSessionResponse(session *baby.PlaybackSession, now time.Time) (*response.PlaybackSessionResponse, error) {
+	queue, err := session.GetQueue()
+	if err != nil {
+		return nil, errors.New("播放队列数据错误")
+	}
+
+	var musics []baby.Music
+	if len(queue) > 0 {
+		if err := global.GVA_DB.Where("id IN ?", queue).Find(&musics).Error; err != nil {
+			return nil, err
+		}
+	}
+	musicMap := make(map[uint]*baby.Music, len(musics))
+	for i := range musics {
+		musicMap[musics[i].ID] = &musics[i]
+	}
+
+	vip := newVipChecker(session.UserID)
+	musicResponses := make([]response.MusicResponse, 0, len(queue))
+	for _, id := range queue {
+		resp := response.MusicResponse{ID: id}
+		if music, ok := musicMap[id]; ok {
+			resp.FromMusic(music, "", false)
+			if music.IsActive {
+				vip.guardMusic(&resp, music.IsVIP)
+			} else {
+				resp.Lock()
+			}
+		} else {
+			resp.Lock()
+		}
+		musicResponses = append(musicResponses, resp)
+	}
+
+	var resp response.PlaybackSessionResponse
+	resp.FromPlaybackSession(session, musicResponses, now)
+	if session.DeviceID > 0 {
+		var device baby.Device
+		if err := global.GVA_DB.Select("id", "device_name").Where("id = ?", session.DeviceID).First(&device).Error; err == nil {
+			resp.DeviceName = device.DeviceName
+		}
+	}
+	return &resp, nil
+}
+
+// lockPlaybackSession 在事务中锁定操作方的会话：用户按归属查找，设备只能操作正在本设备上播放的会话
+func lockPlaybackSession(tx *gorm.DB, id uint, actor playbackActor) (*baby.PlaybackSession, error) {
+	db := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id)
+	if actor.deviceID > 0 {
+		db = db.Where("device_id = ?", actor.deviceID)
+	} else {
+		db = db.Where("user_id = ?", actor.userID)
+	}
+
+	var session baby.PlaybackSession
+	if err := db.First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("播放会话不存在")
+		}
+		return nil, err
+	}
+	if session.IsEnded() {
+		return nil, errors.New("播放会话已结束")
+	}
+	return &session, nil
+}
+
+// addTrackPlayTime 累加会话内单首音乐的播放时长，调用方需已锁定会话
+func addTrackPlayTime(tx *gorm.DB, sessionID uint, musicID uint, seconds int, finished bool) error {
+	var track baby.PlaybackSessionTrack
+	err := tx.Where("session_id = ? AND music_id = ?", sessionID, musicID).First(&track).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Create(&baby.PlaybackSessionTrack{
+			SessionID:  sessionID,
+			MusicID:    musicID,
+			PlayTime:   seconds,
+			IsFinished: finished,
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{"play_time": gorm.Expr("play_time + ?", seconds)}
+	if finished {
+		updates["is_finished"] = true
+	}
+	return tx.Model(&track).Updates(updates).Error
+}
+
+// authorizeSpeaker 校验用户可以控制该音响设备播放
+func authorizeSpeaker(deviceID uint, userID uint) (*baby.Device, error) {
+	device, err := authorizeDevice(deviceID, userID, deviceAccessControl)
+	if err != nil {
+		return nil, err
+	}
+	if device.DeviceType != deviceTypeSpeaker {
+		return nil, errors.New("仅音响设备支持播放音乐")
+	}
+	if !device.IsActive {
+		return nil, errors.New("设备已停用")
+	}
+	if !device.IsOnline() {
+		return nil, errors.New("设备不在线，无法播放")
+	}
+	return device, nil
+}
+
+// notifySpeaker 通过设备命令通知音响会话变更，音响收到后拉取会话；通知失败不影响会话本身
+func notifySpeaker(userID uint, deviceID uint, command string, sessionID uint) {
+	_, err := new(DeviceCommandService).SendCommand(userID, &request.DeviceCommandRequest{
+		DeviceID:    deviceID,
+		CommandType: 5,
+		Command:     command,
+		Parameters:  fmt.Sprintf(`{"session_id":%d}`, sessionID),
+	})
+	if err != nil {
+		global.GVA_LOG.Warn("通知音响播放会话变更失败", zap.Uint("deviceID", deviceID), zap.String("command", command), zap.Error(err))
+	}
+}
+
+// validateSleepTimer 淡出时长不能超过睡眠定时
+func validateSleepTimer(sleepMinutes int, fadeOutSeconds int) error {
+	if sleepMinutes > 0 && fadeOutSeconds > sleepMinutes*60 {
+		return errors.New("淡出时长不能超过睡眠定时")
+	}
+	return nil
+}
+
+// playbackSleepAt 睡眠定时的停止时间，不定时返回nil
+func playbackSleepAt(now time.Time, sleepMinutes int) *time.Time {
+	if sleepMinutes <= 0 {
+		return nil
+	}
+	sleepAt := now.Add(time.Duration(sleepMinutes) * time.Minute)
+	return &sleepAt
+}
+
+// playbackSleepDue 播放中的会话睡眠定时是否已到
+func playbackSleepDue(session *baby.PlaybackSession, now time.Time) bool {
+	return session.Status == playbackStatusPlaying && session.SleepAt != nil && !session.SleepAt.After(now)
+}
+
+// clampPlayedSeconds 上报的播放时长不能超过距上次同步实际经过的时间，暂停期间只允许少量误差
+func clampPlayedSeconds(reported int, session *baby.PlaybackSession, now time.Time) int {
+	if reported <= 0 {
+		return 0
+	}
+	limit := playbackReportSlack
+	if session.Status == playbackStatusPlaying {
+		limit += now.Sub(session.LastActiveAt)
+	}
+	if maxSeconds := int(limit.Seconds()); reported > maxSeconds {
+		return maxSeconds
+	}
+	return reported
+}
+
+// playbackQueueIndex 音乐在队列中的位置，不存在返回-1
+func playbackQueueIndex(queue []uint, musicID uint) int {
+	for i, id := range queue {
+		if id == musicID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,199 @@
+package baby
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"baby_admin/server/model/system"
+)
+
+func TestClampPlayedSeconds(t *testing.T) {
+	now := time.Date(2024, 5, 1, 21, 0, 0, 0, time.Local)
+	tests := []struct {
+		name     string
+		status   int
+		sinceAgo time.Duration
+		reported int
+		want     int
+	}{
+		{"正常上报", playbackStatusPlaying, 60 * time.Second, 58, 58},
+		{"允许少量误差", playbackStatusPlaying, 60 * time.Second, 68, 68},
+		{"超出实际间隔", playbackStatusPlaying, 60 * time.Second, 600, 70},
+		{"暂停期间", playbackStatusPaused, time.Hour, 600, 10},
+		{"负数", playbackStatusPlaying, time.Minute, -5, 0},
+	}
+	for _, tt := range tests {
+		session := &baby.PlaybackSession{Status: tt.status, LastActiveAt: now.Add(-tt.sinceAgo)}
+		if got := clampPlayedSeconds(tt.reported, session, now); got != tt.want {
+			t.Errorf("%s: clampPlayedSeconds = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPlaybackSleepTimer(t *testing.T) {
+	now := time.Date(2024, 5, 1, 20, 30, 0, 0, time.Local)
+
+	if playbackSleepAt(now, 0) != nil {
+		t.Errorf("sleep timer set without minutes")
+	}
+	sleepAt := playbackSleepAt(now, 30)
+	if sleepAt == nil || !sleepAt.Equal(now.Add(30*time.Minute)) {
+		t.Fatalf("playbackSleepAt = %v, want %v", sleepAt, now.Add(30*time.Minute))
+	}
+
+	if err := validateSleepTimer(30, 120); err != nil {
+		t.Errorf("valid fade-out rejected: %v", err)
+	}
+	if err := validateSleepTimer(1, 90); err == nil {
+		t.Errorf("fade-out longer than sleep timer accepted")
+	}
+
+	session := &baby.PlaybackSession{Status: playbackStatusPlaying, SleepAt: sleepAt, FadeOutSeconds: 120}
+	if playbackSleepDue(session, now.Add(29*time.Minute)) {
+		t.Errorf("sleep timer due before sleep_at")
+	}
+	if !playbackSleepDue(session, now.Add(30*time.Minute)) {
+		t.Errorf("sleep timer not due at sleep_at")
+	}
+	session.Status = playbackStatusPaused
+	if playbackSleepDue(session, now.Add(time.Hour)) {
+		t.Errorf("paused session reported due")
+	}
+
+	var resp response.PlaybackSessionResponse
+	session.Status = playbackStatusPlaying
+	resp.FromPlaybackSession(session, nil, now.Add(20*time.Minute))
+	if resp.FadeOutAt == nil || !resp.FadeOutAt.Equal(now.Add(28*time.Minute)) {
+		t.Errorf("fade_out_at = %v, want %v", resp.FadeOutAt, now.Add(28*time.Minute))
+	}
+	if resp.SleepRemaining != 600 {
+		t.Errorf("sleep_remaining = %d, want 600", resp.SleepRemaining)
+	}
+}
+
+func TestPlaybackQueue(t *testing.T) {
+	var session baby.PlaybackSession
+	if err := session.SetQueue([]uint{7, 3, 9}); err != nil {
+		t.Fatalf("SetQueue: %v", err)
+	}
+	queue, err := session.GetQueue()
+	if err != nil || len(queue) != 3 || queue[1] != 3 {
+		t.Fatalf("GetQueue = %v, %v", queue, err)
+	}
+	if got := playbackQueueIndex(queue, 9); got != 2 {
+		t.Errorf("playbackQueueIndex(9) = %d, want 2", got)
+	}
+	if got := playbackQueueIndex(queue, 1); got != -1 {
+		t.Errorf("playbackQueueIndex(1) = %d, want -1", got)
+	}
+}
+
+func TestDeviceResumeSessionHandoff(t *testing.T) {
+	db := setupTestDB(t, &baby.Device{}, &baby.DeviceShare{}, &baby.DeviceCommand{}, &baby.Music{},
+		&baby.PlaybackSession{}, &baby.PlaybackSessionTrack{})
+	transport := NewMockDeviceTransport()
+	SetDeviceTransport(transport)
+	t.Cleanup(func() { SetDeviceTransport(unconfiguredDeviceTransport{}) })
+
+	// 用户2在自己卧室的音响上播放，客厅音响属于用户1并分享给用户2控制
+	bedroom := baby.Device{UserID: 2, DeviceName: "卧室音响", DeviceType: deviceTypeSpeaker, ProductID: "speaker", DeviceSecret: "secret", Status: 1, IsActive: true}
+	living := baby.Device{UserID: 1, DeviceName: "客厅音响", DeviceType: deviceTypeSpeaker, ProductID: "speaker", DeviceSecret: "secret", Status: 1, IsActive: true}
+	for _, device := range []*baby.Device{&bedroom, &living} {
+		if err := db.Create(device).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	acceptedAt := time.Now().Add(-time.Hour)
+	share := baby.DeviceShare{DeviceID: living.ID, OwnerID: 1, SharedUserID: 2, ShareType: deviceAccessControl, IsActive: true, AcceptedAt: &acceptedAt}
+	music := baby.Music{Title: "摇篮曲", AudioURL: "https://cdn.example.com/lullaby.mp3", IsActive: true}
+	if err := db.Create(&share).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&music).Error; err != nil {
+		t.Fatal(err)
+	}
+	session := baby.PlaybackSession{UserID: 2, DeviceID: bedroom.ID, Status: playbackStatusPlaying, LastActiveAt: time.Now()}
+	if err := session.SetQueue([]uint{music.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&session).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := new(PlaybackService).DeviceResumeSession(&living)
+	if err != nil {
+		t.Fatalf("客厅音响应接续被分享用户的会话: %v", err)
+	}
+	if resp.ID != session.ID || resp.DeviceID != living.ID {
+		t.Fatalf("接续的会话 = %d 设备 %d, want %d 设备 %d", resp.ID, resp.DeviceID, session.ID, living.ID)
+	}
+
+	// 命令异步下发，等待原音响收到停止命令
+	var command baby.DeviceCommand
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		err := db.Where("device_id = ? AND command = ?", bedroom.ID, "playback_stop").First(&command).Error
+		if err == nil && command.Status == commandStatusSuccess {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("原音响未收到停止命令: %v, status %d", err, command.Status)
+		}
+	}
+	sent := transport.Sent()
+	if len(sent) != 1 || sent[0].DeviceID != bedroom.ID || sent[0].Command != "playback_stop" ||
+		sent[0].Parameters != fmt.Sprintf(`{"session_id":%d}`, session.ID) {
+		t.Fatalf("下发的命令 = %+v", sent)
+	}
+}
+
+func TestPlaybackBuildQueue(t *testing.T) {
+	db := setupTestDB(t, &baby.Music{}, &system.MiniprogramUser{})
+	expiredAt := time.Now().AddDate(0, 1, 0)
+	users := []system.MiniprogramUser{
+		{OpenID: "free", Username: "free", Email: "free@example.com"},
+		{OpenID: "vip", Username: "vip", Email: "vip@example.com", VipExpiredAt: &expiredAt},
+	}
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatal(err)
+	}
+	musics := []baby.Music{
+		{CategoryID: 1, Title: "摇篮曲", AudioURL: "music/lullaby.mp3", IsActive: true},
+		{CategoryID: 1, Title: "白噪音", AudioURL: "music/noise.mp3", IsActive: true, IsVIP: true},
+		{CategoryID: 1, Title: "已下架", AudioURL: "music/removed.mp3", IsActive: true},
+	}
+	if err := db.Create(&musics).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&musics[2]).Update("is_active", false).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []uint{musics[0].ID, musics[1].ID, musics[2].ID, musics[0].ID}
+	tests := []struct {
+		name   string
+		userID uint
+		want   []uint
+	}{
+		{"非会员跳过会员音乐", users[0].ID, []uint{musics[0].ID}},
+		{"会员", users[1].ID, []uint{musics[0].ID, musics[1].ID}},
+	}
+	service := new(PlaybackService)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue, err := service.buildQueue(tt.userID, &request.StartPlaybackRequest{MusicIDs: ids})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(queue, tt.want) {
+				t.Errorf("queue = %v, want %v", queue, tt.want)
+			}
+		})
+	}
+	if _, err := service.buildQueue(users[0].ID, &request.StartPlaybackRequest{MusicIDs: []uint{musics[1].ID}}); err == nil {
+		t.Error("没有可播放的音乐时应返回错误")
+	}
+}
@@ -0,0 +1,428 @@
+package baby
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	commonRequest "baby_admin/server/model/common/request"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxPlaylistMusicCount 单个播放列表最多包含的音乐数量
+const maxPlaylistMusicCount = 200
+
+// CreatePlaylist 创建播放列表
+func (s *MusicService) CreatePlaylist(userID uint, req *request.CreatePlaylistRequest) (*response.PlaylistResponse, error) {
+	playlist := &baby.Playlist{
+		UserID:      userID,
+		Name:        req.Name,
+		Description: req.Description,
+		CoverURL:    req.CoverURL,
+		IsPublic:    req.IsPublic,
+	}
+	if err := global.GVA_DB.Create(playlist).Error; err != nil {
+		return nil, err
+	}
+
+	var resp response.PlaylistResponse
+	resp.FromPlaylist(playlist, userID, 0)
+	return &resp, nil
+}
+
+// UpdatePlaylist 更新播放列表信息
+func (s *MusicService) UpdatePlaylist(userID uint, req *request.UpdatePlaylistRequest) error {
+	playlist, err := getUserPlaylist(global.GVA_DB, req.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	return global.GVA_DB.Model(playlist).Updates(map[string]interface{}{
+		"name":        req.Name,
+		"description": req.Description,
+		"cover_url":   req.CoverURL,
+		"is_public":   req.IsPublic,
+	}).Error
+}
+
+// DeletePlaylist 删除播放列表及其中的音乐关联
+func (s *MusicService) DeletePlaylist(userID uint, id uint) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		playlist, err := lockUserPlaylist(tx, id, userID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("playlist_id = ?", playlist.ID).Delete(&baby.PlaylistMusic{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(playlist).Error
+	})
+}
+
+// GetPlaylistDetail 获取播放列表详情，自己的或公开的播放列表可查看
+func (s *MusicService) GetPlaylistDetail(userID uint, id uint) (*response.PlaylistResponse, error) {
+	var playlist baby.Playlist
+	err := global.GVA_DB.Where("id = ? AND (user_id = ? OR is_public = ?)", id, userID, true).First(&playlist).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("播放列表不存在")
+		}
+		return nil, err
+	}
+
+	var items []baby.PlaylistMusic
+	if err := global.GVA_DB.Where("playlist_id = ?", playlist.ID).Order("sort_order ASC, id ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	var musicIDs []uint
+	for _, item := range items {
+		musicIDs = append(musicIDs, item.MusicID)
+	}
+
+	// 获取音乐信息，已下架的音乐不展示
+	var musics []baby.Music
+	if len(musicIDs) > 0 {
+		global.GVA_DB.Where("id IN ? AND is_active = ?", musicIDs, true).Find(&musics)
+	}
+
+	musicMap := make(map[uint]*baby.Music)
+	var categoryIDs []uint
+	for i := range musics {
+		musicMap[musics[i].ID] = &musics[i]
+		categoryIDs = append(categoryIDs, musics[i].CategoryID)
+	}
+
+	// 获取分类信息
+	var categories []baby.MusicCategory
+	if len(categoryIDs) > 0 {
+		global.GVA_DB.Where("id IN ?", categoryIDs).Find(&categories)
+	}
+
+	categoryMap := make(map[uint]string)
+	for _, category := range categories {
+		categoryMap[category.ID] = category.Name
+	}
+
+	// 获取用户收藏信息
+	var favorites []baby.UserMusicFavorite
+	if len(musicIDs) > 0 {
+		global.GVA_DB.Where("user_id = ? AND music_id IN ?", userID, musicIDs).Find(&favorites)
+	}
+
+	favoriteMap := make(map[uint]bool)
+	for _, favorite := range favorites {
+		favoriteMap[favorite.MusicID] = true
+	}
+
+	// 按播放列表顺序转换响应
+	vip := newVipChecker(userID)
+	musicResponses := []response.MusicResponse{}
+	duration := 0
+	for _, item := range items {
+		music, exists := musicMap[item.MusicID]
+		if !exists {
+			continue
+		}
+		var resp response.MusicResponse
+		resp.FromMusic(music, categoryMap[music.CategoryID], favoriteMap[music.ID])
+		vip.guardMusic(&resp, music.IsVIP)
+		musicResponses = append(musicResponses, resp)
+		duration += music.Duration
+	}
+
+	var resp response.PlaylistResponse
+	resp.FromPlaylist(&playlist, userID, duration)
+	resp.Musics = musicResponses
+	return &resp, nil
+}
+
+// GetMyPlaylists 获取当前用户的播放列表
+func (s *MusicService) GetMyPlaylists(userID uint, req *commonRequest.PageInfo) (*response.PlaylistListResponse, error) {
+	db := global.GVA_DB.Model(&baby.Playlist{}).Where("user_id = ?", userID)
+	return buildPlaylistList(db, userID, req.Page, req.PageSize, "updated_at DESC")
+}
+
+// GetPublicPlaylists 浏览公开的播放列表，按被复制次数排序
+func (s *MusicService) GetPublicPlaylists(userID uint, req *request.PlaylistSearch) (*response.PlaylistListResponse, error) {
+	db := global.GVA_DB.Model(&baby.Playlist{}).Where("is_public = ? AND music_count > ?", true, 0)
+	if req.Keyword != "" {
+		db = db.Where("name LIKE ? OR description LIKE ?", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
+	}
+	return buildPlaylistList(db, userID, req.Page, req.PageSize, "clone_count DESC, updated_at DESC")
+}
+
+// AddMusicToPlaylist 添加音乐到播放列表末尾，已在列表中的音乐跳过，返回实际添加的数量
+func (s *MusicService) AddMusicToPlaylist(userID uint, req *request.AddMusicToPlaylistRequest) (int, error) {
+	added := 0
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		playlist, err := lockUserPlaylist(tx, req.PlaylistID, userID)
+		if err != nil {
+			return err
+		}
+
+		items, err := getPlaylistItems(tx, playlist.ID)
+		if err != nil {
+			return err
+		}
+		exists := make(map[uint]bool)
+		for _, item := range items {
+			exists[item.MusicID] = true
+		}
+
+		// 过滤重复及已在列表中的音乐
+		var musicIDs []uint
+		for _, musicID := range req.MusicIDs {
+			if !exists[musicID] {
+				exists[musicID] = true
+				musicIDs = append(musicIDs, musicID)
+			}
+		}
+		if len(musicIDs) == 0 {
+			return nil
+		}
+		if len(items)+len(musicIDs) > maxPlaylistMusicCount {
+			return fmt.Errorf("播放列表最多包含%d首音乐", maxPlaylistMusicCount)
+		}
+
+		var count int64
+		if err := tx.Model(&baby.Music{}).Where("id IN ? AND is_active = ?", musicIDs, true).Count(&count).Error; err != nil {
+			return err
+		}
+		if int(count) != len(musicIDs) {
+			return errors.New("音乐不存在")
+		}
+
+		newItems := make([]baby.PlaylistMusic, len(musicIDs))
+		for i, musicID := range musicIDs {
+			newItems[i] = baby.PlaylistMusic{PlaylistID: playlist.ID, MusicID: musicID, SortOrder: len(items) + i + 1}
+		}
+		if err := tx.Create(&newItems).Error; err != nil {
+			return err
+		}
+		added = len(newItems)
+
+		return savePlaylistOrder(tx, playlist, append(items, newItems...))
+	})
+	return added, err
+}
+
+// RemoveMusicFromPlaylist 从播放列表移除音乐，剩余音乐重新连续排序
+func (s *MusicService) RemoveMusicFromPlaylist(userID uint, req *request.RemoveMusicFromPlaylistRequest) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		playlist, err := lockUserPlaylist(tx, req.PlaylistID, userID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Where("playlist_id = ? AND music_id IN ?", playlist.ID, req.MusicIDs).
+			Delete(&baby.PlaylistMusic{}).Error
+		if err != nil {
+			return err
+		}
+
+		items, err := getPlaylistItems(tx, playlist.ID)
+		if err != nil {
+			return err
+		}
+		return savePlaylistOrder(tx, playlist, items)
+	})
+}
+
+// ReorderPlaylist 按传入顺序重排播放列表，必须包含列表中的全部音乐
+func (s *MusicService) ReorderPlaylist(userID uint, req *request.ReorderPlaylistRequest) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		playlist, err := lockUserPlaylist(tx, req.PlaylistID, userID)
+		if err != nil {
+			return err
+		}
+
+		items, err := getPlaylistItems(tx, playlist.ID)
+		if err != nil {
+			return err
+		}
+		ordered, err := reorderPlaylistItems(items, req.MusicIDs)
+		if err != nil {
+			return err
+		}
+		return savePlaylistOrder(tx, playlist, ordered)
+	})
+}
+
+// ClonePlaylist 复制公开的播放列表到自己名下，复制出的列表默认不公开
+func (s *MusicService) ClonePlaylist(userID uint, req *request.ClonePlaylistRequest) (*response.PlaylistResponse, error) {
+	var clone baby.Playlist
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var source baby.Playlist
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND (user_id = ? OR is_public = ?)", req.PlaylistID, userID, true).First(&source).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("播放列表不存在")
+			}
+			return err
+		}
+
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			name = source.Name
+		}
+		clone = baby.Playlist{
+			UserID:      userID,
+			Name:        name,
+			Description: source.Description,
+			CoverURL:    source.CoverURL,
+			SourceID:    source.ID,
+		}
+		if err := tx.Create(&clone).Error; err != nil {
+			return err
+		}
+
+		items, err := getPlaylistItems(tx, source.ID)
+		if err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			cloneItems := make([]baby.PlaylistMusic, len(items))
+			for i, item := range items {
+				cloneItems[i] = baby.PlaylistMusic{PlaylistID: clone.ID, MusicID: item.MusicID, SortOrder: i + 1}
+			}
+			if err := tx.Create(&cloneItems).Error; err != nil {
+				return err
+			}
+			if err := savePlaylistOrder(tx, &clone, cloneItems); err != nil {
+				return err
+			}
+		}
+
+		// 复制自己的播放列表不计入被复制次数
+		if source.UserID != userID {
+			return tx.Model(&source).UpdateColumn("clone_count", gorm.Expr("clone_count + ?", 1)).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPlaylistDetail(userID, clone.ID)
+}
+
+// getUserPlaylist 获取当前用户的播放列表
+func getUserPlaylist(db *gorm.DB, id uint, userID uint) (*baby.Playlist, error) {
+	var playlist baby.Playlist
+	if err := db.Where("id = ? AND user_id = ?", id, userID).First(&playlist).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("播放列表不存在")
+		}
+		return nil, err
+	}
+	return &playlist, nil
+}
+
+// lockUserPlaylist 在事务中锁定当前用户的播放列表，串行化对同一列表的修改
+func lockUserPlaylist(tx *gorm.DB, id uint, userID uint) (*baby.Playlist, error) {
+	return getUserPlaylist(tx.Clauses(clause.Locking{Strength: "UPDATE"}), id, userID)
+}
+
+// getPlaylistItems 按顺序获取播放列表中的音乐关联
+func getPlaylistItems(tx *gorm.DB, playlistID uint) ([]baby.PlaylistMusic, error) {
+	var items []baby.PlaylistMusic
+	err := tx.Where("playlist_id = ?", playlistID).Order("sort_order ASC, id ASC").Find(&items).Error
+	return items, err
+}
+
+// reorderPlaylistItems 按musicIDs的顺序排列items，musicIDs必须与列表中的音乐一一对应
+func reorderPlaylistItems(items []baby.PlaylistMusic, musicIDs []uint) ([]baby.PlaylistMusic, error) {
+	if len(musicIDs) != len(items) {
+		return nil, errors.New("排序列表与播放列表中的音乐不一致")
+	}
+
+	itemMap := make(map[uint]baby.PlaylistMusic, len(items))
+	for _, item := range items {
+		itemMap[item.MusicID] = item
+	}
+
+	ordered := make([]baby.PlaylistMusic, 0, len(musicIDs))
+	for _, musicID := range musicIDs {
+		item, exists := itemMap[musicID]
+		if !exists {
+			return nil, errors.New("排序列表与播放列表中的音乐不一致")
+		}
+		delete(itemMap, musicID)
+		ordered = append(ordered, item)
+	}
+	return ordered, nil
+}
+
+// savePlaylistOrder 按items的顺序将排序权重更新为1..n，并同步播放列表的音乐数量
+func savePlaylistOrder(tx *gorm.DB, playlist *baby.Playlist, items []baby.PlaylistMusic) error {
+	for i := range items {
+		if items[i].SortOrder == i+1 {
+			continue
+		}
+		if err := tx.Model(&items[i]).UpdateColumn("sort_order", i+1).Error; err != nil {
+			return err
+		}
+	}
+
+	playlist.MusicCount = len(items)
+	return tx.Model(playlist).Update("music_count", playlist.MusicCount).Error
+}
+
+// buildPlaylistList 分页查询播放列表并统计各列表总时长
+func buildPlaylistList(db *gorm.DB, userID uint, page int, pageSize int, order string) (*response.PlaylistListResponse, error) {
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var playlists []baby.Playlist
+	offset := (page - 1) * pageSize
+	if err := db.Offset(offset).Limit(pageSize).Order(order).Find(&playlists).Error; err != nil {
+		return nil, err
+	}
+
+	var playlistIDs []uint
+	for _, playlist := range playlists {
+		playlistIDs = append(playlistIDs, playlist.ID)
+	}
+
+	// 统计各播放列表中在架音乐的总时长
+	durationMap := make(map[uint]int)
+	if len(playlistIDs) > 0 {
+		var rows []struct {
+			PlaylistID uint
+			Duration   int
+		}
+		err := global.GVA_DB.Model(&baby.PlaylistMusic{}).
+			Select("playlist_musics.playlist_id, SUM(musics.duration) AS duration").
+			Joins("JOIN musics ON musics.id = playlist_musics.music_id AND musics.is_active = ? AND musics.deleted_at IS NULL", true).
+			Where("playlist_musics.playlist_id IN ?", playlistIDs).
+			Group("playlist_musics.playlist_id").Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			durationMap[row.PlaylistID] = row.Duration
+		}
+	}
+
+	list := []response.PlaylistResponse{}
+	for i := range playlists {
+		var resp response.PlaylistResponse
+		resp.FromPlaylist(&playlists[i], userID, durationMap[playlists[i].ID])
+		list = append(list, resp)
+	}
+
+	return &response.PlaylistListResponse{
+		List:     list,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
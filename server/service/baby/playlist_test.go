@@ -0,0 +1,31 @@
+package baby
+
+import (
+	"testing"
+	"baby_admin/server/model/baby"
+)
+
+func TestReorderPlaylistItems(t *testing.T) {
+	items := []baby.PlaylistMusic{
+		{MusicID: 11, SortOrder: 1},
+		{MusicID: 12, SortOrder: 2},
+		{MusicID: 13, SortOrder: 5},
+	}
+
+	ordered, err := reorderPlaylistItems(items, []uint{13, 11, 12})
+	if err != nil {
+		t.Fatalf("reorder: %v", err)
+	}
+	for i, want := range []uint{13, 11, 12} {
+		if ordered[i].MusicID != want {
+			t.Errorf("position %d = %d, want %d", i, ordered[i].MusicID, want)
+		}
+	}
+
+	// 缺少、重复或多出的音乐均视为不一致
+	for _, musicIDs := range [][]uint{{13, 11}, {13, 11, 11}, {13, 11, 12, 14}, {13, 11, 14}} {
+		if _, err := reorderPlaylistItems(items, musicIDs); err == nil {
+			t.Errorf("reorder %v: expected error", musicIDs)
+		}
+	}
+}
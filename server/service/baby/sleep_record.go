@@ -0,0 +1,553 @@
+package baby
+
+import (
+	"errors"
+	"math"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SleepRecordService struct{}
+
+// 睡眠记录状态，对应SleepRecord.Status
+const (
+	sleepStatusInProgress = 1 // 进行中
+	sleepStatusCompleted  = 2 // 已完成
+	sleepStatusAbnormal   = 3 // 异常
+)
+
+// 夜间睡眠时段为19:00至次日07:00，其余时段计为小睡
+const (
+	nightStartHour = 19
+	nightEndHour   = 7
+)
+
+// StartSleep 开始一次睡眠
+func (s *SleepRecordService) StartSleep(userID uint, req *request.StartSleepRequest) (*response.SleepRecordResponse, error) {
+	babyProfile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	// 关联设备时需有权查看该设备的监控数据
+	if req.DeviceID > 0 {
+		if _, err := authorizeDevice(req.DeviceID, userID, deviceAccessRead); err != nil {
+			return nil, err
+		}
+	}
+
+	startTime := time.Now()
+	if req.StartTime != nil {
+		startTime = *req.StartTime
+	}
+	if startTime.After(time.Now()) {
+		return nil, errors.New("开始时间不能晚于当前时间")
+	}
+
+	record := &baby.SleepRecord{
+		UserID:      userID,
+		BabyID:      req.BabyID,
+		DeviceID:    req.DeviceID,
+		StartTime:   startTime,
+		Temperature: req.Temperature,
+		Humidity:    req.Humidity,
+		NoiseLevel:  req.NoiseLevel,
+		Status:      sleepStatusInProgress,
+		Notes:       req.Notes,
+	}
+	if err := s.saveWithoutOverlap(record); err != nil {
+		return nil, err
+	}
+
+	var resp response.SleepRecordResponse
+	resp.FromSleepRecord(record, babyProfile.Name)
+	return &resp, nil
+}
+
+// EndSleep 结束进行中的睡眠
+func (s *SleepRecordService) EndSleep(userID uint, req *request.EndSleepRequest) (*response.SleepRecordResponse, error) {
+	record, babyProfile, err := s.authorizeSleepTimer(req.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if record.Status != sleepStatusInProgress || record.EndTime != nil {
+		return nil, errors.New("该睡眠已结束")
+	}
+
+	endTime := time.Now()
+	if req.EndTime != nil {
+		endTime = *req.EndTime
+	}
+
+	record.EndTime = &endTime
+	record.Quality = req.Quality
+	record.DeepSleep = req.DeepSleep
+	record.LightSleep = req.LightSleep
+	record.AwakeCount = req.AwakeCount
+	record.Status = sleepStatusCompleted
+	if req.Notes != "" {
+		record.Notes = req.Notes
+	}
+	if err := s.saveWithoutOverlap(record); err != nil {
+		return nil, err
+	}
+
+	var resp response.SleepRecordResponse
+	resp.FromSleepRecord(record, babyProfile.Name)
+	return &resp, nil
+}
+
+// CreateSleepRecord 补录睡眠记录，未填写结束时间时作为进行中的睡眠
+func (s *SleepRecordService) CreateSleepRecord(userID uint, req *request.CreateSleepRecordRequest) error {
+	if _, err := getUserBaby(req.BabyID, userID); err != nil {
+		return err
+	}
+	if req.DeviceID > 0 {
+		if _, err := authorizeDevice(req.DeviceID, userID, deviceAccessRead); err != nil {
+			return err
+		}
+	}
+
+	record := &baby.SleepRecord{
+		UserID:      userID,
+		BabyID:      req.BabyID,
+		DeviceID:    req.DeviceID,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Quality:     req.Quality,
+		DeepSleep:   req.DeepSleep,
+		LightSleep:  req.LightSleep,
+		AwakeCount:  req.AwakeCount,
+		Temperature: req.Temperature,
+		Humidity:    req.Humidity,
+		NoiseLevel:  req.NoiseLevel,
+		Status:      sleepStatusInProgress,
+		Notes:       req.Notes,
+	}
+	if req.EndTime != nil {
+		record.Status = sleepStatusCompleted
+	}
+	if record.StartTime.After(time.Now()) {
+		return errors.New("开始时间不能晚于当前时间")
+	}
+
+	return s.saveWithoutOverlap(record)
+}
+
+// GetSleepRecord 获取睡眠记录详情
+func (s *SleepRecordService) GetSleepRecord(id uint, userID uint) (*response.SleepRecordResponse, error) {
+	record, babyProfile, err := s.authorizeSleepRecord(id, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp response.SleepRecordResponse
+	resp.FromSleepRecord(record, babyProfile.Name)
+	return &resp, nil
+}
+
+// GetCurrentSleep 获取宝宝进行中的睡眠，没有时返回nil
+func (s *SleepRecordService) GetCurrentSleep(userID uint, babyID uint) (*response.SleepRecordResponse, error) {
+	babyProfile, _, err := authorizeBaby(babyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var record baby.SleepRecord
+	err = global.GVA_DB.Where("baby_id = ? AND status = ? AND end_time IS NULL", babyID, sleepStatusInProgress).
+		Order("start_time DESC").First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var resp response.SleepRecordResponse
+	resp.FromSleepRecord(&record, babyProfile.Name)
+	return &resp, nil
+}
+
+// GetSleepRecordList 获取睡眠记录列表，包含家庭其他成员添加的记录
+func (s *SleepRecordService) GetSleepRecordList(userID uint, req *request.SleepRecordSearch) (*response.SleepRecordListResponse, error) {
+	db := global.GVA_DB.Model(&baby.SleepRecord{}).Where("baby_id IN (?)", accessibleBabyIDs(userID, babyAccessRead))
+
+	// 搜索条件
+	if req.BabyID > 0 {
+		db = db.Where("baby_id = ?", req.BabyID)
+	}
+	if req.Status > 0 {
+		db = db.Where("status = ?", req.Status)
+	}
+	if req.StartDate != "" {
+		db = db.Where("start_time >= ?", req.StartDate)
+	}
+	if req.EndDate != "" {
+		// 仅传日期时包含结束当天的全部记录
+		if endDate, err := time.ParseInLocation("2006-01-02", req.EndDate, time.Local); err == nil {
+			db = db.Where("start_time < ?", endDate.AddDate(0, 0, 1))
+		} else {
+			db = db.Where("start_time <= ?", req.EndDate)
+		}
+	}
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var records []baby.SleepRecord
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order("start_time DESC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取所有相关的宝宝信息
+	var babyIDs []uint
+	for _, record := range records {
+		babyIDs = append(babyIDs, record.BabyID)
+	}
+
+	var babies []baby.BabyProfile
+	if len(babyIDs) > 0 {
+		global.GVA_DB.Where("id IN ?", babyIDs).Find(&babies)
+	}
+
+	babyNameMap := make(map[uint]string)
+	for _, babyProfile := range babies {
+		babyNameMap[babyProfile.ID] = babyProfile.Name
+	}
+
+	// 转换响应
+	list := make([]response.SleepRecordResponse, 0, len(records))
+	for _, record := range records {
+		var resp response.SleepRecordResponse
+		resp.FromSleepRecord(&record, babyNameMap[record.BabyID])
+		list = append(list, resp)
+	}
+
+	return &response.SleepRecordListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// UpdateSleepRecord 修正睡眠记录
+func (s *SleepRecordService) UpdateSleepRecord(userID uint, req *request.UpdateSleepRecordRequest) error {
+	record, _, err := s.authorizeSleepRecord(req.ID, userID, babyAccessRecord)
+	if err != nil {
+		return err
+	}
+
+	if req.StartTime != nil {
+		record.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		record.EndTime = req.EndTime
+	}
+	record.Quality = req.Quality
+	record.DeepSleep = req.DeepSleep
+	record.LightSleep = req.LightSleep
+	record.AwakeCount = req.AwakeCount
+	record.Temperature = req.Temperature
+	record.Humidity = req.Humidity
+	record.NoiseLevel = req.NoiseLevel
+	record.Notes = req.Notes
+
+	// 有结束时间的记录只能是已完成或异常
+	if record.EndTime != nil {
+		record.Status = sleepStatusCompleted
+		if req.Status == sleepStatusAbnormal {
+			record.Status = sleepStatusAbnormal
+		}
+	}
+
+	return s.saveWithoutOverlap(record)
+}
+
+// DeleteSleepRecord 删除睡眠记录
+func (s *SleepRecordService) DeleteSleepRecord(id uint, userID uint) error {
+	record, _, err := s.authorizeSleepRecord(id, userID, babyAccessRecord)
+	if err != nil {
+		return err
+	}
+
+	return global.GVA_DB.Delete(record).Error
+}
+
+// GetSleepStatistics 获取宝宝的睡眠统计，包括每日总时长、最长连续睡眠、夜间与小睡拆分及趋势
+func (s *SleepRecordService) GetSleepStatistics(userID uint, req *request.SleepStatisticsRequest) (*response.SleepAnalytics, error) {
+	babyProfile, _, err := authorizeBaby(req.BabyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	days := req.Days
+	if days <= 0 {
+		days = 7
+	}
+	now := time.Now()
+	endDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if req.EndDate != "" {
+		endDay, err = time.ParseInLocation("2006-01-02", req.EndDate, time.Local)
+		if err != nil {
+			return nil, errors.New("截止日期格式错误")
+		}
+	}
+	rangeStart := endDay.AddDate(0, 0, -(days - 1))
+	rangeEnd := endDay.AddDate(0, 0, 1)
+
+	// 与统计区间有交集的有效睡眠
+	var records []baby.SleepRecord
+	err = global.GVA_DB.Where("baby_id = ? AND status <> ?", req.BabyID, sleepStatusAbnormal).
+		Where("start_time < ? AND (end_time IS NULL OR end_time > ?)", rangeEnd, rangeStart).
+		Order("start_time ASC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := summarizeSleep(records, rangeStart, days, now)
+	analytics.BabyID = req.BabyID
+
+	for i := range records {
+		if records[i].Status == sleepStatusInProgress && records[i].EndTime == nil {
+			var resp response.SleepRecordResponse
+			resp.FromSleepRecord(&records[i], babyProfile.Name)
+			analytics.InProgressSession = &resp
+		}
+	}
+
+	return analytics, nil
+}
+
+// summarizeSleep 按日汇总睡眠；跨天、跨夜间时段的睡眠按实际时长拆分，进行中的睡眠计算到当前时间
+func summarizeSleep(records []baby.SleepRecord, rangeStart time.Time, days int, now time.Time) *response.SleepAnalytics {
+	rangeEnd := rangeStart.AddDate(0, 0, days)
+	analytics := &response.SleepAnalytics{
+		StartDate: rangeStart.Format("2006-01-02"),
+		EndDate:   rangeEnd.AddDate(0, 0, -1).Format("2006-01-02"),
+		Days:      days,
+		Daily:     make([]response.SleepDailySummary, days),
+	}
+
+	dayIndex := make(map[string]int, days)
+	for i := 0; i < days; i++ {
+		date := rangeStart.AddDate(0, 0, i).Format("2006-01-02")
+		analytics.Daily[i].Date = date
+		dayIndex[date] = i
+	}
+
+	nightSeconds := make([]float64, days)
+	napSeconds := make([]float64, days)
+	var qualitySum, qualityCount int
+
+	for _, record := range records {
+		end := now
+		if record.EndTime != nil {
+			end = *record.EndTime
+		}
+		if !end.After(record.StartTime) {
+			continue
+		}
+
+		// 次数和最长连续睡眠计入开始当天
+		if i, ok := dayIndex[record.StartTime.In(rangeStart.Location()).Format("2006-01-02")]; ok {
+			minutes := int(end.Sub(record.StartTime).Minutes())
+			analytics.Daily[i].SessionCount++
+			if minutes > analytics.Daily[i].LongestStretch {
+				analytics.Daily[i].LongestStretch = minutes
+			}
+			if minutes > analytics.LongestStretch {
+				analytics.LongestStretch = minutes
+			}
+			if record.Quality > 0 {
+				qualitySum += record.Quality
+				qualityCount++
+			}
+		}
+
+		// 时长按日期和夜间时段拆分
+		start := record.StartTime.In(rangeStart.Location())
+		if start.Before(rangeStart) {
+			start = rangeStart
+		}
+		if end.After(rangeEnd) {
+			end = rangeEnd
+		}
+		splitSleepInterval(start, end, func(date string, night bool, seconds float64) {
+			i, ok := dayIndex[date]
+			if !ok {
+				return
+			}
+			if night {
+				nightSeconds[i] += seconds
+			} else {
+				napSeconds[i] += seconds
+			}
+		})
+	}
+
+	var totalSum, nightSum, napSum int
+	for i := range analytics.Daily {
+		analytics.Daily[i].NightMinutes = int(math.Round(nightSeconds[i] / 60))
+		analytics.Daily[i].NapMinutes = int(math.Round(napSeconds[i] / 60))
+		analytics.Daily[i].TotalMinutes = analytics.Daily[i].NightMinutes + analytics.Daily[i].NapMinutes
+		totalSum += analytics.Daily[i].TotalMinutes
+		nightSum += analytics.Daily[i].NightMinutes
+		napSum += analytics.Daily[i].NapMinutes
+	}
+
+	analytics.AverageTotal = totalSum / days
+	analytics.AverageNight = nightSum / days
+	analytics.AverageNap = napSum / days
+	if qualityCount > 0 {
+		analytics.AverageQuality = math.Round(float64(qualitySum)/float64(qualityCount)*10) / 10
+	}
+	analytics.SleepTrend = sleepTrend(analytics.Daily)
+	return analytics
+}
+
+// splitSleepInterval 将睡眠区间在零点、07:00和19:00处切分，逐段回调所属日期和是否夜间
+func splitSleepInterval(start, end time.Time, fn func(date string, night bool, seconds float64)) {
+	for cur := start; cur.Before(end); {
+		next := nextSleepBoundary(cur)
+		if next.After(end) {
+			next = end
+		}
+		hour := cur.Hour()
+		night := hour >= nightStartHour || hour < nightEndHour
+		fn(cur.Format("2006-01-02"), night, next.Sub(cur).Seconds())
+		cur = next
+	}
+}
+
+// nextSleepBoundary 返回t之后最近的切分点
+func nextSleepBoundary(t time.Time) time.Time {
+	for _, hour := range []int{nightEndHour, nightStartHour} {
+		boundary := time.Date(t.Year(), t.Month(), t.Day(), hour, 0, 0, 0, t.Location())
+		if boundary.After(t) {
+			return boundary
+		}
+	}
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+}
+
+// sleepTrend 比较前后两半区间的日均睡眠，变化超过10%视为上升或下降
+func sleepTrend(daily []response.SleepDailySummary) string {
+	half := len(daily) / 2
+	if half == 0 {
+		return "稳定"
+	}
+
+	var first, second int
+	for i, day := range daily {
+		if i < half {
+			first += day.TotalMinutes
+		} else if i >= len(daily)-half {
+			second += day.TotalMinutes
+		}
+	}
+	switch {
+	case first == 0 && second == 0:
+		return "稳定"
+	case float64(second) > float64(first)*1.1:
+		return "上升"
+	case float64(second) < float64(first)*0.9:
+		return "下降"
+	default:
+		return "稳定"
+	}
+}
+
+// authorizeSleepRecord 校验用户对睡眠记录的访问权限，返回记录及所属宝宝
+// 家庭成员均可查看；修改、删除他人添加的记录需要家长及以上角色
+func (s *SleepRecordService) authorizeSleepRecord(id uint, userID uint, level int) (*baby.SleepRecord, *baby.BabyProfile, error) {
+	record, err := s.loadSleepRecord(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	profile, _, err := authorizeBabyRecord(record.BabyID, record.UserID, userID, level, "睡眠记录不存在")
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, profile, nil
+}
+
+// authorizeSleepTimer 校验用户能否结束进行中的睡眠，家庭中看护人及以上角色均可结束他人开始的睡眠
+func (s *SleepRecordService) authorizeSleepTimer(id uint, userID uint) (*baby.SleepRecord, *baby.BabyProfile, error) {
+	record, err := s.loadSleepRecord(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	profile, access, err := authorizeBabyRecord(record.BabyID, record.UserID, userID, babyAccessRead, "睡眠记录不存在")
+	if err != nil {
+		return nil, nil, err
+	}
+	if access < babyAccessRecord {
+		return nil, nil, errors.New("家庭角色权限不足")
+	}
+	return record, profile, nil
+}
+
+// loadSleepRecord 按ID获取睡眠记录
+func (s *SleepRecordService) loadSleepRecord(id uint) (*baby.SleepRecord, error) {
+	var record baby.SleepRecord
+	err := global.GVA_DB.Where("id = ?", id).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("睡眠记录不存在")
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// saveWithoutOverlap 校验时间并保存睡眠记录，同一宝宝的睡眠时段不能重叠
+func (s *SleepRecordService) saveWithoutOverlap(record *baby.SleepRecord) error {
+	if record.EndTime != nil {
+		if !record.EndTime.After(record.StartTime) {
+			return errors.New("结束时间必须晚于开始时间")
+		}
+		if record.EndTime.After(time.Now().Add(time.Minute)) {
+			return errors.New("结束时间不能晚于当前时间")
+		}
+		record.Duration = int(record.EndTime.Sub(record.StartTime).Minutes())
+		if record.DeepSleep+record.LightSleep > record.Duration {
+			return errors.New("深睡与浅睡时长之和不能超过睡眠总时长")
+		}
+	} else {
+		record.Duration = 0
+	}
+
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		// 锁定宝宝档案，串行化同一宝宝的睡眠写入
+		var babyProfile baby.BabyProfile
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", record.BabyID).First(&babyProfile).Error; err != nil {
+			return err
+		}
+
+		db := tx.Model(&baby.SleepRecord{}).Where("baby_id = ? AND id <> ?", record.BabyID, record.ID)
+		if record.EndTime != nil {
+			db = db.Where("start_time < ?", *record.EndTime)
+		}
+		var count int64
+		err := db.Where("(end_time IS NULL OR end_time > ?)", record.StartTime).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("该时段与宝宝已有的睡眠记录重叠")
+		}
+
+		return tx.Save(record).Error
+	})
+}
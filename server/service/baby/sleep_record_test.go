@@ -0,0 +1,84 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/response"
+)
+
+func TestSummarizeSleep(t *testing.T) {
+	loc := time.Local
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 5, day, hour, minute, 0, 0, loc)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	records := []baby.SleepRecord{
+		// 跨零点的夜间睡眠：1日20:00-2日06:00，1日计240分钟，2日计360分钟
+		{StartTime: at(1, 20, 0), EndTime: ptr(at(2, 6, 0)), Quality: 8},
+		// 2日小睡：13:00-14:30
+		{StartTime: at(2, 13, 0), EndTime: ptr(at(2, 14, 30)), Quality: 6},
+		// 跨越夜间边界的小睡：2日18:00-19:30，小睡60分钟，夜间30分钟
+		{StartTime: at(2, 18, 0), EndTime: ptr(at(2, 19, 30))},
+		// 进行中的睡眠：3日05:00开始，统计到06:00
+		{StartTime: at(3, 5, 0), Status: sleepStatusInProgress},
+	}
+
+	analytics := summarizeSleep(records, at(1, 0, 0), 3, at(3, 6, 0))
+
+	want := []struct {
+		total, night, nap, longest, sessions int
+	}{
+		{240, 240, 0, 600, 1},
+		{540, 390, 150, 90, 2},
+		{60, 60, 0, 60, 1},
+	}
+	for i, w := range want {
+		got := analytics.Daily[i]
+		if got.TotalMinutes != w.total || got.NightMinutes != w.night || got.NapMinutes != w.nap ||
+			got.LongestStretch != w.longest || got.SessionCount != w.sessions {
+			t.Errorf("第%d天汇总 = %+v, want %+v", i+1, got, w)
+		}
+	}
+
+	if analytics.LongestStretch != 600 {
+		t.Errorf("最长连续睡眠 = %d, want 600", analytics.LongestStretch)
+	}
+	if analytics.AverageTotal != (240+540+60)/3 {
+		t.Errorf("日均睡眠 = %d", analytics.AverageTotal)
+	}
+	if analytics.AverageQuality != 7 {
+		t.Errorf("平均质量 = %v, want 7", analytics.AverageQuality)
+	}
+	if analytics.StartDate != "2024-05-01" || analytics.EndDate != "2024-05-03" {
+		t.Errorf("统计区间 = %s ~ %s", analytics.StartDate, analytics.EndDate)
+	}
+}
+
+func TestSleepTrend(t *testing.T) {
+	days := func(totals ...int) []response.SleepDailySummary {
+		daily := make([]response.SleepDailySummary, len(totals))
+		for i, total := range totals {
+			daily[i].TotalMinutes = total
+		}
+		return daily
+	}
+
+	tests := []struct {
+		name  string
+		daily []response.SleepDailySummary
+		want  string
+	}{
+		{"单日", days(600), "稳定"},
+		{"无记录", days(0, 0, 0, 0), "稳定"},
+		{"上升", days(600, 600, 600, 700, 700, 700, 700), "上升"},
+		{"下降", days(700, 700, 700, 700, 600, 600, 600), "下降"},
+		{"波动较小", days(600, 620, 610, 600, 630, 600, 640), "稳定"},
+	}
+	for _, tt := range tests {
+		if got := sleepTrend(tt.daily); got != tt.want {
+			t.Errorf("%s: sleepTrend() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
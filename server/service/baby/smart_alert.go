@@ -0,0 +1,348 @@
+package baby
+
+import (
+	"errors"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+type SmartAlertService struct{}
+
+// defaultAlertCooldown 规则未设置冷却时间时的默认值
+const defaultAlertCooldown = 10 * time.Minute
+
+// CreateAlertRule 创建警报规则
+func (s *SmartAlertService) CreateAlertRule(userID uint, req *request.CreateAlertRuleRequest) (*response.AlertRuleResponse, error) {
+	if err := s.validateAlertRule(userID, req); err != nil {
+		return nil, err
+	}
+
+	rule := &baby.AlertRule{
+		UserID:          userID,
+		BabyID:          req.BabyID,
+		DeviceID:        req.DeviceID,
+		Name:            req.Name,
+		RuleType:        req.RuleType,
+		MinValue:        req.MinValue,
+		MaxValue:        req.MaxValue,
+		DurationSeconds: req.DurationSeconds,
+		AlertLevel:      req.AlertLevel,
+		CooldownMinutes: req.CooldownMinutes,
+		IsEnabled:       true,
+	}
+	if req.IsEnabled != nil {
+		rule.IsEnabled = *req.IsEnabled
+	}
+	if err := global.GVA_DB.Create(rule).Error; err != nil {
+		return nil, err
+	}
+
+	list := s.buildRuleResponses([]baby.AlertRule{*rule})
+	return &list[0], nil
+}
+
+// GetAlertRuleList 获取当前用户的警报规则
+func (s *SmartAlertService) GetAlertRuleList(userID uint, req *request.AlertRuleSearch) ([]response.AlertRuleResponse, error) {
+	db := global.GVA_DB.Where("user_id = ?", userID)
+	if req.BabyID > 0 {
+		db = db.Where("baby_id = ?", req.BabyID)
+	}
+	if req.DeviceID > 0 {
+		db = db.Where("device_id = ?", req.DeviceID)
+	}
+	if req.RuleType > 0 {
+		db = db.Where("rule_type = ?", req.RuleType)
+	}
+
+	var rules []baby.AlertRule
+	if err := db.Order("created_at DESC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return s.buildRuleResponses(rules), nil
+}
+
+// UpdateAlertRule 更新警报规则
+func (s *SmartAlertService) UpdateAlertRule(userID uint, req *request.UpdateAlertRuleRequest) error {
+	var rule baby.AlertRule
+	err := global.GVA_DB.Where("id = ? AND user_id = ?", req.ID, userID).First(&rule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("警报规则不存在")
+		}
+		return err
+	}
+
+	if err := s.validateAlertRule(userID, &req.CreateAlertRuleRequest); err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"baby_id":          req.BabyID,
+		"device_id":        req.DeviceID,
+		"name":             req.Name,
+		"rule_type":        req.RuleType,
+		"min_value":        req.MinValue,
+		"max_value":        req.MaxValue,
+		"duration_seconds": req.DurationSeconds,
+		"alert_level":      req.AlertLevel,
+		"cooldown_minutes": req.CooldownMinutes,
+	}
+	if req.IsEnabled != nil {
+		updates["is_enabled"] = *req.IsEnabled
+	}
+	return global.GVA_DB.Model(&rule).Updates(updates).Error
+}
+
+// DeleteAlertRule 删除警报规则
+func (s *SmartAlertService) DeleteAlertRule(id uint, userID uint) error {
+	var rule baby.AlertRule
+	err := global.GVA_DB.Where("id = ? AND user_id = ?", id, userID).First(&rule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("警报规则不存在")
+		}
+		return err
+	}
+
+	return global.GVA_DB.Delete(&rule).Error
+}
+
+// GetAlert 获取警报详情，查看后自动标记为已读
+func (s *SmartAlertService) GetAlert(id uint, userID uint) (*response.SmartAlertResponse, error) {
+	var alert baby.SmartAlert
+	err := global.GVA_DB.Scopes(accessibleAlerts(userID, babyAccessRead)).Where("id = ?", id).First(&alert).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("警报不存在")
+		}
+		return nil, err
+	}
+
+	if !alert.IsRead {
+		if err := global.GVA_DB.Model(&alert).Update("is_read", true).Error; err != nil {
+			return nil, err
+		}
+		alert.IsRead = true
+	}
+
+	// 获取宝宝姓名
+	var babyProfile baby.BabyProfile
+	if alert.BabyID > 0 {
+		global.GVA_DB.Where("id = ?", alert.BabyID).First(&babyProfile)
+	}
+
+	var resp response.SmartAlertResponse
+	resp.FromSmartAlert(&alert, babyProfile.Name)
+	return &resp, nil
+}
+
+// GetAlertList 获取警报列表
+func (s *SmartAlertService) GetAlertList(userID uint, req *request.SmartAlertSearch) (*response.SmartAlertListResponse, error) {
+	db := global.GVA_DB.Model(&baby.SmartAlert{}).Scopes(accessibleAlerts(userID, babyAccessRead))
+
+	// 搜索条件
+	if req.BabyID > 0 {
+		db = db.Where("baby_id = ?", req.BabyID)
+	}
+	if req.AlertType > 0 {
+		db = db.Where("alert_type = ?", req.AlertType)
+	}
+	if req.AlertLevel > 0 {
+		db = db.Where("alert_level = ?", req.AlertLevel)
+	}
+	if req.IsRead != nil {
+		db = db.Where("is_read = ?", *req.IsRead)
+	}
+	if req.IsProcessed != nil {
+		db = db.Where("is_processed = ?", *req.IsProcessed)
+	}
+
+	// 获取总数
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 分页查询
+	var alerts []baby.SmartAlert
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order("created_at DESC").Find(&alerts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取所有相关的宝宝信息
+	var babyIDs []uint
+	for _, alert := range alerts {
+		if alert.BabyID > 0 {
+			babyIDs = append(babyIDs, alert.BabyID)
+		}
+	}
+
+	var babies []baby.BabyProfile
+	if len(babyIDs) > 0 {
+		global.GVA_DB.Where("id IN ?", babyIDs).Find(&babies)
+	}
+
+	babyNameMap := make(map[uint]string)
+	for _, babyProfile := range babies {
+		babyNameMap[babyProfile.ID] = babyProfile.Name
+	}
+
+	// 转换响应
+	list := make([]response.SmartAlertResponse, 0, len(alerts))
+	for _, alert := range alerts {
+		var resp response.SmartAlertResponse
+		resp.FromSmartAlert(&alert, babyNameMap[alert.BabyID])
+		list = append(list, resp)
+	}
+
+	return &response.SmartAlertListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// GetAlertSummary 获取未读、未处理警报数量
+func (s *SmartAlertService) GetAlertSummary(userID uint) (*response.AlertSummary, error) {
+	summary := &response.AlertSummary{}
+	err := global.GVA_DB.Model(&baby.SmartAlert{}).Scopes(accessibleAlerts(userID, babyAccessRead)).
+		Where("is_read = ?", false).Count(&summary.UnreadCount).Error
+	if err != nil {
+		return nil, err
+	}
+	err = global.GVA_DB.Model(&baby.SmartAlert{}).Scopes(accessibleAlerts(userID, babyAccessRead)).
+		Where("is_processed = ?", false).Count(&summary.UnprocessedCount).Error
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// MarkAlertsRead 标记警报已读，返回本次标记的条数
+func (s *SmartAlertService) MarkAlertsRead(userID uint, req *request.MarkAlertReadRequest) (int64, error) {
+	if !req.All && len(req.IDs) == 0 {
+		return 0, errors.New("请选择要标记的警报")
+	}
+
+	db := global.GVA_DB.Model(&baby.SmartAlert{}).Scopes(accessibleAlerts(userID, babyAccessRead)).Where("is_read = ?", false)
+	if !req.All {
+		db = db.Where("id IN ?", req.IDs)
+	}
+	result := db.Update("is_read", true)
+	return result.RowsAffected, result.Error
+}
+
+// ProcessAlert 处理警报，记录用户的处理动作
+func (s *SmartAlertService) ProcessAlert(userID uint, req *request.ProcessAlertRequest) error {
+	var alert baby.SmartAlert
+	err := global.GVA_DB.Scopes(accessibleAlerts(userID, babyAccessRead)).Where("id = ?", req.ID).First(&alert).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("警报不存在")
+		}
+		return err
+	}
+	if alert.IsProcessed {
+		return errors.New("警报已处理")
+	}
+	// 宝宝的警报由看护人及以上角色处理
+	if alert.BabyID > 0 {
+		if _, _, err := authorizeBaby(alert.BabyID, userID, babyAccessRecord); err != nil {
+			return err
+		}
+	}
+
+	return global.GVA_DB.Model(&alert).Updates(map[string]interface{}{
+		"is_read":      true,
+		"is_processed": true,
+		"processed_at": time.Now(),
+		"user_action":  req.UserAction,
+	}).Error
+}
+
+// accessibleAlerts 用户可见的警报：自己的非宝宝警报，以及访问级别不低于level的宝宝的警报，家庭成员共享已读和处理状态
+func accessibleAlerts(userID uint, level int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("((baby_id = 0 AND user_id = ?) OR baby_id IN (?))", userID, accessibleBabyIDs(userID, level))
+	}
+}
+
+// validateAlertRule 校验规则的适用对象和阈值
+func (s *SmartAlertService) validateAlertRule(userID uint, req *request.CreateAlertRuleRequest) error {
+	if req.BabyID > 0 {
+		if _, err := getUserBaby(req.BabyID, userID); err != nil {
+			return err
+		}
+	}
+	if req.DeviceID > 0 {
+		if _, err := authorizeDevice(req.DeviceID, userID, deviceAccessRead); err != nil {
+			return err
+		}
+	}
+
+	rule := baby.AlertRule{RuleType: req.RuleType}
+	switch {
+	case rule.IsEnvironmentRule():
+		if req.MinValue == nil && req.MaxValue == nil {
+			return errors.New("请至少设置上限或下限")
+		}
+		if req.MinValue != nil && req.MaxValue != nil && *req.MinValue >= *req.MaxValue {
+			return errors.New("下限必须小于上限")
+		}
+	case req.RuleType == alertRuleCry:
+		if req.MinValue == nil || *req.MinValue < 1 || *req.MinValue > 10 {
+			return errors.New("哭声规则需设置1-10的最低强度")
+		}
+	case req.RuleType == alertRuleMovement:
+		if req.MinValue != nil && (*req.MinValue < 1 || *req.MinValue > 10) {
+			return errors.New("动作强度必须为1-10")
+		}
+	}
+	return nil
+}
+
+// buildRuleResponses 组装规则响应，补充宝宝和设备名称
+func (s *SmartAlertService) buildRuleResponses(rules []baby.AlertRule) []response.AlertRuleResponse {
+	var babyIDs, deviceIDs []uint
+	for _, rule := range rules {
+		if rule.BabyID > 0 {
+			babyIDs = append(babyIDs, rule.BabyID)
+		}
+		if rule.DeviceID > 0 {
+			deviceIDs = append(deviceIDs, rule.DeviceID)
+		}
+	}
+
+	babyNameMap := make(map[uint]string)
+	if len(babyIDs) > 0 {
+		var babies []baby.BabyProfile
+		global.GVA_DB.Where("id IN ?", babyIDs).Find(&babies)
+		for _, babyProfile := range babies {
+			babyNameMap[babyProfile.ID] = babyProfile.Name
+		}
+	}
+
+	deviceNameMap := make(map[uint]string)
+	if len(deviceIDs) > 0 {
+		var devices []baby.Device
+		global.GVA_DB.Where("id IN ?", deviceIDs).Find(&devices)
+		for _, device := range devices {
+			deviceNameMap[device.ID] = device.DeviceName
+		}
+	}
+
+	list := make([]response.AlertRuleResponse, 0, len(rules))
+	for i := range rules {
+		var resp response.AlertRuleResponse
+		resp.FromAlertRule(&rules[i], babyNameMap[rules[i].BabyID], deviceNameMap[rules[i].DeviceID])
+		list = append(list, resp)
+	}
+	return list
+}
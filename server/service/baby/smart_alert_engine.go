@@ -0,0 +1,313 @@
+package baby
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 警报规则类型，对应AlertRule.RuleType
+const (
+	alertRuleTemperature = 1 // 温度
+	alertRuleHumidity    = 2 // 湿度
+	alertRuleCO2         = 3 // 二氧化碳
+	alertRuleNoise       = 4 // 噪音
+	alertRuleAirQuality  = 5 // 空气质量
+	alertRuleCry         = 6 // 哭声
+	alertRuleMovement    = 7 // 异常动作
+)
+
+// 警报类型，对应SmartAlert.AlertType
+const (
+	alertTypeCry         = 1 // 哭声
+	alertTypeMovement    = 2 // 异常动作
+	alertTypeEnvironment = 3 // 环境异常
+	alertTypeFever       = 6 // 体温异常
+	alertTypeMedication  = 7 // 用药提醒
+	alertTypeVaccination = 8 // 疫苗接种提醒
+)
+
+// cryEpisodeGap 相邻两次哭声间隔不超过该时长时视为同一次持续哭闹
+const cryEpisodeGap = 60 * time.Second
+
+// EvaluateEnvironmentData 按环境规则检查新写入的环境数据
+func (s *SmartAlertService) EvaluateEnvironmentData(device *baby.Device, records []baby.EnvironmentData) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	rules, err := s.matchingRules(device.UserID, device.ID, 0,
+		alertRuleTemperature, alertRuleHumidity, alertRuleCO2, alertRuleNoise, alertRuleAirQuality)
+	if err != nil {
+		return err
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		for j := range records {
+			value := environmentValue(rule.RuleType, &records[j])
+			breach, bound := environmentBreach(rule, value)
+			if breach == "" {
+				continue
+			}
+
+			name := rule.GetRuleTypeText()
+			unit := environmentUnit(rule.RuleType)
+			title := "环境" + name + breach
+			message := fmt.Sprintf("设备「%s」检测到%s %s%s，%s设定的%s%s",
+				device.DeviceName, name, formatAlertValue(value), unit, breach, formatAlertValue(bound), unit)
+			triggerData := map[string]interface{}{
+				"environment_data_id": records[j].ID,
+				"rule_type":           rule.RuleType,
+				"value":               value,
+				"bound":               bound,
+				"recorded_at":         records[j].RecordedAt,
+			}
+			if err := s.trigger(rule, rule.BabyID, device.ID, alertTypeEnvironment, title, message, triggerData); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// EvaluateCryDetection 按哭声规则检查新的哭声事件，连续哭闹达到设定时长时警报
+func (s *SmartAlertService) EvaluateCryDetection(detection *baby.CryDetection) error {
+	rules, err := s.matchingRules(detection.UserID, detection.DeviceID, detection.BabyID, alertRuleCry)
+	if err != nil {
+		return err
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		minIntensity := 1
+		if rule.MinValue != nil {
+			minIntensity = int(*rule.MinValue)
+		}
+		if detection.Intensity < minIntensity {
+			continue
+		}
+
+		// 取同一宝宝此前一小时内达到强度的哭声，计算本次持续哭闹时长
+		var previous []baby.CryDetection
+		err := global.GVA_DB.Where("baby_id = ? AND id <> ? AND intensity >= ?", detection.BabyID, detection.ID, minIntensity).
+			Where("detected_at BETWEEN ? AND ?", detection.DetectedAt.Add(-time.Hour), detection.DetectedAt).
+			Order("detected_at DESC").Find(&previous).Error
+		if err != nil {
+			return err
+		}
+		seconds := cryEpisodeSeconds(detection, previous)
+		if seconds < rule.DurationSeconds {
+			continue
+		}
+
+		title := "宝宝持续哭闹"
+		message := fmt.Sprintf("宝宝已持续哭闹约%d秒，强度%d，可能原因：%s", seconds, detection.Intensity, detection.GetCryTypeText())
+		triggerData := map[string]interface{}{
+			"cry_detection_id": detection.ID,
+			"cry_type":         detection.CryType,
+			"intensity":        detection.Intensity,
+			"episode_seconds":  seconds,
+			"detected_at":      detection.DetectedAt,
+		}
+		alert := &baby.SmartAlert{MediaURL: detection.AudioURL}
+		if err := s.triggerAlert(rule, detection.BabyID, detection.DeviceID, alertTypeCry, title, message, triggerData, alert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EvaluateMovementDetection 按动作规则检查新的动作事件，异常动作或设备标记需警报时触发
+func (s *SmartAlertService) EvaluateMovementDetection(detection *baby.MovementDetection) error {
+	if !detection.IsAlert && detection.MovementType != 5 {
+		return nil
+	}
+
+	rules, err := s.matchingRules(detection.UserID, detection.DeviceID, detection.BabyID, alertRuleMovement)
+	if err != nil {
+		return err
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		if rule.MinValue != nil && float64(detection.Intensity) < *rule.MinValue {
+			continue
+		}
+
+		title := "检测到异常动作"
+		message := fmt.Sprintf("检测到宝宝%s，强度%d，持续%d秒，请及时查看", detection.GetMovementTypeText(), detection.Intensity, detection.Duration)
+		triggerData := map[string]interface{}{
+			"movement_detection_id": detection.ID,
+			"movement_type":         detection.MovementType,
+			"intensity":             detection.Intensity,
+			"detected_at":           detection.DetectedAt,
+		}
+		alert := &baby.SmartAlert{MediaURL: detection.VideoURL}
+		if err := s.triggerAlert(rule, detection.BabyID, detection.DeviceID, alertTypeMovement, title, message, triggerData, alert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchingRules 查找适用的已启用规则：设备主人针对全部设备或该设备的规则，以及共享用户针对该设备的规则
+// babyID为0时不按宝宝过滤（环境数据不区分宝宝）
+func (s *SmartAlertService) matchingRules(ownerID, deviceID, babyID uint, ruleTypes ...int) ([]baby.AlertRule, error) {
+	db := global.GVA_DB.Where("is_enabled = ? AND rule_type IN ?", true, ruleTypes)
+	if deviceID > 0 {
+		db = db.Where("((user_id = ? AND device_id IN ?) OR device_id = ?)", ownerID, []uint{0, deviceID}, deviceID)
+	} else {
+		db = db.Where("user_id = ? AND device_id = ?", ownerID, 0)
+	}
+	if babyID > 0 {
+		db = db.Where("baby_id IN ?", []uint{0, babyID})
+	}
+
+	var rules []baby.AlertRule
+	if err := db.Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	// 共享用户的规则仅在分享仍有效时生效
+	result := rules[:0]
+	for _, rule := range rules {
+		if rule.UserID != ownerID {
+			if _, err := authorizeDevice(deviceID, rule.UserID, deviceAccessRead); err != nil {
+				continue
+			}
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+// trigger 按规则创建警报
+func (s *SmartAlertService) trigger(rule *baby.AlertRule, babyID, deviceID uint, alertType int, title, message string, triggerData map[string]interface{}) error {
+	return s.triggerAlert(rule, babyID, deviceID, alertType, title, message, triggerData, &baby.SmartAlert{})
+}
+
+// triggerAlert 按规则创建警报；同一规则对同一宝宝和设备在冷却时间内只警报一次
+func (s *SmartAlertService) triggerAlert(rule *baby.AlertRule, babyID, deviceID uint, alertType int, title, message string, triggerData map[string]interface{}, alert *baby.SmartAlert) error {
+	cooldown := time.Duration(rule.CooldownMinutes) * time.Minute
+	if cooldown <= 0 {
+		cooldown = defaultAlertCooldown
+	}
+
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		// 锁定规则，串行化同一规则的并发触发
+		var locked baby.AlertRule
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", rule.ID).First(&locked).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var count int64
+		err := tx.Model(&baby.SmartAlert{}).
+			Where("rule_id = ? AND user_id = ? AND baby_id = ? AND device_id = ? AND created_at > ?", rule.ID, rule.UserID, babyID, deviceID, now.Add(-cooldown)).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		data, _ := json.Marshal(triggerData)
+		alert.UserID = rule.UserID
+		alert.BabyID = babyID
+		alert.DeviceID = deviceID
+		alert.AlertType = alertType
+		alert.AlertLevel = rule.AlertLevel
+		alert.Title = title
+		alert.Message = message
+		alert.TriggerData = string(data)
+		alert.RuleID = rule.ID
+		if err := tx.Create(alert).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&locked).Update("last_triggered_at", now).Error
+	})
+}
+
+// logAlertError 规则评估失败不影响数据写入，仅记录日志
+func logAlertError(err error) {
+	if err != nil && global.GVA_LOG != nil {
+		global.GVA_LOG.Error("警报规则评估失败!", zap.Error(err))
+	}
+}
+
+// cryEpisodeSeconds 从当前哭声往前累计持续哭闹时长，previous需按检测时间倒序
+func cryEpisodeSeconds(current *baby.CryDetection, previous []baby.CryDetection) int {
+	total := current.Duration
+	episodeStart := current.DetectedAt
+	for _, prev := range previous {
+		if prev.DetectedAt.After(episodeStart) {
+			continue
+		}
+		prevEnd := prev.DetectedAt.Add(time.Duration(prev.Duration) * time.Second)
+		if episodeStart.Sub(prevEnd) > cryEpisodeGap {
+			break
+		}
+		total += prev.Duration
+		episodeStart = prev.DetectedAt
+	}
+	return total
+}
+
+// environmentValue 取环境数据中规则对应的指标
+func environmentValue(ruleType int, data *baby.EnvironmentData) float64 {
+	switch ruleType {
+	case alertRuleTemperature:
+		return data.Temperature
+	case alertRuleHumidity:
+		return data.Humidity
+	case alertRuleCO2:
+		return float64(data.CO2Level)
+	case alertRuleNoise:
+		return data.NoiseLevel
+	case alertRuleAirQuality:
+		return float64(data.AirQuality)
+	default:
+		return 0
+	}
+}
+
+// environmentBreach 判断指标是否越界，返回越界描述和对应阈值
+func environmentBreach(rule *baby.AlertRule, value float64) (string, float64) {
+	if rule.MaxValue != nil && value > *rule.MaxValue {
+		return "过高", *rule.MaxValue
+	}
+	if rule.MinValue != nil && value < *rule.MinValue {
+		return "过低", *rule.MinValue
+	}
+	return "", 0
+}
+
+// environmentUnit 环境指标单位
+func environmentUnit(ruleType int) string {
+	switch ruleType {
+	case alertRuleTemperature:
+		return "℃"
+	case alertRuleHumidity:
+		return "%"
+	case alertRuleCO2:
+		return "ppm"
+	case alertRuleNoise:
+		return "dB"
+	default:
+		return ""
+	}
+}
+
+// formatAlertValue 格式化警报中的数值，去掉多余的小数位
+func formatAlertValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
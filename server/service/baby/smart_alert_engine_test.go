@@ -0,0 +1,68 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/model/baby"
+)
+
+func TestCryEpisodeSeconds(t *testing.T) {
+	now := time.Date(2024, 5, 6, 2, 0, 0, 0, time.Local)
+	current := &baby.CryDetection{DetectedAt: now, Duration: 30}
+	previous := []baby.CryDetection{
+		{DetectedAt: now.Add(-90 * time.Second), Duration: 40},  // 结束于-50s，间隔50s，连续
+		{DetectedAt: now.Add(-200 * time.Second), Duration: 60}, // 结束于-140s，间隔50s，连续
+		{DetectedAt: now.Add(-600 * time.Second), Duration: 30}, // 结束于-570s，间隔过长
+		{DetectedAt: now.Add(-630 * time.Second), Duration: 60},
+	}
+
+	if got := cryEpisodeSeconds(current, previous); got != 130 {
+		t.Errorf("持续哭闹时长 = %d, want 130", got)
+	}
+	if got := cryEpisodeSeconds(current, nil); got != 30 {
+		t.Errorf("单次哭闹时长 = %d, want 30", got)
+	}
+}
+
+func TestEnvironmentBreach(t *testing.T) {
+	low, high := 18.0, 26.0
+	rule := &baby.AlertRule{RuleType: alertRuleTemperature, MinValue: &low, MaxValue: &high}
+
+	tests := []struct {
+		value  float64
+		breach string
+		bound  float64
+	}{
+		{value: 27.5, breach: "过高", bound: high},
+		{value: 16, breach: "过低", bound: low},
+		{value: 26, breach: ""},
+		{value: 18, breach: ""},
+	}
+	for _, tt := range tests {
+		breach, bound := environmentBreach(rule, tt.value)
+		if breach != tt.breach || bound != tt.bound {
+			t.Errorf("environmentBreach(%v) = %q, %v, want %q, %v", tt.value, breach, bound, tt.breach, tt.bound)
+		}
+	}
+
+	upperOnly := &baby.AlertRule{RuleType: alertRuleCO2, MaxValue: &high}
+	if breach, _ := environmentBreach(upperOnly, -5); breach != "" {
+		t.Errorf("未设置下限时不应触发: %q", breach)
+	}
+}
+
+func TestEnvironmentValue(t *testing.T) {
+	data := &baby.EnvironmentData{Temperature: 22.5, Humidity: 55, CO2Level: 900, NoiseLevel: 42.5, AirQuality: 3}
+	want := map[int]float64{
+		alertRuleTemperature: 22.5,
+		alertRuleHumidity:    55,
+		alertRuleCO2:         900,
+		alertRuleNoise:       42.5,
+		alertRuleAirQuality:  3,
+	}
+	for ruleType, value := range want {
+		if got := environmentValue(ruleType, data); got != value {
+			t.Errorf("environmentValue(%d) = %v, want %v", ruleType, got, value)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package baby
+
+import (
+	"testing"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	commonRequest "baby_admin/server/model/common/request"
+)
+
+func TestSmartAlertFamilyAccess(t *testing.T) {
+	db := setupTestDB(t, &baby.FamilyGroup{}, &baby.FamilyMember{}, &baby.BabyProfile{}, &baby.SmartAlert{})
+	profile := seedFamilyBaby(t, db)
+
+	// 宝宝的警报归属创建者，环境警报不关联宝宝
+	fever := baby.SmartAlert{UserID: 1, BabyID: profile.ID, AlertType: alertTypeFever, AlertLevel: 3, Title: "宝宝体温异常"}
+	alerts := []*baby.SmartAlert{
+		&fever,
+		{UserID: 1, AlertType: 3, AlertLevel: 2, Title: "创建者房间温度过高"},
+		{UserID: 3, AlertType: 3, AlertLevel: 2, Title: "看护人房间湿度过低"},
+	}
+	for _, alert := range alerts {
+		if err := db.Create(alert).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service := new(SmartAlertService)
+	tests := []struct {
+		name   string
+		userID uint
+		total  int64
+	}{
+		{"创建者", 1, 2},
+		{"看护人", 3, 2},
+		{"观察者", 4, 1},
+		{"家庭外用户", 9, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := service.GetAlertList(tt.userID, &request.SmartAlertSearch{PageInfo: commonRequest.PageInfo{Page: 1, PageSize: 10}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if list.Total != tt.total {
+				t.Errorf("警报数 = %d, want %d", list.Total, tt.total)
+			}
+			summary, err := service.GetAlertSummary(tt.userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if summary.UnreadCount != tt.total || summary.UnprocessedCount != tt.total {
+				t.Errorf("未读 = %d, 未处理 = %d, want %d", summary.UnreadCount, summary.UnprocessedCount, tt.total)
+			}
+		})
+	}
+
+	if _, err := service.GetAlert(fever.ID, 9); err == nil {
+		t.Error("家庭外用户不应查看宝宝的警报")
+	}
+	if err := service.ProcessAlert(4, &request.ProcessAlertRequest{ID: fever.ID, UserAction: "已查看"}); err == nil {
+		t.Error("观察者不应处理宝宝的警报")
+	}
+	if err := service.ProcessAlert(3, &request.ProcessAlertRequest{ID: fever.ID, UserAction: "已物理降温"}); err != nil {
+		t.Fatalf("看护人处理警报: %v", err)
+	}
+	// 处理状态在家庭成员间共享
+	summary, err := service.GetAlertSummary(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.UnreadCount != 1 || summary.UnprocessedCount != 1 {
+		t.Errorf("创建者未读 = %d, 未处理 = %d, want 1, 1", summary.UnreadCount, summary.UnprocessedCount)
+	}
+
+	// 全部标记已读不影响其他成员自己的环境警报
+	count, err := service.MarkAlertsRead(3, &request.MarkAlertReadRequest{All: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("标记已读 = %d, want 1", count)
+	}
+	if summary, _ := service.GetAlertSummary(1); summary.UnreadCount != 1 {
+		t.Errorf("创建者未读 = %d, want 1", summary.UnreadCount)
+	}
+}
@@ -0,0 +1,573 @@
+package baby
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VaccineService struct{}
+
+// 接种计划剂次状态，对应VaccinationPlanItem.Status
+const (
+	vaccinationStatusDone     = 1 // 已接种
+	vaccinationStatusPlanned  = 2 // 未到期
+	vaccinationStatusUpcoming = 3 // 即将接种
+	vaccinationStatusDue      = 4 // 已到接种时间，尚未逾期
+	vaccinationStatusOverdue  = 5 // 已逾期
+)
+
+// 接种提醒类型，对应VaccinationReminder.ReminderType
+const (
+	vaccinationReminderUpcoming = 1 // 即将接种
+	vaccinationReminderOverdue  = 2 // 已逾期
+)
+
+const (
+	// vaccinationLeadDays 推荐接种日期前该天数内视为即将接种
+	vaccinationLeadDays = 7
+	// vaccinationOverdueRemindDays 逾期超过该天数的剂次不再提醒，多为已接种但未记录
+	vaccinationOverdueRemindDays = 30
+)
+
+// GetVaccineCatalog 获取启用的疫苗目录及各剂次的推荐接种年龄
+func (s *VaccineService) GetVaccineCatalog() ([]response.VaccineResponse, error) {
+	schedules, vaccines, err := loadVaccinationCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]response.VaccineResponse, 0, len(vaccines))
+	index := make(map[uint]int, len(vaccines))
+	for _, vaccine := range sortedVaccines(vaccines) {
+		var item response.VaccineResponse
+		item.FromVaccine(vaccine)
+		index[vaccine.ID] = len(list)
+		list = append(list, item)
+	}
+	for i := range schedules {
+		if j, ok := index[schedules[i].VaccineID]; ok {
+			var item response.VaccineScheduleResponse
+			item.FromVaccineSchedule(&schedules[i], list[j].Name)
+			list[j].Schedules = append(list[j].Schedules, item)
+		}
+	}
+	return list, nil
+}
+
+// GetVaccinationPlan 获取宝宝按出生日期推算的完整接种计划及各剂次状态
+func (s *VaccineService) GetVaccinationPlan(userID uint, babyID uint) (*response.VaccinationPlanResponse, error) {
+	profile, _, err := authorizeBaby(babyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.planForBaby(profile, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.VaccinationPlanResponse{
+		BabyID:     profile.ID,
+		BabyName:   profile.Name,
+		Birthday:   profile.Birthday,
+		TotalCount: len(items),
+		Items:      items,
+	}
+	for _, item := range items {
+		if item.Status == vaccinationStatusDone {
+			result.CompletedCount++
+		}
+	}
+	return result, nil
+}
+
+// GetUpcomingVaccinations 获取宝宝即将接种、已到接种时间及逾期未接种的剂次
+func (s *VaccineService) GetUpcomingVaccinations(userID uint, babyID uint) (*response.VaccinationUpcomingResponse, error) {
+	profile, _, err := authorizeBaby(babyID, userID, babyAccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.planForBaby(profile, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.VaccinationUpcomingResponse{
+		BabyID:   profile.ID,
+		BabyName: profile.Name,
+		Upcoming: []response.VaccinationPlanItem{},
+		Overdue:  []response.VaccinationPlanItem{},
+	}
+	for _, item := range items {
+		switch item.Status {
+		case vaccinationStatusUpcoming, vaccinationStatusDue:
+			result.Upcoming = append(result.Upcoming, item)
+		case vaccinationStatusOverdue:
+			result.Overdue = append(result.Overdue, item)
+		}
+	}
+	return result, nil
+}
+
+// CreateVaccinationRecord 记录一剂接种，同一疫苗的同一剂次只能记录一次
+func (s *VaccineService) CreateVaccinationRecord(userID uint, req *request.CreateVaccinationRecordRequest) (*response.VaccinationRecordResponse, error) {
+	profile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &baby.VaccinationRecord{UserID: userID}
+	vaccine, err := s.fillVaccinationRecord(record, profile, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveVaccinationRecord(record); err != nil {
+		return nil, err
+	}
+
+	var resp response.VaccinationRecordResponse
+	resp.FromVaccinationRecord(record, vaccine.Name)
+	return &resp, nil
+}
+
+// UpdateVaccinationRecord 更新接种记录
+func (s *VaccineService) UpdateVaccinationRecord(userID uint, req *request.UpdateVaccinationRecordRequest) error {
+	record, err := s.authorizeVaccinationRecord(req.ID, userID, babyAccessRecord)
+	if err != nil {
+		return err
+	}
+
+	// 改到其他宝宝时需对该宝宝有记录权限
+	profile, err := getUserBaby(req.BabyID, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.fillVaccinationRecord(record, profile, &req.CreateVaccinationRecordRequest); err != nil {
+		return err
+	}
+	return s.saveVaccinationRecord(record)
+}
+
+// GetVaccinationRecordList 获取接种记录列表，包含家庭其他成员添加的记录
+func (s *VaccineService) GetVaccinationRecordList(userID uint, req *request.VaccinationRecordSearch) (*response.VaccinationRecordListResponse, error) {
+	db := global.GVA_DB.Model(&baby.VaccinationRecord{}).Where("baby_id IN (?)", accessibleBabyIDs(userID, babyAccessRead))
+	if req.BabyID > 0 {
+		db = db.Where("baby_id = ?", req.BabyID)
+	}
+	if req.VaccineID > 0 {
+		db = db.Where("vaccine_id = ?", req.VaccineID)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var records []baby.VaccinationRecord
+	offset := (req.Page - 1) * req.PageSize
+	err := db.Offset(offset).Limit(req.PageSize).Order("vaccinated_at DESC, id DESC").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 已删除的疫苗也显示名称
+	var vaccineIDs []uint
+	for _, record := range records {
+		vaccineIDs = append(vaccineIDs, record.VaccineID)
+	}
+	var vaccines []baby.Vaccine
+	if len(vaccineIDs) > 0 {
+		global.GVA_DB.Unscoped().Where("id IN ?", vaccineIDs).Find(&vaccines)
+	}
+	vaccineNameMap := make(map[uint]string, len(vaccines))
+	for _, vaccine := range vaccines {
+		vaccineNameMap[vaccine.ID] = vaccine.Name
+	}
+
+	list := make([]response.VaccinationRecordResponse, len(records))
+	for i := range records {
+		list[i].FromVaccinationRecord(&records[i], vaccineNameMap[records[i].VaccineID])
+	}
+	return &response.VaccinationRecordListResponse{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// DeleteVaccinationRecord 删除接种记录
+func (s *VaccineService) DeleteVaccinationRecord(id uint, userID uint) error {
+	record, err := s.authorizeVaccinationRecord(id, userID, babyAccessRecord)
+	if err != nil {
+		return err
+	}
+	return global.GVA_DB.Delete(record).Error
+}
+
+// SendVaccinationReminders 为所有宝宝生成疫苗接种提醒，返回本次提醒的剂次数，由定时任务调用
+// 每个宝宝的即将接种和逾期剂次各汇总为一条警报，已提醒过的剂次不再重复提醒
+func (s *VaccineService) SendVaccinationReminders(now time.Time) (int, error) {
+	schedules, vaccines, err := loadVaccinationCatalog()
+	if err != nil || len(schedules) == 0 {
+		return 0, err
+	}
+
+	// 只检查仍在接种程序年龄范围内的宝宝
+	maxMonths := 0
+	for _, schedule := range schedules {
+		if schedule.AgeMonths > maxMonths {
+			maxMonths = schedule.AgeMonths
+		}
+	}
+	cutoff := now.AddDate(0, -(maxMonths + 1), -vaccinationOverdueRemindDays)
+
+	var reminded int
+	var lastErr error
+	var babies []baby.BabyProfile
+	err = global.GVA_DB.Where("birthday > ?", cutoff).
+		FindInBatches(&babies, 100, func(tx *gorm.DB, batch int) error {
+			babyIDs := make([]uint, len(babies))
+			for i := range babies {
+				babyIDs[i] = babies[i].ID
+			}
+			var records []baby.VaccinationRecord
+			if err := global.GVA_DB.Where("baby_id IN ?", babyIDs).Find(&records).Error; err != nil {
+				return err
+			}
+			recordMap := make(map[uint][]baby.VaccinationRecord)
+			for _, record := range records {
+				recordMap[record.BabyID] = append(recordMap[record.BabyID], record)
+			}
+
+			for i := range babies {
+				items := buildVaccinationPlan(babies[i].Birthday, schedules, vaccines, recordMap[babies[i].ID], now)
+				count, err := s.remindBaby(&babies[i], items, now)
+				if err != nil {
+					lastErr = fmt.Errorf("宝宝%d接种提醒失败: %w", babies[i].ID, err)
+					continue
+				}
+				reminded += count
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return reminded, err
+	}
+	return reminded, lastErr
+}
+
+// remindBaby 为宝宝尚未提醒过的即将接种和逾期剂次各创建一条汇总警报
+func (s *VaccineService) remindBaby(profile *baby.BabyProfile, items []response.VaccinationPlanItem, now time.Time) (int, error) {
+	var upcoming, overdue []response.VaccinationPlanItem
+	for _, item := range items {
+		switch item.Status {
+		case vaccinationStatusUpcoming, vaccinationStatusDue:
+			upcoming = append(upcoming, item)
+		case vaccinationStatusOverdue:
+			if now.Sub(item.OverdueDate) <= vaccinationOverdueRemindDays*24*time.Hour {
+				overdue = append(overdue, item)
+			}
+		}
+	}
+	if len(upcoming) == 0 && len(overdue) == 0 {
+		return 0, nil
+	}
+
+	var reminded int
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		groups := []struct {
+			reminderType int
+			items        []response.VaccinationPlanItem
+			alertLevel   int
+			title        string
+			format       string
+		}{
+			{vaccinationReminderUpcoming, upcoming, 1, "疫苗接种提醒", "%s有%d剂疫苗即将接种：%s"},
+			{vaccinationReminderOverdue, overdue, 2, "疫苗逾期未接种", "%s有%d剂疫苗已逾期未接种：%s，请尽快补种"},
+		}
+		for _, group := range groups {
+			// 插入成功的剂次即本次需要提醒的剂次
+			var reminders []baby.VaccinationReminder
+			var doses []string
+			for _, item := range group.items {
+				reminder := baby.VaccinationReminder{BabyID: profile.ID, ScheduleID: item.ScheduleID, ReminderType: group.reminderType}
+				result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&reminder)
+				if result.Error != nil {
+					return result.Error
+				}
+				if result.RowsAffected == 1 {
+					reminders = append(reminders, reminder)
+					doses = append(doses, fmt.Sprintf("%s第%d剂(%s)", item.VaccineName, item.DoseNumber, item.DueDate.Format("01-02")))
+				}
+			}
+			if len(reminders) == 0 {
+				continue
+			}
+
+			data, _ := json.Marshal(map[string]interface{}{
+				"reminder_type": group.reminderType,
+				"schedule_ids":  reminderScheduleIDs(reminders),
+			})
+			alert := &baby.SmartAlert{
+				UserID:      profile.UserID,
+				BabyID:      profile.ID,
+				AlertType:   alertTypeVaccination,
+				AlertLevel:  group.alertLevel,
+				Title:       group.title,
+				Message:     fmt.Sprintf(group.format, profile.Name, len(doses), strings.Join(doses, "、")),
+				TriggerData: string(data),
+			}
+			if err := tx.Create(alert).Error; err != nil {
+				return err
+			}
+			ids := make([]uint, len(reminders))
+			for i := range reminders {
+				ids[i] = reminders[i].ID
+			}
+			if err := tx.Model(&baby.VaccinationReminder{}).Where("id IN ?", ids).Update("alert_id", alert.ID).Error; err != nil {
+				return err
+			}
+			reminded += len(reminders)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return reminded, nil
+}
+
+// planForBaby 获取宝宝的接种计划
+func (s *VaccineService) planForBaby(profile *baby.BabyProfile, now time.Time) ([]response.VaccinationPlanItem, error) {
+	schedules, vaccines, err := loadVaccinationCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []baby.VaccinationRecord
+	if err := global.GVA_DB.Where("baby_id = ?", profile.ID).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return buildVaccinationPlan(profile.Birthday, schedules, vaccines, records, now), nil
+}
+
+// authorizeVaccinationRecord 校验用户对接种记录的访问权限，修改、删除他人添加的记录需要家长及以上角色
+func (s *VaccineService) authorizeVaccinationRecord(id uint, userID uint, level int) (*baby.VaccinationRecord, error) {
+	var record baby.VaccinationRecord
+	err := global.GVA_DB.Where("id = ?", id).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("接种记录不存在")
+		}
+		return nil, err
+	}
+	if _, _, err := authorizeBabyRecord(record.BabyID, record.UserID, userID, level, "接种记录不存在"); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// fillVaccinationRecord 校验疫苗和接种日期并写入记录
+func (s *VaccineService) fillVaccinationRecord(record *baby.VaccinationRecord, profile *baby.BabyProfile, req *request.CreateVaccinationRecordRequest) (*baby.Vaccine, error) {
+	var vaccine baby.Vaccine
+	if err := global.GVA_DB.Where("id = ?", req.VaccineID).First(&vaccine).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("疫苗不存在")
+		}
+		return nil, err
+	}
+	if req.VaccinatedAt.After(time.Now()) {
+		return nil, errors.New("接种日期不能晚于今天")
+	}
+	if req.VaccinatedAt.Before(startOfDay(profile.Birthday)) {
+		return nil, errors.New("接种日期不能早于宝宝出生日期")
+	}
+
+	record.BabyID = profile.ID
+	record.VaccineID = vaccine.ID
+	record.DoseNumber = req.DoseNumber
+	record.VaccinatedAt = req.VaccinatedAt
+	record.BatchNumber = req.BatchNumber
+	record.Manufacturer = req.Manufacturer
+	record.Clinic = req.Clinic
+	record.Reaction = req.Reaction
+	record.Notes = req.Notes
+	return &vaccine, nil
+}
+
+// saveVaccinationRecord 保存接种记录，同一宝宝同一疫苗的剂次不能重复
+func (s *VaccineService) saveVaccinationRecord(record *baby.VaccinationRecord) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		// 锁定宝宝档案，串行化同一宝宝的接种记录写入
+		var profile baby.BabyProfile
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", record.BabyID).First(&profile).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		err := tx.Model(&baby.VaccinationRecord{}).
+			Where("baby_id = ? AND vaccine_id = ? AND dose_number = ? AND id <> ?", record.BabyID, record.VaccineID, record.DoseNumber, record.ID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("该剂次已有接种记录")
+		}
+		return tx.Save(record).Error
+	})
+}
+
+// loadVaccinationCatalog 加载启用的疫苗及其启用的接种程序剂次
+func loadVaccinationCatalog() ([]baby.VaccineSchedule, map[uint]*baby.Vaccine, error) {
+	var vaccineList []baby.Vaccine
+	if err := global.GVA_DB.Where("is_active = ?", true).Find(&vaccineList).Error; err != nil {
+		return nil, nil, err
+	}
+	vaccines := make(map[uint]*baby.Vaccine, len(vaccineList))
+	ids := make([]uint, len(vaccineList))
+	for i := range vaccineList {
+		vaccines[vaccineList[i].ID] = &vaccineList[i]
+		ids[i] = vaccineList[i].ID
+	}
+	if len(ids) == 0 {
+		return nil, vaccines, nil
+	}
+
+	var schedules []baby.VaccineSchedule
+	err := global.GVA_DB.Where("is_active = ? AND vaccine_id IN ?", true, ids).
+		Order("age_months ASC, age_days ASC, vaccine_id ASC, dose_number ASC").Find(&schedules).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	return schedules, vaccines, nil
+}
+
+// buildVaccinationPlan 根据接种程序和接种记录生成接种计划，按推荐接种日期排序
+// 推荐接种日期按出生日期推算，超过推荐日期加宽限天数仍未接种为逾期
+func buildVaccinationPlan(birthday time.Time, schedules []baby.VaccineSchedule, vaccines map[uint]*baby.Vaccine, records []baby.VaccinationRecord, now time.Time) []response.VaccinationPlanItem {
+	type doseKey struct {
+		vaccineID uint
+		dose      int
+	}
+	recordMap := make(map[doseKey]*baby.VaccinationRecord, len(records))
+	for i := range records {
+		recordMap[doseKey{records[i].VaccineID, records[i].DoseNumber}] = &records[i]
+	}
+	totalDoses := make(map[uint]int)
+	for _, schedule := range schedules {
+		totalDoses[schedule.VaccineID]++
+	}
+
+	birthDay := startOfDay(birthday)
+	today := startOfDay(now)
+	items := make([]response.VaccinationPlanItem, 0, len(schedules))
+	for i := range schedules {
+		schedule := &schedules[i]
+		vaccine, ok := vaccines[schedule.VaccineID]
+		if !ok {
+			continue
+		}
+
+		dueDate := schedule.DueDate(birthDay)
+		overdueDate := dueDate.AddDate(0, 0, schedule.GraceDays)
+		item := response.VaccinationPlanItem{
+			ScheduleID:   schedule.ID,
+			VaccineID:    vaccine.ID,
+			VaccineCode:  vaccine.Code,
+			VaccineName:  vaccine.Name,
+			Category:     vaccine.Category,
+			CategoryText: vaccine.GetCategoryText(),
+			DoseNumber:   schedule.DoseNumber,
+			TotalDoses:   totalDoses[vaccine.ID],
+			AgeText:      schedule.GetAgeText(),
+			DueDate:      dueDate,
+			OverdueDate:  overdueDate,
+			DaysUntilDue: int(math.Round(dueDate.Sub(today).Hours() / 24)),
+		}
+
+		if record, ok := recordMap[doseKey{vaccine.ID, schedule.DoseNumber}]; ok {
+			var resp response.VaccinationRecordResponse
+			resp.FromVaccinationRecord(record, vaccine.Name)
+			item.Record = &resp
+			item.Status = vaccinationStatusDone
+		} else {
+			switch {
+			case today.After(overdueDate):
+				item.Status = vaccinationStatusOverdue
+			case !today.Before(dueDate):
+				item.Status = vaccinationStatusDue
+			case item.DaysUntilDue <= vaccinationLeadDays:
+				item.Status = vaccinationStatusUpcoming
+			default:
+				item.Status = vaccinationStatusPlanned
+			}
+		}
+		item.StatusText = vaccinationStatusText(item.Status)
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].DueDate.Equal(items[j].DueDate) {
+			return items[i].DueDate.Before(items[j].DueDate)
+		}
+		return vaccines[items[i].VaccineID].SortOrder < vaccines[items[j].VaccineID].SortOrder
+	})
+	return items
+}
+
+// sortedVaccines 按排序值和ID排列疫苗
+func sortedVaccines(vaccines map[uint]*baby.Vaccine) []*baby.Vaccine {
+	list := make([]*baby.Vaccine, 0, len(vaccines))
+	for _, vaccine := range vaccines {
+		list = append(list, vaccine)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].SortOrder != list[j].SortOrder {
+			return list[i].SortOrder < list[j].SortOrder
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// reminderScheduleIDs 提醒涉及的接种程序ID
+func reminderScheduleIDs(reminders []baby.VaccinationReminder) []uint {
+	ids := make([]uint, len(reminders))
+	for i := range reminders {
+		ids[i] = reminders[i].ScheduleID
+	}
+	return ids
+}
+
+// vaccinationStatusText 接种计划剂次状态文本
+func vaccinationStatusText(status int) string {
+	switch status {
+	case vaccinationStatusDone:
+		return "已接种"
+	case vaccinationStatusPlanned:
+		return "未到期"
+	case vaccinationStatusUpcoming:
+		return "即将接种"
+	case vaccinationStatusDue:
+		return "可接种"
+	case vaccinationStatusOverdue:
+		return "已逾期"
+	default:
+		return "未知"
+	}
+}
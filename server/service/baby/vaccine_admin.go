@@ -0,0 +1,264 @@
+package baby
+
+import (
+	"errors"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+	"baby_admin/server/model/baby/request"
+	"baby_admin/server/model/baby/response"
+	"gorm.io/gorm"
+)
+
+// VaccineAdminService 疫苗目录及接种程序维护，供管理后台使用，各地免疫程序不同可自行调整
+type VaccineAdminService struct{}
+
+// CreateVaccine 创建疫苗
+func (s *VaccineAdminService) CreateVaccine(req *request.CreateVaccineRequest) (*baby.Vaccine, error) {
+	if err := s.checkVaccineCode(req.Code, 0); err != nil {
+		return nil, err
+	}
+
+	vaccine := &baby.Vaccine{IsActive: true}
+	fillVaccine(vaccine, req)
+	if err := global.GVA_DB.Create(vaccine).Error; err != nil {
+		return nil, err
+	}
+	// IsActive为false时默认值会覆盖零值，单独更新
+	if !vaccine.IsActive {
+		if err := global.GVA_DB.Model(vaccine).Update("is_active", false).Error; err != nil {
+			return nil, err
+		}
+	}
+	return vaccine, nil
+}
+
+// UpdateVaccine 更新疫苗
+func (s *VaccineAdminService) UpdateVaccine(req *request.UpdateVaccineRequest) error {
+	var vaccine baby.Vaccine
+	if err := global.GVA_DB.Where("id = ?", req.ID).First(&vaccine).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("疫苗不存在")
+		}
+		return err
+	}
+	if err := s.checkVaccineCode(req.Code, vaccine.ID); err != nil {
+		return err
+	}
+
+	fillVaccine(&vaccine, &req.CreateVaccineRequest)
+	return global.GVA_DB.Save(&vaccine).Error
+}
+
+// DeleteVaccine 删除疫苗及其接种程序，已有接种记录的疫苗只能停用
+func (s *VaccineAdminService) DeleteVaccine(id uint) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&baby.VaccinationRecord{}).Where("vaccine_id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("已有接种记录，请停用该疫苗")
+		}
+
+		result := tx.Where("id = ?", id).Delete(&baby.Vaccine{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("疫苗不存在")
+		}
+		return tx.Where("vaccine_id = ?", id).Delete(&baby.VaccineSchedule{}).Error
+	})
+}
+
+// GetVaccineList 分页获取疫苗列表
+func (s *VaccineAdminService) GetVaccineList(req *request.VaccineSearch) ([]response.VaccineResponse, int64, error) {
+	db := global.GVA_DB.Model(&baby.Vaccine{})
+	if req.Name != "" {
+		db = db.Where("name LIKE ? OR code LIKE ?", "%"+req.Name+"%", "%"+req.Name+"%")
+	}
+	if req.Category > 0 {
+		db = db.Where("category = ?", req.Category)
+	}
+	if req.IsActive != nil {
+		db = db.Where("is_active = ?", *req.IsActive)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var vaccines []baby.Vaccine
+	offset := (req.Page - 1) * req.PageSize
+	if err := db.Offset(offset).Limit(req.PageSize).Order("sort_order ASC, id ASC").Find(&vaccines).Error; err != nil {
+		return nil, 0, err
+	}
+
+	list := make([]response.VaccineResponse, len(vaccines))
+	index := make(map[uint]int, len(vaccines))
+	ids := make([]uint, len(vaccines))
+	for i := range vaccines {
+		list[i].FromVaccine(&vaccines[i])
+		index[vaccines[i].ID] = i
+		ids[i] = vaccines[i].ID
+	}
+	if len(ids) > 0 {
+		var schedules []baby.VaccineSchedule
+		err := global.GVA_DB.Where("vaccine_id IN ?", ids).Order("dose_number ASC, id ASC").Find(&schedules).Error
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range schedules {
+			j := index[schedules[i].VaccineID]
+			var item response.VaccineScheduleResponse
+			item.FromVaccineSchedule(&schedules[i], list[j].Name)
+			list[j].Schedules = append(list[j].Schedules, item)
+		}
+	}
+	return list, total, nil
+}
+
+// CreateSchedule 创建接种程序剂次
+func (s *VaccineAdminService) CreateSchedule(req *request.CreateVaccineScheduleRequest) (*baby.VaccineSchedule, error) {
+	if err := s.checkSchedule(req, 0); err != nil {
+		return nil, err
+	}
+
+	schedule := &baby.VaccineSchedule{}
+	fillVaccineSchedule(schedule, req)
+	if err := global.GVA_DB.Create(schedule).Error; err != nil {
+		return nil, err
+	}
+	// 默认值会覆盖零值，宽限天数为0或停用时单独更新
+	if schedule.GraceDays == 0 || !schedule.IsActive {
+		err := global.GVA_DB.Model(schedule).Updates(map[string]interface{}{
+			"grace_days": schedule.GraceDays,
+			"is_active":  schedule.IsActive,
+		}).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return schedule, nil
+}
+
+// UpdateSchedule 更新接种程序剂次
+func (s *VaccineAdminService) UpdateSchedule(req *request.UpdateVaccineScheduleRequest) error {
+	var schedule baby.VaccineSchedule
+	if err := global.GVA_DB.Where("id = ?", req.ID).First(&schedule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("接种程序不存在")
+		}
+		return err
+	}
+	if err := s.checkSchedule(&req.CreateVaccineScheduleRequest, schedule.ID); err != nil {
+		return err
+	}
+
+	fillVaccineSchedule(&schedule, &req.CreateVaccineScheduleRequest)
+	return global.GVA_DB.Save(&schedule).Error
+}
+
+// DeleteSchedule 删除接种程序剂次
+func (s *VaccineAdminService) DeleteSchedule(id uint) error {
+	result := global.GVA_DB.Where("id = ?", id).Delete(&baby.VaccineSchedule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("接种程序不存在")
+	}
+	return nil
+}
+
+// GetScheduleList 获取接种程序列表，按推荐接种月龄排序
+func (s *VaccineAdminService) GetScheduleList(req *request.VaccineScheduleSearch) ([]response.VaccineScheduleResponse, error) {
+	db := global.GVA_DB.Model(&baby.VaccineSchedule{})
+	if req.VaccineID > 0 {
+		db = db.Where("vaccine_id = ?", req.VaccineID)
+	}
+	var schedules []baby.VaccineSchedule
+	if err := db.Order("age_months ASC, age_days ASC, vaccine_id ASC, dose_number ASC").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+
+	var vaccines []baby.Vaccine
+	if err := global.GVA_DB.Find(&vaccines).Error; err != nil {
+		return nil, err
+	}
+	vaccineNameMap := make(map[uint]string, len(vaccines))
+	for _, vaccine := range vaccines {
+		vaccineNameMap[vaccine.ID] = vaccine.Name
+	}
+
+	list := make([]response.VaccineScheduleResponse, len(schedules))
+	for i := range schedules {
+		list[i].FromVaccineSchedule(&schedules[i], vaccineNameMap[schedules[i].VaccineID])
+	}
+	return list, nil
+}
+
+// checkVaccineCode 校验疫苗编码唯一
+func (s *VaccineAdminService) checkVaccineCode(code string, excludeID uint) error {
+	var count int64
+	err := global.GVA_DB.Model(&baby.Vaccine{}).Where("code = ? AND id <> ?", code, excludeID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("疫苗编码已存在")
+	}
+	return nil
+}
+
+// checkSchedule 校验疫苗存在且同一疫苗的剂次不重复
+func (s *VaccineAdminService) checkSchedule(req *request.CreateVaccineScheduleRequest, excludeID uint) error {
+	var vaccine baby.Vaccine
+	if err := global.GVA_DB.Where("id = ?", req.VaccineID).First(&vaccine).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("疫苗不存在")
+		}
+		return err
+	}
+
+	var count int64
+	err := global.GVA_DB.Model(&baby.VaccineSchedule{}).
+		Where("vaccine_id = ? AND dose_number = ? AND id <> ?", req.VaccineID, req.DoseNumber, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("该疫苗的剂次已存在")
+	}
+	return nil
+}
+
+// fillVaccine 将请求写入疫苗，未指定是否启用时保持原值
+func fillVaccine(vaccine *baby.Vaccine, req *request.CreateVaccineRequest) {
+	vaccine.Code = req.Code
+	vaccine.Name = req.Name
+	vaccine.Category = req.Category
+	vaccine.Prevents = req.Prevents
+	vaccine.Description = req.Description
+	vaccine.SortOrder = req.SortOrder
+	if req.IsActive != nil {
+		vaccine.IsActive = *req.IsActive
+	}
+}
+
+// fillVaccineSchedule 将请求写入接种程序剂次，新建且未指定是否启用时默认启用
+func fillVaccineSchedule(schedule *baby.VaccineSchedule, req *request.CreateVaccineScheduleRequest) {
+	schedule.VaccineID = req.VaccineID
+	schedule.DoseNumber = req.DoseNumber
+	schedule.AgeMonths = req.AgeMonths
+	schedule.AgeDays = req.AgeDays
+	schedule.GraceDays = req.GraceDays
+	schedule.Notes = req.Notes
+	if req.IsActive != nil {
+		schedule.IsActive = *req.IsActive
+	} else if schedule.ID == 0 {
+		schedule.IsActive = true
+	}
+}
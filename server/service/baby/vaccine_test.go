@@ -0,0 +1,67 @@
+package baby
+
+import (
+	"testing"
+	"time"
+	"baby_admin/server/global"
+	"baby_admin/server/model/baby"
+)
+
+func TestBuildVaccinationPlan(t *testing.T) {
+	birthday := time.Date(2024, 1, 31, 8, 30, 0, 0, time.Local)
+	vaccines := map[uint]*baby.Vaccine{
+		1: {GVA_MODEL: global.GVA_MODEL{ID: 1}, Code: "HepB", Name: "乙肝疫苗", Category: 1, SortOrder: 1},
+		2: {GVA_MODEL: global.GVA_MODEL{ID: 2}, Code: "BCG", Name: "卡介苗", Category: 1, SortOrder: 2},
+	}
+	schedules := []baby.VaccineSchedule{
+		{GVA_MODEL: global.GVA_MODEL{ID: 10}, VaccineID: 2, DoseNumber: 1, AgeMonths: 0, GraceDays: 30},
+		{GVA_MODEL: global.GVA_MODEL{ID: 11}, VaccineID: 1, DoseNumber: 1, AgeMonths: 0, GraceDays: 30},
+		{GVA_MODEL: global.GVA_MODEL{ID: 12}, VaccineID: 1, DoseNumber: 2, AgeMonths: 1, GraceDays: 30},
+		{GVA_MODEL: global.GVA_MODEL{ID: 13}, VaccineID: 1, DoseNumber: 3, AgeMonths: 6, GraceDays: 30},
+		// 已停用疫苗的剂次不出现在计划中
+		{GVA_MODEL: global.GVA_MODEL{ID: 14}, VaccineID: 3, DoseNumber: 1, AgeMonths: 2, GraceDays: 30},
+	}
+	records := []baby.VaccinationRecord{
+		{VaccineID: 1, DoseNumber: 1, VaccinatedAt: birthday},
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want map[uint]int // ScheduleID -> Status
+	}{
+		{"出生当天", time.Date(2024, 1, 31, 20, 0, 0, 0, time.Local), map[uint]int{
+			10: vaccinationStatusDue, 11: vaccinationStatusDone, 12: vaccinationStatusPlanned, 13: vaccinationStatusPlanned,
+		}},
+		// 1月龄按月末对齐为2月29日
+		{"1月龄前一周", time.Date(2024, 2, 22, 9, 0, 0, 0, time.Local), map[uint]int{
+			10: vaccinationStatusDue, 11: vaccinationStatusDone, 12: vaccinationStatusUpcoming, 13: vaccinationStatusPlanned,
+		}},
+		{"卡介苗逾期", time.Date(2024, 3, 2, 9, 0, 0, 0, time.Local), map[uint]int{
+			10: vaccinationStatusOverdue, 11: vaccinationStatusDone, 12: vaccinationStatusDue, 13: vaccinationStatusPlanned,
+		}},
+	}
+	for _, tt := range tests {
+		items := buildVaccinationPlan(birthday, schedules, vaccines, records, tt.now)
+		if len(items) != len(tt.want) {
+			t.Fatalf("%s: got %d items, want %d", tt.name, len(items), len(tt.want))
+		}
+		for _, item := range items {
+			if item.Status != tt.want[item.ScheduleID] {
+				t.Errorf("%s: schedule %d status = %d, want %d", tt.name, item.ScheduleID, item.Status, tt.want[item.ScheduleID])
+			}
+		}
+	}
+
+	items := buildVaccinationPlan(birthday, schedules, vaccines, records, birthday)
+	// 同一天的剂次按疫苗排序值排列
+	if items[0].ScheduleID != 11 || items[1].ScheduleID != 10 {
+		t.Errorf("order = %d, %d, want 11, 10", items[0].ScheduleID, items[1].ScheduleID)
+	}
+	if items[0].Record == nil || items[0].TotalDoses != 3 {
+		t.Errorf("HepB dose 1: record = %v, total doses = %d", items[0].Record, items[0].TotalDoses)
+	}
+	if want := time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local); !items[2].DueDate.Equal(want) {
+		t.Errorf("HepB dose 2 due = %v, want %v", items[2].DueDate, want)
+	}
+}
@@ -0,0 +1,108 @@
+package baby
+
+import (
+	"context"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/baby"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const initOrderVaccine = system.InitOrderInternal + 1
+
+type initVaccine struct{}
+
+// auto run
+func init() {
+	system.RegisterInit(initOrderVaccine, &initVaccine{})
+}
+
+// vaccineSeed 疫苗及其各剂次推荐接种月龄，默认按国家免疫规划儿童免疫程序(2021年版)，管理员可按当地程序调整
+type vaccineSeed struct {
+	vaccine baby.Vaccine
+	months  []int
+}
+
+var vaccineSeeds = []vaccineSeed{
+	{baby.Vaccine{Code: "HepB", Name: "乙肝疫苗", Category: 1, Prevents: "乙型病毒性肝炎", Description: "第1剂在出生后24小时内接种"}, []int{0, 1, 6}},
+	{baby.Vaccine{Code: "BCG", Name: "卡介苗", Category: 1, Prevents: "结核病", Description: "出生时接种，未接种的儿童3月龄内可直接补种"}, []int{0}},
+	{baby.Vaccine{Code: "IPV", Name: "脊灰灭活疫苗", Category: 1, Prevents: "脊髓灰质炎", Description: "脊灰疫苗第1、2剂"}, []int{2, 3}},
+	{baby.Vaccine{Code: "bOPV", Name: "脊灰减毒活疫苗", Category: 1, Prevents: "脊髓灰质炎", Description: "脊灰疫苗第3、4剂"}, []int{4, 48}},
+	{baby.Vaccine{Code: "DTaP", Name: "百白破疫苗", Category: 1, Prevents: "百日咳、白喉、破伤风"}, []int{3, 4, 5, 18}},
+	{baby.Vaccine{Code: "DT", Name: "白破疫苗", Category: 1, Prevents: "白喉、破伤风"}, []int{72}},
+	{baby.Vaccine{Code: "MMR", Name: "麻腮风疫苗", Category: 1, Prevents: "麻疹、流行性腮腺炎、风疹"}, []int{8, 18}},
+	{baby.Vaccine{Code: "JE-L", Name: "乙脑减毒活疫苗", Category: 1, Prevents: "流行性乙型脑炎"}, []int{8, 24}},
+	{baby.Vaccine{Code: "MPSV-A", Name: "A群流脑多糖疫苗", Category: 1, Prevents: "流行性脑脊髓膜炎", Description: "两剂间隔不少于3个月"}, []int{6, 9}},
+	{baby.Vaccine{Code: "MPSV-AC", Name: "A群C群流脑多糖疫苗", Category: 1, Prevents: "流行性脑脊髓膜炎"}, []int{36, 72}},
+	{baby.Vaccine{Code: "HepA-L", Name: "甲肝减毒活疫苗", Category: 1, Prevents: "甲型病毒性肝炎"}, []int{18}},
+	{baby.Vaccine{Code: "PCV13", Name: "13价肺炎球菌结合疫苗", Category: 2, Prevents: "肺炎球菌感染", Description: "自费疫苗，加强剂次在12-15月龄接种"}, []int{2, 4, 6, 12}},
+	{baby.Vaccine{Code: "Hib", Name: "b型流感嗜血杆菌结合疫苗", Category: 2, Prevents: "b型流感嗜血杆菌感染", Description: "自费疫苗，不同厂家程序略有差异"}, []int{2, 3, 4, 18}},
+	{baby.Vaccine{Code: "RV5", Name: "五价轮状病毒疫苗", Category: 2, Prevents: "轮状病毒腹泻", Description: "自费口服疫苗，第1剂在6-12周龄接种，第3剂不晚于32周龄"}, []int{2, 3, 4}},
+	{baby.Vaccine{Code: "VarV", Name: "水痘疫苗", Category: 2, Prevents: "水痘", Description: "自费疫苗，部分地区已纳入免疫规划"}, []int{12, 48}},
+	{baby.Vaccine{Code: "EV71", Name: "肠道病毒71型灭活疫苗", Category: 2, Prevents: "EV71感染引起的手足口病", Description: "自费疫苗，两剂间隔1个月"}, []int{6, 7}},
+}
+
+func (i *initVaccine) MigrateTable(ctx context.Context) (context.Context, error) {
+	db, ok := ctx.Value("db").(*gorm.DB)
+	if !ok {
+		return ctx, system.ErrMissingDBContext
+	}
+	return ctx, db.AutoMigrate(&baby.Vaccine{}, &baby.VaccineSchedule{})
+}
+
+func (i *initVaccine) TableCreated(ctx context.Context) bool {
+	db, ok := ctx.Value("db").(*gorm.DB)
+	if !ok {
+		return false
+	}
+	return db.Migrator().HasTable(&baby.Vaccine{}) && db.Migrator().HasTable(&baby.VaccineSchedule{})
+}
+
+func (i *initVaccine) InitializerName() string {
+	return baby.Vaccine{}.TableName()
+}
+
+func (i *initVaccine) InitializeData(ctx context.Context) (context.Context, error) {
+	db, ok := ctx.Value("db").(*gorm.DB)
+	if !ok {
+		return ctx, system.ErrMissingDBContext
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for sortOrder, seed := range vaccineSeeds {
+			vaccine := seed.vaccine
+			vaccine.SortOrder = sortOrder + 1
+			vaccine.IsActive = true
+			if err := tx.Create(&vaccine).Error; err != nil {
+				return errors.Wrap(err, baby.Vaccine{}.TableName()+"表数据初始化失败!")
+			}
+
+			schedules := make([]baby.VaccineSchedule, len(seed.months))
+			for dose, months := range seed.months {
+				schedules[dose] = baby.VaccineSchedule{
+					VaccineID:  vaccine.ID,
+					DoseNumber: dose + 1,
+					AgeMonths:  months,
+					GraceDays:  30,
+					IsActive:   true,
+				}
+			}
+			if err := tx.Create(&schedules).Error; err != nil {
+				return errors.Wrap(err, baby.VaccineSchedule{}.TableName()+"表数据初始化失败!")
+			}
+		}
+		return nil
+	})
+	return ctx, err
+}
+
+func (i *initVaccine) DataInserted(ctx context.Context) bool {
+	db, ok := ctx.Value("db").(*gorm.DB)
+	if !ok {
+		return false
+	}
+	lookup := baby.Vaccine{Code: "HepB"}
+	if errors.Is(db.First(&lookup, &lookup).Error, gorm.ErrRecordNotFound) {
+		return false
+	}
+	return true
+}
@@ -197,6 +197,25 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "版本控制", Method: "POST", Path: "/sysVersion/importVersion", Description: "同步版本"},
 		{ApiGroup: "版本控制", Method: "DELETE", Path: "/sysVersion/deleteSysVersion", Description: "删除版本"},
 		{ApiGroup: "版本控制", Method: "DELETE", Path: "/sysVersion/deleteSysVersionByIds", Description: "批量删除版本"},
+
+		{ApiGroup: "疫苗管理", Method: "POST", Path: "/vaccineAdmin/createVaccine", Description: "创建疫苗"},
+		{ApiGroup: "疫苗管理", Method: "PUT", Path: "/vaccineAdmin/updateVaccine", Description: "更新疫苗"},
+		{ApiGroup: "疫苗管理", Method: "DELETE", Path: "/vaccineAdmin/deleteVaccine", Description: "删除疫苗"},
+		{ApiGroup: "疫苗管理", Method: "GET", Path: "/vaccineAdmin/getVaccineList", Description: "获取疫苗列表"},
+		{ApiGroup: "疫苗管理", Method: "POST", Path: "/vaccineAdmin/createSchedule", Description: "创建接种程序剂次"},
+		{ApiGroup: "疫苗管理", Method: "PUT", Path: "/vaccineAdmin/updateSchedule", Description: "更新接种程序剂次"},
+		{ApiGroup: "疫苗管理", Method: "DELETE", Path: "/vaccineAdmin/deleteSchedule", Description: "删除接种程序剂次"},
+		{ApiGroup: "疫苗管理", Method: "GET", Path: "/vaccineAdmin/getScheduleList", Description: "获取接种程序列表"},
+
+		{ApiGroup: "会员管理", Method: "POST", Path: "/membershipAdmin/createVipPlan", Description: "创建会员套餐"},
+		{ApiGroup: "会员管理", Method: "PUT", Path: "/membershipAdmin/updateVipPlan", Description: "更新会员套餐"},
+		{ApiGroup: "会员管理", Method: "DELETE", Path: "/membershipAdmin/deleteVipPlan", Description: "删除会员套餐"},
+		{ApiGroup: "会员管理", Method: "GET", Path: "/membershipAdmin/getVipPlanList", Description: "获取会员套餐列表"},
+		{ApiGroup: "会员管理", Method: "POST", Path: "/membershipAdmin/grantMembership", Description: "开通会员"},
+		{ApiGroup: "会员管理", Method: "POST", Path: "/membershipAdmin/extendMembership", Description: "延长会员"},
+		{ApiGroup: "会员管理", Method: "POST", Path: "/membershipAdmin/revokeMembership", Description: "撤销会员"},
+		{ApiGroup: "会员管理", Method: "GET", Path: "/membershipAdmin/getMemberList", Description: "获取会员用户列表"},
+		{ApiGroup: "会员管理", Method: "GET", Path: "/membershipAdmin/getMembershipLogList", Description: "获取会员变更记录"},
 	}
 	if err := db.Create(&entities).Error; err != nil {
 		return ctx, errors.Wrap(err, sysModel.SysApi{}.TableName()+"表数据初始化失败!")
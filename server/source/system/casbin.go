@@ -200,6 +200,25 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/sysVersion/deleteSysVersion", V2: "DELETE"},
 		{Ptype: "p", V0: "888", V1: "/sysVersion/deleteSysVersionByIds", V2: "DELETE"},
 
+		{Ptype: "p", V0: "888", V1: "/vaccineAdmin/createVaccine", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/vaccineAdmin/updateVaccine", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/vaccineAdmin/deleteVaccine", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/vaccineAdmin/getVaccineList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/vaccineAdmin/createSchedule", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/vaccineAdmin/updateSchedule", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/vaccineAdmin/deleteSchedule", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/vaccineAdmin/getScheduleList", V2: "GET"},
+
+		{Ptype: "p", V0: "888", V1: "/membershipAdmin/createVipPlan", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/membershipAdmin/updateVipPlan", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/membershipAdmin/deleteVipPlan", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/membershipAdmin/getVipPlanList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/membershipAdmin/grantMembership", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/membershipAdmin/extendMembership", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/membershipAdmin/revokeMembership", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/membershipAdmin/getMemberList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/membershipAdmin/getMembershipLogList", V2: "GET"},
+
 		{Ptype: "p", V0: "8881", V1: "/user/admin_register", V2: "POST"},
 		{Ptype: "p", V0: "8881", V1: "/api/createApi", V2: "POST"},
 		{Ptype: "p", V0: "8881", V1: "/api/getApiList", V2: "POST"},
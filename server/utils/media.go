@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// MediaContentTypes 常见音视频格式的Content-Type，系统mime表中通常缺少这些扩展名
+var MediaContentTypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".aac":  "audio/aac",
+	".wav":  "audio/wav",
+	".ogg":  "audio/ogg",
+	".flac": "audio/flac",
+	".mp4":  "video/mp4",
+	".mov":  "video/quicktime",
+	".webm": "video/webm",
+	".m3u8": "application/vnd.apple.mpegurl",
+	".ts":   "video/mp2t",
+}
+
+// IsMediaFile 是否为音视频文件，音视频只能经媒体网关校验签名后访问，不能从静态目录直接下载
+func IsMediaFile(name string) bool {
+	_, ok := MediaContentTypes[strings.ToLower(filepath.Ext(name))]
+	return ok
+}
@@ -0,0 +1,22 @@
+package utils
+
+import "testing"
+
+func TestIsMediaFile(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"/uploads/file/lullaby.mp3", true},
+		{"video/intro.MP4", true},
+		{"hls/index.m3u8", true},
+		{"avatar.png", false},
+		{"report.pdf", false},
+		{"mp3", false},
+	}
+	for _, tt := range tests {
+		if got := IsMediaFile(tt.name); got != tt.want {
+			t.Errorf("IsMediaFile(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}